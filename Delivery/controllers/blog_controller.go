@@ -55,7 +55,7 @@ func (bc *BlogController) GetBlogByID(c *gin.Context) {
 		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
 		return
 	}
-	c.JSON(http.StatusOK, blog)
+	respondCacheable(c, blog, blog.UpdatedAt)
 }
 
 // GetAllBlogs handles fetching all blogs with pagination
@@ -74,8 +74,9 @@ func (bc *BlogController) GetAllBlogs(c *gin.Context) {
 	}
 
 	pagination := blogpkg.PaginationRequest{
-		Page:  page,
-		Limit: limit,
+		Page:          page,
+		Limit:         limit,
+		IncludePinned: c.Query("pinned") == "true",
 	}
 
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
@@ -86,7 +87,7 @@ func (bc *BlogController) GetAllBlogs(c *gin.Context) {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
-	c.JSON(http.StatusOK, result)
+	respondCacheableList(c, result)
 }
 
 // UpdateBlog handles updating an existing blog post
@@ -106,6 +107,9 @@ func (bc *BlogController) UpdateBlog(c *gin.Context) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
+	if userID, exists := c.Get("user_id"); exists {
+		ctx = context.WithValue(ctx, "user_id", userID)
+	}
 
 	updatedBlog, err := bc.blogUsecase.UpdateBlog(ctx, id, &blog)
 	if err != nil {
@@ -115,6 +119,46 @@ func (bc *BlogController) UpdateBlog(c *gin.Context) {
 	c.JSON(http.StatusOK, updatedBlog)
 }
 
+// PatchBlog applies an RFC 7396 JSON Merge Patch to a blog post
+func (bc *BlogController) PatchBlog(c *gin.Context) {
+	id := c.Param("id")
+	if id == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Blog ID is required"})
+		return
+	}
+
+	contentType := c.ContentType()
+	if contentType != blogpkg.MergePatchContentType && contentType != "application/json" {
+		c.JSON(http.StatusUnsupportedMediaType, gin.H{"error": "Content-Type must be " + blogpkg.MergePatchContentType})
+		return
+	}
+
+	body, err := c.GetRawData()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body", "details": err.Error()})
+		return
+	}
+	patch, err := blogpkg.ParseMergePatch(body)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid merge patch", "details": err.Error()})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if userID, exists := c.Get("user_id"); exists {
+		ctx = context.WithValue(ctx, "user_id", userID)
+	}
+
+	patchedBlog, err := bc.blogUsecase.PatchBlog(ctx, id, patch)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, patchedBlog)
+}
+
 // DeleteBlog handles deleting a blog post
 func (bc *BlogController) DeleteBlog(c *gin.Context) {
 	id := c.Param("id")
@@ -155,7 +199,7 @@ func (bc *BlogController) SearchBlogs(c *gin.Context) {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
-	c.JSON(http.StatusOK, result)
+	respondCacheableList(c, result)
 }
 
 // Helper function
@@ -201,7 +245,7 @@ func (bc *BlogController) FilterByTags(c *gin.Context) {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
-	c.JSON(http.StatusOK, result)
+	respondCacheableList(c, result)
 }
 
 func ParseInt64(s string) (int64, error) {
@@ -265,3 +309,90 @@ func (bc *BlogController) AddComment(c *gin.Context) {
 	}
 	c.JSON(http.StatusCreated, gin.H{"message": "Comment added successfully", "comment": createdComment})
 }
+
+// GetFeaturedBlogs lists the posts admins featured site-wide
+func (bc *BlogController) GetFeaturedBlogs(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	blogs, err := bc.blogUsecase.GetFeaturedBlogs(ctx)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	respondCacheableList(c, gin.H{"data": blogs})
+}
+
+// GetPinnedBlogs lists the posts an author pinned on their profile
+func (bc *BlogController) GetPinnedBlogs(c *gin.Context) {
+	authorID := c.Param("id")
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	blogs, err := bc.blogUsecase.GetPinnedBlogs(ctx, authorID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	respondCacheableList(c, gin.H{"data": blogs})
+}
+
+// FeatureBlog pins a post globally (admin only)
+func (bc *BlogController) FeatureBlog(c *gin.Context) {
+	bc.pin(c, blogpkg.PinScopeGlobal)
+}
+
+// UnfeatureBlog removes a global pin (admin only)
+func (bc *BlogController) UnfeatureBlog(c *gin.Context) {
+	bc.unpin(c, blogpkg.PinScopeGlobal)
+}
+
+// PinBlog pins one of the caller's posts on their profile
+func (bc *BlogController) PinBlog(c *gin.Context) {
+	bc.pin(c, blogpkg.PinScopeProfile)
+}
+
+// UnpinBlog removes a pin from the caller's profile
+func (bc *BlogController) UnpinBlog(c *gin.Context) {
+	bc.unpin(c, blogpkg.PinScopeProfile)
+}
+
+func (bc *BlogController) pin(c *gin.Context, scope blogpkg.PinScope) {
+	blogID := c.Param("id")
+	var req blogpkg.PinRequest
+	// The body is optional: an empty one pins at position 0 without expiry
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body", "details": err.Error()})
+			return
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if userID, exists := c.Get("user_id"); exists {
+		ctx = context.WithValue(ctx, "user_id", userID)
+	}
+
+	pin, err := bc.blogUsecase.PinBlog(ctx, blogID, scope, req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, pin)
+}
+
+func (bc *BlogController) unpin(c *gin.Context, scope blogpkg.PinScope) {
+	blogID := c.Param("id")
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if userID, exists := c.Get("user_id"); exists {
+		ctx = context.WithValue(ctx, "user_id", userID)
+	}
+
+	if err := bc.blogUsecase.UnpinBlog(ctx, blogID, scope); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Blog unpinned"})
+}
@@ -35,9 +35,20 @@ func (s *BlogControllerSuite) SetupTest() {
 	s.router.GET("/blogs", s.controller.GetAllBlogs)
 	s.router.GET("/blogs/:id", s.controller.GetBlogByID)
 	s.router.PUT("/blogs/:id", s.controller.UpdateBlog)
+	s.router.PATCH("/blogs/:id", s.controller.PatchBlog)
 	s.router.DELETE("/blogs/:id", s.controller.DeleteBlog)
 	s.router.GET("/blogs/search", s.controller.SearchBlogs)
 	s.router.GET("/blogs/filter", s.controller.FilterByTags)
+	s.router.GET("/blogs/featured", s.controller.GetFeaturedBlogs)
+	s.router.GET("/users/:id/pins", s.controller.GetPinnedBlogs)
+	s.router.POST("/blogs/:id/pin", func(c *gin.Context) {
+		c.Set("user_id", "user-1")
+		s.controller.PinBlog(c)
+	})
+	s.router.DELETE("/blogs/:id/feature", func(c *gin.Context) {
+		c.Set("user_id", "admin-1")
+		s.controller.UnfeatureBlog(c)
+	})
 	s.router.POST("/blogs/:id/like", func(c *gin.Context) {
 		// Simulate user_id in context
 		c.Set("user_id", "user-1")
@@ -163,6 +174,96 @@ func (s *BlogControllerSuite) TestGetBlogByID_Error() {
 	assert.Contains(res.Body.String(), "not found")
 }
 
+func (s *BlogControllerSuite) TestGetBlogByID_SetsCacheValidators() {
+	assert := assert.New(s.T())
+	id := "blog-1"
+	updated := time.Date(2025, 7, 1, 12, 0, 0, 0, time.UTC)
+	expected := &blogpkg.Blog{ID: id, Title: "Title1", Content: "Content1", UpdatedAt: updated}
+	s.blogUsecase.On("GetBlogByID", mock.Anything, id).Return(expected, nil)
+	s.router.GET("/cached/blogs/:id", func(c *gin.Context) {
+		c.Set("cache_control", "public, max-age=60")
+		s.controller.GetBlogByID(c)
+	})
+
+	req, _ := http.NewRequest("GET", "/cached/blogs/"+id, nil)
+	res := httptest.NewRecorder()
+	s.router.ServeHTTP(res, req)
+
+	assert.Equal(http.StatusOK, res.Code)
+	assert.NotEmpty(res.Header().Get("ETag"))
+	assert.Equal(updated.Format(http.TimeFormat), res.Header().Get("Last-Modified"))
+	assert.Equal("public, max-age=60", res.Header().Get("Cache-Control"))
+}
+
+func (s *BlogControllerSuite) TestGetBlogByID_IfNoneMatch_NotModified() {
+	assert := assert.New(s.T())
+	id := "blog-1"
+	expected := &blogpkg.Blog{ID: id, Title: "Title1", Content: "Content1", UpdatedAt: time.Now()}
+	s.blogUsecase.On("GetBlogByID", mock.Anything, id).Return(expected, nil)
+
+	req, _ := http.NewRequest("GET", "/blogs/"+id, nil)
+	res := httptest.NewRecorder()
+	s.router.ServeHTTP(res, req)
+	etag := res.Header().Get("ETag")
+	assert.NotEmpty(etag)
+
+	req, _ = http.NewRequest("GET", "/blogs/"+id, nil)
+	req.Header.Set("If-None-Match", `"stale", W/`+etag)
+	res = httptest.NewRecorder()
+	s.router.ServeHTTP(res, req)
+
+	assert.Equal(http.StatusNotModified, res.Code)
+	assert.Empty(res.Body.String())
+	assert.Equal(etag, res.Header().Get("ETag"))
+}
+
+func (s *BlogControllerSuite) TestGetBlogByID_IfNoneMatch_Changed() {
+	assert := assert.New(s.T())
+	id := "blog-1"
+	expected := &blogpkg.Blog{ID: id, Title: "Title1", Content: "Content1", UpdatedAt: time.Now()}
+	s.blogUsecase.On("GetBlogByID", mock.Anything, id).Return(expected, nil)
+
+	req, _ := http.NewRequest("GET", "/blogs/"+id, nil)
+	req.Header.Set("If-None-Match", `"outdated"`)
+	// If-None-Match takes precedence over If-Modified-Since
+	req.Header.Set("If-Modified-Since", time.Now().Add(time.Hour).UTC().Format(http.TimeFormat))
+	res := httptest.NewRecorder()
+	s.router.ServeHTTP(res, req)
+
+	assert.Equal(http.StatusOK, res.Code)
+	assert.Contains(res.Body.String(), "Title1")
+}
+
+func (s *BlogControllerSuite) TestGetAllBlogs_IgnoresIfModifiedSince() {
+	assert := assert.New(s.T())
+	updated := time.Date(2025, 7, 1, 12, 0, 0, 0, time.UTC)
+	expected := blogpkg.PaginationResponse{
+		Data: []blogpkg.Blog{
+			{ID: "1", Title: "One", UpdatedAt: updated.Add(-time.Hour)},
+			{ID: "2", Title: "Two", UpdatedAt: updated},
+		},
+		Total: 2, Page: 1, Limit: 10, TotalPages: 1,
+	}
+	pagination := blogpkg.PaginationRequest{Page: 1, Limit: 10}
+	s.blogUsecase.On("GetAllBlogs", mock.Anything, pagination).Return(expected, nil)
+
+	// A post deleted from the page would not move the page's newest UpdatedAt
+	req, _ := http.NewRequest("GET", "/blogs", nil)
+	req.Header.Set("If-Modified-Since", updated.Add(time.Hour).Format(http.TimeFormat))
+	res := httptest.NewRecorder()
+	s.router.ServeHTTP(res, req)
+	assert.Equal(http.StatusOK, res.Code)
+	assert.Empty(res.Header().Get("Last-Modified"))
+	assert.NotEmpty(res.Header().Get("ETag"))
+	assert.Contains(res.Body.String(), "Two")
+
+	req, _ = http.NewRequest("GET", "/blogs", nil)
+	req.Header.Set("If-None-Match", res.Header().Get("ETag"))
+	res = httptest.NewRecorder()
+	s.router.ServeHTTP(res, req)
+	assert.Equal(http.StatusNotModified, res.Code)
+}
+
 func (s *BlogControllerSuite) TestUpdateBlog_Success() {
 	assert := assert.New(s.T())
 	id := "blog-1"
@@ -202,6 +303,55 @@ func (s *BlogControllerSuite) TestUpdateBlog_Error() {
 	assert.Contains(res.Body.String(), "update failed")
 }
 
+func (s *BlogControllerSuite) TestPatchBlog_Success() {
+	assert := assert.New(s.T())
+	id := "blog-1"
+	expected := &blogpkg.Blog{ID: id, Title: "Patched", Content: "Content", Tags: []string{}}
+	s.blogUsecase.On("PatchBlog", mock.Anything, id, mock.MatchedBy(func(p blogpkg.BlogPatch) bool {
+		return p.Title != nil && *p.Title == "Patched" && p.Content == nil && p.Tags != nil && len(*p.Tags) == 0
+	})).Return(expected, nil).Once()
+
+	req, _ := http.NewRequest("PATCH", "/blogs/"+id, bytes.NewBufferString(`{"title":"Patched","tags":null}`))
+	req.Header.Set("Content-Type", blogpkg.MergePatchContentType)
+	res := httptest.NewRecorder()
+	s.router.ServeHTTP(res, req)
+
+	assert.Equal(http.StatusOK, res.Code)
+	assert.Contains(res.Body.String(), "Patched")
+	s.blogUsecase.AssertExpectations(s.T())
+}
+
+func (s *BlogControllerSuite) TestPatchBlog_ReadOnlyField() {
+	assert := assert.New(s.T())
+	req, _ := http.NewRequest("PATCH", "/blogs/blog-1", bytes.NewBufferString(`{"views":1000}`))
+	req.Header.Set("Content-Type", blogpkg.MergePatchContentType)
+	res := httptest.NewRecorder()
+	s.router.ServeHTTP(res, req)
+
+	assert.Equal(http.StatusBadRequest, res.Code)
+	assert.Contains(res.Body.String(), "read-only")
+}
+
+func (s *BlogControllerSuite) TestPatchBlog_NotAnObject() {
+	assert := assert.New(s.T())
+	req, _ := http.NewRequest("PATCH", "/blogs/blog-1", bytes.NewBufferString(`["title"]`))
+	req.Header.Set("Content-Type", blogpkg.MergePatchContentType)
+	res := httptest.NewRecorder()
+	s.router.ServeHTTP(res, req)
+
+	assert.Equal(http.StatusBadRequest, res.Code)
+}
+
+func (s *BlogControllerSuite) TestPatchBlog_UnsupportedMediaType() {
+	assert := assert.New(s.T())
+	req, _ := http.NewRequest("PATCH", "/blogs/blog-1", bytes.NewBufferString(`{"title":"x"}`))
+	req.Header.Set("Content-Type", "text/plain")
+	res := httptest.NewRecorder()
+	s.router.ServeHTTP(res, req)
+
+	assert.Equal(http.StatusUnsupportedMediaType, res.Code)
+}
+
 func (s *BlogControllerSuite) TestDeleteBlog_Success() {
 	assert := assert.New(s.T())
 	id := "blog-1"
@@ -432,6 +582,84 @@ func (s *BlogControllerSuite) TestAddComment_Success() {
 	s.blogUsecase.AssertExpectations(s.T())
 }
 
+func (s *BlogControllerSuite) TestGetAllBlogs_PinnedQuery() {
+	assert := assert.New(s.T())
+	pagination := blogpkg.PaginationRequest{Page: 1, Limit: 10, IncludePinned: true}
+	s.blogUsecase.On("GetAllBlogs", mock.Anything, pagination).Return(blogpkg.PaginationResponse{Pinned: 1}, nil).Once()
+
+	req, _ := http.NewRequest("GET", "/blogs?pinned=true", nil)
+	res := httptest.NewRecorder()
+	s.router.ServeHTTP(res, req)
+
+	assert.Equal(http.StatusOK, res.Code)
+	s.blogUsecase.AssertExpectations(s.T())
+}
+
+func (s *BlogControllerSuite) TestGetFeaturedBlogs_Success() {
+	assert := assert.New(s.T())
+	s.blogUsecase.On("GetFeaturedBlogs", mock.Anything).Return([]blogpkg.Blog{{ID: "f1", Pinned: true}}, nil).Once()
+
+	req, _ := http.NewRequest("GET", "/blogs/featured", nil)
+	res := httptest.NewRecorder()
+	s.router.ServeHTTP(res, req)
+
+	assert.Equal(http.StatusOK, res.Code)
+	assert.Contains(res.Body.String(), `"id":"f1"`)
+	assert.NotEmpty(res.Header().Get("ETag"))
+}
+
+func (s *BlogControllerSuite) TestGetPinnedBlogs_Success() {
+	assert := assert.New(s.T())
+	s.blogUsecase.On("GetPinnedBlogs", mock.Anything, "author-1").Return([]blogpkg.Blog{{ID: "p1"}}, nil).Once()
+
+	req, _ := http.NewRequest("GET", "/users/author-1/pins", nil)
+	res := httptest.NewRecorder()
+	s.router.ServeHTTP(res, req)
+
+	assert.Equal(http.StatusOK, res.Code)
+	assert.Contains(res.Body.String(), `"id":"p1"`)
+}
+
+func (s *BlogControllerSuite) TestPinBlog_WithBody() {
+	assert := assert.New(s.T())
+	s.blogUsecase.On("PinBlog", mock.Anything, "blog-1", blogpkg.PinScopeProfile, mock.MatchedBy(func(r blogpkg.PinRequest) bool {
+		return r.Position == 2 && r.ExpiresAt != nil
+	})).Return(blogpkg.Pin{BlogID: "blog-1", Position: 2}, nil).Once()
+
+	body := bytes.NewBufferString(`{"position":2,"expires_at":"2030-01-01T00:00:00Z"}`)
+	req, _ := http.NewRequest("POST", "/blogs/blog-1/pin", body)
+	req.Header.Set("Content-Type", "application/json")
+	res := httptest.NewRecorder()
+	s.router.ServeHTTP(res, req)
+
+	assert.Equal(http.StatusOK, res.Code)
+	s.blogUsecase.AssertExpectations(s.T())
+}
+
+func (s *BlogControllerSuite) TestPinBlog_Error() {
+	assert := assert.New(s.T())
+	s.blogUsecase.On("PinBlog", mock.Anything, "blog-1", blogpkg.PinScopeProfile, blogpkg.PinRequest{}).
+		Return(blogpkg.Pin{}, errors.New("you can only pin your own posts")).Once()
+
+	req, _ := http.NewRequest("POST", "/blogs/blog-1/pin", nil)
+	res := httptest.NewRecorder()
+	s.router.ServeHTTP(res, req)
+
+	assert.Equal(http.StatusBadRequest, res.Code)
+	assert.Contains(res.Body.String(), "only pin your own")
+}
+
+func (s *BlogControllerSuite) TestUnfeatureBlog_Success() {
+	assert := assert.New(s.T())
+	s.blogUsecase.On("UnpinBlog", mock.Anything, "blog-1", blogpkg.PinScopeGlobal).Return(nil).Once()
+
+	req, _ := http.NewRequest("DELETE", "/blogs/blog-1/feature", nil)
+	res := httptest.NewRecorder()
+	s.router.ServeHTTP(res, req)
+
+	assert.Equal(http.StatusOK, res.Code)
+}
+
 func TestBlogControllerSuite(t *testing.T) {
 	suite.Run(t, new(BlogControllerSuite))
 }
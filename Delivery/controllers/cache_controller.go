@@ -0,0 +1,23 @@
+package controllers
+
+import (
+	"net/http"
+
+	blogpkg "github.com/Amaankaa/Blog-Starter-Project/Domain/blog"
+	"github.com/gin-gonic/gin"
+)
+
+type CacheController struct {
+	blogCache blogpkg.IBlogCacheStats
+}
+
+func NewCacheController(blogCache blogpkg.IBlogCacheStats) *CacheController {
+	return &CacheController{
+		blogCache: blogCache,
+	}
+}
+
+// GetCacheStats reports hit/miss counters of the blog read cache
+func (cc *CacheController) GetCacheStats(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"blogs": cc.blogCache.CacheStats()})
+}
@@ -0,0 +1,62 @@
+package controllers
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	emaildomainpkg "github.com/Amaankaa/Blog-Starter-Project/Domain/emaildomain"
+	"github.com/gin-gonic/gin"
+)
+
+type DomainRuleController struct {
+	domainRules emaildomainpkg.IDomainRuleUsecase
+}
+
+func NewDomainRuleController(domainRules emaildomainpkg.IDomainRuleUsecase) *DomainRuleController {
+	return &DomainRuleController{
+		domainRules: domainRules,
+	}
+}
+
+// SetRule allows or denies registrations from a domain, replacing any existing rule for it
+func (dc *DomainRuleController) SetRule(c *gin.Context) {
+	var req emaildomainpkg.SetDomainRuleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body", "details": err.Error()})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	rule, err := dc.domainRules.SetRule(ctx, req, c.GetString("user_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, rule)
+}
+
+func (dc *DomainRuleController) ListRules(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	rules, err := dc.domainRules.ListRules(ctx)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"data": rules})
+}
+
+func (dc *DomainRuleController) DeleteRule(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if err := dc.domainRules.DeleteRule(ctx, c.Param("domain")); err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Domain rule deleted"})
+}
@@ -0,0 +1,82 @@
+package controllers_test
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Amaankaa/Blog-Starter-Project/Delivery/controllers"
+	emaildomainpkg "github.com/Amaankaa/Blog-Starter-Project/Domain/emaildomain"
+	"github.com/Amaankaa/Blog-Starter-Project/mocks"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type DomainRuleControllerSuite struct {
+	suite.Suite
+	domainRules *mocks.IDomainRuleUsecase
+	controller  *controllers.DomainRuleController
+	router      *gin.Engine
+}
+
+func (s *DomainRuleControllerSuite) SetupTest() {
+	s.domainRules = mocks.NewIDomainRuleUsecase(s.T())
+	s.controller = controllers.NewDomainRuleController(s.domainRules)
+	s.router = gin.Default()
+
+	s.router.POST("/admin/email/domains", func(c *gin.Context) {
+		c.Set("user_id", "admin-1")
+		s.controller.SetRule(c)
+	})
+	s.router.GET("/admin/email/domains", s.controller.ListRules)
+	s.router.DELETE("/admin/email/domains/:domain", s.controller.DeleteRule)
+}
+
+func TestDomainRuleControllerSuite(t *testing.T) {
+	suite.Run(t, new(DomainRuleControllerSuite))
+}
+
+func (s *DomainRuleControllerSuite) TestSetRule() {
+	req := emaildomainpkg.SetDomainRuleRequest{Domain: "example.com", Action: "allow", Note: "partner"}
+	s.domainRules.On("SetRule", mock.Anything, req, "admin-1").
+		Return(emaildomainpkg.DomainRule{Domain: "example.com", Action: "allow", CreatedBy: "admin-1"}, nil).Once()
+
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/admin/email/domains", bytes.NewBufferString(`{"domain":"example.com","action":"allow","note":"partner"}`)))
+
+	s.Equal(http.StatusOK, w.Code)
+	s.Contains(w.Body.String(), `"domain":"example.com"`)
+}
+
+func (s *DomainRuleControllerSuite) TestSetRule_ValidationError() {
+	s.domainRules.On("SetRule", mock.Anything, mock.Anything, "admin-1").
+		Return(emaildomainpkg.DomainRule{}, errors.New("invalid domain name")).Once()
+
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/admin/email/domains", bytes.NewBufferString(`{"domain":"nope","action":"deny"}`)))
+
+	s.Equal(http.StatusBadRequest, w.Code)
+	s.Contains(w.Body.String(), "invalid domain name")
+}
+
+func (s *DomainRuleControllerSuite) TestListRules() {
+	s.domainRules.On("ListRules", mock.Anything).Return([]emaildomainpkg.DomainRule{{Domain: "mailinator.com", Action: "deny"}}, nil).Once()
+
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/admin/email/domains", nil))
+
+	s.Equal(http.StatusOK, w.Code)
+	s.Contains(w.Body.String(), "mailinator.com")
+}
+
+func (s *DomainRuleControllerSuite) TestDeleteRule_NotFound() {
+	s.domainRules.On("DeleteRule", mock.Anything, "example.com").Return(errors.New("domain rule not found")).Once()
+
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, httptest.NewRequest(http.MethodDelete, "/admin/email/domains/example.com", nil))
+
+	s.Equal(http.StatusNotFound, w.Code)
+}
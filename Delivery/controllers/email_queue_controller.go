@@ -0,0 +1,58 @@
+package controllers
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	emailjobpkg "github.com/Amaankaa/Blog-Starter-Project/Domain/emailjob"
+	"github.com/gin-gonic/gin"
+)
+
+type EmailQueueController struct {
+	emailQueue emailjobpkg.IEmailQueueUsecase
+}
+
+func NewEmailQueueController(emailQueue emailjobpkg.IEmailQueueUsecase) *EmailQueueController {
+	return &EmailQueueController{
+		emailQueue: emailQueue,
+	}
+}
+
+// ListQueued shows emails waiting for their first or next attempt
+func (ec *EmailQueueController) ListQueued(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	jobs, err := ec.emailQueue.ListQueued(ctx)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"data": jobs})
+}
+
+// ListDeadLetters shows emails that exhausted their retries
+func (ec *EmailQueueController) ListDeadLetters(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	jobs, err := ec.emailQueue.ListDeadLetters(ctx)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"data": jobs})
+}
+
+func (ec *EmailQueueController) RequeueDeadLetter(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	job, err := ec.emailQueue.RequeueDeadLetter(ctx, c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusAccepted, job)
+}
@@ -0,0 +1,71 @@
+package controllers_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Amaankaa/Blog-Starter-Project/Delivery/controllers"
+	emailjobpkg "github.com/Amaankaa/Blog-Starter-Project/Domain/emailjob"
+	"github.com/Amaankaa/Blog-Starter-Project/mocks"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type EmailQueueControllerSuite struct {
+	suite.Suite
+	emailQueue *mocks.IEmailQueueUsecase
+	router     *gin.Engine
+}
+
+func (s *EmailQueueControllerSuite) SetupTest() {
+	s.emailQueue = mocks.NewIEmailQueueUsecase(s.T())
+	controller := controllers.NewEmailQueueController(s.emailQueue)
+	s.router = gin.Default()
+	s.router.GET("/admin/email/jobs", controller.ListQueued)
+	s.router.GET("/admin/email/dead-letters", controller.ListDeadLetters)
+	s.router.POST("/admin/email/dead-letters/:id/requeue", controller.RequeueDeadLetter)
+}
+
+func TestEmailQueueControllerSuite(t *testing.T) {
+	suite.Run(t, new(EmailQueueControllerSuite))
+}
+
+func (s *EmailQueueControllerSuite) TestListDeadLetters_HidesContent() {
+	failedAt := time.Now()
+	s.emailQueue.On("ListDeadLetters", mock.Anything).Return([]emailjobpkg.EmailJob{
+		{To: "a@example.com", Subject: "Your OTP Code", Text: "Your OTP: 123456", Attempts: 6, LastError: "brevo 503", FailedAt: &failedAt},
+	}, nil).Once()
+
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/admin/email/dead-letters", nil))
+
+	s.Equal(http.StatusOK, w.Code)
+	s.Contains(w.Body.String(), "brevo 503")
+	s.NotContains(w.Body.String(), "123456")
+}
+
+func (s *EmailQueueControllerSuite) TestListQueued_Error() {
+	s.emailQueue.On("ListQueued", mock.Anything).Return(nil, errors.New("db down")).Once()
+
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/admin/email/jobs", nil))
+
+	s.Equal(http.StatusInternalServerError, w.Code)
+}
+
+func (s *EmailQueueControllerSuite) TestRequeueDeadLetter() {
+	s.emailQueue.On("RequeueDeadLetter", mock.Anything, "job-1").Return(emailjobpkg.EmailJob{To: "a@example.com"}, nil).Once()
+	s.emailQueue.On("RequeueDeadLetter", mock.Anything, "missing").Return(emailjobpkg.EmailJob{}, errors.New("dead letter not found")).Once()
+
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/admin/email/dead-letters/job-1/requeue", nil))
+	s.Equal(http.StatusAccepted, w.Code)
+
+	w = httptest.NewRecorder()
+	s.router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/admin/email/dead-letters/missing/requeue", nil))
+	s.Equal(http.StatusNotFound, w.Code)
+}
@@ -0,0 +1,49 @@
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/Amaankaa/Blog-Starter-Project/Domain/services"
+	"github.com/gin-gonic/gin"
+)
+
+type EmailTemplateController struct {
+	renderer services.IEmailRenderer
+}
+
+func NewEmailTemplateController(renderer services.IEmailRenderer) *EmailTemplateController {
+	return &EmailTemplateController{
+		renderer: renderer,
+	}
+}
+
+// ListTemplates reports the available templates and locales
+func (ec *EmailTemplateController) ListTemplates(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{
+		"templates": services.EmailTemplates,
+		"locales":   ec.renderer.Locales(),
+	})
+}
+
+// PreviewTemplate renders a template with sample data. ?format=html or ?format=text
+// returns that part alone so it can be opened directly in a browser.
+func (ec *EmailTemplateController) PreviewTemplate(c *gin.Context) {
+	msg, err := ec.renderer.Preview(c.Param("name"), c.Query("locale"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	switch c.Query("format") {
+	case "html":
+		c.Data(http.StatusOK, "text/html; charset=utf-8", []byte(msg.HTML))
+	case "text":
+		c.Data(http.StatusOK, "text/plain; charset=utf-8", []byte(msg.Text))
+	default:
+		c.JSON(http.StatusOK, gin.H{
+			"subject": msg.Subject,
+			"html":    msg.HTML,
+			"text":    msg.Text,
+		})
+	}
+}
@@ -0,0 +1,80 @@
+package controllers_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Amaankaa/Blog-Starter-Project/Delivery/controllers"
+	"github.com/Amaankaa/Blog-Starter-Project/Domain/services"
+	"github.com/Amaankaa/Blog-Starter-Project/mocks"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+)
+
+type EmailTemplateControllerSuite struct {
+	suite.Suite
+	renderer   *mocks.IEmailRenderer
+	controller *controllers.EmailTemplateController
+	router     *gin.Engine
+}
+
+func (s *EmailTemplateControllerSuite) SetupTest() {
+	s.renderer = mocks.NewIEmailRenderer(s.T())
+	s.controller = controllers.NewEmailTemplateController(s.renderer)
+	s.router = gin.Default()
+	s.router.GET("/admin/email/templates", s.controller.ListTemplates)
+	s.router.GET("/admin/email/templates/:name/preview", s.controller.PreviewTemplate)
+}
+
+func TestEmailTemplateControllerSuite(t *testing.T) {
+	suite.Run(t, new(EmailTemplateControllerSuite))
+}
+
+var previewMessage = services.EmailMessage{
+	Subject: "Verifica tu dirección de correo",
+	HTML:    "<p>Tu código: 482913</p>",
+	Text:    "Tu código: 482913\n",
+}
+
+func (s *EmailTemplateControllerSuite) TestListTemplates() {
+	s.renderer.On("Locales").Return([]string{"en", "es"}).Once()
+
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/admin/email/templates", nil))
+
+	s.Equal(http.StatusOK, w.Code)
+	s.JSONEq(`{"templates":["verification","password_reset","security_alert","account_locked","email_change","digest"],"locales":["en","es"]}`, w.Body.String())
+}
+
+func (s *EmailTemplateControllerSuite) TestPreview_JSON() {
+	s.renderer.On("Preview", services.TemplateVerification, "es").Return(previewMessage, nil).Once()
+
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/admin/email/templates/verification/preview?locale=es", nil))
+
+	s.Equal(http.StatusOK, w.Code)
+	s.Contains(w.Body.String(), `"subject":"Verifica tu dirección de correo"`)
+	s.Contains(w.Body.String(), `"text":"Tu código: 482913\n"`)
+}
+
+func (s *EmailTemplateControllerSuite) TestPreview_RawHTML() {
+	s.renderer.On("Preview", services.TemplateVerification, "").Return(previewMessage, nil).Once()
+
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/admin/email/templates/verification/preview?format=html", nil))
+
+	s.Equal(http.StatusOK, w.Code)
+	s.Equal("text/html; charset=utf-8", w.Header().Get("Content-Type"))
+	s.Equal(previewMessage.HTML, w.Body.String())
+}
+
+func (s *EmailTemplateControllerSuite) TestPreview_UnknownTemplate() {
+	s.renderer.On("Preview", "newsletter", "").Return(services.EmailMessage{}, errors.New(`unknown email template "newsletter"`)).Once()
+
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/admin/email/templates/newsletter/preview", nil))
+
+	s.Equal(http.StatusNotFound, w.Code)
+}
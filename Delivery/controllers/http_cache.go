@@ -0,0 +1,86 @@
+package controllers
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// respondCacheable writes payload as JSON with ETag and Last-Modified validators,
+// or a bare 304 when the request's conditional headers show the client copy is fresh.
+func respondCacheable(c *gin.Context, payload interface{}, lastModified time.Time) {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	etag := computeETag(body, lastModified)
+	c.Header("ETag", etag)
+	if !lastModified.IsZero() {
+		c.Header("Last-Modified", lastModified.UTC().Format(http.TimeFormat))
+	}
+	if cacheControl := c.GetString("cache_control"); cacheControl != "" {
+		c.Header("Cache-Control", cacheControl)
+	}
+
+	if isNotModified(c.Request, etag, lastModified) {
+		c.Status(http.StatusNotModified)
+		return
+	}
+
+	c.Data(http.StatusOK, "application/json; charset=utf-8", body)
+}
+
+// computeETag derives a strong validator from the serialized body and its modification time.
+func computeETag(body []byte, lastModified time.Time) string {
+	h := sha256.New()
+	h.Write(body)
+	h.Write([]byte(lastModified.UTC().Format(time.RFC3339Nano)))
+	return `"` + hex.EncodeToString(h.Sum(nil)[:16]) + `"`
+}
+
+// isNotModified evaluates If-None-Match and If-Modified-Since (RFC 9110 §13.2.2).
+// If-None-Match takes precedence; If-Modified-Since is only consulted when it is absent.
+func isNotModified(r *http.Request, etag string, lastModified time.Time) bool {
+	if inm := r.Header.Get("If-None-Match"); inm != "" {
+		return etagMatches(inm, etag)
+	}
+
+	ims := r.Header.Get("If-Modified-Since")
+	if ims == "" || lastModified.IsZero() {
+		return false
+	}
+	since, err := http.ParseTime(ims)
+	if err != nil {
+		return false
+	}
+	// HTTP dates have second precision
+	return !lastModified.Truncate(time.Second).After(since)
+}
+
+// etagMatches uses weak comparison, as required for If-None-Match.
+func etagMatches(header, etag string) bool {
+	if strings.TrimSpace(header) == "*" {
+		return true
+	}
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+		if candidate == strings.TrimPrefix(etag, "W/") {
+			return true
+		}
+	}
+	return false
+}
+
+// respondCacheableList sends a listing with an ETag only. The newest post on a page says
+// nothing about posts deleted from it or pushed off it, so a Last-Modified date taken from
+// the page would let If-Modified-Since keep a stale listing alive.
+func respondCacheableList(c *gin.Context, payload interface{}) {
+	respondCacheable(c, payload, time.Time{})
+}
@@ -0,0 +1,120 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	identitypkg "github.com/Amaankaa/Blog-Starter-Project/Domain/identity"
+	"github.com/gin-gonic/gin"
+)
+
+// IdentityController serves sign-in through external providers and the user's linked identities
+type IdentityController struct {
+	identities identitypkg.IIdentityUsecase
+}
+
+func NewIdentityController(identities identitypkg.IIdentityUsecase) *IdentityController {
+	return &IdentityController{
+		identities: identities,
+	}
+}
+
+func (ic *IdentityController) Providers(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"data": ic.identities.Providers()})
+}
+
+// Login redirects the browser to the provider
+func (ic *IdentityController) Login(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Second)
+	defer cancel()
+
+	authURL, err := ic.identities.StartLogin(ctx, c.Param("provider"))
+	if err != nil {
+		c.JSON(identityErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.Redirect(http.StatusFound, authURL)
+}
+
+// Callback is where the provider sends the browser back, either to log in or to finish linking
+func (ic *IdentityController) Callback(c *gin.Context) {
+	if providerErr := c.Query("error"); providerErr != "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "sign-in was cancelled or refused: " + providerErr})
+		return
+	}
+	code, state := c.Query("code"), c.Query("state")
+	if code == "" || state == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "code and state required"})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 15*time.Second)
+	defer cancel()
+
+	result, err := ic.identities.Callback(ctx, c.Param("provider"), code, state, deviceInfo(c))
+	if respondMFARequired(c, err) {
+		return
+	}
+	if err != nil {
+		c.JSON(identityErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	if result.Linked {
+		c.JSON(http.StatusOK, gin.H{"message": "Identity linked", "identity": result.Identity})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"user":          result.User,
+		"access_token":  result.AccessToken,
+		"refresh_token": result.RefreshToken,
+	})
+}
+
+func (ic *IdentityController) ListIdentities(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Second)
+	defer cancel()
+
+	identities, err := ic.identities.ListIdentities(ctx, c.GetString("user_id"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"data": identities})
+}
+
+// Link returns the provider URL rather than redirecting, since the request carries a bearer token
+func (ic *IdentityController) Link(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Second)
+	defer cancel()
+
+	authURL, err := ic.identities.StartLink(ctx, c.Param("provider"), c.GetString("user_id"))
+	if err != nil {
+		c.JSON(identityErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"authorization_url": authURL})
+}
+
+func (ic *IdentityController) Unlink(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Second)
+	defer cancel()
+
+	if err := ic.identities.Unlink(ctx, c.GetString("user_id"), c.Param("id")); err != nil {
+		c.JSON(identityErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Identity unlinked"})
+}
+
+func identityErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, identitypkg.ErrUnknownProvider), errors.Is(err, identitypkg.ErrIdentityNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, identitypkg.ErrIdentityTaken):
+		return http.StatusConflict
+	default:
+		return http.StatusBadRequest
+	}
+}
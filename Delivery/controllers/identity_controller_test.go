@@ -0,0 +1,249 @@
+package controllers_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/Amaankaa/Blog-Starter-Project/Delivery/controllers"
+	"github.com/Amaankaa/Blog-Starter-Project/Domain/event/eventtest"
+	identitypkg "github.com/Amaankaa/Blog-Starter-Project/Domain/identity"
+	userpkg "github.com/Amaankaa/Blog-Starter-Project/Domain/user"
+	infrastructure "github.com/Amaankaa/Blog-Starter-Project/Infrastructure"
+	"github.com/Amaankaa/Blog-Starter-Project/Infrastructure/oidctest"
+	usecases "github.com/Amaankaa/Blog-Starter-Project/Usecases"
+	"github.com/Amaankaa/Blog-Starter-Project/mocks"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// IdentityControllerSuite runs the whole sign-in flow over HTTP: our routes, the real
+// OIDC client and usecase, and a stub provider. Only users and sessions are mocked.
+type IdentityControllerSuite struct {
+	suite.Suite
+	stub     *oidctest.Provider
+	app      *httptest.Server
+	repo     *memIdentityRepo
+	userRepo *mocks.IUserRepository
+	sessions *mocks.ISessionIssuer
+	userID   string
+}
+
+func TestIdentityControllerSuite(t *testing.T) {
+	suite.Run(t, new(IdentityControllerSuite))
+}
+
+func (s *IdentityControllerSuite) SetupTest() {
+	s.stub = oidctest.NewProvider(s.T(), oidctest.User{Subject: "sub-1", Email: "jane@example.com", EmailVerified: true, Name: "Jane Doe"})
+	router := gin.New()
+	s.app = httptest.NewServer(router)
+	s.T().Cleanup(s.app.Close)
+
+	provider := infrastructure.NewOIDCProvider(infrastructure.OIDCProviderConfig{
+		Name:         "stub",
+		Issuer:       s.stub.Issuer(),
+		ClientID:     s.stub.ClientID,
+		ClientSecret: s.stub.ClientSecret,
+		RedirectURL:  s.app.URL + "/auth/oidc/stub/callback",
+	}, nil)
+	s.repo = newMemIdentityRepo()
+	s.userRepo = mocks.NewIUserRepository(s.T())
+	s.sessions = mocks.NewISessionIssuer(s.T())
+	usecase := usecases.NewIdentityUsecase(s.repo, s.userRepo, s.sessions, eventtest.NewRecorder(), provider)
+	controller := controllers.NewIdentityController(usecase)
+
+	s.userID = primitive.NewObjectID().Hex()
+	router.GET("/auth/oidc/providers", controller.Providers)
+	router.GET("/auth/oidc/:provider/login", controller.Login)
+	router.GET("/auth/oidc/:provider/callback", controller.Callback)
+	protected := router.Group("", func(c *gin.Context) {
+		c.Set("user_id", s.userID)
+		c.Next()
+	})
+	protected.GET("/me/identities", controller.ListIdentities)
+	protected.POST("/me/identities/:provider/link", controller.Link)
+	protected.DELETE("/me/identities/:id", controller.Unlink)
+}
+
+// get requests path on the app, following redirects through the provider and back
+func (s *IdentityControllerSuite) get(rawURL string) (int, map[string]interface{}) {
+	resp, err := http.Get(rawURL)
+	s.Require().NoError(err)
+	defer resp.Body.Close()
+	var body map[string]interface{}
+	s.Require().NoError(json.NewDecoder(resp.Body).Decode(&body))
+	return resp.StatusCode, body
+}
+
+func (s *IdentityControllerSuite) TestLogin_NewUserThenReturningUser() {
+	created := userpkg.User{ID: primitive.NewObjectID(), Username: "jane", Email: "jane@example.com", Role: "user"}
+	s.userRepo.On("ExistsByEmail", mock.Anything, "jane@example.com").Return(false, nil).Once()
+	s.userRepo.On("ExistsByUsername", mock.Anything, "jane").Return(false, nil).Once()
+	s.userRepo.On("CountUsers", mock.Anything).Return(int64(1), nil).Once()
+	s.userRepo.On("CreateUser", mock.Anything, mock.MatchedBy(func(u userpkg.User) bool {
+		return u.Username == "jane" && u.Fullname == "Jane Doe" && u.Email == "jane@example.com"
+	})).Return(created, nil).Once()
+	s.userRepo.On("UpdateIsVerifiedByEmail", mock.Anything, "jane@example.com", true).Return(nil).Once()
+	s.userRepo.On("FindByID", mock.Anything, created.ID.Hex()).Return(created, nil).Once()
+	s.sessions.On("SignIn", mock.Anything, mock.MatchedBy(func(u userpkg.User) bool { return u.ID == created.ID }), mock.Anything).
+		Return(created, "access", "refresh", nil).Twice()
+
+	status, body := s.get(s.app.URL + "/auth/oidc/stub/login")
+	s.Equal(http.StatusOK, status, body)
+	s.Equal("access", body["access_token"])
+
+	// The second login finds the identity and creates nothing
+	status, body = s.get(s.app.URL + "/auth/oidc/stub/login")
+	s.Equal(http.StatusOK, status, body)
+	s.Len(s.repo.identities, 1)
+}
+
+func (s *IdentityControllerSuite) TestLogin_MFARequired() {
+	user := userpkg.User{ID: primitive.NewObjectID(), Email: "jane@example.com", IsVerified: true}
+	s.userRepo.On("ExistsByEmail", mock.Anything, "jane@example.com").Return(true, nil).Once()
+	s.userRepo.On("FindByEmail", mock.Anything, "jane@example.com").Return(user, nil).Once()
+	s.sessions.On("SignIn", mock.Anything, user, mock.Anything).
+		Return(userpkg.User{}, "", "", &userpkg.MFARequiredError{ChallengeToken: "challenge"}).Once()
+
+	status, body := s.get(s.app.URL + "/auth/oidc/stub/login")
+
+	s.Equal(http.StatusOK, status)
+	s.Equal(true, body["mfa_required"])
+	s.Equal("challenge", body["mfa_token"])
+}
+
+func (s *IdentityControllerSuite) TestCallback_StateIsSingleUse() {
+	client := &http.Client{CheckRedirect: func(req *http.Request, via []*http.Request) error {
+		if req.URL.Host == s.app.Listener.Addr().String() {
+			return http.ErrUseLastResponse // stop before our callback
+		}
+		return nil
+	}}
+	resp, err := client.Get(s.app.URL + "/auth/oidc/stub/login")
+	s.Require().NoError(err)
+	resp.Body.Close()
+	callback := resp.Header.Get("Location")
+
+	s.userRepo.On("ExistsByEmail", mock.Anything, "jane@example.com").Return(true, nil).Once()
+	s.userRepo.On("FindByEmail", mock.Anything, "jane@example.com").Return(userpkg.User{ID: primitive.NewObjectID(), IsVerified: true}, nil).Once()
+	s.sessions.On("SignIn", mock.Anything, mock.Anything, mock.Anything).Return(userpkg.User{}, "access", "refresh", nil).Once()
+	status, _ := s.get(callback)
+	s.Equal(http.StatusOK, status)
+
+	status, body := s.get(callback)
+	s.Equal(http.StatusBadRequest, status)
+	s.Equal(identitypkg.ErrStateNotFound.Error(), body["error"])
+}
+
+func (s *IdentityControllerSuite) TestLinkListAndUnlink() {
+	resp, err := http.Post(s.app.URL+"/me/identities/stub/link", "application/json", nil)
+	s.Require().NoError(err)
+	var link map[string]string
+	s.Require().NoError(json.NewDecoder(resp.Body).Decode(&link))
+	resp.Body.Close()
+
+	status, body := s.get(link["authorization_url"])
+	s.Equal(http.StatusOK, status, body)
+	s.Equal("Identity linked", body["message"])
+
+	status, body = s.get(s.app.URL + "/me/identities")
+	s.Equal(http.StatusOK, status)
+	identities := body["data"].([]interface{})
+	s.Require().Len(identities, 1)
+	id := identities[0].(map[string]interface{})["id"].(string)
+
+	s.userRepo.On("FindByID", mock.Anything, s.userID).Return(userpkg.User{Password: "hash"}, nil).Once()
+	req, _ := http.NewRequest(http.MethodDelete, s.app.URL+"/me/identities/"+id, nil)
+	resp, err = http.DefaultClient.Do(req)
+	s.Require().NoError(err)
+	resp.Body.Close()
+	s.Equal(http.StatusOK, resp.StatusCode)
+	s.Empty(s.repo.identities)
+}
+
+func (s *IdentityControllerSuite) TestLogin_UnknownProvider() {
+	status, _ := s.get(s.app.URL + "/auth/oidc/myspace/login")
+	s.Equal(http.StatusNotFound, status)
+}
+
+// memIdentityRepo is an in-memory identitypkg.IIdentityRepository
+type memIdentityRepo struct {
+	mu         sync.Mutex
+	identities []identitypkg.Identity
+	states     map[string]identitypkg.AuthState
+}
+
+func newMemIdentityRepo() *memIdentityRepo {
+	return &memIdentityRepo{states: map[string]identitypkg.AuthState{}}
+}
+
+func (r *memIdentityRepo) FindIdentity(_ context.Context, provider, subject string) (identitypkg.Identity, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, i := range r.identities {
+		if i.Provider == provider && i.Subject == subject {
+			return i, nil
+		}
+	}
+	return identitypkg.Identity{}, identitypkg.ErrIdentityNotFound
+}
+
+func (r *memIdentityRepo) ListIdentities(_ context.Context, userID string) ([]identitypkg.Identity, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var out []identitypkg.Identity
+	for _, i := range r.identities {
+		if i.UserID.Hex() == userID {
+			out = append(out, i)
+		}
+	}
+	return out, nil
+}
+
+func (r *memIdentityRepo) CreateIdentity(ctx context.Context, identity identitypkg.Identity) (identitypkg.Identity, error) {
+	if _, err := r.FindIdentity(ctx, identity.Provider, identity.Subject); err == nil {
+		return identitypkg.Identity{}, identitypkg.ErrIdentityTaken
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	identity.ID = primitive.NewObjectID()
+	r.identities = append(r.identities, identity)
+	return identity, nil
+}
+
+func (r *memIdentityRepo) TouchIdentity(context.Context, string) error { return nil }
+
+func (r *memIdentityRepo) DeleteIdentity(_ context.Context, userID, identityID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for n, i := range r.identities {
+		if i.UserID.Hex() == userID && i.ID.Hex() == identityID {
+			r.identities = append(r.identities[:n], r.identities[n+1:]...)
+			return nil
+		}
+	}
+	return identitypkg.ErrIdentityNotFound
+}
+
+func (r *memIdentityRepo) StoreState(_ context.Context, state identitypkg.AuthState) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.states[state.ID] = state
+	return nil
+}
+
+func (r *memIdentityRepo) ConsumeState(_ context.Context, id string) (identitypkg.AuthState, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	state, ok := r.states[id]
+	if !ok {
+		return identitypkg.AuthState{}, identitypkg.ErrStateNotFound
+	}
+	delete(r.states, id)
+	return state, nil
+}
@@ -0,0 +1,23 @@
+package controllers
+
+import (
+	"net/http"
+
+	signingkeypkg "github.com/Amaankaa/Blog-Starter-Project/Domain/signingkey"
+	"github.com/gin-gonic/gin"
+)
+
+type JWKSController struct {
+	keys signingkeypkg.IPublicKeySet
+}
+
+func NewJWKSController(keys signingkeypkg.IPublicKeySet) *JWKSController {
+	return &JWKSController{
+		keys: keys,
+	}
+}
+
+// GetJWKS publishes the public keys that verify our access and refresh tokens
+func (jc *JWKSController) GetJWKS(c *gin.Context) {
+	c.JSON(http.StatusOK, jc.keys.PublicKeys())
+}
@@ -0,0 +1,28 @@
+package controllers_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Amaankaa/Blog-Starter-Project/Delivery/controllers"
+	signingkeypkg "github.com/Amaankaa/Blog-Starter-Project/Domain/signingkey"
+	"github.com/Amaankaa/Blog-Starter-Project/mocks"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJWKSController_GetJWKS(t *testing.T) {
+	keys := mocks.NewIPublicKeySet(t)
+	keys.On("PublicKeys").Return(signingkeypkg.JSONWebKeySet{Keys: []signingkeypkg.JSONWebKey{
+		{KeyType: "OKP", Use: "sig", Algorithm: "EdDSA", KeyID: "k1", Curve: "Ed25519", X: "abc"},
+	}})
+	router := gin.New()
+	router.GET("/.well-known/jwks.json", controllers.NewJWKSController(keys).GetJWKS)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/.well-known/jwks.json", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"keys":[{"kty":"OKP","use":"sig","alg":"EdDSA","kid":"k1","crv":"Ed25519","x":"abc"}]}`, w.Body.String())
+}
@@ -0,0 +1,45 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	lockoutpkg "github.com/Amaankaa/Blog-Starter-Project/Domain/lockout"
+	"github.com/gin-gonic/gin"
+)
+
+type LockoutController struct {
+	lockout lockoutpkg.ILockoutUsecase
+}
+
+func NewLockoutController(lockout lockoutpkg.ILockoutUsecase) *LockoutController {
+	return &LockoutController{
+		lockout: lockout,
+	}
+}
+
+// Unlock takes the token from the link in the lockout email
+func (lc *LockoutController) Unlock(c *gin.Context) {
+	var req struct {
+		Token string `json:"token" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Second)
+	defer cancel()
+
+	err := lc.lockout.Unlock(ctx, req.Token)
+	if errors.Is(err, lockoutpkg.ErrUnlockTokenInvalid) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Account unlocked"})
+}
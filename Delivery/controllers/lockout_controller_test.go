@@ -0,0 +1,56 @@
+package controllers_test
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Amaankaa/Blog-Starter-Project/Delivery/controllers"
+	lockoutpkg "github.com/Amaankaa/Blog-Starter-Project/Domain/lockout"
+	"github.com/Amaankaa/Blog-Starter-Project/mocks"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type LockoutControllerSuite struct {
+	suite.Suite
+	lockout *mocks.ILockoutUsecase
+	router  *gin.Engine
+}
+
+func (s *LockoutControllerSuite) SetupTest() {
+	s.lockout = mocks.NewILockoutUsecase(s.T())
+	s.router = gin.Default()
+	s.router.POST("/unlock-account", controllers.NewLockoutController(s.lockout).Unlock)
+}
+
+func TestLockoutControllerSuite(t *testing.T) {
+	suite.Run(t, new(LockoutControllerSuite))
+}
+
+func (s *LockoutControllerSuite) do(body string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/unlock-account", bytes.NewBufferString(body)))
+	return w
+}
+
+func (s *LockoutControllerSuite) TestUnlock() {
+	s.lockout.On("Unlock", mock.Anything, "abc").Return(nil).Once()
+
+	s.Equal(http.StatusOK, s.do(`{"token":"abc"}`).Code)
+}
+
+func (s *LockoutControllerSuite) TestUnlock_InvalidToken() {
+	s.lockout.On("Unlock", mock.Anything, "abc").Return(lockoutpkg.ErrUnlockTokenInvalid).Once()
+
+	w := s.do(`{"token":"abc"}`)
+
+	s.Equal(http.StatusBadRequest, w.Code)
+	s.Contains(w.Body.String(), lockoutpkg.ErrUnlockTokenInvalid.Error())
+}
+
+func (s *LockoutControllerSuite) TestUnlock_MissingToken() {
+	s.Equal(http.StatusBadRequest, s.do(`{}`).Code)
+}
@@ -0,0 +1,151 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	mfapkg "github.com/Amaankaa/Blog-Starter-Project/Domain/mfa"
+	"github.com/gin-gonic/gin"
+)
+
+type MFAController struct {
+	mfa mfapkg.IMFAUsecase
+}
+
+func NewMFAController(mfa mfapkg.IMFAUsecase) *MFAController {
+	return &MFAController{
+		mfa: mfa,
+	}
+}
+
+type mfaCodeRequest struct {
+	Code string `json:"code" binding:"required"`
+}
+
+func (mc *MFAController) Status(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Second)
+	defer cancel()
+
+	status, err := mc.mfa.Status(ctx, c.GetString("user_id"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, status)
+}
+
+// Setup returns a new secret and its otpauth:// URI for the authenticator app
+func (mc *MFAController) Setup(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Second)
+	defer cancel()
+
+	setup, err := mc.mfa.BeginSetup(ctx, c.GetString("user_id"), c.GetString("username"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, setup)
+}
+
+// Enable confirms setup; the recovery codes in the response are never shown again
+func (mc *MFAController) Enable(c *gin.Context) {
+	var req mfaCodeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "code required"})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Second)
+	defer cancel()
+
+	codes, err := mc.mfa.Enable(ctx, c.GetString("user_id"), req.Code)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"recovery_codes": codes})
+}
+
+func (mc *MFAController) Disable(c *gin.Context) {
+	var req mfaCodeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "code required"})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Second)
+	defer cancel()
+
+	err := mc.mfa.Disable(ctx, c.GetString("user_id"), req.Code, deviceInfo(c))
+	if respondThrottled(c, err) {
+		return
+	}
+	if err != nil {
+		c.JSON(mfaErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Two-factor authentication disabled"})
+}
+
+func (mc *MFAController) RegenerateRecoveryCodes(c *gin.Context) {
+	var req mfaCodeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "code required"})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Second)
+	defer cancel()
+
+	codes, err := mc.mfa.RegenerateRecoveryCodes(ctx, c.GetString("user_id"), req.Code, deviceInfo(c))
+	if respondThrottled(c, err) {
+		return
+	}
+	if err != nil {
+		c.JSON(mfaErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"recovery_codes": codes})
+}
+
+func (mc *MFAController) GetPolicy(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Second)
+	defer cancel()
+
+	policy, err := mc.mfa.GetPolicy(ctx)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, policy)
+}
+
+func (mc *MFAController) SetPolicy(c *gin.Context) {
+	var policy mfapkg.Policy
+	if err := c.ShouldBindJSON(&policy); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body", "details": err.Error()})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Second)
+	defer cancel()
+
+	saved, err := mc.mfa.SetPolicy(ctx, policy, c.GetString("user_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, saved)
+}
+
+func mfaErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, mfapkg.ErrInvalidCode):
+		return http.StatusUnauthorized
+	case errors.Is(err, mfapkg.ErrNotEnrolled):
+		return http.StatusBadRequest
+	}
+	return http.StatusInternalServerError
+}
@@ -0,0 +1,112 @@
+package controllers_test
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Amaankaa/Blog-Starter-Project/Delivery/controllers"
+	lockoutpkg "github.com/Amaankaa/Blog-Starter-Project/Domain/lockout"
+	mfapkg "github.com/Amaankaa/Blog-Starter-Project/Domain/mfa"
+	"github.com/Amaankaa/Blog-Starter-Project/mocks"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type MFAControllerSuite struct {
+	suite.Suite
+	mfa    *mocks.IMFAUsecase
+	router *gin.Engine
+}
+
+func (s *MFAControllerSuite) SetupTest() {
+	s.mfa = mocks.NewIMFAUsecase(s.T())
+	controller := controllers.NewMFAController(s.mfa)
+	s.router = gin.Default()
+
+	s.router.Use(func(c *gin.Context) {
+		c.Set("user_id", "user-1")
+		c.Set("username", "jane")
+		c.Next()
+	})
+	s.router.GET("/me/2fa", controller.Status)
+	s.router.POST("/me/2fa/setup", controller.Setup)
+	s.router.POST("/me/2fa/enable", controller.Enable)
+	s.router.POST("/me/2fa/disable", controller.Disable)
+	s.router.PUT("/admin/security/2fa-policy", controller.SetPolicy)
+}
+
+func TestMFAControllerSuite(t *testing.T) {
+	suite.Run(t, new(MFAControllerSuite))
+}
+
+func (s *MFAControllerSuite) do(method, path, body string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, httptest.NewRequest(method, path, bytes.NewBufferString(body)))
+	return w
+}
+
+func (s *MFAControllerSuite) TestSetup() {
+	s.mfa.On("BeginSetup", mock.Anything, "user-1", "jane").
+		Return(mfapkg.Setup{Secret: "SECRET", ProvisioningURI: "otpauth://totp/Blog:jane?secret=SECRET"}, nil).Once()
+
+	w := s.do(http.MethodPost, "/me/2fa/setup", "")
+
+	s.Equal(http.StatusOK, w.Code)
+	s.JSONEq(`{"secret":"SECRET","otpauth_url":"otpauth://totp/Blog:jane?secret=SECRET"}`, w.Body.String())
+}
+
+func (s *MFAControllerSuite) TestEnable() {
+	s.mfa.On("Enable", mock.Anything, "user-1", "123456").Return([]string{"abcde-fghjk"}, nil).Once()
+
+	w := s.do(http.MethodPost, "/me/2fa/enable", `{"code":"123456"}`)
+
+	s.Equal(http.StatusOK, w.Code)
+	s.JSONEq(`{"recovery_codes":["abcde-fghjk"]}`, w.Body.String())
+}
+
+func (s *MFAControllerSuite) TestEnable_MissingCode() {
+	w := s.do(http.MethodPost, "/me/2fa/enable", `{}`)
+
+	s.Equal(http.StatusBadRequest, w.Code)
+}
+
+func (s *MFAControllerSuite) TestDisable_WrongCode() {
+	s.mfa.On("Disable", mock.Anything, "user-1", "000000", mock.Anything).Return(mfapkg.ErrInvalidCode).Once()
+
+	w := s.do(http.MethodPost, "/me/2fa/disable", `{"code":"000000"}`)
+
+	s.Equal(http.StatusUnauthorized, w.Code)
+}
+
+func (s *MFAControllerSuite) TestDisable_Throttled() {
+	s.mfa.On("Disable", mock.Anything, "user-1", "000000", mock.Anything).
+		Return(&lockoutpkg.ThrottledError{RetryAfter: 90 * time.Second, Locked: true}).Once()
+
+	w := s.do(http.MethodPost, "/me/2fa/disable", `{"code":"000000"}`)
+
+	s.Equal(http.StatusTooManyRequests, w.Code)
+	s.Equal("90", w.Header().Get("Retry-After"))
+}
+
+func (s *MFAControllerSuite) TestStatus() {
+	s.mfa.On("Status", mock.Anything, "user-1").Return(mfapkg.Status{Enabled: true, RecoveryCodesRemaining: 8}, nil).Once()
+
+	w := s.do(http.MethodGet, "/me/2fa", "")
+
+	s.Equal(http.StatusOK, w.Code)
+	s.JSONEq(`{"enabled":true,"recovery_codes_remaining":8}`, w.Body.String())
+}
+
+func (s *MFAControllerSuite) TestSetPolicy() {
+	s.mfa.On("SetPolicy", mock.Anything, mfapkg.Policy{RequireForAdmins: true}, "user-1").
+		Return(mfapkg.Policy{RequireForAdmins: true}, nil).Once()
+
+	w := s.do(http.MethodPut, "/admin/security/2fa-policy", `{"require_for_admins":true}`)
+
+	s.Equal(http.StatusOK, w.Code)
+	s.JSONEq(`{"require_for_admins":true}`, w.Body.String())
+}
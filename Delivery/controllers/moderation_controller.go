@@ -0,0 +1,119 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	blogpkg "github.com/Amaankaa/Blog-Starter-Project/Domain/blog"
+	"github.com/gin-gonic/gin"
+)
+
+type ModerationController struct {
+	moderation blogpkg.IModerationUsecase
+}
+
+func NewModerationController(moderation blogpkg.IModerationUsecase) *ModerationController {
+	return &ModerationController{
+		moderation: moderation,
+	}
+}
+
+// ReviewBlog approves a post or asks its author for changes (editors only)
+func (mc *ModerationController) ReviewBlog(c *gin.Context) {
+	var req blogpkg.ReviewRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body", "details": err.Error()})
+		return
+	}
+	ctx, cancel := mc.requestContext(c)
+	defer cancel()
+
+	blog, err := mc.moderation.ReviewBlog(ctx, c.Param("id"), req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, blog)
+}
+
+// DeleteComment removes a comment from a post (moderators only)
+func (mc *ModerationController) DeleteComment(c *gin.Context) {
+	ctx, cancel := mc.requestContext(c)
+	defer cancel()
+
+	err := mc.moderation.DeleteComment(ctx, c.Param("id"), c.Param("commentId"))
+	if errors.Is(err, blogpkg.ErrCommentNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Comment deleted"})
+}
+
+// ReportContent lets any signed-in user flag a post or one of its comments
+func (mc *ModerationController) ReportContent(c *gin.Context) {
+	var req blogpkg.ReportRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body", "details": err.Error()})
+		return
+	}
+	ctx, cancel := mc.requestContext(c)
+	defer cancel()
+
+	report, err := mc.moderation.ReportContent(ctx, c.Param("id"), req)
+	if errors.Is(err, blogpkg.ErrCommentNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, report)
+}
+
+// ListReports returns the report queue; ?status= picks resolved or dismissed reports instead of open ones
+func (mc *ModerationController) ListReports(c *gin.Context) {
+	ctx, cancel := mc.requestContext(c)
+	defer cancel()
+
+	reports, err := mc.moderation.ListReports(ctx, blogpkg.ReportStatus(c.Query("status")))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"data": reports})
+}
+
+// HandleReport closes an open report as resolved or dismissed
+func (mc *ModerationController) HandleReport(c *gin.Context) {
+	var req blogpkg.HandleReportRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body", "details": err.Error()})
+		return
+	}
+	ctx, cancel := mc.requestContext(c)
+	defer cancel()
+
+	report, err := mc.moderation.HandleReport(ctx, c.Param("id"), req)
+	if errors.Is(err, blogpkg.ErrReportNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, report)
+}
+
+// requestContext carries the caller's user ID to the usecase, which records who acted
+func (mc *ModerationController) requestContext(c *gin.Context) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Second)
+	return context.WithValue(ctx, "user_id", c.GetString("user_id")), cancel
+}
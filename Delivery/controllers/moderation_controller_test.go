@@ -0,0 +1,101 @@
+package controllers_test
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Amaankaa/Blog-Starter-Project/Delivery/controllers"
+	blogpkg "github.com/Amaankaa/Blog-Starter-Project/Domain/blog"
+	"github.com/Amaankaa/Blog-Starter-Project/mocks"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type ModerationControllerSuite struct {
+	suite.Suite
+	moderation *mocks.IModerationUsecase
+	router     *gin.Engine
+}
+
+func (s *ModerationControllerSuite) SetupTest() {
+	s.moderation = mocks.NewIModerationUsecase(s.T())
+	controller := controllers.NewModerationController(s.moderation)
+	s.router = gin.Default()
+	s.router.Use(func(c *gin.Context) { c.Set("user_id", "mod-1") })
+
+	s.router.POST("/blogs/:id/review", controller.ReviewBlog)
+	s.router.DELETE("/blogs/:id/comments/:commentId", controller.DeleteComment)
+	s.router.POST("/blogs/:id/report", controller.ReportContent)
+	s.router.GET("/admin/reports", controller.ListReports)
+	s.router.POST("/admin/reports/:id/handle", controller.HandleReport)
+}
+
+func TestModerationControllerSuite(t *testing.T) {
+	suite.Run(t, new(ModerationControllerSuite))
+}
+
+func (s *ModerationControllerSuite) do(method, path, body string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, httptest.NewRequest(method, path, bytes.NewBufferString(body)))
+	return w
+}
+
+// withCaller matches contexts carrying the signed-in user, which the usecase records as the actor
+func withCaller() interface{} {
+	return mock.MatchedBy(func(ctx interface{ Value(any) any }) bool { return ctx.Value("user_id") == "mod-1" })
+}
+
+func (s *ModerationControllerSuite) TestReviewBlog() {
+	req := blogpkg.ReviewRequest{Status: blogpkg.ReviewApproved}
+	s.moderation.On("ReviewBlog", withCaller(), "blog-1", req).
+		Return(&blogpkg.Blog{ID: "blog-1", Review: &blogpkg.Review{Status: blogpkg.ReviewApproved}}, nil).Once()
+
+	w := s.do(http.MethodPost, "/blogs/blog-1/review", `{"status":"approved"}`)
+
+	s.Equal(http.StatusOK, w.Code)
+	s.Contains(w.Body.String(), `"status":"approved"`)
+}
+
+func (s *ModerationControllerSuite) TestDeleteComment_NotFound() {
+	s.moderation.On("DeleteComment", mock.Anything, "blog-1", "c-1").Return(blogpkg.ErrCommentNotFound).Once()
+
+	w := s.do(http.MethodDelete, "/blogs/blog-1/comments/c-1", "")
+
+	s.Equal(http.StatusNotFound, w.Code)
+}
+
+func (s *ModerationControllerSuite) TestReportContent_RequiresAReason() {
+	w := s.do(http.MethodPost, "/blogs/blog-1/report", `{}`)
+
+	s.Equal(http.StatusBadRequest, w.Code)
+}
+
+func (s *ModerationControllerSuite) TestReportContent() {
+	req := blogpkg.ReportRequest{Reason: "spam"}
+	s.moderation.On("ReportContent", withCaller(), "blog-1", req).
+		Return(blogpkg.Report{BlogID: "blog-1", Status: blogpkg.ReportOpen}, nil).Once()
+
+	w := s.do(http.MethodPost, "/blogs/blog-1/report", `{"reason":"spam"}`)
+
+	s.Equal(http.StatusCreated, w.Code)
+}
+
+func (s *ModerationControllerSuite) TestListReports_PassesTheStatus() {
+	s.moderation.On("ListReports", mock.Anything, blogpkg.ReportDismissed).Return([]blogpkg.Report{}, nil).Once()
+
+	w := s.do(http.MethodGet, "/admin/reports?status=dismissed", "")
+
+	s.Equal(http.StatusOK, w.Code)
+}
+
+func (s *ModerationControllerSuite) TestHandleReport_AlreadyHandled() {
+	req := blogpkg.HandleReportRequest{Status: blogpkg.ReportResolved}
+	s.moderation.On("HandleReport", mock.Anything, "r-1", req).Return(blogpkg.Report{}, blogpkg.ErrReportNotFound).Once()
+
+	w := s.do(http.MethodPost, "/admin/reports/r-1/handle", `{"status":"resolved"}`)
+
+	s.Equal(http.StatusNotFound, w.Code)
+}
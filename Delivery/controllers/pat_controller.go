@@ -0,0 +1,68 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	patpkg "github.com/Amaankaa/Blog-Starter-Project/Domain/pat"
+	"github.com/gin-gonic/gin"
+)
+
+type PATController struct {
+	pats patpkg.IPATUsecase
+}
+
+func NewPATController(pats patpkg.IPATUsecase) *PATController {
+	return &PATController{
+		pats: pats,
+	}
+}
+
+// Create returns the token itself this one time only
+func (pc *PATController) Create(c *gin.Context) {
+	var req patpkg.CreateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Second)
+	defer cancel()
+
+	created, err := pc.pats.Create(ctx, c.GetString("user_id"), req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, created)
+}
+
+func (pc *PATController) List(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Second)
+	defer cancel()
+
+	tokens, err := pc.pats.List(ctx, c.GetString("user_id"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"data": tokens})
+}
+
+func (pc *PATController) Revoke(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Second)
+	defer cancel()
+
+	err := pc.pats.Revoke(ctx, c.GetString("user_id"), c.Param("id"))
+	if errors.Is(err, patpkg.ErrTokenNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Token revoked"})
+}
@@ -0,0 +1,70 @@
+package controllers_test
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Amaankaa/Blog-Starter-Project/Delivery/controllers"
+	patpkg "github.com/Amaankaa/Blog-Starter-Project/Domain/pat"
+	"github.com/Amaankaa/Blog-Starter-Project/mocks"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type PATControllerSuite struct {
+	suite.Suite
+	pats   *mocks.IPATUsecase
+	router *gin.Engine
+}
+
+func (s *PATControllerSuite) SetupTest() {
+	s.pats = mocks.NewIPATUsecase(s.T())
+	controller := controllers.NewPATController(s.pats)
+	s.router = gin.Default()
+
+	s.router.Use(func(c *gin.Context) {
+		c.Set("user_id", "user-1")
+		c.Next()
+	})
+	s.router.POST("/me/tokens", controller.Create)
+	s.router.GET("/me/tokens", controller.List)
+	s.router.DELETE("/me/tokens/:id", controller.Revoke)
+}
+
+func TestPATControllerSuite(t *testing.T) {
+	suite.Run(t, new(PATControllerSuite))
+}
+
+func (s *PATControllerSuite) do(method, path, body string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, httptest.NewRequest(method, path, bytes.NewBufferString(body)))
+	return w
+}
+
+func (s *PATControllerSuite) TestCreate() {
+	req := patpkg.CreateRequest{Name: "ci", Scopes: []string{"blogs:write"}, ExpiresInDays: 7}
+	s.pats.On("Create", mock.Anything, "user-1", req).
+		Return(patpkg.CreatedToken{PersonalAccessToken: patpkg.PersonalAccessToken{Name: "ci"}, Token: "bsp_pat_x"}, nil).Once()
+
+	w := s.do(http.MethodPost, "/me/tokens", `{"name":"ci","scopes":["blogs:write"],"expires_in_days":7}`)
+
+	s.Equal(http.StatusCreated, w.Code)
+	s.Contains(w.Body.String(), `"token":"bsp_pat_x"`)
+	s.NotContains(w.Body.String(), "token_hash")
+}
+
+func (s *PATControllerSuite) TestCreate_MissingScopes() {
+	w := s.do(http.MethodPost, "/me/tokens", `{"name":"ci"}`)
+	s.Equal(http.StatusBadRequest, w.Code)
+}
+
+func (s *PATControllerSuite) TestRevoke_NotFound() {
+	s.pats.On("Revoke", mock.Anything, "user-1", "t1").Return(patpkg.ErrTokenNotFound).Once()
+
+	w := s.do(http.MethodDelete, "/me/tokens/t1", "")
+
+	s.Equal(http.StatusNotFound, w.Code)
+}
@@ -0,0 +1,92 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	rolepkg "github.com/Amaankaa/Blog-Starter-Project/Domain/role"
+	"github.com/gin-gonic/gin"
+)
+
+type RoleController struct {
+	roles rolepkg.IRoleUsecase
+}
+
+func NewRoleController(roles rolepkg.IRoleUsecase) *RoleController {
+	return &RoleController{
+		roles: roles,
+	}
+}
+
+func (rc *RoleController) ListRoles(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Second)
+	defer cancel()
+
+	roles, err := rc.roles.ListRoles(ctx)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"data": roles})
+}
+
+// ListPermissions lists every permission a role can be granted
+func (rc *RoleController) ListPermissions(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"data": rolepkg.Permissions})
+}
+
+func (rc *RoleController) CreateRole(c *gin.Context) {
+	var req rolepkg.RoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Second)
+	defer cancel()
+
+	role, err := rc.roles.CreateRole(ctx, req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, role)
+}
+
+func (rc *RoleController) UpdateRole(c *gin.Context) {
+	var req rolepkg.RoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Second)
+	defer cancel()
+
+	role, err := rc.roles.UpdateRole(ctx, c.Param("name"), req)
+	if errors.Is(err, rolepkg.ErrRoleNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, role)
+}
+
+func (rc *RoleController) DeleteRole(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Second)
+	defer cancel()
+
+	err := rc.roles.DeleteRole(ctx, c.Param("name"))
+	if errors.Is(err, rolepkg.ErrRoleNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Role deleted"})
+}
@@ -0,0 +1,79 @@
+package controllers_test
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Amaankaa/Blog-Starter-Project/Delivery/controllers"
+	rolepkg "github.com/Amaankaa/Blog-Starter-Project/Domain/role"
+	"github.com/Amaankaa/Blog-Starter-Project/mocks"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type RoleControllerSuite struct {
+	suite.Suite
+	roles  *mocks.IRoleUsecase
+	router *gin.Engine
+}
+
+func (s *RoleControllerSuite) SetupTest() {
+	s.roles = mocks.NewIRoleUsecase(s.T())
+	controller := controllers.NewRoleController(s.roles)
+	s.router = gin.Default()
+
+	s.router.GET("/admin/roles", controller.ListRoles)
+	s.router.GET("/admin/permissions", controller.ListPermissions)
+	s.router.POST("/admin/roles", controller.CreateRole)
+	s.router.PUT("/admin/roles/:name", controller.UpdateRole)
+	s.router.DELETE("/admin/roles/:name", controller.DeleteRole)
+}
+
+func TestRoleControllerSuite(t *testing.T) {
+	suite.Run(t, new(RoleControllerSuite))
+}
+
+func (s *RoleControllerSuite) do(method, path, body string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, httptest.NewRequest(method, path, bytes.NewBufferString(body)))
+	return w
+}
+
+func (s *RoleControllerSuite) TestListPermissions() {
+	w := s.do(http.MethodGet, "/admin/permissions", "")
+
+	s.Equal(http.StatusOK, w.Code)
+	s.Contains(w.Body.String(), rolepkg.PermPostsFeature)
+}
+
+func (s *RoleControllerSuite) TestCreateRole() {
+	req := rolepkg.RoleRequest{Name: "support", Permissions: []string{"comments:moderate"}}
+	s.roles.On("CreateRole", mock.Anything, req).
+		Return(rolepkg.Role{Name: "support", Permissions: req.Permissions}, nil).Once()
+
+	w := s.do(http.MethodPost, "/admin/roles", `{"name":"support","permissions":["comments:moderate"]}`)
+
+	s.Equal(http.StatusCreated, w.Code)
+	s.Contains(w.Body.String(), `"name":"support"`)
+}
+
+func (s *RoleControllerSuite) TestUpdateRole_NotFound() {
+	s.roles.On("UpdateRole", mock.Anything, "ghost", mock.Anything).Return(rolepkg.Role{}, rolepkg.ErrRoleNotFound).Once()
+
+	w := s.do(http.MethodPut, "/admin/roles/ghost", `{"permissions":[]}`)
+
+	s.Equal(http.StatusNotFound, w.Code)
+}
+
+func (s *RoleControllerSuite) TestDeleteRole_Refused() {
+	s.roles.On("DeleteRole", mock.Anything, "editor").Return(errors.New("built-in roles cannot be deleted")).Once()
+
+	w := s.do(http.MethodDelete, "/admin/roles/editor", "")
+
+	s.Equal(http.StatusBadRequest, w.Code)
+	s.Contains(w.Body.String(), "built-in roles cannot be deleted")
+}
@@ -2,10 +2,17 @@ package controllers
 
 import (
 	"context"
+	"errors"
+	"math"
 	"net/http"
 	"os"
+	"strconv"
+	"strings"
 	"time"
 
+	lockoutpkg "github.com/Amaankaa/Blog-Starter-Project/Domain/lockout"
+	otppkg "github.com/Amaankaa/Blog-Starter-Project/Domain/otp"
+	rolepkg "github.com/Amaankaa/Blog-Starter-Project/Domain/role"
 	userpkg "github.com/Amaankaa/Blog-Starter-Project/Domain/user"
 	"github.com/gin-gonic/gin"
 )
@@ -30,6 +37,11 @@ func (ctrl *Controller) Register(c *gin.Context) {
 		return
 	}
 
+	// Emails default to the browser's language unless the client picked one
+	if user.Language == "" {
+		user.Language = primaryLanguage(c.GetHeader("Accept-Language"))
+	}
+
 	// 2. Create context with timeout (e.g. 20s)
 	//We needed a longer timeout to verify the emails validity
 	ctx, cancel := context.WithTimeout(c.Request.Context(), 20*time.Second)
@@ -65,7 +77,44 @@ func (ctrl *Controller) Login(c *gin.Context) {
 	ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Second)
 	defer cancel()
 
-	user, accessToken, refreshToken, err := ctrl.userUsecase.LoginUser(ctx, input.Login, input.Password)
+	user, accessToken, refreshToken, err := ctrl.userUsecase.LoginUser(ctx, input.Login, input.Password, deviceInfo(c))
+	if respondMFARequired(c, err) {
+		return
+	}
+	if respondThrottled(c, err) {
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"user":          user,
+		"access_token":  accessToken,
+		"refresh_token": refreshToken,
+	})
+}
+
+// LoginMFA completes a login with the challenge token from Login and a TOTP or recovery code
+func (ctrl *Controller) LoginMFA(c *gin.Context) {
+	var input struct {
+		MFAToken string `json:"mfa_token" binding:"required"`
+		Code     string `json:"code" binding:"required"`
+	}
+
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Second)
+	defer cancel()
+
+	user, accessToken, refreshToken, err := ctrl.userUsecase.CompleteMFALogin(ctx, input.MFAToken, input.Code, deviceInfo(c))
+	if respondThrottled(c, err) {
+		return
+	}
 	if err != nil {
 		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
 		return
@@ -91,7 +140,7 @@ func (ctrl *Controller) RefreshToken(c *gin.Context) {
 	ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Second)
 	defer cancel()
 
-	newTokens, err := ctrl.userUsecase.RefreshToken(ctx, body.RefreshToken)
+	newTokens, err := ctrl.userUsecase.RefreshToken(ctx, body.RefreshToken, deviceInfo(c))
 	if err != nil {
 		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
 		return
@@ -110,7 +159,11 @@ func (ctrl *Controller) ForgotPassword(c *gin.Context) {
 	ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Second)
 	defer cancel()
 
-	if err := ctrl.userUsecase.SendResetOTP(ctx, req.Email); err != nil {
+	err := ctrl.userUsecase.SendResetOTP(ctx, req.Email)
+	if respondCooldown(c, err) {
+		return
+	}
+	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
@@ -132,18 +185,19 @@ func (ctrl *Controller) VerifyOTP(c *gin.Context) {
 	ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Second)
 	defer cancel()
 
-	if err := ctrl.userUsecase.VerifyOTP(ctx, req.Email, req.OTP); err != nil {
+	resetToken, err := ctrl.userUsecase.VerifyOTP(ctx, req.Email, req.OTP)
+	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"message": "OTP verified, you can reset your password."})
+	c.JSON(http.StatusOK, gin.H{"message": "OTP verified, you can reset your password.", "reset_token": resetToken})
 }
 
 func (ctrl *Controller) ResetPassword(c *gin.Context) {
 	var req struct {
-		Email       string `json:"email"`
-		NewPassword string `json:"new_password"`
+		ResetToken  string `json:"reset_token" binding:"required"`
+		NewPassword string `json:"new_password" binding:"required"`
 	}
 
 	if err := c.ShouldBindJSON(&req); err != nil {
@@ -154,7 +208,7 @@ func (ctrl *Controller) ResetPassword(c *gin.Context) {
 	ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Second)
 	defer cancel()
 
-	if err := ctrl.userUsecase.ResetPassword(ctx, req.Email, req.NewPassword); err != nil {
+	if err := ctrl.userUsecase.ResetPassword(ctx, req.ResetToken, req.NewPassword, deviceInfo(c)); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
@@ -163,13 +217,13 @@ func (ctrl *Controller) ResetPassword(c *gin.Context) {
 }
 
 func (ctrl *Controller) Logout(c *gin.Context) {
-	userID := c.GetString("userID") // assuming middleware sets this
+	userID := c.GetString("user_id")
 	if userID == "" {
 		c.JSON(http.StatusUnauthorized, gin.H{"message": "Unauthorized"})
 		return
 	}
 
-	err := ctrl.userUsecase.Logout(c.Request.Context(), userID)
+	err := ctrl.userUsecase.Logout(c.Request.Context(), userID, c.GetString("session_id"))
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"message": "Logout failed"})
 		return
@@ -218,6 +272,34 @@ func (ctrl *Controller) DemoteUser(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"message": "user demoted"})
 }
 
+func (ctrl *Controller) AssignRole(c *gin.Context) {
+	var req struct {
+		Role string `json:"role" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	actorID, ok := c.Get("user_id")
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "missing user context"})
+		return
+	}
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Second)
+	defer cancel()
+
+	err := ctrl.userUsecase.AssignRole(ctx, c.Param("id"), req.Role, actorID.(string))
+	if errors.Is(err, rolepkg.ErrRoleNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "role updated", "role": req.Role})
+}
+
 func (ctrl *Controller) VerifyUser(c *gin.Context) {
 	var req struct {
 		Email string `json:"email"`
@@ -240,6 +322,49 @@ func (ctrl *Controller) VerifyUser(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"message": "User verified"})
 }
 
+func (ctrl *Controller) RequestEmailChange(c *gin.Context) {
+	var req struct {
+		Password string `json:"password" binding:"required"`
+		NewEmail string `json:"new_email" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Second)
+	defer cancel()
+
+	err := ctrl.userUsecase.RequestEmailChange(ctx, c.GetString("user_id"), req.Password, req.NewEmail)
+	if respondCooldown(c, err) {
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "We sent a code to your new email address"})
+}
+
+func (ctrl *Controller) ConfirmEmailChange(c *gin.Context) {
+	var req struct {
+		Code string `json:"code" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Second)
+	defer cancel()
+
+	if err := ctrl.userUsecase.ConfirmEmailChange(ctx, c.GetString("user_id"), req.Code, deviceInfo(c)); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Email address updated"})
+}
+
 func (ctrl *Controller) GetProfile(c *gin.Context) {
 
     userID := c.GetString("user_id")
@@ -293,6 +418,9 @@ func (ctrl *Controller) UpdateProfile(c *gin.Context) {
     if linkedin := c.PostForm("linkedin"); linkedin != "" {
         updates.ContactInfo.LinkedIn = linkedin
     }
+    if language := c.PostForm("language"); language != "" {
+        updates.Language = language
+    }
 
     // Handle file upload
     file, header, err := c.Request.FormFile("profilePicture")
@@ -319,3 +447,103 @@ func (ctrl *Controller) UpdateProfile(c *gin.Context) {
 
     c.JSON(http.StatusOK, updatedUser)
 }
+
+func (ctrl *Controller) ListSessions(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Second)
+	defer cancel()
+
+	sessions, err := ctrl.userUsecase.ListSessions(ctx, c.GetString("user_id"), c.GetString("session_id"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"data": sessions})
+}
+
+func (ctrl *Controller) RevokeSession(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Second)
+	defer cancel()
+
+	err := ctrl.userUsecase.RevokeSession(ctx, c.GetString("user_id"), c.Param("id"))
+	if errors.Is(err, userpkg.ErrSessionNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Session revoked"})
+}
+
+// RevokeOtherSessions logs the user out everywhere except the device making the request
+func (ctrl *Controller) RevokeOtherSessions(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Second)
+	defer cancel()
+
+	if err := ctrl.userUsecase.RevokeOtherSessions(ctx, c.GetString("user_id"), c.GetString("session_id")); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Logged out of all other sessions"})
+}
+
+// respondThrottled answers an attempt the login guard turned away with 429 and Retry-After.
+// It reports whether err was such a refusal.
+func respondThrottled(c *gin.Context, err error) bool {
+	var throttled *lockoutpkg.ThrottledError
+	if !errors.As(err, &throttled) {
+		return false
+	}
+	c.Header("Retry-After", strconv.Itoa(int(math.Ceil(throttled.RetryAfter.Seconds()))))
+	c.JSON(http.StatusTooManyRequests, gin.H{"error": err.Error()})
+	return true
+}
+
+// respondMFARequired answers a login that still needs a second factor, which the client
+// finishes at /login/2fa. It reports whether err was such a login.
+func respondMFARequired(c *gin.Context, err error) bool {
+	var mfaRequired *userpkg.MFARequiredError
+	if !errors.As(err, &mfaRequired) {
+		return false
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"mfa_required":   true,
+		"mfa_token":      mfaRequired.ChallengeToken,
+		"mfa_expires_at": mfaRequired.ExpiresAt,
+	})
+	return true
+}
+
+// respondCooldown answers a request for a new code that came too soon after the last one.
+// It reports whether err was such a request.
+func respondCooldown(c *gin.Context, err error) bool {
+	var cooldown *otppkg.CooldownError
+	if !errors.As(err, &cooldown) {
+		return false
+	}
+	c.Header("Retry-After", strconv.Itoa(int(math.Ceil(cooldown.RetryAfter.Seconds()))))
+	c.JSON(http.StatusTooManyRequests, gin.H{"error": err.Error()})
+	return true
+}
+
+// deviceInfo describes the client for the session list and login lockouts. ClientIP only
+// follows X-Forwarded-For from the proxies the router trusts, so clients can't pick their address.
+func deviceInfo(c *gin.Context) userpkg.DeviceInfo {
+	userAgent := c.Request.UserAgent()
+	if len(userAgent) > 512 {
+		userAgent = userAgent[:512]
+	}
+	return userpkg.DeviceInfo{UserAgent: userAgent, IPAddress: c.ClientIP()}
+}
+
+// primaryLanguage returns the first tag of an Accept-Language header, ignoring weights
+func primaryLanguage(header string) string {
+	first, _, _ := strings.Cut(header, ",")
+	tag, _, _ := strings.Cut(first, ";")
+	tag = strings.TrimSpace(tag)
+	if len(tag) < 2 || len(tag) > 35 || strings.Trim(tag, "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789-") != "" {
+		return "" // "*" or a malformed header; the default locale is used
+	}
+	return tag
+}
@@ -10,6 +10,9 @@ import (
 	"time"
 
 	"github.com/Amaankaa/Blog-Starter-Project/Delivery/controllers"
+	lockoutpkg "github.com/Amaankaa/Blog-Starter-Project/Domain/lockout"
+	otppkg "github.com/Amaankaa/Blog-Starter-Project/Domain/otp"
+	rolepkg "github.com/Amaankaa/Blog-Starter-Project/Domain/role"
 	userpkg "github.com/Amaankaa/Blog-Starter-Project/Domain/user"
 	mock_user "github.com/Amaankaa/Blog-Starter-Project/mocks"
 	"github.com/gin-gonic/gin"
@@ -32,6 +35,7 @@ func (s *ControllerTestSuite) SetupTest() {
 	// Registration verification endpoint
 	s.router.POST("/verify-user", ctrl.VerifyUser)
 	s.router.POST("/login", ctrl.Login)
+	s.router.POST("/login/2fa", ctrl.LoginMFA)
 	s.router.POST("/forgot-password", ctrl.ForgotPassword)
 	s.router.POST("/refresh", ctrl.RefreshToken)
 	s.router.POST("/verify-otp", ctrl.VerifyOTP)
@@ -43,6 +47,19 @@ func (s *ControllerTestSuite) SetupTest() {
 	}
 	s.router.PUT("/user/:id/promote", addActor, ctrl.PromoteUser)
 	s.router.PUT("/user/:id/demote", addActor, ctrl.DemoteUser)
+	s.router.PUT("/admin/users/:id/role", addActor, ctrl.AssignRole)
+
+	addSession := func(c *gin.Context) {
+		c.Set("user_id", "user-1")
+		c.Set("session_id", "laptop")
+		c.Next()
+	}
+	s.router.POST("/logout", addSession, ctrl.Logout)
+	s.router.GET("/me/sessions", addSession, ctrl.ListSessions)
+	s.router.DELETE("/me/sessions/:id", addSession, ctrl.RevokeSession)
+	s.router.POST("/me/sessions/logout-others", addSession, ctrl.RevokeOtherSessions)
+	s.router.POST("/me/email", addSession, ctrl.RequestEmailChange)
+	s.router.POST("/me/email/confirm", addSession, ctrl.ConfirmEmailChange)
 }
 
 func (s *ControllerTestSuite) performRequest(method, path string, body interface{}) *httptest.ResponseRecorder {
@@ -72,7 +89,7 @@ func (s *ControllerTestSuite) TestRefreshToken_Success() {
 		AccessExpiresAt:  time.Now().Add(1 * time.Hour),
 		RefreshExpiresAt: time.Now().Add(24 * time.Hour),
 	}
-	s.mockUC.On("RefreshToken", mock.Anything, "valid-refresh-token").
+	s.mockUC.On("RefreshToken", mock.Anything, "valid-refresh-token", mock.Anything).
 		Return(expected, nil)
 
 	w := s.performRequest("POST", "/refresh", map[string]string{"refresh_token": "valid-refresh-token"})
@@ -86,23 +103,135 @@ func (s *ControllerTestSuite) TestRefreshToken_Success() {
 	s.False(res.RefreshExpiresAt.IsZero())
 }
 
+func (s *ControllerTestSuite) TestRefreshToken_UsesLoginFieldNames() {
+	s.mockUC.On("RefreshToken", mock.Anything, "valid-refresh-token", mock.Anything).
+		Return(userpkg.TokenResult{AccessToken: "new-access", RefreshToken: "new-refresh"}, nil)
+
+	w := s.performRequest("POST", "/refresh", map[string]string{"refresh_token": "valid-refresh-token"})
+
+	s.Equal(http.StatusOK, w.Code)
+	s.Contains(w.Body.String(), `"access_token":"new-access"`)
+	s.Contains(w.Body.String(), `"refresh_token":"new-refresh"`)
+}
+
 func (s *ControllerTestSuite) TestRefreshToken_MissingToken() {
 	w := s.performRequest("POST", "/refresh", map[string]string{})
 	s.Equal(http.StatusBadRequest, w.Code)
 }
 
 func (s *ControllerTestSuite) TestRefreshToken_InvalidToken() {
-	s.mockUC.On("RefreshToken", mock.Anything, "bad-token").
+	s.mockUC.On("RefreshToken", mock.Anything, "bad-token", mock.Anything).
 		Return(userpkg.TokenResult{}, errors.New("unauthorized"))
 
 	w := s.performRequest("POST", "/refresh", map[string]string{"refresh_token": "bad-token"})
 	s.Equal(http.StatusUnauthorized, w.Code)
 }
 
+func (s *ControllerTestSuite) TestRefreshToken_Reused() {
+	s.mockUC.On("RefreshToken", mock.Anything, "rotated-token", mock.Anything).
+		Return(userpkg.TokenResult{}, userpkg.ErrRefreshTokenReused)
+
+	w := s.performRequest("POST", "/refresh", map[string]string{"refresh_token": "rotated-token"})
+	s.Equal(http.StatusUnauthorized, w.Code)
+	s.Contains(w.Body.String(), "refresh token reuse detected")
+}
+
+func (s *ControllerTestSuite) TestRefreshToken_RecordsDevice() {
+	s.mockUC.On("RefreshToken", mock.Anything, "valid-refresh-token", userpkg.DeviceInfo{UserAgent: "Firefox", IPAddress: "192.0.2.1"}).
+		Return(userpkg.TokenResult{AccessToken: "new-access"}, nil)
+
+	b, _ := json.Marshal(map[string]string{"refresh_token": "valid-refresh-token"})
+	req := httptest.NewRequest("POST", "/refresh", bytes.NewBuffer(b))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "Firefox")
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+
+	s.Equal(http.StatusOK, w.Code)
+	s.mockUC.AssertExpectations(s.T())
+}
+
+func (s *ControllerTestSuite) TestLogout_EndsCurrentSession() {
+	s.mockUC.On("Logout", mock.Anything, "user-1", "laptop").Return(nil).Once()
+
+	w := s.performRequest("POST", "/logout", nil)
+
+	s.Equal(http.StatusOK, w.Code)
+	s.mockUC.AssertExpectations(s.T())
+}
+
+func (s *ControllerTestSuite) TestListSessions() {
+	s.mockUC.On("ListSessions", mock.Anything, "user-1", "laptop").
+		Return([]userpkg.Session{{ID: "laptop", UserAgent: "Firefox", Current: true}}, nil).Once()
+
+	w := s.performRequest("GET", "/me/sessions", nil)
+
+	s.Equal(http.StatusOK, w.Code)
+	s.Contains(w.Body.String(), `"id":"laptop"`)
+	s.Contains(w.Body.String(), `"current":true`)
+}
+
+func (s *ControllerTestSuite) TestRevokeSession() {
+	s.mockUC.On("RevokeSession", mock.Anything, "user-1", "phone").Return(nil).Once()
+
+	w := s.performRequest("DELETE", "/me/sessions/phone", nil)
+	s.Equal(http.StatusOK, w.Code)
+}
+
+func (s *ControllerTestSuite) TestRevokeSession_NotFound() {
+	s.mockUC.On("RevokeSession", mock.Anything, "user-1", "ghost").Return(userpkg.ErrSessionNotFound).Once()
+
+	w := s.performRequest("DELETE", "/me/sessions/ghost", nil)
+	s.Equal(http.StatusNotFound, w.Code)
+}
+
+func (s *ControllerTestSuite) TestRevokeOtherSessions() {
+	s.mockUC.On("RevokeOtherSessions", mock.Anything, "user-1", "laptop").Return(nil).Once()
+
+	w := s.performRequest("POST", "/me/sessions/logout-others", nil)
+
+	s.Equal(http.StatusOK, w.Code)
+	s.mockUC.AssertExpectations(s.T())
+}
+
+func (s *ControllerTestSuite) TestForgotPassword_Cooldown() {
+	s.mockUC.On("SendResetOTP", mock.Anything, "test@example.com").Return(&otppkg.CooldownError{RetryAfter: 42 * time.Second})
+
+	w := s.performRequest("POST", "/forgot-password", map[string]string{"email": "test@example.com"})
+
+	s.Equal(http.StatusTooManyRequests, w.Code)
+	s.Equal("42", w.Header().Get("Retry-After"))
+}
+
+func (s *ControllerTestSuite) TestRequestEmailChange() {
+	s.mockUC.On("RequestEmailChange", mock.Anything, "user-1", "Secret123!", "new@example.com").Return(nil)
+
+	w := s.performRequest("POST", "/me/email", map[string]string{"password": "Secret123!", "new_email": "new@example.com"})
+
+	s.Equal(http.StatusOK, w.Code)
+}
+
+func (s *ControllerTestSuite) TestRequestEmailChange_MissingPassword() {
+	w := s.performRequest("POST", "/me/email", map[string]string{"new_email": "new@example.com"})
+
+	s.Equal(http.StatusBadRequest, w.Code)
+	s.mockUC.AssertNotCalled(s.T(), "RequestEmailChange", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (s *ControllerTestSuite) TestConfirmEmailChange_InvalidCode() {
+	s.mockUC.On("ConfirmEmailChange", mock.Anything, "user-1", "000000", mock.Anything).Return(otppkg.ErrInvalidCode)
+
+	w := s.performRequest("POST", "/me/email/confirm", map[string]string{"code": "000000"})
+
+	s.Equal(http.StatusBadRequest, w.Code)
+	s.Contains(w.Body.String(), "invalid code")
+}
+
 func (s *ControllerTestSuite) TestVerifyOTP_Success() {
-	s.mockUC.On("VerifyOTP", mock.Anything, "test@example.com", "123456").Return(nil)
+	s.mockUC.On("VerifyOTP", mock.Anything, "test@example.com", "123456").Return("reset-token", nil)
 	w := s.performRequest("POST", "/verify-otp", map[string]string{"email": "test@example.com", "otp": "123456"})
 	s.Equal(http.StatusOK, w.Code)
+	s.Contains(w.Body.String(), `"reset_token":"reset-token"`)
 }
 
 func (s *ControllerTestSuite) TestVerifyOTP_InvalidJSON() {
@@ -114,17 +243,23 @@ func (s *ControllerTestSuite) TestVerifyOTP_InvalidJSON() {
 }
 
 func (s *ControllerTestSuite) TestVerifyOTP_WrongOTP() {
-	s.mockUC.On("VerifyOTP", mock.Anything, "test@example.com", "wrong").Return(errors.New("invalid otp"))
+	s.mockUC.On("VerifyOTP", mock.Anything, "test@example.com", "wrong").Return("", errors.New("invalid otp"))
 	w := s.performRequest("POST", "/verify-otp", map[string]string{"email": "test@example.com", "otp": "wrong"})
 	s.Equal(http.StatusBadRequest, w.Code)
 }
 
 func (s *ControllerTestSuite) TestResetPassword_Success() {
-	s.mockUC.On("ResetPassword", mock.Anything, "test@example.com", "NewPass@123").Return(nil)
-	w := s.performRequest("POST", "/reset-password", map[string]string{"email": "test@example.com", "new_password": "NewPass@123"})
+	s.mockUC.On("ResetPassword", mock.Anything, "reset-token", "NewPass@123", mock.Anything).Return(nil)
+	w := s.performRequest("POST", "/reset-password", map[string]string{"reset_token": "reset-token", "new_password": "NewPass@123"})
 	s.Equal(http.StatusOK, w.Code)
 }
 
+func (s *ControllerTestSuite) TestResetPassword_MissingToken() {
+	w := s.performRequest("POST", "/reset-password", map[string]string{"email": "test@example.com", "new_password": "NewPass@123"})
+	s.Equal(http.StatusBadRequest, w.Code)
+	s.mockUC.AssertNotCalled(s.T(), "ResetPassword", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
 func (s *ControllerTestSuite) TestResetPassword_InvalidJSON() {
 	req := httptest.NewRequest("POST", "/reset-password", bytes.NewBuffer([]byte(`{"email":`)))
 	req.Header.Set("Content-Type", "application/json")
@@ -134,8 +269,8 @@ func (s *ControllerTestSuite) TestResetPassword_InvalidJSON() {
 }
 
 func (s *ControllerTestSuite) TestResetPassword_WeakPassword() {
-	s.mockUC.On("ResetPassword", mock.Anything, "test@example.com", "123").Return(errors.New("weak password"))
-	w := s.performRequest("POST", "/reset-password", map[string]string{"email": "test@example.com", "new_password": "123"})
+	s.mockUC.On("ResetPassword", mock.Anything, "reset-token", "123", mock.Anything).Return(errors.New("weak password"))
+	w := s.performRequest("POST", "/reset-password", map[string]string{"reset_token": "reset-token", "new_password": "123"})
 	s.Equal(http.StatusBadRequest, w.Code)
 }
 
@@ -174,7 +309,7 @@ func (s *ControllerTestSuite) TestRegister_VerificationFail() {
 }
 
 func (s *ControllerTestSuite) TestLogin_InvalidCredentials() {
-	s.mockUC.On("LoginUser", mock.Anything, "user1", "wrongpass").
+	s.mockUC.On("LoginUser", mock.Anything, "user1", "wrongpass", mock.Anything).
 		Return(userpkg.User{}, "", "", errors.New("invalid credentials"))
 
 	w := s.performRequest("POST", "/login", map[string]string{"login": "user1", "password": "wrongpass"})
@@ -183,12 +318,52 @@ func (s *ControllerTestSuite) TestLogin_InvalidCredentials() {
 
 func (s *ControllerTestSuite) TestLogin_Unverified() {
 	// usecase.LoginUser returns error "email not verified"
-	s.mockUC.On("LoginUser", mock.Anything, "user1", "pass").Return(userpkg.User{}, "", "", errors.New("email not verified"))
+	s.mockUC.On("LoginUser", mock.Anything, "user1", "pass", mock.Anything).Return(userpkg.User{}, "", "", errors.New("email not verified"))
 
 	w := s.performRequest("POST", "/login", map[string]string{"login": "user1", "password": "pass"})
 	s.Equal(http.StatusUnauthorized, w.Code)
 }
 
+func (s *ControllerTestSuite) TestLogin_MFARequired() {
+	expiresAt := time.Date(2030, 1, 1, 12, 0, 0, 0, time.UTC)
+	s.mockUC.On("LoginUser", mock.Anything, "user1", "pass", mock.Anything).
+		Return(userpkg.User{}, "", "", &userpkg.MFARequiredError{ChallengeToken: "challenge", ExpiresAt: expiresAt})
+
+	w := s.performRequest("POST", "/login", map[string]string{"login": "user1", "password": "pass"})
+
+	s.Equal(http.StatusOK, w.Code)
+	s.JSONEq(`{"mfa_required":true,"mfa_token":"challenge","mfa_expires_at":"2030-01-01T12:00:00Z"}`, w.Body.String())
+}
+
+func (s *ControllerTestSuite) TestLogin_Throttled() {
+	s.mockUC.On("LoginUser", mock.Anything, "user1", "pass", mock.Anything).
+		Return(userpkg.User{}, "", "", &lockoutpkg.ThrottledError{RetryAfter: 1500 * time.Millisecond})
+
+	w := s.performRequest("POST", "/login", map[string]string{"login": "user1", "password": "pass"})
+
+	s.Equal(http.StatusTooManyRequests, w.Code)
+	s.Equal("2", w.Header().Get("Retry-After"))
+}
+
+func (s *ControllerTestSuite) TestLoginMFA() {
+	s.mockUC.On("CompleteMFALogin", mock.Anything, "challenge", "123456", mock.Anything).
+		Return(userpkg.User{Username: "user1"}, "access", "refresh", nil)
+
+	w := s.performRequest("POST", "/login/2fa", map[string]string{"mfa_token": "challenge", "code": "123456"})
+
+	s.Equal(http.StatusOK, w.Code)
+	s.Contains(w.Body.String(), `"access_token":"access"`)
+}
+
+func (s *ControllerTestSuite) TestLoginMFA_InvalidCode() {
+	s.mockUC.On("CompleteMFALogin", mock.Anything, "challenge", "000000", mock.Anything).
+		Return(userpkg.User{}, "", "", errors.New("invalid two-factor code"))
+
+	w := s.performRequest("POST", "/login/2fa", map[string]string{"mfa_token": "challenge", "code": "000000"})
+
+	s.Equal(http.StatusUnauthorized, w.Code)
+}
+
 func (s *ControllerTestSuite) TestPromoteUser_Success() {
 	id := "user123"
 	s.mockUC.On("PromoteUser", mock.Anything, id, "admin999").Return(nil)
@@ -233,6 +408,25 @@ func (s *ControllerTestSuite) TestDemoteUser_Error() {
 	s.Contains(w.Body.String(), "fail to demote")
 }
 
+func (s *ControllerTestSuite) TestAssignRole_Success() {
+	s.mockUC.On("AssignRole", mock.Anything, "user456", "editor", "admin999").Return(nil).Once()
+	w := s.performRequest(http.MethodPut, "/admin/users/user456/role", map[string]string{"role": "editor"})
+	s.Equal(http.StatusOK, w.Code)
+	s.Contains(w.Body.String(), "role updated")
+}
+
+func (s *ControllerTestSuite) TestAssignRole_UnknownRole() {
+	s.mockUC.On("AssignRole", mock.Anything, "user456", "wizard", "admin999").Return(rolepkg.ErrRoleNotFound).Once()
+	w := s.performRequest(http.MethodPut, "/admin/users/user456/role", map[string]string{"role": "wizard"})
+	s.Equal(http.StatusNotFound, w.Code)
+}
+
+func (s *ControllerTestSuite) TestAssignRole_MissingRole() {
+	w := s.performRequest(http.MethodPut, "/admin/users/user456/role", map[string]string{})
+	s.Equal(http.StatusBadRequest, w.Code)
+	s.mockUC.AssertNotCalled(s.T(), "AssignRole", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
 func (s *ControllerTestSuite) TestGetProfile_Success() {
 	// Arrange
 	expectedUser := userpkg.User{
@@ -0,0 +1,88 @@
+package controllers
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	webhookpkg "github.com/Amaankaa/Blog-Starter-Project/Domain/webhook"
+	"github.com/gin-gonic/gin"
+)
+
+type WebhookController struct {
+	webhookUsecase webhookpkg.IWebhookUsecase
+}
+
+func NewWebhookController(webhookUsecase webhookpkg.IWebhookUsecase) *WebhookController {
+	return &WebhookController{
+		webhookUsecase: webhookUsecase,
+	}
+}
+
+// RegisterWebhook creates a webhook; the signing secret is only returned in this response
+func (wc *WebhookController) RegisterWebhook(c *gin.Context) {
+	var req webhookpkg.CreateWebhookRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body", "details": err.Error()})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	hook, err := wc.webhookUsecase.RegisterWebhook(ctx, req, c.GetString("user_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, hook)
+}
+
+func (wc *WebhookController) ListWebhooks(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	hooks, err := wc.webhookUsecase.ListWebhooks(ctx)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"data": hooks})
+}
+
+func (wc *WebhookController) DeleteWebhook(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if err := wc.webhookUsecase.DeleteWebhook(ctx, c.Param("id")); err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Webhook deleted"})
+}
+
+// ListDeliveries returns the most recent delivery attempts of a webhook
+func (wc *WebhookController) ListDeliveries(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	deliveries, err := wc.webhookUsecase.ListDeliveries(ctx, c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"data": deliveries})
+}
+
+// ReplayDelivery queues a past delivery to be sent again
+func (wc *WebhookController) ReplayDelivery(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	delivery, err := wc.webhookUsecase.ReplayDelivery(ctx, c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusAccepted, delivery)
+}
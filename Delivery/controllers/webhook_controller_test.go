@@ -0,0 +1,120 @@
+package controllers_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Amaankaa/Blog-Starter-Project/Delivery/controllers"
+	webhookpkg "github.com/Amaankaa/Blog-Starter-Project/Domain/webhook"
+	"github.com/Amaankaa/Blog-Starter-Project/mocks"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type WebhookControllerSuite struct {
+	suite.Suite
+	webhookUsecase *mocks.IWebhookUsecase
+	controller     *controllers.WebhookController
+	router         *gin.Engine
+}
+
+func (s *WebhookControllerSuite) SetupTest() {
+	s.webhookUsecase = mocks.NewIWebhookUsecase(s.T())
+	s.controller = controllers.NewWebhookController(s.webhookUsecase)
+	s.router = gin.Default()
+
+	s.router.POST("/admin/webhooks", func(c *gin.Context) {
+		c.Set("user_id", "admin-1")
+		s.controller.RegisterWebhook(c)
+	})
+	s.router.GET("/admin/webhooks", s.controller.ListWebhooks)
+	s.router.DELETE("/admin/webhooks/:id", s.controller.DeleteWebhook)
+	s.router.GET("/admin/webhooks/:id/deliveries", s.controller.ListDeliveries)
+	s.router.POST("/admin/webhooks/deliveries/:id/replay", s.controller.ReplayDelivery)
+}
+
+func TestWebhookControllerSuite(t *testing.T) {
+	suite.Run(t, new(WebhookControllerSuite))
+}
+
+func (s *WebhookControllerSuite) TestRegisterWebhook_ReturnsSecretOnce() {
+	req := webhookpkg.CreateWebhookRequest{URL: "https://example.com/hook", Events: []string{webhookpkg.EventBlogCreated}}
+	s.webhookUsecase.On("RegisterWebhook", mock.Anything, req, "admin-1").
+		Return(webhookpkg.Webhook{ID: primitive.NewObjectID(), URL: req.URL, Secret: "s3cret", Events: req.Events, Active: true}, nil).Once()
+
+	body, _ := json.Marshal(req)
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/admin/webhooks", bytes.NewReader(body)))
+
+	s.Equal(http.StatusCreated, w.Code)
+	var hook webhookpkg.Webhook
+	s.NoError(json.Unmarshal(w.Body.Bytes(), &hook))
+	s.Equal("s3cret", hook.Secret)
+}
+
+func (s *WebhookControllerSuite) TestRegisterWebhook_InvalidBody() {
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/admin/webhooks", bytes.NewBufferString("{")))
+
+	s.Equal(http.StatusBadRequest, w.Code)
+}
+
+func (s *WebhookControllerSuite) TestRegisterWebhook_ValidationError() {
+	s.webhookUsecase.On("RegisterWebhook", mock.Anything, mock.Anything, "admin-1").
+		Return(webhookpkg.Webhook{}, errors.New(`unknown event type "x"`)).Once()
+
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/admin/webhooks", bytes.NewBufferString(`{"url":"https://example.com","events":["x"]}`)))
+
+	s.Equal(http.StatusBadRequest, w.Code)
+	s.Contains(w.Body.String(), "unknown event type")
+}
+
+func (s *WebhookControllerSuite) TestListWebhooks() {
+	s.webhookUsecase.On("ListWebhooks", mock.Anything).Return([]webhookpkg.Webhook{{URL: "https://example.com"}}, nil).Once()
+
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/admin/webhooks", nil))
+
+	s.Equal(http.StatusOK, w.Code)
+	s.Contains(w.Body.String(), "https://example.com")
+	s.NotContains(w.Body.String(), "secret")
+}
+
+func (s *WebhookControllerSuite) TestDeleteWebhook_NotFound() {
+	s.webhookUsecase.On("DeleteWebhook", mock.Anything, "missing").Return(errors.New("webhook not found")).Once()
+
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, httptest.NewRequest(http.MethodDelete, "/admin/webhooks/missing", nil))
+
+	s.Equal(http.StatusNotFound, w.Code)
+}
+
+func (s *WebhookControllerSuite) TestListDeliveries() {
+	s.webhookUsecase.On("ListDeliveries", mock.Anything, "hook-1").
+		Return([]webhookpkg.Delivery{{EventType: webhookpkg.EventBlogCreated, Status: webhookpkg.DeliveryFailed, Attempts: 3}}, nil).Once()
+
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/admin/webhooks/hook-1/deliveries", nil))
+
+	s.Equal(http.StatusOK, w.Code)
+	s.Contains(w.Body.String(), `"status":"failed"`)
+}
+
+func (s *WebhookControllerSuite) TestReplayDelivery() {
+	original := primitive.NewObjectID()
+	s.webhookUsecase.On("ReplayDelivery", mock.Anything, original.Hex()).
+		Return(webhookpkg.Delivery{ID: primitive.NewObjectID(), ReplayOf: original, Status: webhookpkg.DeliveryPending}, nil).Once()
+
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/admin/webhooks/deliveries/"+original.Hex()+"/replay", nil))
+
+	s.Equal(http.StatusAccepted, w.Code)
+	s.Contains(w.Body.String(), original.Hex())
+}
@@ -2,12 +2,23 @@ package main
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"io"
 	"log"
 	"os"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/Amaankaa/Blog-Starter-Project/Delivery/controllers"
 	"github.com/Amaankaa/Blog-Starter-Project/Delivery/routers"
+	eventpkg "github.com/Amaankaa/Blog-Starter-Project/Domain/event"
+	identitypkg "github.com/Amaankaa/Blog-Starter-Project/Domain/identity"
+	lockoutpkg "github.com/Amaankaa/Blog-Starter-Project/Domain/lockout"
+	otppkg "github.com/Amaankaa/Blog-Starter-Project/Domain/otp"
+	outboxpkg "github.com/Amaankaa/Blog-Starter-Project/Domain/outbox"
+	passwordpkg "github.com/Amaankaa/Blog-Starter-Project/Domain/password"
 	infrastructure "github.com/Amaankaa/Blog-Starter-Project/Infrastructure"
 	repositories "github.com/Amaankaa/Blog-Starter-Project/Repositories"
 	usecases "github.com/Amaankaa/Blog-Starter-Project/Usecases"
@@ -41,19 +52,73 @@ func main() {
 	userCollection := db.Collection("users")
 	tokenCollection := db.Collection("tokens")
 	blogCollection := db.Collection("blogs")
-	passwordResetCollection := db.Collection("password_resets")
 	commentCollection := db.Collection("comments")
-	verificationCollection := db.Collection("verifications")
+	pinCollection := db.Collection("pins")
+	reportCollection := db.Collection("reports")
+	webhookCollection := db.Collection("webhooks")
+	deliveryCollection := db.Collection("webhook_deliveries")
+	outboxCollection := db.Collection("outbox")
+	emailJobCollection := db.Collection("email_jobs")
+	emailDeadLetterCollection := db.Collection("email_dead_letters")
+	domainRuleCollection := db.Collection("email_domain_rules")
+	revokedTokenCollection := db.Collection("revoked_tokens")
+	signingKeyCollection := db.Collection("jwt_signing_keys")
+	mfaEnrollmentCollection := db.Collection("mfa_enrollments")
+	securitySettingsCollection := db.Collection("security_settings")
+	identityCollection := db.Collection("identities")
+	oidcStateCollection := db.Collection("oidc_states")
+	patCollection := db.Collection("personal_access_tokens")
+	roleCollection := db.Collection("roles")
+	loginAttemptCollection := db.Collection("login_attempts")
+	unlockTokenCollection := db.Collection("unlock_tokens")
+	otpCollection := db.Collection("otp_challenges")
+	passwordHistoryCollection := db.Collection("password_history")
 
 	// Initialize infrastructure services
-	passwordService := infrastructure.NewPasswordService()
-	jwtService := infrastructure.NewJWTService()
+	argon2Params, err := loadArgon2Params()
+	if err != nil {
+		log.Fatalf("Invalid password hashing settings: %v", err)
+	}
+	passwordService := infrastructure.NewPasswordService(argon2Params)
+	signingKeyPolicy, err := loadSigningKeyPolicy()
+	if err != nil {
+		log.Fatalf("Invalid JWT key settings: %v", err)
+	}
+	signingKeyRepo := repositories.NewSigningKeyRepository(signingKeyCollection)
+	if err := signingKeyRepo.EnsureIndexes(ctx); err != nil {
+		log.Fatalf("Failed to create signing key indexes: %v", err)
+	}
+	// SIGNING_KEY_SECRET encrypts the stored private keys; every instance needs the same one
+	signingKeys, err := infrastructure.NewSigningKeyRing(signingKeyRepo, signingKeyPolicy, os.Getenv("SIGNING_KEY_SECRET"))
+	if err != nil {
+		log.Fatalf("Invalid JWT key settings: %v", err)
+	}
+	if err := signingKeys.Rotate(ctx); err != nil {
+		log.Fatalf("Failed to load JWT signing keys: %v", err)
+	}
+	// JWT_SECRET is only needed to accept HS256 tokens issued before the switch to signing keys
+	jwtService := infrastructure.NewJWTService(signingKeys, os.Getenv("JWT_SECRET"))
 
-	emailVerifier, err := infrastructure.NewEmailListVerifyVerifier()
+	// EMAIL_TEMPLATE_DIR may hold <locale>/<name>.html|.txt files that replace the built-in templates
+	appName := os.Getenv("APP_NAME")
+	if appName == "" {
+		appName = "Blog Starter"
+	}
+	emailRenderer, err := infrastructure.NewEmailTemplateRenderer(appName, os.Getenv("EMAIL_TEMPLATE_DIR"))
 	if err != nil {
-		log.Fatalf("Failed to initialize email verifier: %v", err)
+		log.Fatalf("Failed to load email templates: %v", err)
+	}
+	emailJobRepo := repositories.NewEmailJobRepository(emailJobCollection, emailDeadLetterCollection)
+	if err := emailJobRepo.EnsureIndexes(ctx); err != nil {
+		log.Fatalf("Failed to create email job indexes: %v", err)
 	}
-	emailSender := infrastructure.NewBrevoEmailSender()
+	// EMAIL_PROVIDERS lists the providers to try in order, e.g. "smtp" locally or "brevo,resend"
+	emailSender, err := infrastructure.NewEmailSender(infrastructure.LoadEmailSenderConfig())
+	if err != nil {
+		log.Fatalf("Failed to configure email providers: %v", err)
+	}
+	// Usecases only enqueue emails; the queue's workers send them through the providers
+	emailQueue := usecases.NewEmailQueueUsecase(emailJobRepo, emailSender, usecases.DefaultEmailQueuePolicy)
 
 	// Cloudinary configuration
 	cloudName := os.Getenv("CLOUDINARY_CLOUD_NAME")
@@ -73,8 +138,73 @@ func main() {
 	//Repositories: only take collection (not services)
 	userRepo := repositories.NewUserRepository(userCollection)
 	tokenRepo := repositories.NewTokenRepository(tokenCollection)
-	blogRepo := repositories.NewBlogRepository(blogCollection, commentCollection)
-	passwordResetRepo := repositories.NewPasswordResetRepo(passwordResetCollection, userCollection)
+	if err := tokenRepo.EnsureIndexes(ctx); err != nil {
+		log.Fatalf("Failed to create token indexes: %v", err)
+	}
+	revokedTokenRepo := repositories.NewRevokedTokenRepository(revokedTokenCollection)
+	if err := revokedTokenRepo.EnsureIndexes(ctx); err != nil {
+		log.Fatalf("Failed to create revoked token indexes: %v", err)
+	}
+	tokenRevocations := infrastructure.NewAccessTokenRevocationList(revokedTokenRepo)
+	if err := tokenRevocations.Sync(ctx); err != nil {
+		log.Fatalf("Failed to load revoked tokens: %v", err)
+	}
+	blogCacheSize, blogCacheTTL := loadBlogCacheConfig()
+	blogRepo := repositories.NewCachedBlogRepository(
+		repositories.NewBlogRepository(blogCollection, commentCollection),
+		blogCacheSize,
+		blogCacheTTL,
+	)
+	pinRepo := repositories.NewPinRepository(pinCollection)
+	reportRepo := repositories.NewReportRepository(reportCollection)
+	if err := reportRepo.EnsureIndexes(ctx); err != nil {
+		log.Fatalf("Failed to create report indexes: %v", err)
+	}
+	webhookRepo := repositories.NewWebhookRepository(webhookCollection)
+	deliveryRepo := repositories.NewDeliveryRepository(deliveryCollection)
+	outboxRepo := repositories.NewOutboxRepository(outboxCollection)
+	if err := outboxRepo.EnsureIndexes(ctx); err != nil {
+		log.Fatalf("Failed to create outbox indexes: %v", err)
+	}
+	transactor := repositories.NewMongoTransactor(client)
+	domainRuleUsecase := usecases.NewDomainRuleUsecase(repositories.NewDomainRuleRepository(domainRuleCollection))
+	emailVerifier, err := loadEmailVerifier(domainRuleUsecase)
+	if err != nil {
+		log.Fatalf("Failed to initialize email verifier: %v", err)
+	}
+	otpRepo := repositories.NewOTPRepository(otpCollection)
+	if err := otpRepo.EnsureIndexes(ctx); err != nil {
+		log.Fatalf("Failed to create one-time code indexes: %v", err)
+	}
+	otpUsecase := usecases.NewOTPUsecase(otpRepo, passwordService, otppkg.DefaultPolicies)
+	passwordHistoryRepo := repositories.NewPasswordHistoryRepository(passwordHistoryCollection)
+	if err := passwordHistoryRepo.EnsureIndexes(ctx); err != nil {
+		log.Fatalf("Failed to create password history indexes: %v", err)
+	}
+	breachCorpus, err := loadBreachCorpus()
+	if err != nil {
+		log.Fatalf("Failed to open breached password corpus: %v", err)
+	}
+	passwordPolicy := usecases.NewPasswordPolicyUsecase(
+		infrastructure.NewStrengthEstimator(),
+		breachCorpus,
+		passwordHistoryRepo,
+		passwordService,
+		passwordpkg.DefaultPolicy,
+	)
+	loginAttemptRepo := repositories.NewLoginAttemptRepository(loginAttemptCollection, unlockTokenCollection)
+	if err := loginAttemptRepo.EnsureIndexes(ctx); err != nil {
+		log.Fatalf("Failed to create login attempt indexes: %v", err)
+	}
+	// ACCOUNT_UNLOCK_URL is the page behind the link in lockout emails; it posts the token to /unlock-account
+	lockoutUsecase := usecases.NewLockoutUsecase(loginAttemptRepo, emailQueue, emailRenderer, lockoutpkg.DefaultPolicy, os.Getenv("ACCOUNT_UNLOCK_URL"))
+	mfaRepo := repositories.NewMFARepository(mfaEnrollmentCollection, securitySettingsCollection)
+	// APP_NAME is also the issuer shown next to the account in authenticator apps
+	mfaUsecase := usecases.NewMFAUsecase(mfaRepo, infrastructure.NewTOTPService(appName), otpUsecase, userRepo, lockoutUsecase)
+	roleUsecase := usecases.NewRoleUsecase(repositories.NewRoleRepository(roleCollection, securitySettingsCollection, userCollection))
+	if err := roleUsecase.EnsureDefaults(ctx); err != nil {
+		log.Fatalf("Failed to create default roles: %v", err)
+	}
 	//AI configuration
 	aiAPIKey := os.Getenv("GEMINI_API_KEY")
 	if aiAPIKey == "" {
@@ -86,29 +216,90 @@ func main() {
 	}
 
 	//Usecase: handles business logic, gets all dependencies
-	verificationRepo := repositories.NewVerificationRepo(verificationCollection)
+	eventBus := eventpkg.NewBus()
+	webhookUsecase := usecases.NewWebhookUsecase(
+		webhookRepo,
+		deliveryRepo,
+		infrastructure.NewWebhookSender(usecases.DefaultWebhookRetryPolicy.SendTimeout),
+		usecases.DefaultWebhookRetryPolicy,
+	)
+	webhookUsecase.SubscribeTo(eventBus)
+	patRepo := repositories.NewPATRepository(patCollection)
+	if err := patRepo.EnsureIndexes(ctx); err != nil {
+		log.Fatalf("Failed to create personal access token indexes: %v", err)
+	}
 	userUsecase := usecases.NewUserUsecase(
 		userRepo,
 		passwordService,
+		passwordPolicy,
 		tokenRepo,
 		jwtService,
+		tokenRevocations,
+		patRepo,
+		mfaUsecase,
+		roleUsecase,
+		lockoutUsecase,
 		emailVerifier,
-		emailSender,
-		passwordResetRepo,
-		verificationRepo,
+		emailQueue,
+		emailRenderer,
+		otpUsecase,
 		cloudinaryService,
+		eventBus,
+		outboxRepo,
+		transactor,
 	)
-	blogUsecase := usecases.NewBlogUsecase(blogRepo)
+	outboxDispatcher := usecases.NewOutboxDispatcher(outboxRepo, usecases.DefaultOutboxRetryPolicy)
+	outboxDispatcher.Register(outboxpkg.KindVerificationEmail, userUsecase.DeliverVerificationEmail)
+	blogUsecase := usecases.NewBlogUsecase(blogRepo, pinRepo, eventBus)
+	moderationUsecase := usecases.NewModerationUsecase(blogRepo, reportRepo)
 	aiUseCase := usecases.NewAIUseCase(aiAPIKey, aiAPIURL)
+	identityRepo := repositories.NewIdentityRepository(identityCollection, oidcStateCollection)
+	if err := identityRepo.EnsureIndexes(ctx); err != nil {
+		log.Fatalf("Failed to create identity indexes: %v", err)
+	}
+	identityProviders, err := loadIdentityProviders()
+	if err != nil {
+		log.Fatalf("Failed to configure sign-in providers: %v", err)
+	}
+	identityUsecase := usecases.NewIdentityUsecase(identityRepo, userRepo, userUsecase, eventBus, identityProviders...)
+	patUsecase := usecases.NewPATUsecase(patRepo, userRepo)
 	//Controller
 	controller := controllers.NewController(userUsecase)
 	blogController := controllers.NewBlogController(blogUsecase)
 	aiController := controllers.NewAIController(aiUseCase)
+	cacheController := controllers.NewCacheController(blogRepo)
+	webhookController := controllers.NewWebhookController(webhookUsecase)
+	emailQueueController := controllers.NewEmailQueueController(emailQueue)
+	emailTemplateController := controllers.NewEmailTemplateController(emailRenderer)
+	domainRuleController := controllers.NewDomainRuleController(domainRuleUsecase)
+	jwksController := controllers.NewJWKSController(signingKeys)
+	mfaController := controllers.NewMFAController(mfaUsecase)
+	identityController := controllers.NewIdentityController(identityUsecase)
+	patController := controllers.NewPATController(patUsecase)
+	roleController := controllers.NewRoleController(roleUsecase)
+	lockoutController := controllers.NewLockoutController(lockoutUsecase)
+	moderationController := controllers.NewModerationController(moderationUsecase)
 	// Initialize AuthMiddleware
-	authMiddleware := infrastructure.NewAuthMiddleware(jwtService)
-	aiRateLimiter := infrastructure.NewRateLimiter(infrastructure.RateLimit, infrastructure.BurstLimit)
+	authMiddleware := infrastructure.NewAuthMiddleware(jwtService, tokenRevocations, mfaUsecase, patUsecase, roleUsecase)
+	rateLimiter := infrastructure.NewRateLimiter(infrastructure.NewMemoryRateLimitStore(infrastructure.DefaultRateLimitKeys))
 	//Router
-	r := routers.SetupRouter(controller, blogController, authMiddleware, aiController, aiRateLimiter)
+	r, err := routers.SetupRouter(controller, blogController, authMiddleware, aiController, rateLimiter, cacheController, webhookController, emailQueueController, emailTemplateController, domainRuleController, jwksController, mfaController, identityController, patController, roleController, lockoutController, moderationController, loadTrustedProxies())
+	if err != nil {
+		log.Fatalf("Invalid TRUSTED_PROXIES: %v", err)
+	}
+
+	// Deliver queued webhooks in the background
+	go webhookUsecase.Run(context.Background(), 15*time.Second)
+	// Deliver side effects recorded in the outbox
+	go outboxDispatcher.Run(context.Background(), 5*time.Second)
+	// Send queued emails
+	go emailQueue.Run(context.Background())
+	// Pick up access tokens revoked by other instances
+	go tokenRevocations.Run(context.Background(), 5*time.Second)
+	// Rotate JWT signing keys and pick up keys generated by other instances
+	go signingKeys.Run(context.Background(), 10*time.Minute)
+	// Pick up role changes made on other instances
+	go roleUsecase.Run(context.Background(), 30*time.Second)
 
 	//Start Server
 	log.Println("Server running on :8080")
@@ -116,3 +307,180 @@ func main() {
 		log.Fatalf("Failed to start server: %v", err)
 	}
 }
+
+// loadBlogCacheConfig reads BLOG_CACHE_SIZE (entries) and BLOG_CACHE_TTL (Go duration), falling back to defaults.
+func loadBlogCacheConfig() (int, time.Duration) {
+	size, ttl := 1000, time.Minute
+	if v := os.Getenv("BLOG_CACHE_SIZE"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil && n > 0 {
+			size = n
+		}
+	}
+	if v := os.Getenv("BLOG_CACHE_TTL"); v != "" {
+		if d, err := time.ParseDuration(v); err == nil && d > 0 {
+			ttl = d
+		}
+	}
+	return size, ttl
+}
+
+// loadTrustedProxies reads TRUSTED_PROXIES, a comma-separated list of the IP addresses or CIDR
+// ranges of the reverse proxies in front of the API. Without it X-Forwarded-For is ignored.
+func loadTrustedProxies() []string {
+	var proxies []string
+	for _, proxy := range strings.Split(os.Getenv("TRUSTED_PROXIES"), ",") {
+		if proxy = strings.TrimSpace(proxy); proxy != "" {
+			proxies = append(proxies, proxy)
+		}
+	}
+	return proxies
+}
+
+// loadSigningKeyPolicy reads JWT_SIGNING_ALG ("RS256" or "EdDSA"), JWT_KEY_ROTATION_INTERVAL
+// and JWT_KEY_PUBLISH_AHEAD (Go durations). A changed algorithm applies from the next rotation.
+func loadSigningKeyPolicy() (infrastructure.SigningKeyPolicy, error) {
+	policy := infrastructure.DefaultSigningKeyPolicy
+	if v := os.Getenv("JWT_SIGNING_ALG"); v != "" {
+		policy.Algorithm = v
+	}
+	for env, d := range map[string]*time.Duration{
+		"JWT_KEY_ROTATION_INTERVAL": &policy.RotationInterval,
+		"JWT_KEY_PUBLISH_AHEAD":     &policy.PublishAhead,
+	} {
+		if v := os.Getenv(env); v != "" {
+			parsed, err := time.ParseDuration(v)
+			if err != nil || parsed <= 0 {
+				return policy, fmt.Errorf("%s must be a positive duration", env)
+			}
+			*d = parsed
+		}
+	}
+	return policy, nil
+}
+
+// loadArgon2Params reads PASSWORD_ARGON2_MEMORY_KIB, PASSWORD_ARGON2_ITERATIONS and
+// PASSWORD_ARGON2_PARALLELISM. Changing them rehashes each password at its user's next login.
+func loadArgon2Params() (infrastructure.Argon2Params, error) {
+	params := infrastructure.DefaultArgon2Params
+	for env, setting := range map[string]*uint32{
+		"PASSWORD_ARGON2_MEMORY_KIB": &params.Memory,
+		"PASSWORD_ARGON2_ITERATIONS": &params.Iterations,
+	} {
+		if v := os.Getenv(env); v != "" {
+			parsed, err := strconv.ParseUint(v, 10, 32)
+			if err != nil || parsed == 0 {
+				return params, fmt.Errorf("%s must be a positive integer", env)
+			}
+			*setting = uint32(parsed)
+		}
+	}
+	if v := os.Getenv("PASSWORD_ARGON2_PARALLELISM"); v != "" {
+		parsed, err := strconv.ParseUint(v, 10, 8)
+		if err != nil || parsed == 0 {
+			return params, errors.New("PASSWORD_ARGON2_PARALLELISM must be between 1 and 255")
+		}
+		params.Parallelism = uint8(parsed)
+	}
+	return params, nil
+}
+
+// loadIdentityProviders reads OIDC_PROVIDERS, a comma-separated list of provider names. Each
+// needs OIDC_<NAME>_CLIENT_ID and OIDC_<NAME>_CLIENT_SECRET, and OIDC_<NAME>_ISSUER unless it
+// is "google" or "github"; "github" uses GitHub's OAuth API instead of discovery. Callbacks go
+// to OIDC_REDIRECT_BASE_URL + "/auth/oidc/<name>/callback".
+func loadIdentityProviders() ([]identitypkg.IIdentityProvider, error) {
+	var providers []identitypkg.IIdentityProvider
+	redirectBase := strings.TrimSuffix(os.Getenv("OIDC_REDIRECT_BASE_URL"), "/")
+	for _, name := range strings.Split(os.Getenv("OIDC_PROVIDERS"), ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+		if redirectBase == "" {
+			return nil, errors.New("OIDC_REDIRECT_BASE_URL is required")
+		}
+		prefix := "OIDC_" + strings.ToUpper(name) + "_"
+		clientID, clientSecret := os.Getenv(prefix+"CLIENT_ID"), os.Getenv(prefix+"CLIENT_SECRET")
+		if clientID == "" || clientSecret == "" {
+			return nil, fmt.Errorf("%sCLIENT_ID and %sCLIENT_SECRET are required", prefix, prefix)
+		}
+		redirectURL := redirectBase + "/auth/oidc/" + name + "/callback"
+
+		if name == "github" {
+			providers = append(providers, infrastructure.NewGitHubProvider(clientID, clientSecret, redirectURL))
+			continue
+		}
+		issuer := os.Getenv(prefix + "ISSUER")
+		if issuer == "" && name == "google" {
+			issuer = "https://accounts.google.com"
+		}
+		if issuer == "" {
+			return nil, fmt.Errorf("%sISSUER is required", prefix)
+		}
+		providers = append(providers, infrastructure.NewOIDCProvider(infrastructure.OIDCProviderConfig{
+			Name:         name,
+			Issuer:       issuer,
+			ClientID:     clientID,
+			ClientSecret: clientSecret,
+			RedirectURL:  redirectURL,
+		}, nil))
+	}
+	return providers, nil
+}
+
+// loadBreachCorpus opens BREACHED_PASSWORDS_FILE, the Pwned Passwords SHA-1 corpus as one
+// "HASH:COUNT" file sorted by hash. Without it new passwords are only checked against the
+// bundled list of common ones.
+func loadBreachCorpus() (passwordpkg.IBreachCorpus, error) {
+	path := os.Getenv("BREACHED_PASSWORDS_FILE")
+	if path == "" {
+		return nil, nil
+	}
+	corpus, err := infrastructure.OpenBreachCorpus(path)
+	if err != nil {
+		return nil, err
+	}
+	return corpus, nil
+}
+
+// loadEmailVerifier builds the registration email checks. Only the optional EmailListVerify
+// step (enabled by EMAILLISTVERIFY_API_KEY) leaves the process; by default it fails open so
+// an outage of that API doesn't block sign-ups. EMAIL_DISPOSABLE_DOMAINS_FILE extends the
+// bundled disposable-domain list.
+func loadEmailVerifier(domainRules *usecases.DomainRuleUsecase) (*infrastructure.EmailVerifierChain, error) {
+	var extra []io.Reader
+	if path := os.Getenv("EMAIL_DISPOSABLE_DOMAINS_FILE"); path != "" {
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		extra = append(extra, f)
+	}
+	disposable, err := infrastructure.NewDisposableDomainCheck(extra...)
+	if err != nil {
+		return nil, err
+	}
+
+	steps := []infrastructure.EmailCheckStep{
+		{Name: "syntax", Check: infrastructure.SyntaxEmailCheck{}},
+		// Admin rules come before the blocklist so a domain can be explicitly allowed
+		{Name: "domain rules", Check: domainRules, Timeout: 3 * time.Second},
+		{Name: "disposable domains", Check: disposable},
+	}
+	if apiKey := os.Getenv("EMAILLISTVERIFY_API_KEY"); apiKey != "" {
+		timeout := 5 * time.Second
+		if v := os.Getenv("EMAILLISTVERIFY_TIMEOUT"); v != "" {
+			if d, err := time.ParseDuration(v); err == nil && d > 0 {
+				timeout = d
+			}
+		}
+		steps = append(steps, infrastructure.EmailCheckStep{
+			Name:     "EmailListVerify",
+			Check:    infrastructure.NewEmailListVerifyVerifier(apiKey),
+			Timeout:  timeout,
+			FailOpen: os.Getenv("EMAILLISTVERIFY_FAIL_CLOSED") != "true",
+		})
+	}
+	return infrastructure.NewEmailVerifierChain(steps...), nil
+}
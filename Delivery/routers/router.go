@@ -1,22 +1,72 @@
 package routers
 
 import (
+	"time"
+
 	"github.com/Amaankaa/Blog-Starter-Project/Delivery/controllers"
+	patpkg "github.com/Amaankaa/Blog-Starter-Project/Domain/pat"
+	ratelimitpkg "github.com/Amaankaa/Blog-Starter-Project/Domain/ratelimit"
+	rolepkg "github.com/Amaankaa/Blog-Starter-Project/Domain/role"
 	infrastructure "github.com/Amaankaa/Blog-Starter-Project/Infrastructure"
 
 	"github.com/gin-gonic/gin"
 )
 
-func SetupRouter(controller *controllers.Controller, blogController *controllers.BlogController, authMiddleware *infrastructure.AuthMiddleware, aiController *controllers.AIController, aiRateLimiter gin.HandlerFunc) *gin.Engine {
+// Cache-Control policies for the public blog endpoints. Single posts change less
+// often than listings, so they may be cached longer by browsers and CDNs.
+var (
+	blogListCachePolicy = infrastructure.CachePolicy{
+		Public:               true,
+		MaxAge:               30 * time.Second,
+		SMaxAge:              60 * time.Second,
+		StaleWhileRevalidate: 30 * time.Second,
+	}
+	blogDetailCachePolicy = infrastructure.CachePolicy{
+		Public:               true,
+		MaxAge:               60 * time.Second,
+		SMaxAge:              5 * time.Minute,
+		StaleWhileRevalidate: time.Minute,
+	}
+	// New keys are published a day before they sign anything, so verifiers may cache the set for a while
+	jwksCachePolicy = infrastructure.CachePolicy{
+		Public: true,
+		MaxAge: 15 * time.Minute,
+	}
+)
+
+// Rate limits per client. Routes before authentication count per IP address, the others per
+// user, however many personal access tokens the user has.
+var (
+	loginRatePolicy     = ratelimitpkg.Policy{Name: "login", Limit: 10, Period: time.Minute}
+	signupRatePolicy    = ratelimitpkg.Policy{Name: "signup", Limit: 5, Period: 10 * time.Minute}
+	otpRatePolicy       = ratelimitpkg.Policy{Name: "otp", Limit: 10, Period: 10 * time.Minute}
+	blogWriteRatePolicy = ratelimitpkg.Policy{Name: "blog_write", Limit: 30, Period: time.Minute}
+	commentRatePolicy   = ratelimitpkg.Policy{Name: "comment", Limit: 20, Period: time.Minute}
+	aiRatePolicy        = ratelimitpkg.Policy{Name: "ai", Limit: 10, Period: time.Minute}
+)
+
+func SetupRouter(controller *controllers.Controller, blogController *controllers.BlogController, authMiddleware *infrastructure.AuthMiddleware, aiController *controllers.AIController, rateLimiter *infrastructure.RateLimiter, cacheController *controllers.CacheController, webhookController *controllers.WebhookController, emailQueueController *controllers.EmailQueueController, emailTemplateController *controllers.EmailTemplateController, domainRuleController *controllers.DomainRuleController, jwksController *controllers.JWKSController, mfaController *controllers.MFAController, identityController *controllers.IdentityController, patController *controllers.PATController, roleController *controllers.RoleController, lockoutController *controllers.LockoutController, moderationController *controllers.ModerationController, trustedProxies []string) (*gin.Engine, error) {
 	r := gin.Default()
+	// Only proxies we run may set X-Forwarded-For; otherwise clients could pick the address
+	// that rate limits and lockouts count them under. nil trusts none.
+	if err := r.SetTrustedProxies(trustedProxies); err != nil {
+		return nil, err
+	}
 
 	// Public routes
-	r.POST("/register", controller.Register)
-	r.POST("/verify-user", controller.VerifyUser) // Registration verification (separate from password-reset OTP)
-	r.POST("/login", controller.Login)
-	r.POST("/forgot-password", controller.ForgotPassword)
-	r.POST("/verify-otp", controller.VerifyOTP)
-	r.POST("/reset-password", controller.ResetPassword)
+	r.POST("/register", rateLimiter.Limit(signupRatePolicy), controller.Register)
+	r.POST("/verify-user", rateLimiter.Limit(otpRatePolicy), controller.VerifyUser) // Registration verification (separate from password-reset OTP)
+	r.POST("/login", rateLimiter.Limit(loginRatePolicy), controller.Login)
+	r.POST("/login/2fa", rateLimiter.Limit(loginRatePolicy), controller.LoginMFA)
+	r.POST("/refresh", controller.RefreshToken)
+	r.POST("/forgot-password", rateLimiter.Limit(otpRatePolicy), controller.ForgotPassword)
+	r.POST("/verify-otp", rateLimiter.Limit(otpRatePolicy), controller.VerifyOTP)
+	r.POST("/reset-password", rateLimiter.Limit(otpRatePolicy), controller.ResetPassword)
+	r.POST("/unlock-account", rateLimiter.Limit(otpRatePolicy), lockoutController.Unlock)
+	r.GET("/.well-known/jwks.json", infrastructure.CacheControlMiddleware(jwksCachePolicy), jwksController.GetJWKS)
+	r.GET("/auth/oidc/providers", identityController.Providers)
+	r.GET("/auth/oidc/:provider/login", identityController.Login)
+	r.GET("/auth/oidc/:provider/callback", identityController.Callback)
 
 	
 	// Protected routes
@@ -27,32 +77,95 @@ func SetupRouter(controller *controllers.Controller, blogController *controllers
 	protected.POST("/logout", controller.Logout)
 	protected.GET("/profile", controller.GetProfile)
     protected.PUT("/profile", controller.UpdateProfile)
+	protected.POST("/me/email", rateLimiter.Limit(otpRatePolicy), controller.RequestEmailChange)
+	protected.POST("/me/email/confirm", rateLimiter.Limit(otpRatePolicy), controller.ConfirmEmailChange)
+	protected.GET("/me/sessions", controller.ListSessions)
+	protected.DELETE("/me/sessions/:id", controller.RevokeSession)
+	protected.POST("/me/sessions/logout-others", controller.RevokeOtherSessions)
+	protected.GET("/me/2fa", mfaController.Status)
+	protected.POST("/me/2fa/setup", mfaController.Setup)
+	protected.POST("/me/2fa/enable", rateLimiter.Limit(otpRatePolicy), mfaController.Enable)
+	protected.POST("/me/2fa/disable", rateLimiter.Limit(otpRatePolicy), mfaController.Disable)
+	protected.POST("/me/2fa/recovery-codes", rateLimiter.Limit(otpRatePolicy), mfaController.RegenerateRecoveryCodes)
+	protected.GET("/me/identities", identityController.ListIdentities)
+	protected.POST("/me/identities/:provider/link", identityController.Link)
+	protected.DELETE("/me/identities/:id", identityController.Unlink)
+	protected.GET("/me/tokens", patController.List)
+	protected.POST("/me/tokens", patController.Create)
+	protected.DELETE("/me/tokens/:id", patController.Revoke)
+	protected.POST("/blogs/:id/report", rateLimiter.Limit(commentRatePolicy), moderationController.ReportContent)
 
-	// Admin routes for user promotion and demotion
+	// Admin routes; each needs its own permission from the role matrix
 	admin := protected.Group("")
-	admin.Use(authMiddleware.AdminOnly())
-	admin.PUT("/user/:id/promote", controller.PromoteUser)
-	admin.PUT("/user/:id/demote", controller.DemoteUser)
+	usersManage := authMiddleware.Require(rolepkg.PermUsersManage)
+	admin.PUT("/user/:id/promote", usersManage, controller.PromoteUser)
+	admin.PUT("/user/:id/demote", usersManage, controller.DemoteUser)
+	admin.PUT("/admin/users/:id/role", usersManage, controller.AssignRole)
+	admin.GET("/admin/cache/stats", authMiddleware.Require(rolepkg.PermSystemMonitor), cacheController.GetCacheStats)
+	postsFeature := authMiddleware.Require(rolepkg.PermPostsFeature)
+	admin.POST("/blogs/:id/feature", postsFeature, blogController.FeatureBlog)
+	admin.DELETE("/blogs/:id/feature", postsFeature, blogController.UnfeatureBlog)
+	admin.POST("/blogs/:id/review", authMiddleware.Require(rolepkg.PermPostsReview), moderationController.ReviewBlog)
+	admin.DELETE("/blogs/:id/comments/:commentId", authMiddleware.Require(rolepkg.PermCommentsModerate), moderationController.DeleteComment)
+	reportsHandle := authMiddleware.Require(rolepkg.PermReportsHandle)
+	admin.GET("/admin/reports", reportsHandle, moderationController.ListReports)
+	admin.POST("/admin/reports/:id/handle", reportsHandle, moderationController.HandleReport)
+	webhooksManage := authMiddleware.Require(rolepkg.PermWebhooksManage)
+	admin.POST("/admin/webhooks", webhooksManage, webhookController.RegisterWebhook)
+	admin.GET("/admin/webhooks", webhooksManage, webhookController.ListWebhooks)
+	admin.DELETE("/admin/webhooks/:id", webhooksManage, webhookController.DeleteWebhook)
+	admin.GET("/admin/webhooks/:id/deliveries", webhooksManage, webhookController.ListDeliveries)
+	admin.POST("/admin/webhooks/deliveries/:id/replay", webhooksManage, webhookController.ReplayDelivery)
+	emailManage := authMiddleware.Require(rolepkg.PermEmailManage)
+	admin.GET("/admin/email/jobs", emailManage, emailQueueController.ListQueued)
+	admin.GET("/admin/email/dead-letters", emailManage, emailQueueController.ListDeadLetters)
+	admin.POST("/admin/email/dead-letters/:id/requeue", emailManage, emailQueueController.RequeueDeadLetter)
+	admin.GET("/admin/email/templates", emailManage, emailTemplateController.ListTemplates)
+	admin.GET("/admin/email/templates/:name/preview", emailManage, emailTemplateController.PreviewTemplate)
+	admin.POST("/admin/email/domains", emailManage, domainRuleController.SetRule)
+	admin.GET("/admin/email/domains", emailManage, domainRuleController.ListRules)
+	admin.DELETE("/admin/email/domains/:domain", emailManage, domainRuleController.DeleteRule)
+	securityManage := authMiddleware.Require(rolepkg.PermSecurityManage)
+	admin.GET("/admin/security/2fa-policy", securityManage, mfaController.GetPolicy)
+	admin.PUT("/admin/security/2fa-policy", securityManage, mfaController.SetPolicy)
+	rolesManage := authMiddleware.Require(rolepkg.PermRolesManage)
+	admin.GET("/admin/permissions", rolesManage, roleController.ListPermissions)
+	admin.GET("/admin/roles", rolesManage, roleController.ListRoles)
+	admin.POST("/admin/roles", rolesManage, roleController.CreateRole)
+	admin.PUT("/admin/roles/:name", rolesManage, roleController.UpdateRole)
+	admin.DELETE("/admin/roles/:name", rolesManage, roleController.DeleteRole)
 
 	// Blog routes (Public)
-	r.GET("/blogs", blogController.GetAllBlogs)
-	r.GET("/blogs/:id", blogController.GetBlogByID)
-	r.GET("/blogs/search", blogController.SearchBlogs)
-	r.GET("/blogs/filter", blogController.FilterByTags)
+	listCache := infrastructure.CacheControlMiddleware(blogListCachePolicy)
+	detailCache := infrastructure.CacheControlMiddleware(blogDetailCachePolicy)
+	r.GET("/blogs", listCache, blogController.GetAllBlogs)
+	r.GET("/blogs/featured", listCache, blogController.GetFeaturedBlogs)
+	r.GET("/users/:id/pins", listCache, blogController.GetPinnedBlogs)
+	r.GET("/blogs/:id", detailCache, blogController.GetBlogByID)
+	r.GET("/blogs/search", listCache, blogController.SearchBlogs)
+	r.GET("/blogs/filter", listCache, blogController.FilterByTags)
 	
-	// Blog routes (Protected)
-	protected.POST("/blogs/create", blogController.CreateBlog)
-	protected.PUT("/blogs/:id", blogController.UpdateBlog)
-	protected.DELETE("/blogs/:id", blogController.DeleteBlog)
-	protected.PATCH("/blogs/:id/like", blogController.LikeBlog)
-	protected.POST("/blogs/:id/comment", blogController.AddComment)
+	// Blog routes (Protected); personal access tokens need the group's scope
+	blogWriter := r.Group("")
+	blogWriter.Use(authMiddleware.AuthMiddleware(patpkg.ScopeBlogsWrite), authMiddleware.Require(rolepkg.PermPostsWrite), rateLimiter.Limit(blogWriteRatePolicy))
+	blogWriter.POST("/blogs/create", blogController.CreateBlog)
+	blogWriter.PUT("/blogs/:id", blogController.UpdateBlog)
+	blogWriter.PATCH("/blogs/:id", blogController.PatchBlog)
+	blogWriter.DELETE("/blogs/:id", blogController.DeleteBlog)
+	blogWriter.PATCH("/blogs/:id/like", blogController.LikeBlog)
+	blogWriter.POST("/blogs/:id/pin", blogController.PinBlog)
+	blogWriter.DELETE("/blogs/:id/pin", blogController.UnpinBlog)
+
+	commenter := r.Group("")
+	commenter.Use(authMiddleware.AuthMiddleware(patpkg.ScopeCommentsWrite), rateLimiter.Limit(commentRatePolicy))
+	commenter.POST("/blogs/:id/comment", authMiddleware.Require(rolepkg.PermCommentsWrite), blogController.AddComment)
 
 	// AI routes
-	aiGroup := protected.Group("/ai")
-	aiGroup.Use(aiRateLimiter)
+	aiGroup := r.Group("/ai")
+	aiGroup.Use(authMiddleware.AuthMiddleware(patpkg.ScopeAIUse), rateLimiter.Limit(aiRatePolicy))
 	{
 		aiGroup.POST("/suggest-content", aiController.SuggestContent)
 	}
 
-	return r
+	return r, nil
 }
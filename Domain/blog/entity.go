@@ -1,6 +1,7 @@
 package blogpkg
 
 import (
+	"errors"
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson/primitive"
@@ -16,12 +17,15 @@ type Blog struct {
 	CreatedAt time.Time `json:"created_at" bson:"created_at"`
 	UpdatedAt time.Time `json:"updated_at" bson:"updated_at"`
 	Views     int       `json:"views" bson:"views"`
+	Review    *Review   `json:"review,omitempty" bson:"review,omitempty"`
+	Pinned    bool      `json:"pinned,omitempty" bson:"-"` // set on listings that merge pinned posts
 }
 
 // PaginationRequest represents pagination parameters
 type PaginationRequest struct {
-	Page  int `json:"page" form:"page"`
-	Limit int `json:"limit" form:"limit"`
+	Page          int  `json:"page" form:"page"`
+	Limit         int  `json:"limit" form:"limit"`
+	IncludePinned bool `json:"pinned" form:"pinned"` // merge globally pinned posts at the top of the first page
 }
 
 // PaginationResponse represents paginated response
@@ -31,6 +35,7 @@ type PaginationResponse struct {
 	Page       int    `json:"page"`
 	Limit      int    `json:"limit"`
 	TotalPages int    `json:"total_pages"`
+	Pinned     int    `json:"pinned,omitempty"` // number of pinned posts at the top of Data
 }
 
 type Comment struct {
@@ -43,4 +48,98 @@ type Comment struct {
 
 type AddCommentRequest struct {
 	Content string `json:"content" binding:"required,min=1,max=1000"`
-}
\ No newline at end of file
+}
+
+// CacheStats reports the counters of the blog read cache
+type CacheStats struct {
+	Hits          uint64  `json:"hits"`
+	Misses        uint64  `json:"misses"`
+	Evictions     uint64  `json:"evictions"`
+	Invalidations uint64  `json:"invalidations"`
+	Entries       int     `json:"entries"`
+	HitRatio      float64 `json:"hit_ratio"`
+}
+
+// PinScope tells where a pinned post is highlighted
+type PinScope string
+
+const (
+	PinScopeGlobal  PinScope = "global"  // featured by an admin on the whole site
+	PinScopeProfile PinScope = "profile" // pinned by the author on their own profile
+)
+
+// Pin highlights a blog post, ordered by Position and optionally expiring
+type Pin struct {
+	ID        primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	BlogID    string             `json:"blog_id" bson:"blog_id"`
+	Scope     PinScope           `json:"scope" bson:"scope"`
+	OwnerID   string             `json:"owner_id,omitempty" bson:"owner_id"` // profile owner; empty for global pins
+	PinnedBy  string             `json:"pinned_by" bson:"pinned_by"`
+	Position  int                `json:"position" bson:"position"`
+	ExpiresAt *time.Time         `json:"expires_at,omitempty" bson:"expires_at,omitempty"`
+	CreatedAt time.Time          `json:"created_at" bson:"created_at"`
+}
+
+type PinRequest struct {
+	Position  int        `json:"position" binding:"min=0"`
+	ExpiresAt *time.Time `json:"expires_at"`
+}
+
+var (
+	ErrCommentNotFound = errors.New("comment not found")
+	ErrReportNotFound  = errors.New("report not found")
+)
+
+// ReviewStatus is an editor's verdict on a post
+type ReviewStatus string
+
+const (
+	ReviewApproved         ReviewStatus = "approved"
+	ReviewChangesRequested ReviewStatus = "changes_requested"
+)
+
+// Review is the latest editorial review of a post; the author editing the post clears it
+type Review struct {
+	Status     ReviewStatus `json:"status" bson:"status"`
+	ReviewerID string       `json:"reviewer_id" bson:"reviewer_id"`
+	Note       string       `json:"note,omitempty" bson:"note,omitempty"`
+	ReviewedAt time.Time    `json:"reviewed_at" bson:"reviewed_at"`
+}
+
+type ReviewRequest struct {
+	Status ReviewStatus `json:"status" binding:"required"`
+	Note   string       `json:"note" binding:"max=1000"`
+}
+
+// ReportStatus tracks a report from filing to a moderator's decision
+type ReportStatus string
+
+const (
+	ReportOpen      ReportStatus = "open"
+	ReportResolved  ReportStatus = "resolved"  // the content was dealt with
+	ReportDismissed ReportStatus = "dismissed" // nothing needed to be done
+)
+
+// Report flags a post, or one of its comments when CommentID is set, for moderators
+type Report struct {
+	ID         primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	BlogID     string             `json:"blog_id" bson:"blog_id"`
+	CommentID  string             `json:"comment_id,omitempty" bson:"comment_id,omitempty"`
+	ReporterID string             `json:"reporter_id" bson:"reporter_id"`
+	Reason     string             `json:"reason" bson:"reason"`
+	Status     ReportStatus       `json:"status" bson:"status"`
+	HandledBy  string             `json:"handled_by,omitempty" bson:"handled_by,omitempty"`
+	Note       string             `json:"note,omitempty" bson:"note,omitempty"`
+	CreatedAt  time.Time          `json:"created_at" bson:"created_at"`
+	HandledAt  *time.Time         `json:"handled_at,omitempty" bson:"handled_at,omitempty"`
+}
+
+type ReportRequest struct {
+	CommentID string `json:"comment_id"`
+	Reason    string `json:"reason" binding:"required,min=1,max=500"`
+}
+
+type HandleReportRequest struct {
+	Status ReportStatus `json:"status" binding:"required"`
+	Note   string       `json:"note" binding:"max=1000"`
+}
@@ -0,0 +1,90 @@
+package blogpkg
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// MergePatchContentType is the media type of RFC 7396 JSON Merge Patch documents
+const MergePatchContentType = "application/merge-patch+json"
+
+// readOnlyFields are owned by the server and can never be written by clients
+var readOnlyFields = map[string]bool{
+	"id":         true,
+	"author_id":  true,
+	"likes":      true,
+	"views":      true,
+	"created_at": true,
+	"updated_at": true,
+}
+
+// BlogPatch holds the client-editable fields present in a merge patch.
+// A nil field was absent from the patch and must be left untouched.
+type BlogPatch struct {
+	Title   *string
+	Content *string
+	Tags    *[]string
+}
+
+// IsEmpty reports whether the patch changes nothing
+func (p BlogPatch) IsEmpty() bool {
+	return p.Title == nil && p.Content == nil && p.Tags == nil
+}
+
+// Apply merges the patch into blog following RFC 7396 semantics
+func (p BlogPatch) Apply(blog *Blog) {
+	if p.Title != nil {
+		blog.Title = *p.Title
+	}
+	if p.Content != nil {
+		blog.Content = *p.Content
+	}
+	if p.Tags != nil {
+		blog.Tags = *p.Tags
+	}
+}
+
+// ParseMergePatch decodes an RFC 7396 merge patch for a blog. Members set to null
+// remove the value; read-only and unknown members are rejected.
+func ParseMergePatch(data []byte) (BlogPatch, error) {
+	var members map[string]json.RawMessage
+	if err := json.Unmarshal(data, &members); err != nil || members == nil {
+		return BlogPatch{}, errors.New("merge patch must be a JSON object")
+	}
+
+	var patch BlogPatch
+	for name, raw := range members {
+		if readOnlyFields[name] {
+			return BlogPatch{}, fmt.Errorf("field %q is read-only", name)
+		}
+		isNull := bytes.Equal(bytes.TrimSpace(raw), []byte("null"))
+
+		switch name {
+		case "title", "content":
+			var value string
+			if !isNull {
+				if err := json.Unmarshal(raw, &value); err != nil {
+					return BlogPatch{}, fmt.Errorf("field %q must be a string", name)
+				}
+			}
+			if name == "title" {
+				patch.Title = &value
+			} else {
+				patch.Content = &value
+			}
+		case "tags":
+			tags := []string{}
+			if !isNull {
+				if err := json.Unmarshal(raw, &tags); err != nil {
+					return BlogPatch{}, errors.New(`field "tags" must be an array of strings`)
+				}
+			}
+			patch.Tags = &tags
+		default:
+			return BlogPatch{}, fmt.Errorf("unknown field %q", name)
+		}
+	}
+	return patch, nil
+}
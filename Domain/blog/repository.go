@@ -1,6 +1,9 @@
 package blogpkg
 
-import "context"
+import (
+	"context"
+	"time"
+)
 
 // BlogRepository interface defines the methods
 type IBlogRepository interface {
@@ -14,6 +17,35 @@ type IBlogRepository interface {
 	AddLike(ctx context.Context, blogID string, userID string) error
 	RemoveLike(ctx context.Context, blogID string, userID string) error
 	AddComment(ctx context.Context, comment *Comment) (*Comment, error)
+	// GetComment returns ErrCommentNotFound unless the comment belongs to the blog
+	GetComment(ctx context.Context, blogID string, commentID string) (*Comment, error)
+	DeleteComment(ctx context.Context, blogID string, commentID string) error
+	SetReview(ctx context.Context, blogID string, review Review) (*Blog, error)
 	UpdateViewCount(ctx context.Context, blogID string) error
 	FindBlogByID(id string) (*Blog, error)
+	GetBlogsByIDs(ctx context.Context, ids []string) ([]Blog, error)
+	ListBlogsExcluding(ctx context.Context, excludeIDs []string, skip, limit int64) ([]Blog, int64, error)
+}
+
+// IPinRepository stores pinned and featured posts
+type IPinRepository interface {
+	UpsertPin(ctx context.Context, pin Pin) (Pin, error)
+	DeletePin(ctx context.Context, scope PinScope, ownerID, blogID string) error
+	// DeletePinsOfBlog removes a post's pins in every scope
+	DeletePinsOfBlog(ctx context.Context, blogID string) error
+	ListActivePins(ctx context.Context, scope PinScope, ownerID string, now time.Time) ([]Pin, error)
+}
+
+// IReportRepository stores reports filed against posts and comments
+type IReportRepository interface {
+	CreateReport(ctx context.Context, report Report) (Report, error)
+	// ListReports returns the reports with the given status, oldest first
+	ListReports(ctx context.Context, status ReportStatus, limit int64) ([]Report, error)
+	// HandleReport closes an open report; it returns ErrReportNotFound if there is no such open report
+	HandleReport(ctx context.Context, id string, status ReportStatus, handledBy, note string, at time.Time) (Report, error)
+}
+
+// IBlogCacheStats is implemented by caching blog repositories
+type IBlogCacheStats interface {
+	CacheStats() CacheStats
 }
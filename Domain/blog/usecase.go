@@ -10,9 +10,23 @@ type IBlogUsecase interface {
 	GetBlogByID(ctx context.Context, id string) (*Blog, error)
 	GetAllBlogs(ctx context.Context, pagination PaginationRequest) (PaginationResponse, error)
 	UpdateBlog(ctx context.Context, id string, blog *Blog) (*Blog, error)
+	PatchBlog(ctx context.Context, id string, patch BlogPatch) (*Blog, error)
 	DeleteBlog(ctx context.Context, id string) error
 	SearchBlogs(ctx context.Context, query string, pagination PaginationRequest) (PaginationResponse, error)
 	FilterByTags(ctx context.Context, tags []string, pagination PaginationRequest) (PaginationResponse, error)
 	ToggleLike(ctx context.Context, blogID string, userID string) error
 	AddComment(ctx context.Context, comment *Comment, blogID string) (*Comment, error)
+	PinBlog(ctx context.Context, blogID string, scope PinScope, req PinRequest) (Pin, error)
+	UnpinBlog(ctx context.Context, blogID string, scope PinScope) error
+	GetFeaturedBlogs(ctx context.Context) ([]Blog, error)
+	GetPinnedBlogs(ctx context.Context, authorID string) ([]Blog, error)
+}
+
+// IModerationUsecase covers editorial review and the moderation of comments and reported content
+type IModerationUsecase interface {
+	ReviewBlog(ctx context.Context, blogID string, req ReviewRequest) (*Blog, error)
+	DeleteComment(ctx context.Context, blogID string, commentID string) error
+	ReportContent(ctx context.Context, blogID string, req ReportRequest) (Report, error)
+	ListReports(ctx context.Context, status ReportStatus) ([]Report, error)
+	HandleReport(ctx context.Context, reportID string, req HandleReportRequest) (Report, error)
 }
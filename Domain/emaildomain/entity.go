@@ -0,0 +1,25 @@
+package emaildomainpkg
+
+import "time"
+
+// Rule actions
+const (
+	ActionAllow = "allow" // accept addresses on the domain without further checks
+	ActionDeny  = "deny"
+)
+
+// DomainRule is an admin decision about registrations from a domain and its subdomains.
+// The most specific rule wins, so "mail.example.com" can be allowed while "example.com" is denied.
+type DomainRule struct {
+	Domain    string    `json:"domain" bson:"_id"`
+	Action    string    `json:"action" bson:"action"`
+	Note      string    `json:"note,omitempty" bson:"note,omitempty"`
+	CreatedBy string    `json:"created_by" bson:"created_by"`
+	CreatedAt time.Time `json:"created_at" bson:"created_at"`
+}
+
+type SetDomainRuleRequest struct {
+	Domain string `json:"domain" binding:"required"`
+	Action string `json:"action" binding:"required"`
+	Note   string `json:"note"`
+}
@@ -0,0 +1,12 @@
+package emaildomainpkg
+
+import "context"
+
+type IDomainRuleRepository interface {
+	// UpsertRule creates the rule for rule.Domain or replaces the existing one
+	UpsertRule(ctx context.Context, rule DomainRule) (DomainRule, error)
+	ListRules(ctx context.Context) ([]DomainRule, error)
+	DeleteRule(ctx context.Context, domain string) error
+	// FindRules returns the rules that exist for any of domains
+	FindRules(ctx context.Context, domains []string) ([]DomainRule, error)
+}
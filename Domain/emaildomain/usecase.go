@@ -0,0 +1,9 @@
+package emaildomainpkg
+
+import "context"
+
+type IDomainRuleUsecase interface {
+	SetRule(ctx context.Context, req SetDomainRuleRequest, createdBy string) (DomainRule, error)
+	ListRules(ctx context.Context) ([]DomainRule, error)
+	DeleteRule(ctx context.Context, domain string) error
+}
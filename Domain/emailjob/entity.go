@@ -0,0 +1,27 @@
+package emailjobpkg
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// DeadLetterRetention is how long a failed job is kept for an admin to requeue. Its
+// bodies hold plaintext codes and links, so it is not kept for good.
+const DeadLetterRetention = 72 * time.Hour
+
+// EmailJob is one queued email. The bodies are never exposed through the API since
+// they usually carry one-time codes.
+type EmailJob struct {
+	ID        primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	To        string             `json:"to" bson:"to"`
+	Subject   string             `json:"subject" bson:"subject"`
+	HTML      string             `json:"-" bson:"html"`
+	Text      string             `json:"-" bson:"text"`
+	Attempts  int                `json:"attempts" bson:"attempts"`
+	LastError string             `json:"last_error,omitempty" bson:"last_error,omitempty"`
+	RunAt     time.Time          `json:"run_at" bson:"run_at"` // earliest time of the next attempt
+	CreatedAt time.Time          `json:"created_at" bson:"created_at"`
+	UpdatedAt time.Time          `json:"updated_at" bson:"updated_at"`
+	FailedAt  *time.Time         `json:"failed_at,omitempty" bson:"failed_at,omitempty"` // set while the job is dead-lettered
+}
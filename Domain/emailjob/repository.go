@@ -0,0 +1,20 @@
+package emailjobpkg
+
+import (
+	"context"
+	"time"
+)
+
+type IEmailJobRepository interface {
+	Enqueue(ctx context.Context, job EmailJob) (EmailJob, error)
+	// ClaimNext leases the earliest due job by moving its RunAt one lease into the future
+	ClaimNext(ctx context.Context, now time.Time, lease time.Duration) (EmailJob, bool, error)
+	Complete(ctx context.Context, id string) error
+	Reschedule(ctx context.Context, job EmailJob) error
+	// Bury moves a job that exhausted its attempts to the dead-letter collection
+	Bury(ctx context.Context, job EmailJob) error
+	ListQueued(ctx context.Context, limit int64) ([]EmailJob, error)
+	ListDead(ctx context.Context, limit int64) ([]EmailJob, error)
+	// Requeue moves a dead-lettered job back to the queue with a fresh attempt budget
+	Requeue(ctx context.Context, id string, now time.Time) (EmailJob, error)
+}
@@ -0,0 +1,9 @@
+package emailjobpkg
+
+import "context"
+
+type IEmailQueueUsecase interface {
+	ListQueued(ctx context.Context) ([]EmailJob, error)
+	ListDeadLetters(ctx context.Context) ([]EmailJob, error)
+	RequeueDeadLetter(ctx context.Context, id string) (EmailJob, error)
+}
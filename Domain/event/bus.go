@@ -0,0 +1,119 @@
+package eventpkg
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+)
+
+// IPublisher is the only part of the bus usecases depend on
+type IPublisher interface {
+	Publish(ctx context.Context, e Event)
+}
+
+// Handler reacts to an event. Returned errors are logged; they never reach the publisher.
+type Handler func(ctx context.Context, e Event) error
+
+// Mode selects how a subscriber is run
+type Mode int
+
+const (
+	// Sync subscribers run on the publisher's goroutine, in subscription order,
+	// before Publish returns
+	Sync Mode = iota
+	// Async subscribers run on their own goroutine with a context that is not
+	// cancelled when the publisher's request ends
+	Async
+)
+
+type subscription struct {
+	id      int
+	name    string
+	mode    Mode
+	handler Handler
+}
+
+// Bus is an in-process publish/subscribe hub. A failing or panicking subscriber
+// never affects the publisher or the other subscribers.
+type Bus struct {
+	mu     sync.RWMutex
+	subs   map[string][]subscription
+	nextID int
+	wg     sync.WaitGroup
+}
+
+func NewBus() *Bus {
+	return &Bus{subs: make(map[string][]subscription)}
+}
+
+// Subscribe registers handler for events named name and returns a function that removes it
+func (b *Bus) Subscribe(name string, mode Mode, handler Handler) (unsubscribe func()) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.nextID++
+	id := b.nextID
+	b.subs[name] = append(b.subs[name], subscription{id: id, name: name, mode: mode, handler: handler})
+
+	return func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		subs := b.subs[name]
+		for i, s := range subs {
+			if s.id == id {
+				b.subs[name] = append(subs[:i:i], subs[i+1:]...)
+				return
+			}
+		}
+	}
+}
+
+// Subscribe registers a handler for one concrete event type, e.g.
+//
+//	eventpkg.Subscribe(bus, eventpkg.Async, func(ctx context.Context, e eventpkg.BlogPublished) error { ... })
+func Subscribe[E Event](b *Bus, mode Mode, handler func(ctx context.Context, e E) error) (unsubscribe func()) {
+	var zero E
+	return b.Subscribe(zero.EventName(), mode, func(ctx context.Context, e Event) error {
+		typed, ok := e.(E)
+		if !ok {
+			return fmt.Errorf("expected %T, got %T", zero, e)
+		}
+		return handler(ctx, typed)
+	})
+}
+
+// Publish delivers e to every current subscriber of its name
+func (b *Bus) Publish(ctx context.Context, e Event) {
+	b.mu.RLock()
+	subs := b.subs[e.EventName()]
+	b.mu.RUnlock()
+
+	for _, s := range subs {
+		if s.mode == Async {
+			b.wg.Add(1)
+			go func(s subscription) {
+				defer b.wg.Done()
+				s.run(context.WithoutCancel(ctx), e)
+			}(s)
+			continue
+		}
+		s.run(ctx, e)
+	}
+}
+
+// Wait blocks until all async subscribers started so far have returned
+func (b *Bus) Wait() {
+	b.wg.Wait()
+}
+
+func (s subscription) run(ctx context.Context, e Event) {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Printf("events: subscriber %d for %s panicked: %v", s.id, s.name, r)
+		}
+	}()
+	if err := s.handler(ctx, e); err != nil {
+		log.Printf("events: subscriber %d for %s failed: %v", s.id, s.name, err)
+	}
+}
@@ -0,0 +1,116 @@
+package eventpkg_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	eventpkg "github.com/Amaankaa/Blog-Starter-Project/Domain/event"
+	"github.com/stretchr/testify/suite"
+)
+
+type busTestSuite struct {
+	suite.Suite
+	ctx context.Context
+	bus *eventpkg.Bus
+}
+
+func TestBusTestSuite(t *testing.T) {
+	suite.Run(t, new(busTestSuite))
+}
+
+func (s *busTestSuite) SetupTest() {
+	s.ctx = context.Background()
+	s.bus = eventpkg.NewBus()
+}
+
+func (s *busTestSuite) TestTypedSubscriberOnlyReceivesItsEvent() {
+	var got []eventpkg.UserPromoted
+	eventpkg.Subscribe(s.bus, eventpkg.Sync, func(_ context.Context, e eventpkg.UserPromoted) error {
+		got = append(got, e)
+		return nil
+	})
+
+	s.bus.Publish(s.ctx, eventpkg.UserDemoted{UserID: "u1"})
+	s.bus.Publish(s.ctx, eventpkg.UserPromoted{UserID: "u2", PromotedBy: "admin"})
+
+	s.Equal([]eventpkg.UserPromoted{{UserID: "u2", PromotedBy: "admin"}}, got)
+}
+
+func (s *busTestSuite) TestSyncSubscribersRunInOrderBeforePublishReturns() {
+	var order []int
+	for i := 1; i <= 3; i++ {
+		i := i
+		eventpkg.Subscribe(s.bus, eventpkg.Sync, func(context.Context, eventpkg.BlogDeleted) error {
+			order = append(order, i)
+			return nil
+		})
+	}
+
+	s.bus.Publish(s.ctx, eventpkg.BlogDeleted{BlogID: "b1"})
+	s.Equal([]int{1, 2, 3}, order)
+}
+
+func (s *busTestSuite) TestPanicsAndErrorsAreIsolated() {
+	var calls int
+	eventpkg.Subscribe(s.bus, eventpkg.Sync, func(context.Context, eventpkg.UserVerified) error {
+		panic("boom")
+	})
+	eventpkg.Subscribe(s.bus, eventpkg.Sync, func(context.Context, eventpkg.UserVerified) error {
+		return errors.New("failed")
+	})
+	eventpkg.Subscribe(s.bus, eventpkg.Async, func(context.Context, eventpkg.UserVerified) error {
+		panic("async boom")
+	})
+	eventpkg.Subscribe(s.bus, eventpkg.Sync, func(context.Context, eventpkg.UserVerified) error {
+		calls++
+		return nil
+	})
+
+	s.NotPanics(func() {
+		s.bus.Publish(s.ctx, eventpkg.UserVerified{Email: "a@example.com"})
+		s.bus.Wait()
+	})
+	s.Equal(1, calls)
+}
+
+func (s *busTestSuite) TestAsyncSubscriberOutlivesPublisherContext() {
+	ctx, cancel := context.WithCancel(context.WithValue(s.ctx, "user_id", "u1"))
+	release := make(chan struct{})
+	var (
+		mu     sync.Mutex
+		ctxErr error
+		userID interface{}
+	)
+	eventpkg.Subscribe(s.bus, eventpkg.Async, func(ctx context.Context, _ eventpkg.CommentAdded) error {
+		<-release
+		mu.Lock()
+		defer mu.Unlock()
+		ctxErr, userID = ctx.Err(), ctx.Value("user_id")
+		return nil
+	})
+
+	s.bus.Publish(ctx, eventpkg.CommentAdded{})
+	cancel()
+	close(release)
+	s.bus.Wait()
+
+	mu.Lock()
+	defer mu.Unlock()
+	s.NoError(ctxErr)
+	s.Equal("u1", userID)
+}
+
+func (s *busTestSuite) TestUnsubscribe() {
+	var calls int
+	unsubscribe := eventpkg.Subscribe(s.bus, eventpkg.Sync, func(context.Context, eventpkg.LikeToggled) error {
+		calls++
+		return nil
+	})
+
+	s.bus.Publish(s.ctx, eventpkg.LikeToggled{})
+	unsubscribe()
+	s.bus.Publish(s.ctx, eventpkg.LikeToggled{})
+	s.Equal(1, calls)
+}
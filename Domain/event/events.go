@@ -0,0 +1,86 @@
+package eventpkg
+
+import (
+	blogpkg "github.com/Amaankaa/Blog-Starter-Project/Domain/blog"
+)
+
+// Event is anything usecases announce on the bus. EventName must work on the
+// zero value, since typed subscriptions use it to pick which events they receive.
+type Event interface {
+	EventName() string
+}
+
+// BlogPublished is emitted after a new blog post has been stored
+type BlogPublished struct {
+	Blog blogpkg.Blog
+}
+
+func (BlogPublished) EventName() string { return "blog.published" }
+
+// BlogUpdated is emitted after a full update or a merge patch of a post
+type BlogUpdated struct {
+	Blog blogpkg.Blog
+}
+
+func (BlogUpdated) EventName() string { return "blog.updated" }
+
+type BlogDeleted struct {
+	BlogID   string
+	AuthorID string
+}
+
+func (BlogDeleted) EventName() string { return "blog.deleted" }
+
+type CommentAdded struct {
+	Comment blogpkg.Comment
+}
+
+func (CommentAdded) EventName() string { return "comment.added" }
+
+// LikeToggled reports whether the user now likes the post (Liked) or just removed their like
+type LikeToggled struct {
+	BlogID string
+	UserID string
+	Liked  bool
+}
+
+func (LikeToggled) EventName() string { return "like.toggled" }
+
+type UserRegistered struct {
+	UserID   string
+	Username string
+	Email    string
+	Role     string
+}
+
+func (UserRegistered) EventName() string { return "user.registered" }
+
+// UserVerified is emitted once the registration OTP has been confirmed
+type UserVerified struct {
+	Email string
+}
+
+func (UserVerified) EventName() string { return "user.verified" }
+
+type UserPromoted struct {
+	UserID     string
+	PromotedBy string
+}
+
+func (UserPromoted) EventName() string { return "user.promoted" }
+
+type UserDemoted struct {
+	UserID    string
+	DemotedBy string
+}
+
+func (UserDemoted) EventName() string { return "user.demoted" }
+
+// UserRoleChanged is emitted when an admin assigns a role other than through promote or demote
+type UserRoleChanged struct {
+	UserID    string
+	Role      string
+	ChangedBy string
+}
+
+func (UserRoleChanged) EventName() string { return "user.role_changed" }
@@ -0,0 +1,94 @@
+// Package eventtest provides helpers for asserting which domain events a usecase emitted.
+package eventtest
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	eventpkg "github.com/Amaankaa/Blog-Starter-Project/Domain/event"
+)
+
+// Recorder is an eventpkg.IPublisher that keeps every published event in order
+type Recorder struct {
+	mu     sync.Mutex
+	events []eventpkg.Event
+}
+
+func NewRecorder() *Recorder {
+	return &Recorder{}
+}
+
+func (r *Recorder) Publish(_ context.Context, e eventpkg.Event) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = append(r.events, e)
+}
+
+// Events returns a copy of everything published so far
+func (r *Recorder) Events() []eventpkg.Event {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]eventpkg.Event(nil), r.events...)
+}
+
+func (r *Recorder) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = nil
+}
+
+// Emitted returns the recorded events of type E, in publish order
+func Emitted[E eventpkg.Event](r *Recorder) []E {
+	var out []E
+	for _, e := range r.Events() {
+		if typed, ok := e.(E); ok {
+			out = append(out, typed)
+		}
+	}
+	return out
+}
+
+// RequireOne fails the test unless exactly one event of type E was recorded, and returns it
+func RequireOne[E eventpkg.Event](t testing.TB, r *Recorder) E {
+	t.Helper()
+	got := Emitted[E](r)
+	if len(got) != 1 {
+		var zero E
+		t.Fatalf("expected exactly one %s event, got %d (all events: %v)", zero.EventName(), len(got), names(r.Events()))
+	}
+	return got[0]
+}
+
+// AssertNone reports a test error if any event of type E was recorded
+func AssertNone[E eventpkg.Event](t testing.TB, r *Recorder) {
+	t.Helper()
+	if got := Emitted[E](r); len(got) > 0 {
+		var zero E
+		t.Errorf("expected no %s event, got %d", zero.EventName(), len(got))
+	}
+}
+
+// AssertNames reports a test error unless the recorded event names match want exactly
+func AssertNames(t testing.TB, r *Recorder, want ...string) {
+	t.Helper()
+	got := names(r.Events())
+	if len(got) != len(want) {
+		t.Errorf("expected events %v, got %v", want, got)
+		return
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("expected events %v, got %v", want, got)
+			return
+		}
+	}
+}
+
+func names(events []eventpkg.Event) []string {
+	out := make([]string, len(events))
+	for i, e := range events {
+		out[i] = e.EventName()
+	}
+	return out
+}
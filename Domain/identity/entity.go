@@ -0,0 +1,60 @@
+package identitypkg
+
+import (
+	"errors"
+	"time"
+
+	userpkg "github.com/Amaankaa/Blog-Starter-Project/Domain/user"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+var (
+	ErrUnknownProvider  = errors.New("unknown sign-in provider")
+	ErrIdentityNotFound = errors.New("linked identity not found")
+	// ErrIdentityTaken is returned when linking an external account that belongs to another user
+	ErrIdentityTaken = errors.New("this account is already linked to another user")
+	// ErrStateNotFound is returned for an unknown, used or expired authorization state
+	ErrStateNotFound = errors.New("sign-in request expired, please try again")
+)
+
+// Identity links an account at an external provider to a local user
+type Identity struct {
+	ID          primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	UserID      primitive.ObjectID `bson:"user_id" json:"-"`
+	Provider    string             `bson:"provider" json:"provider"`
+	Subject     string             `bson:"subject" json:"-"` // the provider's stable user id
+	Email       string             `bson:"email" json:"email"`
+	LinkedAt    time.Time          `bson:"linked_at" json:"linkedAt"`
+	LastLoginAt time.Time          `bson:"last_login_at,omitempty" json:"lastLoginAt,omitempty"`
+}
+
+// ExternalIdentity is what a provider tells us about the user who signed in
+type ExternalIdentity struct {
+	Provider      string
+	Subject       string
+	Email         string
+	EmailVerified bool
+	Name          string
+	Username      string // a suggestion; local usernames are made unique separately
+}
+
+// AuthState is an authorization request in progress. The state parameter sent to the
+// provider is only stored hashed.
+type AuthState struct {
+	ID           string `bson:"_id"`
+	Provider     string `bson:"provider"`
+	Nonce        string `bson:"nonce"`
+	CodeVerifier string `bson:"code_verifier"` // PKCE
+	// LinkUserID is set when a signed-in user is linking a new identity rather than logging in
+	LinkUserID string    `bson:"link_user_id,omitempty"`
+	ExpiresAt  time.Time `bson:"expires_at"`
+}
+
+// CallbackResult is the outcome of a provider redirect: either a login or a newly linked identity
+type CallbackResult struct {
+	Linked       bool
+	Identity     Identity
+	User         userpkg.User
+	AccessToken  string
+	RefreshToken string
+}
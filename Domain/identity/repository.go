@@ -0,0 +1,37 @@
+package identitypkg
+
+import (
+	"context"
+
+	userpkg "github.com/Amaankaa/Blog-Starter-Project/Domain/user"
+)
+
+type IIdentityRepository interface {
+	// FindIdentity returns ErrIdentityNotFound if nobody linked that account
+	FindIdentity(ctx context.Context, provider, subject string) (Identity, error)
+	ListIdentities(ctx context.Context, userID string) ([]Identity, error)
+	// CreateIdentity returns ErrIdentityTaken if the account is already linked
+	CreateIdentity(ctx context.Context, identity Identity) (Identity, error)
+	TouchIdentity(ctx context.Context, identityID string) error
+	// DeleteIdentity removes one of the user's identities, or returns ErrIdentityNotFound
+	DeleteIdentity(ctx context.Context, userID, identityID string) error
+
+	StoreState(ctx context.Context, state AuthState) error
+	// ConsumeState deletes and returns the state, or returns ErrStateNotFound
+	ConsumeState(ctx context.Context, id string) (AuthState, error)
+}
+
+// IIdentityProvider signs users in at an external OAuth 2.0 / OpenID Connect provider
+type IIdentityProvider interface {
+	Name() string
+	// AuthCodeURL is where to send the user's browser; codeChallenge is the S256 PKCE challenge
+	AuthCodeURL(ctx context.Context, state, nonce, codeChallenge string) (string, error)
+	// Exchange redeems the authorization code and returns the verified identity
+	Exchange(ctx context.Context, code, codeVerifier, nonce string) (ExternalIdentity, error)
+}
+
+// ISessionIssuer starts a session for a user authenticated by other means than a password.
+// Two-factor authentication still applies.
+type ISessionIssuer interface {
+	SignIn(ctx context.Context, user userpkg.User, device userpkg.DeviceInfo) (userpkg.User, string, string, error)
+}
@@ -0,0 +1,18 @@
+package identitypkg
+
+import (
+	"context"
+
+	userpkg "github.com/Amaankaa/Blog-Starter-Project/Domain/user"
+)
+
+type IIdentityUsecase interface {
+	Providers() []string
+	// StartLogin returns the provider URL to send the browser to
+	StartLogin(ctx context.Context, provider string) (string, error)
+	// StartLink is StartLogin for a signed-in user adding another way to sign in
+	StartLink(ctx context.Context, provider, userID string) (string, error)
+	Callback(ctx context.Context, provider, code, state string, device userpkg.DeviceInfo) (CallbackResult, error)
+	ListIdentities(ctx context.Context, userID string) ([]Identity, error)
+	Unlink(ctx context.Context, userID, identityID string) error
+}
@@ -0,0 +1,68 @@
+package lockoutpkg
+
+import (
+	"errors"
+	"time"
+)
+
+// Limit is how many failed logins an account or IP address gets
+type Limit struct {
+	FreeAttempts int // failures before every further attempt has to wait
+	LockAfter    int // failures that lock it for the lockout duration
+}
+
+// Policy for failed logins. Failures are counted while they keep coming within Window of
+// each other; delays double from BaseDelay up to MaxDelay.
+type Policy struct {
+	Account         Limit
+	IP              Limit
+	BaseDelay       time.Duration
+	MaxDelay        time.Duration
+	LockoutDuration time.Duration
+	Window          time.Duration
+}
+
+// DefaultPolicy allows many more failures per address than per account, since an address
+// may be shared by a whole office
+var DefaultPolicy = Policy{
+	Account:         Limit{FreeAttempts: 3, LockAfter: 10},
+	IP:              Limit{FreeAttempts: 10, LockAfter: 50},
+	BaseDelay:       time.Second,
+	MaxDelay:        30 * time.Second,
+	LockoutDuration: 15 * time.Minute,
+	Window:          15 * time.Minute,
+}
+
+var ErrUnlockTokenInvalid = errors.New("invalid or expired unlock link")
+
+// Counter holds the recent failures of one account or IP address
+type Counter struct {
+	Key           string    `bson:"_id"`
+	Failures      int       `bson:"failures"`
+	LastFailureAt time.Time `bson:"last_failure_at"`
+	LockedUntil   time.Time `bson:"locked_until,omitempty"`
+	ExpiresAt     time.Time `bson:"expires_at"`
+}
+
+func AccountKey(userID string) string { return "account:" + userID }
+func IPKey(ip string) string          { return "ip:" + ip }
+
+// UnlockToken is emailed to the owner of a locked account; only its hash is stored
+type UnlockToken struct {
+	ID        string    `bson:"_id"`
+	UserID    string    `bson:"user_id"`
+	ExpiresAt time.Time `bson:"expires_at"`
+}
+
+// ThrottledError rejects a login attempt before the password is checked
+type ThrottledError struct {
+	RetryAfter time.Duration
+	Locked     bool
+}
+
+func (e *ThrottledError) Error() string {
+	if e.Locked {
+		return "too many failed login attempts; sign-in is temporarily locked"
+	}
+	return "too many failed login attempts; wait a moment before trying again"
+}
@@ -0,0 +1,20 @@
+package lockoutpkg
+
+import (
+	"context"
+	"time"
+)
+
+type ILoginAttemptRepository interface {
+	// GetCounter returns an empty counter for a key without recent failures
+	GetCounter(ctx context.Context, key string) (Counter, error)
+	// RecordFailure adds a failure and keeps the counter for another window
+	RecordFailure(ctx context.Context, key string, at time.Time, window time.Duration) (Counter, error)
+	// Lock clears the failures and locks the key, or reports false if it is locked already
+	Lock(ctx context.Context, key string, until time.Time) (bool, error)
+	ResetCounter(ctx context.Context, key string) error
+
+	StoreUnlockToken(ctx context.Context, token UnlockToken) error
+	// ConsumeUnlockToken deletes the token, or returns ErrUnlockTokenInvalid if there is none
+	ConsumeUnlockToken(ctx context.Context, id string) (UnlockToken, error)
+}
@@ -0,0 +1,23 @@
+package lockoutpkg
+
+import (
+	"context"
+
+	userpkg "github.com/Amaankaa/Blog-Starter-Project/Domain/user"
+)
+
+// ILoginGuard limits password guessing. Checks take an empty userID when the login matches
+// no account, so only the address is limited.
+type ILoginGuard interface {
+	// Check returns a *ThrottledError if the account or address has to wait
+	Check(ctx context.Context, userID, ip string) error
+	// RecordFailure counts a wrong password; user is the zero User for an unknown login.
+	// Locking an account emails its owner an unlock link.
+	RecordFailure(ctx context.Context, user userpkg.User, device userpkg.DeviceInfo) error
+	// RecordSuccess forgets the account's failures; the address keeps its own
+	RecordSuccess(ctx context.Context, userID string) error
+}
+
+type ILockoutUsecase interface {
+	Unlock(ctx context.Context, token string) error
+}
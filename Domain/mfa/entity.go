@@ -0,0 +1,45 @@
+package mfapkg
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrNotEnrolled = errors.New("two-factor authentication is not enabled")
+	ErrInvalidCode = errors.New("invalid two-factor code")
+	// ErrChallengeNotFound is returned for an unknown, expired or exhausted login challenge
+	ErrChallengeNotFound = errors.New("two-factor challenge expired, please log in again")
+)
+
+// Enrollment is a user's authenticator app. It is pending (Enabled false) from setup until
+// the user proves the app works by entering a code.
+type Enrollment struct {
+	UserID  string `bson:"_id"`
+	Secret  string `bson:"secret"` // base32, as shown to the user
+	Enabled bool   `bson:"enabled"`
+	// RecoveryCodes are SHA-256 hashes of the unused one-time recovery codes
+	RecoveryCodes []string `bson:"recovery_codes"`
+	// LastUsedStep is the TOTP time step of the last accepted code, so no code works twice
+	LastUsedStep int64     `bson:"last_used_step"`
+	CreatedAt    time.Time `bson:"created_at"`
+	EnabledAt    time.Time `bson:"enabled_at,omitempty"`
+}
+
+// Policy holds the site-wide two-factor settings
+type Policy struct {
+	// RequireForAdmins denies admin routes to sessions that did not sign in with a second factor
+	RequireForAdmins bool `json:"require_for_admins" bson:"require_for_admins"`
+}
+
+// Setup is what an authenticator app needs to start generating codes
+type Setup struct {
+	Secret string `json:"secret"`
+	// ProvisioningURI is the otpauth:// URI to render as a QR code
+	ProvisioningURI string `json:"otpauth_url"`
+}
+
+type Status struct {
+	Enabled                bool `json:"enabled"`
+	RecoveryCodesRemaining int  `json:"recovery_codes_remaining"`
+}
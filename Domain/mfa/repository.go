@@ -0,0 +1,30 @@
+package mfapkg
+
+import (
+	"context"
+	"time"
+)
+
+type IMFARepository interface {
+	// FindEnrollment returns ErrNotEnrolled if the user never started setup
+	FindEnrollment(ctx context.Context, userID string) (Enrollment, error)
+	// SaveEnrollment creates the user's enrollment or replaces the existing one
+	SaveEnrollment(ctx context.Context, enrollment Enrollment) error
+	DeleteEnrollment(ctx context.Context, userID string) error
+	// RecordUsedStep stores step as the last used one, or reports false if it isn't newer
+	RecordUsedStep(ctx context.Context, userID string, step int64) (bool, error)
+	// ConsumeRecoveryCode removes the recovery code hash, or reports false if it isn't there
+	ConsumeRecoveryCode(ctx context.Context, userID, codeHash string) (bool, error)
+	ReplaceRecoveryCodes(ctx context.Context, userID string, codeHashes []string) error
+
+	GetPolicy(ctx context.Context) (Policy, error)
+	SavePolicy(ctx context.Context, policy Policy) error
+}
+
+// ITOTPService generates and checks RFC 6238 codes
+type ITOTPService interface {
+	GenerateSecret() (string, error)
+	ProvisioningURI(secret, accountName string) string
+	// Validate reports whether code is valid at t, and the time step it belongs to
+	Validate(secret, code string, t time.Time) (int64, bool)
+}
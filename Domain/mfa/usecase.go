@@ -0,0 +1,40 @@
+package mfapkg
+
+import (
+	"context"
+	"time"
+
+	userpkg "github.com/Amaankaa/Blog-Starter-Project/Domain/user"
+)
+
+type IMFAUsecase interface {
+	Status(ctx context.Context, userID string) (Status, error)
+	// BeginSetup creates a new secret; it takes effect once confirmed with Enable
+	BeginSetup(ctx context.Context, userID, accountName string) (Setup, error)
+	// Enable confirms setup with a code from the app and returns the recovery codes
+	Enable(ctx context.Context, userID, code string) ([]string, error)
+	// Disable and RegenerateRecoveryCodes count wrong codes against the account and the
+	// device's address, like failed logins
+	Disable(ctx context.Context, userID, code string, device userpkg.DeviceInfo) error
+	RegenerateRecoveryCodes(ctx context.Context, userID, code string, device userpkg.DeviceInfo) ([]string, error)
+	GetPolicy(ctx context.Context) (Policy, error)
+	SetPolicy(ctx context.Context, policy Policy, actorID string) (Policy, error)
+}
+
+// IMFAVerifier is the part of two-factor authentication used by the login flow
+type IMFAVerifier interface {
+	IsEnabled(ctx context.Context, userID string) (bool, error)
+	// StartChallenge returns a short-lived token to complete the login with
+	StartChallenge(ctx context.Context, userID string) (string, time.Time, error)
+	// ChallengeUser returns the user a login's challenge belongs to without answering it, or
+	// ErrChallengeNotFound
+	ChallengeUser(ctx context.Context, challengeToken string) (string, error)
+	// CompleteChallenge checks a TOTP or recovery code and returns the challenge's user. With
+	// ErrInvalidCode it still returns the user, so the wrong code can count against the account.
+	CompleteChallenge(ctx context.Context, challengeToken, code string) (string, error)
+}
+
+// IMFAPolicy is consulted before letting a session onto the admin routes
+type IMFAPolicy interface {
+	AdminsRequireMFA(ctx context.Context) (bool, error)
+}
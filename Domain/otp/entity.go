@@ -0,0 +1,73 @@
+package otppkg
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+)
+
+var (
+	// ErrChallengeNotFound is returned when no code was requested, or it expired or was already used
+	ErrChallengeNotFound = errors.New("no valid code was requested, please request a new one")
+	ErrInvalidCode       = errors.New("invalid code")
+	// ErrTooManyAttempts ends the challenge; the user has to request a new code
+	ErrTooManyAttempts = errors.New("too many invalid attempts, please request a new code")
+	ErrInvalidToken    = errors.New("invalid or expired token")
+)
+
+// Purpose is what a challenge proves. A subject has at most one challenge per purpose.
+type Purpose string
+
+const (
+	PurposeVerification  Purpose = "verification"   // subject: email address
+	PurposePasswordReset Purpose = "password_reset" // subject: email address
+	PurposeEmailChange   Purpose = "email_change"   // subject: user id, data: the new address
+	PurposeMFA           Purpose = "mfa"            // subject: hash of the login's challenge token, data: user id
+)
+
+// Policy holds the limits of one purpose
+type Policy struct {
+	// CodeLength is the number of digits. Purposes without a code are answered with a check
+	// of the caller's own, like two-factor logins with an authenticator app.
+	CodeLength  int
+	Lifetime    time.Duration
+	MaxAttempts int
+	// ResendCooldown is how long after sending a code another one can be requested
+	ResendCooldown time.Duration
+}
+
+var DefaultPolicies = map[Purpose]Policy{
+	PurposeVerification:  {CodeLength: 6, Lifetime: 15 * time.Minute, MaxAttempts: 5, ResendCooldown: time.Minute},
+	PurposePasswordReset: {CodeLength: 6, Lifetime: 10 * time.Minute, MaxAttempts: 5, ResendCooldown: time.Minute},
+	PurposeEmailChange:   {CodeLength: 6, Lifetime: 15 * time.Minute, MaxAttempts: 5, ResendCooldown: time.Minute},
+	PurposeMFA:           {Lifetime: 5 * time.Minute, MaxAttempts: 5},
+}
+
+// Challenge is an outstanding request to prove something with a code. Only the code's hash
+// is stored.
+type Challenge struct {
+	ID           string    `bson:"_id"` // see ChallengeID
+	Purpose      Purpose   `bson:"purpose"`
+	Subject      string    `bson:"subject"`
+	CodeHash     string    `bson:"code_hash,omitempty"`
+	Data         string    `bson:"data,omitempty"` // e.g. the new address of an email change
+	AttemptCount int       `bson:"attempt_count"`
+	SentAt       time.Time `bson:"sent_at"`
+	ExpiresAt    time.Time `bson:"expires_at"`
+	// TokenHash is set once the code was exchanged for a token; the code is then cleared
+	TokenHash string `bson:"token_hash,omitempty"`
+}
+
+func ChallengeID(purpose Purpose, subject string) string {
+	return string(purpose) + ":" + subject
+}
+
+// CooldownError is returned when a new code is requested too soon after the last one
+type CooldownError struct {
+	RetryAfter time.Duration
+}
+
+func (e *CooldownError) Error() string {
+	return fmt.Sprintf("a code was sent recently, please wait %d seconds before requesting another", int(math.Ceil(e.RetryAfter.Seconds())))
+}
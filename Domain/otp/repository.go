@@ -0,0 +1,25 @@
+package otppkg
+
+import (
+	"context"
+	"time"
+)
+
+type IOTPRepository interface {
+	// Issue stores challenge in place of the subject's earlier one for the same purpose, unless
+	// that was sent after sentBefore; then it reports false
+	Issue(ctx context.Context, challenge Challenge, sentBefore time.Time) (bool, error)
+	// Find returns ErrChallengeNotFound if there is no such challenge
+	Find(ctx context.Context, id string) (Challenge, error)
+	// TakeAttempt counts an answer against the challenge and returns it, in one step so that
+	// answers sent in parallel can't get past maxAttempts. It returns ErrTooManyAttempts once
+	// the challenge had maxAttempts, and ErrChallengeNotFound if it doesn't exist or was exchanged.
+	TakeAttempt(ctx context.Context, id string, maxAttempts int) (Challenge, error)
+	Delete(ctx context.Context, id string) error
+	// Exchange swaps the challenge's code for the hash of a token, valid until expiresAt
+	Exchange(ctx context.Context, id, tokenHash string, expiresAt time.Time) error
+	// FindByToken returns the challenge holding the token, or ErrInvalidToken
+	FindByToken(ctx context.Context, purpose Purpose, tokenHash string) (Challenge, error)
+	// ConsumeToken deletes the challenge holding the token, or returns ErrInvalidToken
+	ConsumeToken(ctx context.Context, purpose Purpose, tokenHash string) (Challenge, error)
+}
@@ -0,0 +1,26 @@
+package otppkg
+
+import (
+	"context"
+	"time"
+)
+
+// IOTPService issues and checks the one-time challenges of every flow that proves something
+// with a code: email verification, password reset, email change and two-factor logins
+type IOTPService interface {
+	// Issue starts a challenge, replacing the subject's earlier one for purpose, and returns
+	// its code (empty for purposes without one). Too soon after the last code it returns a
+	// *CooldownError.
+	Issue(ctx context.Context, purpose Purpose, subject, data string) (string, time.Time, error)
+	// Verify checks code and uses the challenge up
+	Verify(ctx context.Context, purpose Purpose, subject, code string) (Challenge, error)
+	// Find returns the live challenge without taking one of its attempts, or ErrChallengeNotFound
+	Find(ctx context.Context, purpose Purpose, subject string) (Challenge, error)
+	// Attempt is Verify with the caller's own check; check reports whether the answer was right
+	Attempt(ctx context.Context, purpose Purpose, subject string, check func(Challenge) (bool, error)) (Challenge, error)
+	// Exchange checks code and swaps the challenge for a token that Redeem accepts once
+	Exchange(ctx context.Context, purpose Purpose, subject, code string, lifetime time.Duration) (string, error)
+	Redeem(ctx context.Context, purpose Purpose, token string) (Challenge, error)
+	// Lookup returns the challenge a token belongs to without redeeming it
+	Lookup(ctx context.Context, purpose Purpose, token string) (Challenge, error)
+}
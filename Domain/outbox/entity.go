@@ -0,0 +1,45 @@
+package outboxpkg
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Message kinds handled by the outbox dispatcher
+const (
+	// KindVerificationEmail sends the registration OTP; the payload is a VerificationEmailPayload
+	KindVerificationEmail = "user.verification_email"
+)
+
+// Message statuses
+const (
+	StatusPending = "pending"
+	StatusSent    = "sent"
+	StatusFailed  = "failed"
+)
+
+// Message is a side effect recorded together with the state change that caused it.
+// The dispatcher delivers it at least once; handlers use IdempotencyKey to tolerate repeats.
+type Message struct {
+	ID             primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	Kind           string             `json:"kind" bson:"kind"`
+	IdempotencyKey string             `json:"idempotency_key" bson:"idempotency_key"`
+	Payload        string             `json:"payload" bson:"payload"`
+	Status         string             `json:"status" bson:"status"`
+	Attempts       int                `json:"attempts" bson:"attempts"`
+	LastError      string             `json:"last_error,omitempty" bson:"last_error,omitempty"`
+	NextAttemptAt  time.Time          `json:"next_attempt_at" bson:"next_attempt_at"`
+	CreatedAt      time.Time          `json:"created_at" bson:"created_at"`
+	ProcessedAt    *time.Time         `json:"processed_at,omitempty" bson:"processed_at,omitempty"`
+}
+
+type VerificationEmailPayload struct {
+	Email    string `json:"email"`
+	Name     string `json:"name,omitempty"`
+	Language string `json:"language,omitempty"`
+}
+
+// Handler performs the side effect described by msg
+type Handler func(ctx context.Context, msg Message) error
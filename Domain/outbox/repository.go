@@ -0,0 +1,20 @@
+package outboxpkg
+
+import (
+	"context"
+	"time"
+)
+
+type IOutboxRepository interface {
+	// Enqueue stores msg unless a message with the same idempotency key already exists
+	Enqueue(ctx context.Context, msg Message) error
+	// ClaimDue leases the oldest pending message whose next attempt is due
+	ClaimDue(ctx context.Context, now time.Time, lease time.Duration) (Message, bool, error)
+	UpdateMessage(ctx context.Context, msg Message) error
+}
+
+// ITransactor runs fn atomically. Repository calls made with the ctx passed to fn
+// take part in the transaction.
+type ITransactor interface {
+	WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
@@ -0,0 +1,46 @@
+package passwordpkg
+
+import (
+	"strings"
+	"time"
+)
+
+// Policy holds the rules a new password is checked against
+type Policy struct {
+	MinLength int
+	// MaxLength bounds the work of estimating strength, which grows with the cube of the length
+	MaxLength int
+	// MinScore is the lowest acceptable Strength.Score
+	MinScore int
+	// HistorySize is how many of a user's passwords, counting the current one, can't be reused
+	HistorySize int
+}
+
+// DefaultPolicy asks for passwords that would take about 10^8 guesses, enough against online
+// attacks even without rate limits and a slow hash
+var DefaultPolicy = Policy{MinLength: 8, MaxLength: 128, MinScore: 3, HistorySize: 5}
+
+// Strength estimates how many guesses an attacker trying likely passwords first would need
+type Strength struct {
+	Guesses float64
+	// Score is 0 to 4 for fewer than 10^3, 10^6, 10^8, 10^10 and more guesses
+	Score int
+	// Warning explains the most guessable part of the password, if there is one
+	Warning string
+}
+
+// HistoryEntry is a password hash the user replaced
+type HistoryEntry struct {
+	UserID    string    `bson:"user_id"`
+	Hash      string    `bson:"hash"`
+	RetiredAt time.Time `bson:"retired_at"`
+}
+
+// PolicyError lists every rule a password broke, so they can all be fixed at once
+type PolicyError struct {
+	Reasons []string
+}
+
+func (e *PolicyError) Error() string {
+	return "password rejected: " + strings.Join(e.Reasons, "; ")
+}
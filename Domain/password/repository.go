@@ -0,0 +1,22 @@
+package passwordpkg
+
+import "context"
+
+type IPasswordHistoryRepository interface {
+	// Add stores a replaced hash and drops all but the user's newest keep entries
+	Add(ctx context.Context, entry HistoryEntry, keep int) error
+	// Recent returns up to limit of the user's replaced hashes, newest first
+	Recent(ctx context.Context, userID string, limit int) ([]string, error)
+}
+
+// IStrengthEstimator scores a password the way an attacker would guess it: common passwords,
+// words, keyboard patterns and dates first. userInputs are words the attacker knows about
+// the user, like their name.
+type IStrengthEstimator interface {
+	Estimate(password string, userInputs []string) Strength
+}
+
+// IBreachCorpus looks passwords up in a list of ones exposed in data breaches
+type IBreachCorpus interface {
+	IsBreached(ctx context.Context, password string) (bool, error)
+}
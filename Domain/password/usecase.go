@@ -0,0 +1,16 @@
+package passwordpkg
+
+import (
+	"context"
+
+	userpkg "github.com/Amaankaa/Blog-Starter-Project/Domain/user"
+)
+
+// IPasswordPolicy decides whether a password may be set
+type IPasswordPolicy interface {
+	// Check returns a *PolicyError listing the rules password breaks for user. For a new
+	// user (no ID yet) there is no history to check.
+	Check(ctx context.Context, password string, user userpkg.User) error
+	// Retire adds the user's current password hash to their history before it is replaced
+	Retire(ctx context.Context, user userpkg.User) error
+}
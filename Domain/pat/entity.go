@@ -0,0 +1,71 @@
+package patpkg
+
+import (
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Scopes a personal access token can be granted. Routes that accept tokens name the scope
+// they need; every other authenticated route still requires a login session. Blog reads are
+// public, so they need no scope.
+const (
+	ScopeBlogsWrite    = "blogs:write"
+	ScopeCommentsWrite = "comments:write"
+	ScopeAIUse         = "ai:use"
+)
+
+var Scopes = []string{ScopeBlogsWrite, ScopeCommentsWrite, ScopeAIUse}
+
+// TokenPrefix starts every token, so the middleware can tell them from JWTs and secret
+// scanners can recognise leaked ones
+const TokenPrefix = "bsp_pat_"
+
+var (
+	ErrTokenNotFound = errors.New("personal access token not found")
+	// ErrInvalidToken covers unknown and expired tokens alike
+	ErrInvalidToken = errors.New("invalid or expired personal access token")
+)
+
+type PersonalAccessToken struct {
+	ID         primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	UserID     primitive.ObjectID `bson:"user_id" json:"-"`
+	Name       string             `bson:"name" json:"name"`
+	Scopes     []string           `bson:"scopes" json:"scopes"`
+	TokenHash  string             `bson:"token_hash" json:"-"`
+	Hint       string             `bson:"hint" json:"hint"` // the start of the token, to tell tokens apart
+	CreatedAt  time.Time          `bson:"created_at" json:"createdAt"`
+	ExpiresAt  time.Time          `bson:"expires_at" json:"expiresAt"`
+	LastUsedAt time.Time          `bson:"last_used_at,omitempty" json:"lastUsedAt,omitempty"`
+}
+
+type CreateRequest struct {
+	Name          string   `json:"name" binding:"required"`
+	Scopes        []string `json:"scopes" binding:"required"`
+	ExpiresInDays int      `json:"expires_in_days"` // defaults to 30, at most 365
+}
+
+// CreatedToken is returned once at creation; only the hash is kept
+type CreatedToken struct {
+	PersonalAccessToken
+	Token string `json:"token"`
+}
+
+// Principal is the user a request authenticated with a token acts as
+type Principal struct {
+	TokenID  string
+	UserID   string
+	Username string
+	Role     string
+	Scopes   []string
+}
+
+func (p Principal) HasScope(scope string) bool {
+	for _, s := range p.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,18 @@
+package patpkg
+
+import (
+	"context"
+	"time"
+)
+
+type IPATRepository interface {
+	Create(ctx context.Context, token PersonalAccessToken) (PersonalAccessToken, error)
+	// FindByHash returns ErrTokenNotFound for an unknown hash
+	FindByHash(ctx context.Context, tokenHash string) (PersonalAccessToken, error)
+	ListByUser(ctx context.Context, userID string) ([]PersonalAccessToken, error)
+	CountByUser(ctx context.Context, userID string) (int64, error)
+	// Delete removes one of the user's tokens, or returns ErrTokenNotFound
+	Delete(ctx context.Context, userID, tokenID string) error
+	DeleteByUser(ctx context.Context, userID string) error
+	TouchLastUsed(ctx context.Context, tokenID string, at time.Time) error
+}
@@ -0,0 +1,14 @@
+package patpkg
+
+import "context"
+
+type IPATUsecase interface {
+	Create(ctx context.Context, userID string, req CreateRequest) (CreatedToken, error)
+	List(ctx context.Context, userID string) ([]PersonalAccessToken, error)
+	Revoke(ctx context.Context, userID, tokenID string) error
+}
+
+// IPATAuthenticator resolves a presented token to the user it acts for
+type IPATAuthenticator interface {
+	Authenticate(ctx context.Context, token string) (Principal, error)
+}
@@ -0,0 +1,29 @@
+package ratelimitpkg
+
+import (
+	"fmt"
+	"time"
+)
+
+// Policy allows Limit requests per Period to each client. Unused capacity builds back up
+// over the period, so a client may also send all of it at once.
+type Policy struct {
+	Name   string // keeps the counters of policies apart when a client hits several
+	Limit  int
+	Period time.Duration
+}
+
+// String is the RateLimit-Policy header value, e.g. "10;w=60"
+func (p Policy) String() string {
+	return fmt.Sprintf("%d;w=%d", p.Limit, int(p.Period.Seconds()))
+}
+
+// Decision is the outcome of one request against a policy
+type Decision struct {
+	Allowed   bool
+	Remaining int
+	// ResetAfter is when the client will have its full limit again
+	ResetAfter time.Duration
+	// RetryAfter is when the next request will be allowed; zero if it is now
+	RetryAfter time.Duration
+}
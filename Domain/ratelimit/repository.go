@@ -0,0 +1,12 @@
+package ratelimitpkg
+
+import (
+	"context"
+	"time"
+)
+
+// IRateLimitStore keeps the counters of every client. Take has to be atomic per key, so a
+// store shared between instances needs to do the whole update in one round trip.
+type IRateLimitStore interface {
+	Take(ctx context.Context, key string, policy Policy, now time.Time) (Decision, error)
+}
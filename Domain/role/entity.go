@@ -0,0 +1,123 @@
+package rolepkg
+
+import (
+	"errors"
+	"time"
+)
+
+// Permissions checked by Require. Administrative ones are subject to the two-factor policy.
+const (
+	PermPostsWrite       = "posts:write"
+	PermCommentsWrite    = "comments:write"
+	PermPostsFeature     = "posts:feature"
+	PermPostsReview      = "posts:review"
+	PermCommentsModerate = "comments:moderate"
+	PermReportsHandle    = "reports:handle"
+	PermUsersManage      = "users:manage"
+	PermRolesManage      = "roles:manage"
+	PermWebhooksManage   = "webhooks:manage"
+	PermEmailManage      = "email:manage"
+	PermSecurityManage   = "security:manage"
+	PermSystemMonitor    = "system:monitor"
+)
+
+// Built-in roles; they can be edited but not deleted
+const (
+	RoleAdmin     = "admin"
+	RoleEditor    = "editor"
+	RoleModerator = "moderator"
+	RoleAuthor    = "author"
+	RoleUser      = "user"
+)
+
+var (
+	ErrRoleNotFound      = errors.New("role not found")
+	ErrUnknownPermission = errors.New("unknown permission")
+)
+
+type Permission struct {
+	Name           string `json:"name"`
+	Description    string `json:"description"`
+	Administrative bool   `json:"administrative"`
+}
+
+var Permissions = []Permission{
+	{Name: PermPostsWrite, Description: "Write blog posts"},
+	{Name: PermCommentsWrite, Description: "Comment on posts"},
+	{Name: PermPostsFeature, Description: "Feature posts site-wide", Administrative: true},
+	{Name: PermPostsReview, Description: "Review posts before publication", Administrative: true},
+	{Name: PermCommentsModerate, Description: "Hide and remove comments", Administrative: true},
+	{Name: PermReportsHandle, Description: "Handle reported content", Administrative: true},
+	{Name: PermUsersManage, Description: "Change users' roles", Administrative: true},
+	{Name: PermRolesManage, Description: "Edit roles and their permissions", Administrative: true},
+	{Name: PermWebhooksManage, Description: "Manage webhooks", Administrative: true},
+	{Name: PermEmailManage, Description: "Manage the email queue, templates and domain rules", Administrative: true},
+	{Name: PermSecurityManage, Description: "Change security policies", Administrative: true},
+	{Name: PermSystemMonitor, Description: "View cache and system statistics", Administrative: true},
+}
+
+func IsKnownPermission(name string) bool {
+	for _, p := range Permissions {
+		if p.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func IsAdministrative(name string) bool {
+	for _, p := range Permissions {
+		if p.Name == name {
+			return p.Administrative
+		}
+	}
+	return false
+}
+
+type Role struct {
+	Name        string    `bson:"_id" json:"name"`
+	Description string    `bson:"description" json:"description"`
+	Permissions []string  `bson:"permissions" json:"permissions"`
+	BuiltIn     bool      `bson:"built_in" json:"builtIn"`
+	UpdatedAt   time.Time `bson:"updated_at" json:"updatedAt"`
+}
+
+// DefaultRoles are created on startup if missing; existing roles are left as admins edited them
+func DefaultRoles() []Role {
+	all := make([]string, len(Permissions))
+	for i, p := range Permissions {
+		all[i] = p.Name
+	}
+	return []Role{
+		{Name: RoleAdmin, Description: "Full access", Permissions: all, BuiltIn: true},
+		{Name: RoleEditor, Description: "Reviews and features posts", BuiltIn: true,
+			Permissions: []string{PermPostsWrite, PermCommentsWrite, PermPostsReview, PermPostsFeature}},
+		{Name: RoleModerator, Description: "Handles comments and reports", BuiltIn: true,
+			Permissions: []string{PermPostsWrite, PermCommentsWrite, PermCommentsModerate, PermReportsHandle}},
+		{Name: RoleAuthor, Description: "Writes posts", BuiltIn: true,
+			Permissions: []string{PermPostsWrite, PermCommentsWrite}},
+		{Name: RoleUser, Description: "Default role for new accounts", BuiltIn: true,
+			Permissions: []string{PermPostsWrite, PermCommentsWrite}},
+	}
+}
+
+// Grant is what a role may do under a given version of the role matrix
+type Grant struct {
+	Permissions []string
+	Version     int64
+}
+
+func (g Grant) Has(permission string) bool {
+	for _, p := range g.Permissions {
+		if p == permission {
+			return true
+		}
+	}
+	return false
+}
+
+type RoleRequest struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Permissions []string `json:"permissions" binding:"required"`
+}
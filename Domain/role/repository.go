@@ -0,0 +1,18 @@
+package rolepkg
+
+import "context"
+
+type IRoleRepository interface {
+	ListRoles(ctx context.Context) ([]Role, error)
+	// FindRole returns ErrRoleNotFound for an unknown name
+	FindRole(ctx context.Context, name string) (Role, error)
+	// InsertRoleIfMissing reports whether the role was created
+	InsertRoleIfMissing(ctx context.Context, role Role) (bool, error)
+	// SaveRole creates the role or replaces it
+	SaveRole(ctx context.Context, role Role) error
+	DeleteRole(ctx context.Context, name string) error
+	CountUsersWithRole(ctx context.Context, name string) (int64, error)
+	// Version increases with every change to the role matrix
+	Version(ctx context.Context) (int64, error)
+	BumpVersion(ctx context.Context) (int64, error)
+}
@@ -0,0 +1,17 @@
+package rolepkg
+
+import "context"
+
+type IRoleUsecase interface {
+	ListRoles(ctx context.Context) ([]Role, error)
+	CreateRole(ctx context.Context, req RoleRequest) (Role, error)
+	UpdateRole(ctx context.Context, name string, req RoleRequest) (Role, error)
+	DeleteRole(ctx context.Context, name string) error
+}
+
+// IPermissionResolver answers from a cache of the role matrix, so it is cheap per request
+type IPermissionResolver interface {
+	// Resolve returns ErrRoleNotFound for a role that doesn't exist (any more)
+	Resolve(ctx context.Context, role string) (Grant, error)
+	CurrentVersion(ctx context.Context) (int64, error)
+}
@@ -1,5 +1,14 @@
 package services
 
+// EmailMessage is a rendered email. Text is the plain-text alternative sent
+// alongside HTML for clients that do not display HTML.
+type EmailMessage struct {
+	To      string
+	Subject string
+	HTML    string
+	Text    string
+}
+
 type IEmailSender interface {
-	SendEmail(to, subject, content string) error
-}
\ No newline at end of file
+	SendEmail(msg EmailMessage) error
+}
@@ -0,0 +1,29 @@
+package services
+
+// Email templates shipped with the application
+const (
+	TemplateVerification  = "verification"
+	TemplatePasswordReset = "password_reset"
+	TemplateSecurityAlert = "security_alert"
+	TemplateAccountLocked = "account_locked"
+	TemplateEmailChange   = "email_change"
+	TemplateDigest        = "digest"
+)
+
+// EmailTemplates lists every template name, in display order
+var EmailTemplates = []string{
+	TemplateVerification,
+	TemplatePasswordReset,
+	TemplateSecurityAlert,
+	TemplateAccountLocked,
+	TemplateEmailChange,
+	TemplateDigest,
+}
+
+// IEmailRenderer turns a named template into an email in the closest available locale
+type IEmailRenderer interface {
+	Render(name, locale string, data map[string]interface{}) (EmailMessage, error)
+	// Preview renders a template with built-in sample data
+	Preview(name, locale string) (EmailMessage, error)
+	Locales() []string
+}
@@ -1,5 +1,21 @@
 package services
 
+import "context"
+
 type IEmailVerifier interface {
     IsRealEmail(email string) (bool, error)
-}
\ No newline at end of file
+}
+
+// EmailVerdict is the outcome of one step of an email verification chain
+type EmailVerdict int
+
+const (
+	EmailContinue EmailVerdict = iota // no objection; the next step decides
+	EmailAccept                       // accept without running the remaining steps
+	EmailReject
+)
+
+// IEmailCheck is one step of an email verification chain
+type IEmailCheck interface {
+	Check(ctx context.Context, email string) (EmailVerdict, error)
+}
@@ -0,0 +1,40 @@
+package signingkeypkg
+
+import "time"
+
+// Supported token signing algorithms
+const (
+	AlgorithmRS256 = "RS256"
+	AlgorithmEdDSA = "EdDSA"
+)
+
+// SigningKey is a private key used to sign JWTs. A key is published (and accepted for
+// verification) from CreatedAt, signs new tokens from ActivatesAt until a newer key
+// activates, and is kept until ExpiresAt so the tokens it signed can still be verified.
+type SigningKey struct {
+	ID        string `bson:"_id"` // the "kid" header
+	Algorithm string `bson:"algorithm"`
+	// EncryptedPrivateKey is the PKCS #8 key sealed with AES-GCM, nonce first
+	EncryptedPrivateKey []byte `bson:"encrypted_private_key,omitempty"`
+	// PrivateKeyPEM is the plaintext PKCS #8 key of records stored before keys were encrypted
+	PrivateKeyPEM string    `bson:"private_key_pem,omitempty"`
+	CreatedAt     time.Time `bson:"created_at"`
+	ActivatesAt   time.Time `bson:"activates_at"`
+	ExpiresAt     time.Time `bson:"expires_at"`
+}
+
+// JSONWebKey is the public half of a signing key as published in the JWKS (RFC 7517)
+type JSONWebKey struct {
+	KeyType   string `json:"kty"`
+	Use       string `json:"use"`
+	Algorithm string `json:"alg"`
+	KeyID     string `json:"kid"`
+	N         string `json:"n,omitempty"`   // RSA modulus
+	E         string `json:"e,omitempty"`   // RSA exponent
+	Curve     string `json:"crv,omitempty"` // OKP curve
+	X         string `json:"x,omitempty"`   // OKP public key
+}
+
+type JSONWebKeySet struct {
+	Keys []JSONWebKey `json:"keys"`
+}
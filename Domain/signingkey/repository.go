@@ -0,0 +1,16 @@
+package signingkeypkg
+
+import "context"
+
+type ISigningKeyRepository interface {
+	// ListKeys returns the keys that have not expired yet
+	ListKeys(ctx context.Context) ([]SigningKey, error)
+	InsertKey(ctx context.Context, key SigningKey) error
+	// SealKey replaces a plaintext private key with its encrypted form
+	SealKey(ctx context.Context, id string, encrypted []byte) error
+}
+
+// IPublicKeySet publishes the keys that verify our tokens
+type IPublicKeySet interface {
+	PublicKeys() JSONWebKeySet
+}
@@ -18,6 +18,7 @@ type User struct {
     Bio        string             `bson:"bio,omitempty" json:"bio,omitempty"`
     ProfilePicture  string         `bson:"profilePicture,omitempty" json:"profilePicture,omitempty"`
     ContactInfo ContactInfo        `bson:"contactInfo,omitempty" json:"contactInfo,omitempty"`
+    Language    string             `bson:"language,omitempty" json:"language,omitempty"` // preferred locale for emails, e.g. "es"
     UpdatedAt   time.Time          `bson:"updatedAt" json:"updatedAt"`
 	PromotedBy primitive.ObjectID `bson:"promoted_by,omitempty" json:"promoted_by,omitempty"`
 }
@@ -33,6 +34,7 @@ type UpdateProfileRequest struct {
     Bio             string      `json:"bio,omitempty"`
     ProfilePicture  string      `json:"profilePicture,omitempty"`
     ContactInfo     ContactInfo `json:"contactInfo,omitempty"`
+    Language        string      `json:"language,omitempty"`
 }
 	
 // Token struct (We put it here since it's related with the User)
@@ -41,28 +43,74 @@ type Token struct {
 	UserID       primitive.ObjectID `bson:"user_id"`
 	AccessToken  string             `bson:"access_token"`
 	RefreshToken string             `bson:"refresh_token"`
-	CreatedAt    time.Time          `bson:"created_at"`
-	ExpiresAt    time.Time          `bson:"expires_at"`
+	// AccessTokenID is the jti of AccessToken, kept so the access token can be revoked
+	// along with its session
+	AccessTokenID   string    `bson:"access_token_id,omitempty"`
+	AccessExpiresAt time.Time `bson:"access_expires_at,omitempty"`
+	// FamilyID groups every refresh token descended from one login. Rotated tokens are
+	// kept (with RotatedAt set) until they expire so that replaying one can be detected.
+	FamilyID  string     `bson:"family_id"`
+	RotatedAt *time.Time `bson:"rotated_at,omitempty"`
+	// MFA records that the login passed a second factor; rotations carry it forward
+	MFA bool `bson:"mfa,omitempty"`
+	// Device details as of the last login or refresh
+	UserAgent string `bson:"user_agent,omitempty"`
+	IPAddress string `bson:"ip_address,omitempty"`
+	// CreatedAt is when the session started; rotations carry it forward
+	CreatedAt  time.Time `bson:"created_at"`
+	LastUsedAt time.Time `bson:"last_used_at"`
+	ExpiresAt  time.Time `bson:"expires_at"`
 }
 
-// Response upon login
-type TokenResult struct {
-	AccessToken      string
-	RefreshToken     string
-	AccessExpiresAt  time.Time
-	RefreshExpiresAt time.Time
+// RevokedToken is an access token rejected before its expiry. Entries are only needed
+// until ExpiresAt, after which the token is invalid anyway.
+type RevokedToken struct {
+	ID        string    `bson:"_id"` // the token's jti
+	UserID    string    `bson:"user_id"`
+	RevokedAt time.Time `bson:"revoked_at"`
+	ExpiresAt time.Time `bson:"expires_at"`
+}
+
+// DeviceInfo describes the client a login or refresh came from
+type DeviceInfo struct {
+	UserAgent string
+	IPAddress string
+}
+
+// Session is one signed-in device, i.e. a refresh-token family as shown to its owner
+type Session struct {
+	ID         string    `json:"id"`
+	UserAgent  string    `json:"userAgent"`
+	IPAddress  string    `json:"ipAddress"`
+	CreatedAt  time.Time `json:"createdAt"`
+	LastUsedAt time.Time `json:"lastUsedAt"`
+	ExpiresAt  time.Time `json:"expiresAt"`
+	Current    bool      `json:"current"`
 }
 
-type PasswordReset struct {
-	Email        string    `bson:"email"`
-	OTP          string    `bson:"otp"`
-	ExpiresAt    time.Time `bson:"expiresat"`
-	AttemptCount int       `bson:"attemptcount"`
+// TokenSubject is who an access token is issued to and what it allows
+type TokenSubject struct {
+	UserID    string
+	Username  string
+	Role      string
+	SessionID string // the "sid" claim
+	MFA       bool   // whether the session signed in with a second factor
+	// Permissions are the role's at issue time, from role matrix PermissionsVersion
+	Permissions        []string
+	PermissionsVersion int64
 }
 
-type Verification struct {
-	Email        string    `bson:"email" json:"email"`
-	OTP          string    `bson:"otp" json:"otp"`
-	ExpiresAt    time.Time `bson:"expiresAt" json:"expiresAt"`
-	AttemptCount int       `bson:"attemptCount" json:"attemptCount"`
+// Token types, in the "typ" claim; one kind of token is never accepted as the other
+const (
+	TokenTypeAccess  = "access"
+	TokenTypeRefresh = "refresh"
+)
+
+// Response upon login
+type TokenResult struct {
+	AccessToken      string    `json:"access_token"`
+	AccessTokenID    string    `json:"-"`
+	RefreshToken     string    `json:"refresh_token"`
+	AccessExpiresAt  time.Time `json:"access_expires_at"`
+	RefreshExpiresAt time.Time `json:"refresh_expires_at"`
 }
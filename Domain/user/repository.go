@@ -1,18 +1,46 @@
 package userpkg
 
-import "context"
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+var (
+	// ErrRefreshTokenReused is returned when a refresh token that was already rotated is presented again
+	ErrRefreshTokenReused = errors.New("refresh token reuse detected")
+	ErrSessionNotFound    = errors.New("session not found")
+	ErrInvalidResetToken  = errors.New("invalid or expired reset token")
+)
+
+// MFARequiredError is returned by LoginUser when the password was right but the account
+// uses two-factor authentication. The login is finished with the challenge token.
+type MFARequiredError struct {
+	ChallengeToken string
+	ExpiresAt      time.Time
+}
+
+func (e *MFARequiredError) Error() string {
+	return "two-factor authentication required"
+}
 
 // IUserRepository defines user data access operations
 type IUserRepository interface {
 	FindByID(ctx context.Context, userID string) (User, error)
 	ExistsByUsername(ctx context.Context, username string) (bool, error)
 	ExistsByEmail(ctx context.Context, email string) (bool, error)
+	FindByEmail(ctx context.Context, email string) (User, error)
 	CountUsers(ctx context.Context) (int64, error)
 	CreateUser(ctx context.Context, user User) (User, error)
 	GetUserByLogin(ctx context.Context, login string) (User, error)
 	UpdatePasswordByEmail(ctx context.Context, email, hashedPassword string) error
+	// ReplacePasswordHash swaps the hash only if it is still oldHash, so upgrading a hash can't
+	// undo a password change made in the meantime
+	ReplacePasswordHash(ctx context.Context, userID, oldHash, newHash string) error
 	UpdateUserRoleByID(ctx context.Context, userID, role string) error
 	UpdateIsVerifiedByEmail(ctx context.Context, email string, verified bool) error
+	// UpdateEmailByID changes the user's address and marks it verified
+	UpdateEmailByID(ctx context.Context, userID, email string) error
 	UpdateProfile(ctx context.Context, userID string, updates UpdateProfileRequest) (User, error)
     GetUserProfile(ctx context.Context, userID string) (User, error)
 	UpdateRoleAndPromoter(ctx context.Context, userID string, role string, promoterID *string) error
@@ -23,18 +51,21 @@ type ITokenRepository interface {
 	FindByRefreshToken(ctx context.Context, refreshToken string) (Token, error)
 	DeleteByRefreshToken(ctx context.Context, refreshToken string) error
 	DeleteTokensByUserID(ctx context.Context, userID string) error
+	// RotateRefreshToken marks refreshToken as rotated and stores next in its place. It fails
+	// with ErrRefreshTokenReused if refreshToken had already been rotated.
+	RotateRefreshToken(ctx context.Context, refreshToken string, next Token) error
+	DeleteTokenFamily(ctx context.Context, familyID string) error
+	// ListSessions returns the live (unrotated, unexpired) token of each of the user's sessions, most recently used first
+	ListSessions(ctx context.Context, userID string) ([]Token, error)
+	// DeleteSession revokes one of the user's sessions, or returns ErrSessionNotFound
+	DeleteSession(ctx context.Context, userID, sessionID string) error
+	DeleteOtherSessions(ctx context.Context, userID, keepSessionID string) error
+	// ListActiveAccessTokens returns the user's tokens whose access token has not expired yet
+	ListActiveAccessTokens(ctx context.Context, userID string) ([]Token, error)
 }
 
-type IPasswordResetRepository interface {
-	StoreResetRequest(ctx context.Context, reset PasswordReset) error
-	GetResetRequest(ctx context.Context, email string) (PasswordReset, error)
-	DeleteResetRequest(ctx context.Context, email string) error
-	IncrementAttemptCount(ctx context.Context, email string) error
-}
-
-type IVerificationRepository interface {
-	StoreVerification(ctx context.Context, v Verification) error
-	GetVerification(ctx context.Context, email string) (Verification, error)
-	DeleteVerification(ctx context.Context, email string) error
-	IncrementAttemptCount(ctx context.Context, email string) error
+type IRevokedTokenRepository interface {
+	StoreRevokedTokens(ctx context.Context, tokens []RevokedToken) error
+	// ListRevokedSince returns unexpired entries revoked at or after since
+	ListRevokedSince(ctx context.Context, since time.Time) ([]RevokedToken, error)
 }
@@ -7,30 +7,51 @@ import (
 
 type IUserUsecase interface {
 	RegisterUser(ctx context.Context, user User) (User, error)
-	Logout(ctx context.Context, userID string) error
-	LoginUser(ctx context.Context, login string, password string) (User, string, string, error)
-	RefreshToken(ctx context.Context, refreshToken string) (TokenResult, error)
+	Logout(ctx context.Context, userID, sessionID string) error
+	LoginUser(ctx context.Context, login string, password string, device DeviceInfo) (User, string, string, error)
+	// CompleteMFALogin finishes a login that LoginUser answered with an MFARequiredError
+	CompleteMFALogin(ctx context.Context, challengeToken, code string, device DeviceInfo) (User, string, string, error)
+	RefreshToken(ctx context.Context, refreshToken string, device DeviceInfo) (TokenResult, error)
+	ListSessions(ctx context.Context, userID, currentSessionID string) ([]Session, error)
+	RevokeSession(ctx context.Context, userID, sessionID string) error
+	RevokeOtherSessions(ctx context.Context, userID, currentSessionID string) error
 	SendResetOTP(ctx context.Context, email string) error
-	VerifyOTP(ctx context.Context, email, otp string) error
-	ResetPassword(ctx context.Context, email, newPassword string) error
+	// VerifyOTP returns the single-use token ResetPassword needs
+	VerifyOTP(ctx context.Context, email, otp string) (string, error)
+	ResetPassword(ctx context.Context, resetToken, newPassword string, device DeviceInfo) error
 	PromoteUser(ctx context.Context, targetUserID string, actorUserID string) error
 	DemoteUser(ctx context.Context, targetUserID string, actorUserID string) error
+	AssignRole(ctx context.Context, targetUserID, role, actorUserID string) error
 	SendVerificationOTP(ctx context.Context, email string) error
 	VerifyUser(ctx context.Context, email, otp string) error
+	// RequestEmailChange sends a code to newEmail; ConfirmEmailChange with it moves the account there
+	RequestEmailChange(ctx context.Context, userID, password, newEmail string) error
+	ConfirmEmailChange(ctx context.Context, userID, code string, device DeviceInfo) error
 	UpdateProfile(ctx context.Context, userID string, updates UpdateProfileRequest, file multipart.File, filename string) (User, error)
     GetUserProfile(ctx context.Context, userID string) (User, error)
 }
 
 // User Infrastructure interfaces
 type IJWTService interface {
-	GenerateToken(userID, username, role string) (TokenResult, error)
-	ValidateToken(tokenString string) (map[string]interface{}, error)
+	// GenerateToken issues a token pair for the subject
+	GenerateToken(subject TokenSubject) (TokenResult, error)
+	// ValidateToken returns the claims of a valid token of tokenType (TokenTypeAccess or
+	// TokenTypeRefresh)
+	ValidateToken(tokenString, tokenType string) (map[string]interface{}, error)
+}
+
+// IAccessTokenRevoker rejects access tokens before they expire, e.g. after logout
+type IAccessTokenRevoker interface {
+	Revoke(ctx context.Context, tokens ...RevokedToken) error
+	IsRevoked(jti string) bool
 }
 
 // PasswordService interface defines password operations
 type IPasswordService interface {
 	HashPassword(password string) (string, error)
 	ComparePassword(hashedPassword, password string) error
+	// NeedsRehash reports whether a hash predates the current algorithm or its settings
+	NeedsRehash(hashedPassword string) bool
 }
 
 type ICloudinaryService interface {
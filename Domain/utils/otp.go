@@ -1,17 +1,22 @@
 package domain
 
 import (
-	"math/rand"
-	"time"
+	"crypto/rand"
+	"math/big"
 )
 
-func GenerateOTP(length int) string {
+// GenerateOTP returns a code of length random digits
+func GenerateOTP(length int) (string, error) {
 	const charset = "0123456789"
-	r := rand.New(rand.NewSource(time.Now().UnixNano())) // local generator
+	digits := big.NewInt(int64(len(charset)))
 
 	otp := make([]byte, length)
 	for i := range otp {
-		otp[i] = charset[r.Intn(len(charset))]
+		n, err := rand.Int(rand.Reader, digits)
+		if err != nil {
+			return "", err
+		}
+		otp[i] = charset[n.Int64()]
 	}
-	return string(otp)
-}
\ No newline at end of file
+	return string(otp), nil
+}
@@ -1,6 +1,9 @@
 package domain
 
-import "regexp"
+import (
+	"regexp"
+	"strings"
+)
 
 //Email format validator
 func IsValidEmail(email string) bool {
@@ -9,14 +12,20 @@ func IsValidEmail(email string) bool {
 	return re.MatchString(email)
 }
 
-//Password strength validator
-func IsStrongPassword(password string) bool {
-	var (
-		hasMinLen  = len(password) >= 8
-		hasUpper   = regexp.MustCompile(`[A-Z]`).MatchString(password)
-		hasLower   = regexp.MustCompile(`[a-z]`).MatchString(password)
-		hasNumber  = regexp.MustCompile(`[0-9]`).MatchString(password)
-		hasSpecial = regexp.MustCompile(`[!@#\$%\^&\*]`).MatchString(password)
-	)
-	return hasMinLen && hasUpper && hasLower && hasNumber && hasSpecial
-}
\ No newline at end of file
+// EmailDomainHierarchy returns the domain of email followed by its parent domains,
+// lowercased and without the top-level domain: "a@mail.example.com" gives
+// ["mail.example.com", "example.com"]
+func EmailDomainHierarchy(email string) []string {
+	at := strings.LastIndex(email, "@")
+	if at < 0 {
+		return nil
+	}
+	domain := strings.TrimSuffix(strings.ToLower(email[at+1:]), ".")
+
+	var domains []string
+	for strings.Contains(domain, ".") {
+		domains = append(domains, domain)
+		domain = domain[strings.Index(domain, ".")+1:]
+	}
+	return domains
+}
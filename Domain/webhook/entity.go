@@ -0,0 +1,76 @@
+package webhookpkg
+
+import (
+	"encoding/json"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Event types that webhooks can subscribe to
+const (
+	EventBlogCreated    = "blog.created"
+	EventBlogUpdated    = "blog.updated"
+	EventBlogDeleted    = "blog.deleted"
+	EventCommentAdded   = "comment.added"
+	EventLikeToggled    = "like.toggled"
+	EventUserRegistered = "user.registered"
+)
+
+// EventTypes lists every event type a webhook may subscribe to
+var EventTypes = []string{
+	EventBlogCreated,
+	EventBlogUpdated,
+	EventBlogDeleted,
+	EventCommentAdded,
+	EventLikeToggled,
+	EventUserRegistered,
+}
+
+// Delivery statuses
+const (
+	DeliveryPending   = "pending"
+	DeliverySucceeded = "succeeded"
+	DeliveryFailed    = "failed" // retries exhausted; can be replayed by hand
+)
+
+// Webhook is an endpoint registered by an admin to receive events
+type Webhook struct {
+	ID        primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	URL       string             `json:"url" bson:"url"`
+	Secret    string             `json:"secret,omitempty" bson:"secret"` // only returned when the webhook is created
+	Events    []string           `json:"events" bson:"events"`
+	Active    bool               `json:"active" bson:"active"`
+	CreatedBy string             `json:"created_by" bson:"created_by"`
+	CreatedAt time.Time          `json:"created_at" bson:"created_at"`
+}
+
+// Delivery is one event sent (or to be sent) to one webhook, kept as a delivery log
+type Delivery struct {
+	ID            primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	WebhookID     primitive.ObjectID `json:"webhook_id" bson:"webhook_id"`
+	EventID       string             `json:"event_id" bson:"event_id"`
+	EventType     string             `json:"event_type" bson:"event_type"`
+	Payload       string             `json:"payload" bson:"payload"`
+	Status        string             `json:"status" bson:"status"`
+	Attempts      int                `json:"attempts" bson:"attempts"`
+	LastError     string             `json:"last_error,omitempty" bson:"last_error,omitempty"`
+	ResponseCode  int                `json:"response_code,omitempty" bson:"response_code,omitempty"`
+	NextAttemptAt time.Time          `json:"next_attempt_at" bson:"next_attempt_at"`
+	ReplayOf      primitive.ObjectID `json:"replay_of,omitempty" bson:"replay_of,omitempty"`
+	CreatedAt     time.Time          `json:"created_at" bson:"created_at"`
+	UpdatedAt     time.Time          `json:"updated_at" bson:"updated_at"`
+}
+
+// Event is the JSON envelope posted to webhook endpoints
+type Event struct {
+	ID        string          `json:"id"`
+	Type      string          `json:"type"`
+	CreatedAt time.Time       `json:"created_at"`
+	Data      json.RawMessage `json:"data"`
+}
+
+type CreateWebhookRequest struct {
+	URL    string   `json:"url" binding:"required"`
+	Events []string `json:"events" binding:"required,min=1"`
+}
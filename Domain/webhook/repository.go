@@ -0,0 +1,23 @@
+package webhookpkg
+
+import (
+	"context"
+	"time"
+)
+
+type IWebhookRepository interface {
+	CreateWebhook(ctx context.Context, hook Webhook) (Webhook, error)
+	GetWebhook(ctx context.Context, id string) (Webhook, error)
+	ListWebhooks(ctx context.Context) ([]Webhook, error)
+	DeleteWebhook(ctx context.Context, id string) error
+	FindActiveByEvent(ctx context.Context, eventType string) ([]Webhook, error)
+}
+
+type IDeliveryRepository interface {
+	CreateDelivery(ctx context.Context, delivery Delivery) (Delivery, error)
+	GetDelivery(ctx context.Context, id string) (Delivery, error)
+	ListDeliveries(ctx context.Context, webhookID string, limit int64) ([]Delivery, error)
+	// ClaimDue leases the oldest pending delivery that is due, so concurrent workers never send it twice
+	ClaimDue(ctx context.Context, now time.Time, lease time.Duration) (Delivery, bool, error)
+	UpdateDelivery(ctx context.Context, delivery Delivery) error
+}
@@ -0,0 +1,22 @@
+package webhookpkg
+
+import "context"
+
+// IEventPublisher queues a webhook event; it is fed from the domain event bus
+type IEventPublisher interface {
+	Publish(ctx context.Context, eventType string, data interface{})
+}
+
+type IWebhookUsecase interface {
+	IEventPublisher
+	RegisterWebhook(ctx context.Context, req CreateWebhookRequest, createdBy string) (Webhook, error)
+	ListWebhooks(ctx context.Context) ([]Webhook, error)
+	DeleteWebhook(ctx context.Context, id string) error
+	ListDeliveries(ctx context.Context, webhookID string) ([]Delivery, error)
+	ReplayDelivery(ctx context.Context, deliveryID string) (Delivery, error)
+}
+
+// IWebhookSender posts a signed payload and returns the receiver's status code
+type IWebhookSender interface {
+	Send(ctx context.Context, url, secret string, headers map[string]string, payload []byte) (int, error)
+}
@@ -1,8 +1,12 @@
 package infrastructure
 
 import (
+    "errors"
     "net/http"
     "strings"
+    mfapkg "github.com/Amaankaa/Blog-Starter-Project/Domain/mfa"
+    patpkg "github.com/Amaankaa/Blog-Starter-Project/Domain/pat"
+    rolepkg "github.com/Amaankaa/Blog-Starter-Project/Domain/role"
     domain "github.com/Amaankaa/Blog-Starter-Project/Domain/user"
 
 
@@ -11,18 +15,28 @@ import (
 
 
 type AuthMiddleware struct {
-    jwtService domain.IJWTService
+    jwtService  domain.IJWTService
+    revocations domain.IAccessTokenRevoker
+    mfaPolicy   mfapkg.IMFAPolicy
+    pats        patpkg.IPATAuthenticator
+    permissions rolepkg.IPermissionResolver
 }
 
 
-func NewAuthMiddleware(jwtService domain.IJWTService) *AuthMiddleware {
+func NewAuthMiddleware(jwtService domain.IJWTService, revocations domain.IAccessTokenRevoker, mfaPolicy mfapkg.IMFAPolicy, pats patpkg.IPATAuthenticator, permissions rolepkg.IPermissionResolver) *AuthMiddleware {
     return &AuthMiddleware{
-        jwtService: jwtService,
+        jwtService:  jwtService,
+        revocations: revocations,
+        mfaPolicy:   mfaPolicy,
+        pats:        pats,
+        permissions: permissions,
     }
 }
 
 
-func (am *AuthMiddleware) AuthMiddleware() gin.HandlerFunc {
+// AuthMiddleware accepts login sessions. Given a scope, it also accepts personal access tokens
+// granted that scope; without one, routes stay closed to tokens.
+func (am *AuthMiddleware) AuthMiddleware(scope ...string) gin.HandlerFunc {
     return func(c *gin.Context) {
         header := c.GetHeader("Authorization")
 	
@@ -33,30 +47,125 @@ func (am *AuthMiddleware) AuthMiddleware() gin.HandlerFunc {
 		}
 
         tokenString := strings.TrimPrefix(header, "Bearer ")
-        claims, err := am.jwtService.ValidateToken(tokenString)
+        if strings.HasPrefix(tokenString, patpkg.TokenPrefix) {
+            am.authenticatePAT(c, tokenString, scope)
+            return
+        }
+        // A refresh token is only good for /refresh; its jti is never on the revocation list
+        claims, err := am.jwtService.ValidateToken(tokenString, domain.TokenTypeAccess)
         if err != nil {
             c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
             c.Abort()
             return
         }
+        // Tokens issued before revocation existed have no jti; they expire within minutes
+        if jti, _ := claims["jti"].(string); jti != "" && am.revocations.IsRevoked(jti) {
+            c.JSON(http.StatusUnauthorized, gin.H{"error": "token has been revoked"})
+            c.Abort()
+            return
+        }
 
 
         c.Set("user_id", claims["_id"])
         c.Set("username", claims["username"])
         c.Set("role", claims["role"])
+        c.Set("session_id", claims["sid"])
+        c.Set("mfa", claims["mfa"] == true)
+        c.Set("permissions", claimedPermissions(claims))
+        // Tokens issued before permissions were added have no "pv" and are resolved again
+        if pv, ok := claims["pv"].(float64); ok {
+            c.Set("permissions_version", int64(pv))
+        } else {
+            c.Set("permissions_version", int64(-1))
+        }
         c.Next()
     }
 }
 
+func claimedPermissions(claims map[string]interface{}) []string {
+    raw, _ := claims["perms"].([]interface{})
+    permissions := make([]string, 0, len(raw))
+    for _, p := range raw {
+        if s, ok := p.(string); ok {
+            permissions = append(permissions, s)
+        }
+    }
+    return permissions
+}
+
+func (am *AuthMiddleware) authenticatePAT(c *gin.Context, token string, scopes []string) {
+    if len(scopes) == 0 {
+        c.JSON(http.StatusForbidden, gin.H{"error": "personal access tokens cannot be used here"})
+        c.Abort()
+        return
+    }
+    principal, err := am.pats.Authenticate(c.Request.Context(), token)
+    if err != nil {
+        c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+        c.Abort()
+        return
+    }
+    for _, scope := range scopes {
+        if !principal.HasScope(scope) {
+            c.JSON(http.StatusForbidden, gin.H{"error": "token is missing the " + scope + " scope"})
+            c.Abort()
+            return
+        }
+    }
+
+    c.Set("user_id", principal.UserID)
+    c.Set("username", principal.Username)
+    c.Set("role", principal.Role)
+    c.Set("token_id", principal.TokenID)
+    c.Set("mfa", false)
+    // Token requests are resolved from the role matrix on every Require
+    c.Set("permissions_version", int64(-1))
+    c.Next()
+}
+
 
-func (am *AuthMiddleware) AdminOnly() gin.HandlerFunc {
+// Require lets the request through if the caller's role grants permission. Permissions in
+// the access token are trusted while the role matrix is unchanged since it was issued;
+// otherwise they are looked up again, so edits to a role apply at once.
+func (am *AuthMiddleware) Require(permission string) gin.HandlerFunc {
     return func(c *gin.Context) {
-        role, exists := c.Get("role")
-        if !exists || role != "admin" {
-            c.JSON(http.StatusForbidden, gin.H{"error": "Admin access required"})
+        ctx := c.Request.Context()
+        current, err := am.permissions.CurrentVersion(ctx)
+        if err != nil {
+            c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to check permissions"})
             c.Abort()
             return
         }
+        granted := c.GetStringSlice("permissions")
+        if c.GetInt64("permissions_version") != current {
+            grant, err := am.permissions.Resolve(ctx, c.GetString("role"))
+            if err != nil && !errors.Is(err, rolepkg.ErrRoleNotFound) {
+                c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to check permissions"})
+                c.Abort()
+                return
+            }
+            granted = grant.Permissions
+        }
+        if !(rolepkg.Grant{Permissions: granted}).Has(permission) {
+            c.JSON(http.StatusForbidden, gin.H{"error": "missing permission: " + permission})
+            c.Abort()
+            return
+        }
+
+        if rolepkg.IsAdministrative(permission) && !c.GetBool("mfa") {
+            required, err := am.mfaPolicy.AdminsRequireMFA(ctx)
+            if err != nil {
+                c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to check two-factor policy"})
+                c.Abort()
+                return
+            }
+            if required {
+                // Staff without 2FA can still reach /me/2fa to set it up, then log in again
+                c.JSON(http.StatusForbidden, gin.H{"error": "two-factor authentication is required for admin access"})
+                c.Abort()
+                return
+            }
+        }
         c.Next()
     }
 }
@@ -0,0 +1,190 @@
+package infrastructure_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	patpkg "github.com/Amaankaa/Blog-Starter-Project/Domain/pat"
+	rolepkg "github.com/Amaankaa/Blog-Starter-Project/Domain/role"
+	userpkg "github.com/Amaankaa/Blog-Starter-Project/Domain/user"
+	infrastructure "github.com/Amaankaa/Blog-Starter-Project/Infrastructure"
+	"github.com/Amaankaa/Blog-Starter-Project/mocks"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type RequireSuite struct {
+	suite.Suite
+	policy      *mocks.IMFAPolicy
+	permissions *mocks.IPermissionResolver
+	jwt         *infrastructure.JWTService
+	router      *gin.Engine
+}
+
+func TestRequireSuite(t *testing.T) {
+	suite.Run(t, new(RequireSuite))
+}
+
+func (s *RequireSuite) SetupTest() {
+	gin.SetMode(gin.TestMode)
+	s.policy = mocks.NewIMFAPolicy(s.T())
+	s.permissions = mocks.NewIPermissionResolver(s.T())
+	s.jwt = newTestJWTService(s.T())
+	middleware := infrastructure.NewAuthMiddleware(s.jwt, infrastructure.NewAccessTokenRevocationList(mocks.NewIRevokedTokenRepository(s.T())), s.policy, mocks.NewIPATAuthenticator(s.T()), s.permissions)
+
+	s.router = gin.New()
+	s.router.GET("/admin", middleware.AuthMiddleware(), middleware.Require(rolepkg.PermUsersManage), func(c *gin.Context) {
+		c.Status(http.StatusNoContent)
+	})
+	s.router.POST("/blogs", middleware.AuthMiddleware(), middleware.Require(rolepkg.PermPostsWrite), func(c *gin.Context) {
+		c.Status(http.StatusNoContent)
+	})
+	s.router.GET("/profile", middleware.AuthMiddleware(), func(c *gin.Context) {
+		c.Status(http.StatusNoContent)
+	})
+}
+
+func (s *RequireSuite) call(method, path string, subject userpkg.TokenSubject) *httptest.ResponseRecorder {
+	subject.UserID, subject.Username = "user-1", "jane"
+	tokens, err := s.jwt.GenerateToken(subject)
+	s.Require().NoError(err)
+	return s.callWith(method, path, tokens.AccessToken)
+}
+
+func (s *RequireSuite) callWith(method, path, bearer string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, nil)
+	req.Header.Set("Authorization", "Bearer "+bearer)
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+	return w
+}
+
+func (s *RequireSuite) TestRefreshTokenIsNotABearerToken() {
+	tokens, err := s.jwt.GenerateToken(userpkg.TokenSubject{UserID: "user-1", Username: "jane", Role: "user", SessionID: "session-1"})
+	s.Require().NoError(err)
+
+	s.Equal(http.StatusUnauthorized, s.callWith(http.MethodGet, "/profile", tokens.RefreshToken).Code)
+	s.Equal(http.StatusNoContent, s.callWith(http.MethodGet, "/profile", tokens.AccessToken).Code)
+}
+
+var adminSubject = userpkg.TokenSubject{Role: "admin", Permissions: []string{rolepkg.PermUsersManage}, PermissionsVersion: 3}
+
+func (s *RequireSuite) TestCurrentTokenPermissions() {
+	s.permissions.On("CurrentVersion", mock.Anything).Return(int64(3), nil)
+
+	s.Equal(http.StatusForbidden, s.call(http.MethodGet, "/admin", userpkg.TokenSubject{Role: "user", PermissionsVersion: 3}).Code)
+
+	subject := adminSubject
+	subject.MFA = true
+	s.Equal(http.StatusNoContent, s.call(http.MethodGet, "/admin", subject).Code)
+	s.permissions.AssertNotCalled(s.T(), "Resolve", mock.Anything, mock.Anything)
+	s.policy.AssertNotCalled(s.T(), "AdminsRequireMFA", mock.Anything)
+}
+
+func (s *RequireSuite) TestStaleTokenIsResolvedAgain() {
+	s.permissions.On("CurrentVersion", mock.Anything).Return(int64(4), nil)
+	s.permissions.On("Resolve", mock.Anything, "admin").Return(rolepkg.Grant{Permissions: []string{rolepkg.PermRolesManage}, Version: 4}, nil).Once()
+
+	w := s.call(http.MethodGet, "/admin", adminSubject)
+
+	s.Equal(http.StatusForbidden, w.Code)
+	s.Contains(w.Body.String(), rolepkg.PermUsersManage)
+}
+
+func (s *RequireSuite) TestDeletedRoleHasNoPermissions() {
+	s.permissions.On("CurrentVersion", mock.Anything).Return(int64(4), nil)
+	s.permissions.On("Resolve", mock.Anything, "editor").Return(rolepkg.Grant{Version: 4}, rolepkg.ErrRoleNotFound).Once()
+
+	s.Equal(http.StatusForbidden, s.call(http.MethodPost, "/blogs", userpkg.TokenSubject{Role: "editor", Permissions: []string{rolepkg.PermPostsWrite}}).Code)
+}
+
+func (s *RequireSuite) TestPasswordOnlySession() {
+	s.permissions.On("CurrentVersion", mock.Anything).Return(int64(3), nil)
+
+	s.policy.On("AdminsRequireMFA", mock.Anything).Return(false, nil).Once()
+	s.Equal(http.StatusNoContent, s.call(http.MethodGet, "/admin", adminSubject).Code)
+
+	s.policy.On("AdminsRequireMFA", mock.Anything).Return(true, nil).Once()
+	w := s.call(http.MethodGet, "/admin", adminSubject)
+	s.Equal(http.StatusForbidden, w.Code)
+	s.Contains(w.Body.String(), "two-factor authentication is required")
+
+	s.policy.On("AdminsRequireMFA", mock.Anything).Return(false, errors.New("db down")).Once()
+	s.Equal(http.StatusInternalServerError, s.call(http.MethodGet, "/admin", adminSubject).Code)
+}
+
+func (s *RequireSuite) TestPolicySkipsEverydayPermissions() {
+	s.permissions.On("CurrentVersion", mock.Anything).Return(int64(3), nil)
+
+	w := s.call(http.MethodPost, "/blogs", userpkg.TokenSubject{Role: "user", Permissions: []string{rolepkg.PermPostsWrite}, PermissionsVersion: 3})
+
+	s.Equal(http.StatusNoContent, w.Code)
+	s.policy.AssertNotCalled(s.T(), "AdminsRequireMFA", mock.Anything)
+}
+
+type PATAuthSuite struct {
+	suite.Suite
+	pats   *mocks.IPATAuthenticator
+	router *gin.Engine
+}
+
+func TestPATAuthSuite(t *testing.T) {
+	suite.Run(t, new(PATAuthSuite))
+}
+
+func (s *PATAuthSuite) SetupTest() {
+	gin.SetMode(gin.TestMode)
+	s.pats = mocks.NewIPATAuthenticator(s.T())
+	middleware := infrastructure.NewAuthMiddleware(newTestJWTService(s.T()), infrastructure.NewAccessTokenRevocationList(mocks.NewIRevokedTokenRepository(s.T())), mocks.NewIMFAPolicy(s.T()), s.pats, mocks.NewIPermissionResolver(s.T()))
+
+	s.router = gin.New()
+	s.router.POST("/blogs", middleware.AuthMiddleware(patpkg.ScopeBlogsWrite), func(c *gin.Context) {
+		c.String(http.StatusOK, c.GetString("user_id"))
+	})
+	s.router.GET("/profile", middleware.AuthMiddleware(), func(c *gin.Context) {
+		c.Status(http.StatusNoContent)
+	})
+}
+
+func (s *PATAuthSuite) call(method, path string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, nil)
+	req.Header.Set("Authorization", "Bearer "+patpkg.TokenPrefix+"secret")
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+	return w
+}
+
+func (s *PATAuthSuite) TestScopeGranted() {
+	s.pats.On("Authenticate", mock.Anything, patpkg.TokenPrefix+"secret").
+		Return(patpkg.Principal{UserID: "user-1", Scopes: []string{patpkg.ScopeBlogsWrite}}, nil).Once()
+
+	w := s.call(http.MethodPost, "/blogs")
+
+	s.Equal(http.StatusOK, w.Code)
+	s.Equal("user-1", w.Body.String())
+}
+
+func (s *PATAuthSuite) TestScopeMissing() {
+	s.pats.On("Authenticate", mock.Anything, patpkg.TokenPrefix+"secret").
+		Return(patpkg.Principal{UserID: "user-1", Scopes: []string{patpkg.ScopeAIUse}}, nil).Once()
+
+	w := s.call(http.MethodPost, "/blogs")
+
+	s.Equal(http.StatusForbidden, w.Code)
+	s.Contains(w.Body.String(), patpkg.ScopeBlogsWrite)
+}
+
+func (s *PATAuthSuite) TestInvalidToken() {
+	s.pats.On("Authenticate", mock.Anything, patpkg.TokenPrefix+"secret").
+		Return(patpkg.Principal{}, patpkg.ErrInvalidToken).Once()
+
+	s.Equal(http.StatusUnauthorized, s.call(http.MethodPost, "/blogs").Code)
+}
+
+func (s *PATAuthSuite) TestSessionOnlyRoute() {
+	s.Equal(http.StatusForbidden, s.call(http.MethodGet, "/profile").Code)
+	s.pats.AssertNotCalled(s.T(), "Authenticate", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,92 @@
+package infrastructure
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"io"
+	"os"
+	"strings"
+)
+
+// BreachCorpus looks passwords up in an offline copy of the Pwned Passwords k-anonymity
+// corpus, so no password or hash prefix ever leaves the process. The file has one
+// "SHA1:COUNT" line per breached password, sorted by hash, which is the single-file output of
+// the Have I Been Pwned downloader; it is binary searched in place rather than loaded.
+type BreachCorpus struct {
+	file *os.File
+	size int64
+}
+
+func OpenBreachCorpus(path string) (*BreachCorpus, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+	return &BreachCorpus{file: file, size: info.Size()}, nil
+}
+
+func (b *BreachCorpus) Close() error {
+	return b.file.Close()
+}
+
+func (b *BreachCorpus) IsBreached(ctx context.Context, password string) (bool, error) {
+	sum := sha1.Sum([]byte(password))
+	target := strings.ToUpper(hex.EncodeToString(sum[:]))
+
+	// Find the first line at or after some offset whose hash isn't below the target
+	lo, hi := int64(0), b.size
+	for lo < hi {
+		if err := ctx.Err(); err != nil {
+			return false, err
+		}
+		mid := lo + (hi-lo)/2
+		start, hash, err := b.lineFrom(mid)
+		if err != nil {
+			return false, err
+		}
+		if hash != "" && hash < target {
+			lo = start + 1
+		} else {
+			hi = mid
+		}
+	}
+	_, hash, err := b.lineFrom(lo)
+	if err != nil {
+		return false, err
+	}
+	return hash == target, nil
+}
+
+// lineFrom returns the hash on the first line starting at or after offset, or an empty hash
+// past the last line
+func (b *BreachCorpus) lineFrom(offset int64) (int64, string, error) {
+	start := offset
+	if offset > 0 {
+		start-- // offset may be the start of a line itself
+	}
+	// Lines are about 50 bytes, so a small buffer keeps each probe of the search to one read
+	reader := bufio.NewReaderSize(io.NewSectionReader(b.file, start, b.size-start), 256)
+	if offset > 0 {
+		skipped, err := reader.ReadString('\n')
+		if err == io.EOF {
+			return b.size, "", nil
+		}
+		if err != nil {
+			return 0, "", err
+		}
+		start += int64(len(skipped))
+	}
+	line, err := reader.ReadString('\n')
+	if err != nil && err != io.EOF {
+		return 0, "", err
+	}
+	hash, _, _ := strings.Cut(strings.TrimSpace(line), ":")
+	return start, strings.ToUpper(hash), nil
+}
@@ -0,0 +1,68 @@
+package infrastructure_test
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	infrastructure "github.com/Amaankaa/Blog-Starter-Project/Infrastructure"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeCorpus writes the passwords the way the Pwned Passwords downloader does: uppercase
+// SHA-1 hashes with a count, sorted, with CRLF line endings
+func writeCorpus(t *testing.T, passwords ...string) string {
+	var lines []string
+	for i, password := range passwords {
+		sum := sha1.Sum([]byte(password))
+		lines = append(lines, fmt.Sprintf("%s:%d", strings.ToUpper(hex.EncodeToString(sum[:])), i+1))
+	}
+	sort.Strings(lines)
+	path := filepath.Join(t.TempDir(), "pwned-passwords.txt")
+	require.NoError(t, os.WriteFile(path, []byte(strings.Join(lines, "\r\n")), 0o600))
+	return path
+}
+
+func TestBreachCorpus_FindsEveryListedPassword(t *testing.T) {
+	var breached []string
+	for i := 0; i < 500; i++ {
+		breached = append(breached, fmt.Sprintf("leaked-%d", i))
+	}
+	corpus, err := infrastructure.OpenBreachCorpus(writeCorpus(t, breached...))
+	require.NoError(t, err)
+	defer corpus.Close()
+
+	ctx := context.Background()
+	for _, password := range breached {
+		found, err := corpus.IsBreached(ctx, password)
+		require.NoError(t, err)
+		assert.True(t, found, password)
+	}
+	for i := 0; i < 100; i++ {
+		found, err := corpus.IsBreached(ctx, fmt.Sprintf("fresh-%d", i))
+		require.NoError(t, err)
+		assert.False(t, found)
+	}
+}
+
+func TestBreachCorpus_EmptyFile(t *testing.T) {
+	corpus, err := infrastructure.OpenBreachCorpus(writeCorpus(t))
+	require.NoError(t, err)
+	defer corpus.Close()
+
+	found, err := corpus.IsBreached(context.Background(), "password")
+	assert.NoError(t, err)
+	assert.False(t, found)
+}
+
+func TestOpenBreachCorpus_MissingFile(t *testing.T) {
+	_, err := infrastructure.OpenBreachCorpus(filepath.Join(t.TempDir(), "missing.txt"))
+	assert.Error(t, err)
+}
@@ -0,0 +1,52 @@
+package infrastructure
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CachePolicy describes the Cache-Control directives sent for a route.
+type CachePolicy struct {
+	Public               bool
+	MaxAge               time.Duration
+	SMaxAge              time.Duration // shared caches (CDNs); zero falls back to MaxAge
+	StaleWhileRevalidate time.Duration
+	NoStore              bool
+}
+
+// Header renders the policy as a Cache-Control header value.
+func (p CachePolicy) Header() string {
+	if p.NoStore {
+		return "no-store"
+	}
+
+	directives := []string{"private"}
+	if p.Public {
+		directives[0] = "public"
+	}
+	directives = append(directives, fmt.Sprintf("max-age=%d", int(p.MaxAge.Seconds())))
+	if p.Public && p.SMaxAge > 0 {
+		directives = append(directives, fmt.Sprintf("s-maxage=%d", int(p.SMaxAge.Seconds())))
+	}
+	if p.StaleWhileRevalidate > 0 {
+		directives = append(directives, fmt.Sprintf("stale-while-revalidate=%d", int(p.StaleWhileRevalidate.Seconds())))
+	}
+	return strings.Join(directives, ", ")
+}
+
+// CacheControlKey is the context key under which the route's Cache-Control value is stored.
+const CacheControlKey = "cache_control"
+
+// CacheControlMiddleware attaches a cache policy to the route. The header is only
+// written by handlers on successful responses, so errors are never cached.
+func CacheControlMiddleware(policy CachePolicy) gin.HandlerFunc {
+	header := policy.Header()
+
+	return func(c *gin.Context) {
+		c.Set(CacheControlKey, header)
+		c.Next()
+	}
+}
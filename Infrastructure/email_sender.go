@@ -4,35 +4,61 @@ import (
 	"bytes"
 	"encoding/json"
 	"errors"
+	"io"
 	"net/http"
-	"os"
+	"time"
+
+	"github.com/Amaankaa/Blog-Starter-Project/Domain/services"
 )
 
-type BrevoEmailSender struct{}
+const defaultBrevoEndpoint = "https://api.brevo.com/v3/smtp/email"
 
-func NewBrevoEmailSender() *BrevoEmailSender {
-	return &BrevoEmailSender{}
+type BrevoConfig struct {
+	APIKey    string
+	FromEmail string
+	FromName  string
+	Endpoint  string // defaults to Brevo's transactional email API
 }
 
-func (b *BrevoEmailSender) SendEmail(to, subject, content string) error {
-	apiKey := os.Getenv("BREVO_API_KEY")
-	fromEmail := os.Getenv("FROM_EMAIL")
-	fromName := os.Getenv("FROM_NAME")
+type BrevoEmailSender struct {
+	cfg    BrevoConfig
+	client *http.Client
+}
 
-	if apiKey == "" || fromEmail == "" || fromName == "" {
-		return errors.New("missing Brevo config in environment")
+func NewBrevoEmailSender(cfg BrevoConfig) (*BrevoEmailSender, error) {
+	if cfg.APIKey == "" || cfg.FromEmail == "" {
+		return nil, errors.New("brevo: API key and sender address are required")
+	}
+	if cfg.Endpoint == "" {
+		cfg.Endpoint = defaultBrevoEndpoint
+	}
+	return &BrevoEmailSender{
+		cfg:    cfg,
+		client: &http.Client{Timeout: 15 * time.Second},
+	}, nil
+}
+
+func (b *BrevoEmailSender) SendEmail(msg services.EmailMessage) error {
+	if err := validateEmailMessage(msg); err != nil {
+		return err
 	}
 
 	payload := map[string]interface{}{
 		"sender": map[string]string{
-			"name":  fromName,
-			"email": fromEmail,
+			"name":  b.cfg.FromName,
+			"email": b.cfg.FromEmail,
 		},
 		"to": []map[string]string{
-			{"email": to},
+			{"email": msg.To},
 		},
-		"subject":     subject,
-		"htmlContent": "<p>" + content + "</p>",
+		"subject": msg.Subject,
+	}
+	// Brevo rejects empty content fields, so only send the parts we have
+	if msg.HTML != "" {
+		payload["htmlContent"] = msg.HTML
+	}
+	if msg.Text != "" {
+		payload["textContent"] = msg.Text
 	}
 
 	body, err := json.Marshal(payload)
@@ -40,18 +66,19 @@ func (b *BrevoEmailSender) SendEmail(to, subject, content string) error {
 		return err
 	}
 
-	req, err := http.NewRequest("POST", "https://api.brevo.com/v3/smtp/email", bytes.NewBuffer(body))
+	req, err := http.NewRequest("POST", b.cfg.Endpoint, bytes.NewBuffer(body))
 	if err != nil {
 		return err
 	}
 	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("api-key", apiKey)
+	req.Header.Set("api-key", b.cfg.APIKey)
 
-	resp, err := http.DefaultClient.Do(req)
+	resp, err := b.client.Do(req)
 	if err != nil {
 		return err
 	}
 	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
 
 	if resp.StatusCode >= 400 {
 		return errors.New("failed to send email: " + resp.Status)
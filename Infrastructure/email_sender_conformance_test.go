@@ -0,0 +1,375 @@
+package infrastructure_test
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/mail"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/Amaankaa/Blog-Starter-Project/Domain/services"
+	infrastructure "github.com/Amaankaa/Blog-Starter-Project/Infrastructure"
+	"github.com/stretchr/testify/suite"
+)
+
+// receivedEmail is what a fake provider saw, normalised across APIs
+type receivedEmail struct {
+	FromEmail string
+	FromName  string
+	To        string
+	Subject   string
+	HTML      string
+	Text      string
+}
+
+// fakeProvider stands in for one provider's API; failing makes it reject every request
+type fakeProvider struct {
+	mu       sync.Mutex
+	received []receivedEmail
+	failing  atomic.Bool
+}
+
+func (f *fakeProvider) record(e receivedEmail) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.received = append(f.received, e)
+}
+
+func (f *fakeProvider) Received() []receivedEmail {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]receivedEmail(nil), f.received...)
+}
+
+// emailSenderConformanceSuite is run against every provider. A new provider only
+// needs a fake backend in providerHarnesses to be held to the same behaviour.
+type emailSenderConformanceSuite struct {
+	suite.Suite
+	start    func(t *testing.T, fake *fakeProvider) services.IEmailSender
+	fake     *fakeProvider
+	provider services.IEmailSender
+}
+
+const (
+	testFromEmail = "noreply@example.com"
+	testFromName  = "Blog Starter"
+)
+
+var providerHarnesses = map[string]func(t *testing.T, fake *fakeProvider) services.IEmailSender{
+	infrastructure.EmailProviderBrevo:  startFakeBrevo,
+	infrastructure.EmailProviderResend: startFakeResend,
+	infrastructure.EmailProviderSMTP:   startFakeSMTP,
+}
+
+func TestEmailSenderConformance(t *testing.T) {
+	for name, start := range providerHarnesses {
+		t.Run(name, func(t *testing.T) {
+			suite.Run(t, &emailSenderConformanceSuite{start: start})
+		})
+	}
+}
+
+func (s *emailSenderConformanceSuite) SetupTest() {
+	s.fake = &fakeProvider{}
+	s.provider = s.start(s.T(), s.fake)
+}
+
+func (s *emailSenderConformanceSuite) TestDeliversHTMLAndTextBodies() {
+	msg := services.EmailMessage{
+		To:      "jane@example.com",
+		Subject: "Verifica tu dirección de correo",
+		HTML:    "<p>Tu código: <strong>482913</strong></p>",
+		Text:    "Tu código: 482913\n",
+	}
+
+	s.Require().NoError(s.provider.SendEmail(msg))
+
+	received := s.fake.Received()
+	s.Require().Len(received, 1)
+	s.Equal(receivedEmail{
+		FromEmail: testFromEmail,
+		FromName:  testFromName,
+		To:        msg.To,
+		Subject:   msg.Subject,
+		HTML:      msg.HTML,
+		Text:      msg.Text,
+	}, received[0])
+}
+
+func (s *emailSenderConformanceSuite) TestDeliversTextOnlyEmail() {
+	msg := services.EmailMessage{To: "jane@example.com", Subject: "Plain", Text: "Just text\n"}
+
+	s.Require().NoError(s.provider.SendEmail(msg))
+
+	received := s.fake.Received()
+	s.Require().Len(received, 1)
+	s.Equal("Just text\n", received[0].Text)
+	s.Empty(received[0].HTML)
+}
+
+func (s *emailSenderConformanceSuite) TestReportsProviderFailure() {
+	s.fake.failing.Store(true)
+
+	err := s.provider.SendEmail(services.EmailMessage{To: "jane@example.com", Subject: "Hi", Text: "Hi"})
+
+	s.Error(err)
+	s.Empty(s.fake.Received())
+}
+
+func (s *emailSenderConformanceSuite) TestRejectsMalformedMessagesWithoutCallingTheProvider() {
+	for _, msg := range []services.EmailMessage{
+		{To: "jane@example.com\r\nBcc: eve@example.com", Subject: "Hi", Text: "Hi"},
+		{To: "Jane <jane@example.com>", Subject: "Hi", Text: "Hi"},
+		{To: "jane@example.com", Subject: "", Text: "Hi"},
+		{To: "jane@example.com", Subject: "Hi"},
+	} {
+		s.Error(s.provider.SendEmail(msg), "%+v", msg)
+	}
+	s.Empty(s.fake.Received())
+}
+
+func startFakeBrevo(t *testing.T, fake *fakeProvider) services.IEmailSender {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if fake.failing.Load() || r.Header.Get("api-key") != "brevo-key" {
+			http.Error(w, `{"code":"unavailable"}`, http.StatusServiceUnavailable)
+			return
+		}
+		var body struct {
+			Sender      struct{ Name, Email string }
+			To          []struct{ Email string }
+			Subject     string
+			HTMLContent string `json:"htmlContent"`
+			TextContent string `json:"textContent"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil || len(body.To) != 1 {
+			http.Error(w, `{"code":"invalid_parameter"}`, http.StatusBadRequest)
+			return
+		}
+		fake.record(receivedEmail{
+			FromEmail: body.Sender.Email,
+			FromName:  body.Sender.Name,
+			To:        body.To[0].Email,
+			Subject:   body.Subject,
+			HTML:      body.HTMLContent,
+			Text:      body.TextContent,
+		})
+		w.WriteHeader(http.StatusCreated)
+		io.WriteString(w, `{"messageId":"<1@smtp-relay.mailin.fr>"}`)
+	}))
+	t.Cleanup(server.Close)
+
+	sender, err := infrastructure.NewBrevoEmailSender(infrastructure.BrevoConfig{
+		APIKey:    "brevo-key",
+		FromEmail: testFromEmail,
+		FromName:  testFromName,
+		Endpoint:  server.URL,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return sender
+}
+
+func startFakeResend(t *testing.T, fake *fakeProvider) services.IEmailSender {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if fake.failing.Load() || r.Header.Get("Authorization") != "Bearer resend-key" || r.URL.Path != "/emails" {
+			w.WriteHeader(http.StatusInternalServerError)
+			io.WriteString(w, `{"statusCode":500,"name":"internal_server_error","message":"unavailable"}`)
+			return
+		}
+		var body struct {
+			From    string
+			To      []string
+			Subject string
+			HTML    string `json:"html"`
+			Text    string `json:"text"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil || len(body.To) != 1 {
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			io.WriteString(w, `{"statusCode":422,"name":"validation_error","message":"invalid request"}`)
+			return
+		}
+		from, err := mail.ParseAddress(body.From)
+		if err != nil {
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			io.WriteString(w, `{"statusCode":422,"name":"validation_error","message":"invalid email"}`)
+			return
+		}
+		fake.record(receivedEmail{
+			FromEmail: from.Address,
+			FromName:  from.Name,
+			To:        body.To[0],
+			Subject:   body.Subject,
+			HTML:      body.HTML,
+			Text:      body.Text,
+		})
+		io.WriteString(w, `{"id":"49a3999c-0ce1-4ea6-ab68-afcd6dc2e794"}`)
+	}))
+	t.Cleanup(server.Close)
+
+	sender, err := infrastructure.NewResendEmailSender(infrastructure.ResendConfig{
+		APIKey:    "resend-key",
+		FromEmail: testFromEmail,
+		FromName:  testFromName,
+		BaseURL:   server.URL + "/",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return sender
+}
+
+// startFakeSMTP runs a minimal SMTP server, similar to MailHog, that parses every message it accepts
+func startFakeSMTP(t *testing.T, fake *fakeProvider) services.IEmailSender {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { ln.Close() })
+
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go serveSMTP(conn, fake)
+		}
+	}()
+
+	host, port, _ := net.SplitHostPort(ln.Addr().String())
+	portNum, _ := strconv.Atoi(port)
+	sender, err := infrastructure.NewSMTPEmailSender(infrastructure.SMTPConfig{
+		Host:      host,
+		Port:      portNum,
+		FromEmail: testFromEmail,
+		FromName:  testFromName,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return sender
+}
+
+func serveSMTP(conn net.Conn, fake *fakeProvider) {
+	defer conn.Close()
+	r := bufio.NewReader(conn)
+	reply := func(line string) { io.WriteString(conn, line+"\r\n") }
+
+	reply("220 localhost ESMTP fake")
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return
+		}
+		cmd := strings.ToUpper(strings.TrimSpace(line))
+		switch {
+		case strings.HasPrefix(cmd, "EHLO"), strings.HasPrefix(cmd, "HELO"):
+			reply("250-localhost")
+			reply("250 8BITMIME")
+		case strings.HasPrefix(cmd, "MAIL FROM"):
+			if fake.failing.Load() {
+				reply("451 4.3.0 Mail server temporarily unavailable")
+				continue
+			}
+			reply("250 OK")
+		case strings.HasPrefix(cmd, "RCPT TO"), cmd == "RSET", cmd == "NOOP":
+			reply("250 OK")
+		case cmd == "DATA":
+			reply("354 End data with <CR><LF>.<CR><LF>")
+			var data strings.Builder
+			for {
+				l, err := r.ReadString('\n')
+				if err != nil {
+					return
+				}
+				if l == ".\r\n" {
+					break
+				}
+				data.WriteString(strings.TrimPrefix(l, ".")) // undo dot-stuffing
+			}
+			if e, err := parseMIMEEmail(data.String()); err == nil {
+				fake.record(e)
+				reply("250 OK queued")
+			} else {
+				reply("554 " + err.Error())
+			}
+		case cmd == "QUIT":
+			reply("221 Bye")
+			return
+		default:
+			reply("502 Command not implemented")
+		}
+	}
+}
+
+// parseMIMEEmail extracts the parts the conformance tests compare. SMTP carries text with
+// CRLF line endings, so they are turned back into the "\n" the sender was given.
+func parseMIMEEmail(raw string) (receivedEmail, error) {
+	msg, err := mail.ReadMessage(strings.NewReader(raw))
+	if err != nil {
+		return receivedEmail{}, err
+	}
+	from, err := mail.ParseAddress(msg.Header.Get("From"))
+	if err != nil {
+		return receivedEmail{}, err
+	}
+	subject, err := new(mime.WordDecoder).DecodeHeader(msg.Header.Get("Subject"))
+	if err != nil {
+		return receivedEmail{}, err
+	}
+	e := receivedEmail{FromEmail: from.Address, FromName: from.Name, To: msg.Header.Get("To"), Subject: subject}
+
+	setBody := func(contentType string, body io.Reader) error {
+		b, err := io.ReadAll(body)
+		if err != nil {
+			return err
+		}
+		mediaType, _, _ := mime.ParseMediaType(contentType)
+		switch mediaType {
+		case "text/plain":
+			e.Text = strings.ReplaceAll(string(b), "\r\n", "\n")
+		case "text/html":
+			e.HTML = strings.ReplaceAll(string(b), "\r\n", "\n")
+		}
+		return nil
+	}
+
+	mediaType, params, err := mime.ParseMediaType(msg.Header.Get("Content-Type"))
+	if err != nil {
+		return receivedEmail{}, err
+	}
+	if !strings.HasPrefix(mediaType, "multipart/") {
+		body := msg.Body
+		if strings.EqualFold(msg.Header.Get("Content-Transfer-Encoding"), "quoted-printable") {
+			body = quotedprintable.NewReader(body)
+		}
+		return e, setBody(mediaType, body)
+	}
+
+	// Parts are decoded from quoted-printable by the multipart reader
+	mr := multipart.NewReader(msg.Body, params["boundary"])
+	for {
+		part, err := mr.NextPart()
+		if err == io.EOF {
+			return e, nil
+		}
+		if err != nil {
+			return receivedEmail{}, err
+		}
+		if err := setBody(part.Header.Get("Content-Type"), part); err != nil {
+			return receivedEmail{}, err
+		}
+	}
+}
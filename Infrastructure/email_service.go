@@ -1,3 +1,147 @@
 package infrastructure
 
-//TODO
\ No newline at end of file
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/mail"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/Amaankaa/Blog-Starter-Project/Domain/services"
+)
+
+// Email provider names accepted in EMAIL_PROVIDERS
+const (
+	EmailProviderBrevo  = "brevo"
+	EmailProviderResend = "resend"
+	EmailProviderSMTP   = "smtp"
+)
+
+// EmailSenderConfig selects the email providers. Providers are tried in order and
+// a later one is only used when the ones before it fail.
+type EmailSenderConfig struct {
+	Providers []string
+	Brevo     BrevoConfig
+	Resend    ResendConfig
+	SMTP      SMTPConfig
+}
+
+// LoadEmailSenderConfig reads EMAIL_PROVIDERS (comma separated, default "brevo") and the
+// settings of each provider. FROM_EMAIL and FROM_NAME are shared by all providers.
+func LoadEmailSenderConfig() EmailSenderConfig {
+	fromEmail := os.Getenv("FROM_EMAIL")
+	fromName := os.Getenv("FROM_NAME")
+
+	providers := []string{EmailProviderBrevo}
+	if v := os.Getenv("EMAIL_PROVIDERS"); v != "" {
+		providers = nil
+		for _, p := range strings.Split(v, ",") {
+			if p = strings.ToLower(strings.TrimSpace(p)); p != "" {
+				providers = append(providers, p)
+			}
+		}
+	}
+	port, _ := strconv.Atoi(os.Getenv("SMTP_PORT"))
+
+	return EmailSenderConfig{
+		Providers: providers,
+		Brevo: BrevoConfig{
+			APIKey:    os.Getenv("BREVO_API_KEY"),
+			FromEmail: fromEmail,
+			FromName:  fromName,
+		},
+		Resend: ResendConfig{
+			APIKey:    os.Getenv("RESEND_API_KEY"),
+			FromEmail: fromEmail,
+			FromName:  fromName,
+		},
+		SMTP: SMTPConfig{
+			Host:      os.Getenv("SMTP_HOST"),
+			Port:      port,
+			Username:  os.Getenv("SMTP_USERNAME"),
+			Password:  os.Getenv("SMTP_PASSWORD"),
+			FromEmail: fromEmail,
+			FromName:  fromName,
+		},
+	}
+}
+
+// NewEmailSender builds the configured providers, failing fast on unknown names or missing settings
+func NewEmailSender(cfg EmailSenderConfig) (*FailoverEmailSender, error) {
+	if len(cfg.Providers) == 0 {
+		return nil, errors.New("no email provider configured")
+	}
+
+	providers := make([]EmailProvider, 0, len(cfg.Providers))
+	for _, name := range cfg.Providers {
+		var sender services.IEmailSender
+		var err error
+		switch name {
+		case EmailProviderBrevo:
+			sender, err = NewBrevoEmailSender(cfg.Brevo)
+		case EmailProviderResend:
+			sender, err = NewResendEmailSender(cfg.Resend)
+		case EmailProviderSMTP:
+			sender, err = NewSMTPEmailSender(cfg.SMTP)
+		default:
+			err = fmt.Errorf("unknown email provider %q", name)
+		}
+		if err != nil {
+			return nil, err
+		}
+		providers = append(providers, EmailProvider{Name: name, Sender: sender})
+	}
+	return NewFailoverEmailSender(providers...), nil
+}
+
+type EmailProvider struct {
+	Name   string
+	Sender services.IEmailSender
+}
+
+// FailoverEmailSender sends through the first provider that accepts the email
+type FailoverEmailSender struct {
+	providers []EmailProvider
+}
+
+func NewFailoverEmailSender(providers ...EmailProvider) *FailoverEmailSender {
+	return &FailoverEmailSender{providers: providers}
+}
+
+func (f *FailoverEmailSender) SendEmail(msg services.EmailMessage) error {
+	// A malformed message fails the same way everywhere; don't burn through the providers
+	if err := validateEmailMessage(msg); err != nil {
+		return err
+	}
+
+	var errs []error
+	for i, p := range f.providers {
+		err := p.Sender.SendEmail(msg)
+		if err == nil {
+			return nil
+		}
+		errs = append(errs, fmt.Errorf("%s: %w", p.Name, err))
+		if i < len(f.providers)-1 {
+			log.Printf("email: provider %s failed, trying %s: %v", p.Name, f.providers[i+1].Name, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// validateEmailMessage rejects messages no provider could deliver, including recipients
+// that would smuggle extra headers into an SMTP transaction
+func validateEmailMessage(msg services.EmailMessage) error {
+	addr, err := mail.ParseAddress(msg.To)
+	if err != nil || addr.Name != "" || addr.Address != msg.To {
+		return fmt.Errorf("invalid recipient address %q", msg.To)
+	}
+	if strings.TrimSpace(msg.Subject) == "" {
+		return errors.New("email subject is required")
+	}
+	if msg.HTML == "" && msg.Text == "" {
+		return errors.New("email body is required")
+	}
+	return nil
+}
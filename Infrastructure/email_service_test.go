@@ -0,0 +1,85 @@
+package infrastructure_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Amaankaa/Blog-Starter-Project/Domain/services"
+	infrastructure "github.com/Amaankaa/Blog-Starter-Project/Infrastructure"
+	"github.com/Amaankaa/Blog-Starter-Project/mocks"
+	"github.com/stretchr/testify/suite"
+)
+
+type FailoverEmailSenderSuite struct {
+	suite.Suite
+	primary   *mocks.IEmailSender
+	secondary *mocks.IEmailSender
+	sender    *infrastructure.FailoverEmailSender
+}
+
+func (s *FailoverEmailSenderSuite) SetupTest() {
+	s.primary = mocks.NewIEmailSender(s.T())
+	s.secondary = mocks.NewIEmailSender(s.T())
+	s.sender = infrastructure.NewFailoverEmailSender(
+		infrastructure.EmailProvider{Name: "brevo", Sender: s.primary},
+		infrastructure.EmailProvider{Name: "smtp", Sender: s.secondary},
+	)
+}
+
+func TestFailoverEmailSenderSuite(t *testing.T) {
+	suite.Run(t, new(FailoverEmailSenderSuite))
+}
+
+var failoverMessage = services.EmailMessage{To: "jane@example.com", Subject: "Hi", Text: "Hi"}
+
+func (s *FailoverEmailSenderSuite) TestUsesFirstProviderWhenItSucceeds() {
+	s.primary.On("SendEmail", failoverMessage).Return(nil).Once()
+
+	s.NoError(s.sender.SendEmail(failoverMessage))
+}
+
+func (s *FailoverEmailSenderSuite) TestFallsBackInOrder() {
+	s.primary.On("SendEmail", failoverMessage).Return(errors.New("503")).Once()
+	s.secondary.On("SendEmail", failoverMessage).Return(nil).Once()
+
+	s.NoError(s.sender.SendEmail(failoverMessage))
+}
+
+func (s *FailoverEmailSenderSuite) TestReportsEveryProviderError() {
+	s.primary.On("SendEmail", failoverMessage).Return(errors.New("503")).Once()
+	s.secondary.On("SendEmail", failoverMessage).Return(errors.New("connection refused")).Once()
+
+	s.EqualError(s.sender.SendEmail(failoverMessage), "brevo: 503\nsmtp: connection refused")
+}
+
+func (s *FailoverEmailSenderSuite) TestInvalidMessageSkipsProviders() {
+	s.Error(s.sender.SendEmail(services.EmailMessage{To: "not an address", Subject: "Hi", Text: "Hi"}))
+}
+
+func (s *FailoverEmailSenderSuite) TestNewEmailSender_RejectsUnknownProvider() {
+	_, err := infrastructure.NewEmailSender(infrastructure.EmailSenderConfig{Providers: []string{"sendgrid"}})
+
+	s.EqualError(err, `unknown email provider "sendgrid"`)
+}
+
+func (s *FailoverEmailSenderSuite) TestNewEmailSender_RequiresProviderSettings() {
+	_, err := infrastructure.NewEmailSender(infrastructure.EmailSenderConfig{
+		Providers: []string{infrastructure.EmailProviderSMTP, infrastructure.EmailProviderResend},
+		SMTP:      infrastructure.SMTPConfig{Host: "localhost", Port: 1025, FromEmail: "noreply@example.com"},
+	})
+
+	s.EqualError(err, "resend: API key and sender address are required")
+}
+
+func (s *FailoverEmailSenderSuite) TestLoadEmailSenderConfig() {
+	s.T().Setenv("EMAIL_PROVIDERS", " SMTP , brevo,")
+	s.T().Setenv("FROM_EMAIL", "noreply@example.com")
+	s.T().Setenv("SMTP_HOST", "localhost")
+	s.T().Setenv("SMTP_PORT", "1025")
+
+	cfg := infrastructure.LoadEmailSenderConfig()
+
+	s.Equal([]string{"smtp", "brevo"}, cfg.Providers)
+	s.Equal(1025, cfg.SMTP.Port)
+	s.Equal("noreply@example.com", cfg.Brevo.FromEmail)
+}
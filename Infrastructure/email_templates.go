@@ -0,0 +1,248 @@
+package infrastructure
+
+import (
+	"bytes"
+	"embed"
+	"errors"
+	"fmt"
+	htmltemplate "html/template"
+	"io/fs"
+	"os"
+	"sort"
+	"strings"
+	texttemplate "text/template"
+	"time"
+
+	"github.com/Amaankaa/Blog-Starter-Project/Domain/services"
+)
+
+// DefaultEmailLocale is used when neither the requested locale nor its base language has a template
+const DefaultEmailLocale = "en"
+
+//go:embed templates/email
+var embeddedEmailTemplates embed.FS
+
+// Every template is a pair of files per locale: <locale>/<name>.html defines "content",
+// rendered inside layout.html, and <locale>/<name>.txt defines "subject" and "text".
+type emailTemplate struct {
+	html *htmltemplate.Template
+	text *texttemplate.Template
+}
+
+type EmailTemplateRenderer struct {
+	appName   string
+	templates map[string]map[string]emailTemplate // locale -> name -> template
+}
+
+var emailTemplateFuncs = map[string]interface{}{
+	"datetime": func(t time.Time) string {
+		return t.UTC().Format("2 Jan 2006 15:04 MST")
+	},
+}
+
+// NewEmailTemplateRenderer parses the embedded templates. Files under overrideDir (if not
+// empty) replace embedded files with the same path, and may add new locales.
+func NewEmailTemplateRenderer(appName, overrideDir string) (*EmailTemplateRenderer, error) {
+	embedded, err := fs.Sub(embeddedEmailTemplates, "templates/email")
+	if err != nil {
+		return nil, err
+	}
+	sources := []fs.FS{embedded}
+	if overrideDir != "" {
+		if info, err := os.Stat(overrideDir); err != nil || !info.IsDir() {
+			return nil, fmt.Errorf("email template directory %q is not readable", overrideDir)
+		}
+		sources = append(sources, os.DirFS(overrideDir))
+	}
+
+	// Later sources win, file by file
+	layout := ""
+	files := make(map[string]map[string]string) // locale -> file name -> contents
+	for _, src := range sources {
+		if b, err := fs.ReadFile(src, "layout.html"); err == nil {
+			layout = string(b)
+		}
+		entries, err := fs.ReadDir(src, ".")
+		if err != nil {
+			return nil, err
+		}
+		for _, entry := range entries {
+			if !entry.IsDir() {
+				continue
+			}
+			locale := normalizeLocale(entry.Name())
+			for _, name := range services.EmailTemplates {
+				for _, file := range []string{name + ".html", name + ".txt"} {
+					b, err := fs.ReadFile(src, entry.Name()+"/"+file)
+					if err != nil {
+						continue
+					}
+					if files[locale] == nil {
+						files[locale] = make(map[string]string)
+					}
+					files[locale][file] = string(b)
+				}
+			}
+		}
+	}
+
+	r := &EmailTemplateRenderer{
+		appName:   appName,
+		templates: make(map[string]map[string]emailTemplate),
+	}
+	for locale, localeFiles := range files {
+		r.templates[locale] = make(map[string]emailTemplate)
+		for _, name := range services.EmailTemplates {
+			htmlSrc, hasHTML := localeFiles[name+".html"]
+			textSrc, hasText := localeFiles[name+".txt"]
+			if !hasHTML && !hasText {
+				continue
+			}
+			if !hasHTML || !hasText {
+				return nil, fmt.Errorf("email template %s/%s needs both a .html and a .txt file", locale, name)
+			}
+			tmpl, err := parseEmailTemplate(layout, htmlSrc, textSrc)
+			if err != nil {
+				return nil, fmt.Errorf("email template %s/%s: %w", locale, name, err)
+			}
+			r.templates[locale][name] = tmpl
+		}
+	}
+
+	for _, name := range services.EmailTemplates {
+		if _, ok := r.templates[DefaultEmailLocale][name]; !ok {
+			return nil, fmt.Errorf("email template %s/%s is missing", DefaultEmailLocale, name)
+		}
+	}
+	return r, nil
+}
+
+func parseEmailTemplate(layout, htmlSrc, textSrc string) (emailTemplate, error) {
+	html, err := htmltemplate.New("layout").Funcs(emailTemplateFuncs).Parse(layout)
+	if err != nil {
+		return emailTemplate{}, err
+	}
+	if html, err = html.Parse(htmlSrc); err != nil {
+		return emailTemplate{}, err
+	}
+	if html.Lookup("content") == nil {
+		return emailTemplate{}, errors.New(`html file must define "content"`)
+	}
+
+	text, err := texttemplate.New("text").Funcs(emailTemplateFuncs).Parse(textSrc)
+	if err != nil {
+		return emailTemplate{}, err
+	}
+	if text.Lookup("subject") == nil || text.Lookup("text") == nil {
+		return emailTemplate{}, errors.New(`txt file must define "subject" and "text"`)
+	}
+	return emailTemplate{html: html, text: text}, nil
+}
+
+// Render executes template name for the closest locale: "pt-BR" tries "pt-br", then "pt",
+// then DefaultEmailLocale. The returned message has no recipient set.
+func (r *EmailTemplateRenderer) Render(name, locale string, data map[string]interface{}) (services.EmailMessage, error) {
+	tmpl, ok := r.lookup(name, locale)
+	if !ok {
+		return services.EmailMessage{}, fmt.Errorf("unknown email template %q", name)
+	}
+
+	// Copy so the caller's map is left untouched
+	vars := make(map[string]interface{}, len(data)+1)
+	vars["AppName"] = r.appName
+	for k, v := range data {
+		vars[k] = v
+	}
+
+	var subject, text, html bytes.Buffer
+	if err := tmpl.text.ExecuteTemplate(&subject, "subject", vars); err != nil {
+		return services.EmailMessage{}, err
+	}
+	if err := tmpl.text.ExecuteTemplate(&text, "text", vars); err != nil {
+		return services.EmailMessage{}, err
+	}
+	if err := tmpl.html.ExecuteTemplate(&html, "layout", vars); err != nil {
+		return services.EmailMessage{}, err
+	}
+
+	return services.EmailMessage{
+		Subject: strings.Join(strings.Fields(subject.String()), " "), // a header must stay on one line
+		HTML:    html.String(),
+		Text:    strings.TrimSpace(text.String()) + "\n",
+	}, nil
+}
+
+// Preview renders template name with sample data, for checking overrides and translations
+func (r *EmailTemplateRenderer) Preview(name, locale string) (services.EmailMessage, error) {
+	return r.Render(name, locale, sampleEmailData[name])
+}
+
+// Locales lists every locale that has at least one template
+func (r *EmailTemplateRenderer) Locales() []string {
+	locales := make([]string, 0, len(r.templates))
+	for locale := range r.templates {
+		locales = append(locales, locale)
+	}
+	sort.Strings(locales)
+	return locales
+}
+
+func (r *EmailTemplateRenderer) lookup(name, locale string) (emailTemplate, bool) {
+	locale = normalizeLocale(locale)
+	candidates := []string{locale}
+	if base, _, found := strings.Cut(locale, "-"); found {
+		candidates = append(candidates, base)
+	}
+	candidates = append(candidates, DefaultEmailLocale)
+
+	for _, candidate := range candidates {
+		if tmpl, ok := r.templates[candidate][name]; ok {
+			return tmpl, true
+		}
+	}
+	return emailTemplate{}, false
+}
+
+func normalizeLocale(locale string) string {
+	return strings.ToLower(strings.ReplaceAll(strings.TrimSpace(locale), "_", "-"))
+}
+
+var sampleEmailData = map[string]map[string]interface{}{
+	services.TemplateVerification: {
+		"Name":             "Jane",
+		"Code":             "482913",
+		"ExpiresInMinutes": 10,
+	},
+	services.TemplatePasswordReset: {
+		"Name":             "Jane",
+		"Code":             "482913",
+		"ExpiresInMinutes": 10,
+	},
+	services.TemplateSecurityAlert: {
+		"Name":      "Jane",
+		"Event":     "Your password was changed",
+		"Time":      time.Date(2025, time.January, 15, 9, 30, 0, 0, time.UTC),
+		"IPAddress": "203.0.113.7",
+		"UserAgent": "Firefox on Linux",
+	},
+	services.TemplateAccountLocked: {
+		"Name":          "Jane",
+		"Time":          time.Date(2025, time.January, 15, 9, 30, 0, 0, time.UTC),
+		"LockedMinutes": 15,
+		"IPAddress":     "203.0.113.7",
+		"UserAgent":     "Firefox on Linux",
+		"UnlockURL":     "https://example.com/unlock-account?token=3f9a0c",
+	},
+	services.TemplateEmailChange: {
+		"Name":             "Jane",
+		"Code":             "482913",
+		"ExpiresInMinutes": 15,
+	},
+	services.TemplateDigest: {
+		"Name": "Jane",
+		"Posts": []map[string]string{
+			{"Title": "Getting started with Go", "URL": "https://example.com/blogs/1"},
+			{"Title": "Indexing strategies for MongoDB", "URL": "https://example.com/blogs/2"},
+		},
+	},
+}
@@ -0,0 +1,119 @@
+package infrastructure_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Amaankaa/Blog-Starter-Project/Domain/services"
+	infrastructure "github.com/Amaankaa/Blog-Starter-Project/Infrastructure"
+	"github.com/stretchr/testify/suite"
+)
+
+type EmailTemplateRendererSuite struct {
+	suite.Suite
+	renderer *infrastructure.EmailTemplateRenderer
+}
+
+func (s *EmailTemplateRendererSuite) SetupTest() {
+	renderer, err := infrastructure.NewEmailTemplateRenderer("Blog Starter", "")
+	s.Require().NoError(err)
+	s.renderer = renderer
+}
+
+func TestEmailTemplateRendererSuite(t *testing.T) {
+	suite.Run(t, new(EmailTemplateRendererSuite))
+}
+
+func (s *EmailTemplateRendererSuite) TestEveryTemplatePreviewsInEveryLocale() {
+	for _, locale := range s.renderer.Locales() {
+		for _, name := range services.EmailTemplates {
+			msg, err := s.renderer.Preview(name, locale)
+			s.Require().NoError(err, "%s/%s", locale, name)
+			s.NotEmpty(msg.Subject, "%s/%s", locale, name)
+			s.Contains(msg.HTML, "<html>", "%s/%s", locale, name)
+			s.NotContains(msg.Text, "<no value>", "%s/%s", locale, name)
+		}
+	}
+}
+
+func (s *EmailTemplateRendererSuite) TestRender_EscapesHTMLButNotText() {
+	msg, err := s.renderer.Render(services.TemplateVerification, "en", map[string]interface{}{
+		"Name":             "<b>Eve</b>",
+		"Code":             "123456",
+		"ExpiresInMinutes": 10,
+	})
+
+	s.NoError(err)
+	s.Equal("Verify your email address", msg.Subject)
+	s.Contains(msg.HTML, "&lt;b&gt;Eve&lt;/b&gt;")
+	s.Contains(msg.HTML, "123456")
+	s.Contains(msg.Text, "Hi <b>Eve</b>,")
+	s.Contains(msg.Text, "123456")
+}
+
+func (s *EmailTemplateRendererSuite) TestRender_FallsBackToBaseLanguageThenDefault() {
+	data := map[string]interface{}{"Code": "123456", "ExpiresInMinutes": 10}
+
+	regional, err := s.renderer.Render(services.TemplatePasswordReset, "es_MX", data)
+	s.NoError(err)
+	s.Equal("Tu código para restablecer la contraseña", regional.Subject)
+
+	unknown, err := s.renderer.Render(services.TemplatePasswordReset, "sw", data)
+	s.NoError(err)
+	s.Equal("Your password reset code", unknown.Subject)
+	s.Contains(unknown.Text, "Hi,")
+}
+
+func (s *EmailTemplateRendererSuite) TestRender_UnknownTemplate() {
+	_, err := s.renderer.Render("newsletter", "en", nil)
+
+	s.EqualError(err, `unknown email template "newsletter"`)
+}
+
+func (s *EmailTemplateRendererSuite) TestOverridesFromDisk() {
+	dir := s.T().TempDir()
+	s.Require().NoError(os.MkdirAll(filepath.Join(dir, "en"), 0o755))
+	s.Require().NoError(os.MkdirAll(filepath.Join(dir, "de"), 0o755))
+	write := func(path, contents string) {
+		s.Require().NoError(os.WriteFile(filepath.Join(dir, path), []byte(contents), 0o644))
+	}
+	write("en/digest.txt", `{{define "subject"}}This week on {{.AppName}}{{end}}{{define "text"}}Custom digest{{end}}`)
+	write("de/verification.html", `{{define "content"}}<p>Dein Code: {{.Code}}</p>{{end}}`)
+	write("de/verification.txt", `{{define "subject"}}Bestätige deine E-Mail-Adresse{{end}}{{define "text"}}Dein Code: {{.Code}}{{end}}`)
+
+	renderer, err := infrastructure.NewEmailTemplateRenderer("Blog Starter", dir)
+	s.Require().NoError(err)
+	s.Contains(renderer.Locales(), "de")
+
+	digest, err := renderer.Preview(services.TemplateDigest, "en")
+	s.NoError(err)
+	s.Equal("This week on Blog Starter", digest.Subject)
+	s.Equal("Custom digest\n", digest.Text)
+	s.Contains(digest.HTML, "Getting started with Go") // the embedded HTML part is kept
+
+	verification, err := renderer.Preview(services.TemplateVerification, "de-AT")
+	s.NoError(err)
+	s.Equal("Bestätige deine E-Mail-Adresse", verification.Subject)
+	s.Contains(verification.HTML, "Dein Code: 482913")
+}
+
+func (s *EmailTemplateRendererSuite) TestOverridesMustBePaired() {
+	dir := s.T().TempDir()
+	s.Require().NoError(os.MkdirAll(filepath.Join(dir, "fr"), 0o755))
+	s.Require().NoError(os.WriteFile(filepath.Join(dir, "fr", "digest.html"), []byte(`{{define "content"}}{{end}}`), 0o644))
+
+	_, err := infrastructure.NewEmailTemplateRenderer("Blog Starter", dir)
+
+	s.EqualError(err, "email template fr/digest needs both a .html and a .txt file")
+}
+
+func (s *EmailTemplateRendererSuite) TestOverrideParseErrorsFailAtStartup() {
+	dir := s.T().TempDir()
+	s.Require().NoError(os.MkdirAll(filepath.Join(dir, "en"), 0o755))
+	s.Require().NoError(os.WriteFile(filepath.Join(dir, "en", "digest.txt"), []byte(`{{define "subject"}}{{.Oops{{end}}`), 0o644))
+
+	_, err := infrastructure.NewEmailTemplateRenderer("Blog Starter", dir)
+
+	s.ErrorContains(err, "email template en/digest")
+}
@@ -1,15 +1,26 @@
 package infrastructure
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"net/url"
-	"os"
+	"strings"
+
+	"github.com/Amaankaa/Blog-Starter-Project/Domain/services"
 )
 
+const defaultEmailListVerifyEndpoint = "https://apps.emaillistverify.com/api/verifyEmail"
+
+// EmailListVerifyVerifier asks EmailListVerify whether an address can receive mail.
+// It is the optional external step of the email verification chain.
 type EmailListVerifyVerifier struct {
-	APIKey string
+	APIKey   string
+	Endpoint string
+	client   *http.Client
 }
 
 type emailListVerifyResponse struct {
@@ -24,47 +35,42 @@ type emailListVerifyResponse struct {
 	Deliverable bool   `json:"deliverable"`
 }
 
-func NewEmailListVerifyVerifier() (*EmailListVerifyVerifier, error) {
-	apiKey := os.Getenv("EMAILLISTVERIFY_API_KEY")
-	if apiKey == "" {
-		return nil, fmt.Errorf("EMAILLISTVERIFY_API_KEY is not set")
+func NewEmailListVerifyVerifier(apiKey string) *EmailListVerifyVerifier {
+	return &EmailListVerifyVerifier{
+		APIKey:   apiKey,
+		Endpoint: defaultEmailListVerifyEndpoint,
+		client:   &http.Client{},
 	}
-	return &EmailListVerifyVerifier{APIKey: apiKey}, nil
 }
 
-func (e *EmailListVerifyVerifier) IsRealEmail(email string) (bool, error) {
-	// EmailListVerify API endpoint - try the correct endpoint
-	baseURL := "https://apps.emaillistverify.com/api/verifyEmail"
-
-	// Create URL with parameters - EmailListVerify uses 'secret' and 'email' parameters
+// Check rejects addresses EmailListVerify considers undeliverable. Timeouts come from ctx.
+func (e *EmailListVerifyVerifier) Check(ctx context.Context, email string) (services.EmailVerdict, error) {
+	// EmailListVerify uses 'secret' and 'email' parameters
 	params := url.Values{}
 	params.Add("secret", e.APIKey)
 	params.Add("email", email)
 
-	requestURL := fmt.Sprintf("%s?%s", baseURL, params.Encode())
-
-
-	resp, err := http.Get(requestURL)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, e.Endpoint+"?"+params.Encode(), nil)
+	if err != nil {
+		return services.EmailContinue, err
+	}
+	resp, err := e.client.Do(req)
 	if err != nil {
-		return false, fmt.Errorf("failed to make request to EmailListVerify: %w", err)
+		return services.EmailContinue, fmt.Errorf("failed to make request to EmailListVerify: %w", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return false, fmt.Errorf("EmailListVerify API returned status %d", resp.StatusCode)
+		return services.EmailContinue, fmt.Errorf("EmailListVerify API returned status %d", resp.StatusCode)
 	}
 
-	// Read the response body first to debug
-	body := make([]byte, 0)
-	buf := make([]byte, 1024)
-	for {
-		n, err := resp.Body.Read(buf)
-		if n > 0 {
-			body = append(body, buf[:n]...)
-		}
-		if err != nil {
-			break
-		}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 64<<10))
+	if err != nil {
+		return services.EmailContinue, err
+	}
+	body = []byte(strings.TrimSpace(string(body)))
+	if len(body) == 0 {
+		return services.EmailContinue, errors.New("EmailListVerify returned an empty response")
 	}
 
 	// Check if response starts with '{' (JSON) or is plain text
@@ -72,23 +78,25 @@ func (e *EmailListVerifyVerifier) IsRealEmail(email string) (bool, error) {
 		responseText := string(body)
 		// Handle common plain text responses
 		if responseText == "ok" {
-			return true, nil
+			return services.EmailContinue, nil
 		}
 		if responseText == "invalid" || responseText == "error" {
-			return false, nil
+			return services.EmailReject, nil
 		}
-		return false, fmt.Errorf("EmailListVerify returned non-JSON response: %s", responseText)
+		return services.EmailContinue, fmt.Errorf("EmailListVerify returned non-JSON response: %s", responseText)
 	}
 
 	var result emailListVerifyResponse
 	if err := json.Unmarshal(body, &result); err != nil {
-		return false, fmt.Errorf("failed to decode EmailListVerify JSON response: %w. Raw response: %s", err, string(body))
+		return services.EmailContinue, fmt.Errorf("failed to decode EmailListVerify JSON response: %w. Raw response: %s", err, string(body))
 	}
 
 	isValid := result.Status == "ok" &&
 		(result.Result == "deliverable" || result.Result == "risky") &&
 		result.MXRecord &&
 		!result.Disposable
-
-	return isValid, nil
+	if !isValid {
+		return services.EmailReject, nil
+	}
+	return services.EmailContinue, nil
 }
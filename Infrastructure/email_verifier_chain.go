@@ -0,0 +1,138 @@
+package infrastructure
+
+import (
+	"bufio"
+	"context"
+	_ "embed"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/mail"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/Amaankaa/Blog-Starter-Project/Domain/services"
+	utils "github.com/Amaankaa/Blog-Starter-Project/Domain/utils"
+)
+
+// EmailCheckStep configures one step of an EmailVerifierChain
+type EmailCheckStep struct {
+	Name    string
+	Check   services.IEmailCheck
+	Timeout time.Duration // zero means no limit beyond the chain's context
+	// FailOpen skips the step when it errors or times out. Otherwise the error is
+	// returned and the address is refused.
+	FailOpen bool
+}
+
+// EmailVerifierChain is an IEmailVerifier that runs its steps in order. The first step
+// that accepts or rejects decides; an address no step objected to is accepted.
+type EmailVerifierChain struct {
+	steps []EmailCheckStep
+}
+
+func NewEmailVerifierChain(steps ...EmailCheckStep) *EmailVerifierChain {
+	return &EmailVerifierChain{steps: steps}
+}
+
+func (c *EmailVerifierChain) IsRealEmail(email string) (bool, error) {
+	return c.Verify(context.Background(), email)
+}
+
+func (c *EmailVerifierChain) Verify(ctx context.Context, email string) (bool, error) {
+	for _, step := range c.steps {
+		verdict, err := c.run(ctx, step, email)
+		if err != nil {
+			if step.FailOpen {
+				log.Printf("email verification: skipping %s: %v", step.Name, err)
+				continue
+			}
+			return false, fmt.Errorf("%s: %w", step.Name, err)
+		}
+		switch verdict {
+		case services.EmailAccept:
+			return true, nil
+		case services.EmailReject:
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+func (c *EmailVerifierChain) run(ctx context.Context, step EmailCheckStep, email string) (services.EmailVerdict, error) {
+	if step.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, step.Timeout)
+		defer cancel()
+	}
+	verdict, err := step.Check.Check(ctx, email)
+	if err == nil && ctx.Err() != nil {
+		// A step that ignores ctx must not get to decide after its deadline
+		err = ctx.Err()
+	}
+	if err != nil && errors.Is(err, context.DeadlineExceeded) {
+		err = errors.New("timed out")
+	}
+	return verdict, err
+}
+
+var (
+	emailLocalPartRegex = regexp.MustCompile(`^[a-zA-Z0-9!#$%&'*+/=?^_{|}~-]+(\.[a-zA-Z0-9!#$%&'*+/=?^_{|}~-]+)*$`)
+	emailDomainRegex    = regexp.MustCompile(`^([a-zA-Z0-9]([a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?\.)+[a-zA-Z]{2,63}$`)
+)
+
+// SyntaxEmailCheck rejects anything but a plain "local@domain" address: no display names,
+// comments, quoted local parts or IP literals, and a domain with a real-looking TLD
+type SyntaxEmailCheck struct{}
+
+func (SyntaxEmailCheck) Check(ctx context.Context, email string) (services.EmailVerdict, error) {
+	addr, err := mail.ParseAddress(email)
+	if err != nil || addr.Name != "" || addr.Address != email {
+		return services.EmailReject, nil
+	}
+	at := strings.LastIndex(email, "@")
+	local, domain := email[:at], email[at+1:]
+	if len(email) > 254 || len(local) > 64 || !emailLocalPartRegex.MatchString(local) || !emailDomainRegex.MatchString(domain) {
+		return services.EmailReject, nil
+	}
+	return services.EmailContinue, nil
+}
+
+//go:embed data/disposable_domains.txt
+var bundledDisposableDomains string
+
+// DisposableDomainCheck rejects addresses on known throwaway-mail domains and their subdomains
+type DisposableDomainCheck struct {
+	domains map[string]bool
+}
+
+// NewDisposableDomainCheck loads the bundled blocklist plus any extra lists, each in the
+// same format: one domain per line, "#" starts a comment
+func NewDisposableDomainCheck(extra ...io.Reader) (*DisposableDomainCheck, error) {
+	d := &DisposableDomainCheck{domains: make(map[string]bool)}
+	lists := append([]io.Reader{strings.NewReader(bundledDisposableDomains)}, extra...)
+	for _, list := range lists {
+		scanner := bufio.NewScanner(list)
+		for scanner.Scan() {
+			line, _, _ := strings.Cut(scanner.Text(), "#")
+			if domain := strings.ToLower(strings.TrimSpace(line)); domain != "" {
+				d.domains[domain] = true
+			}
+		}
+		if err := scanner.Err(); err != nil {
+			return nil, err
+		}
+	}
+	return d, nil
+}
+
+func (d *DisposableDomainCheck) Check(ctx context.Context, email string) (services.EmailVerdict, error) {
+	for _, domain := range utils.EmailDomainHierarchy(email) {
+		if d.domains[domain] {
+			return services.EmailReject, nil
+		}
+	}
+	return services.EmailContinue, nil
+}
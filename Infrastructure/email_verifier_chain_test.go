@@ -0,0 +1,164 @@
+package infrastructure_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Amaankaa/Blog-Starter-Project/Domain/services"
+	infrastructure "github.com/Amaankaa/Blog-Starter-Project/Infrastructure"
+	"github.com/Amaankaa/Blog-Starter-Project/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type EmailVerifierChainSuite struct {
+	suite.Suite
+	first  *mocks.IEmailCheck
+	second *mocks.IEmailCheck
+}
+
+func (s *EmailVerifierChainSuite) SetupTest() {
+	s.first = mocks.NewIEmailCheck(s.T())
+	s.second = mocks.NewIEmailCheck(s.T())
+}
+
+func TestEmailVerifierChainSuite(t *testing.T) {
+	suite.Run(t, new(EmailVerifierChainSuite))
+}
+
+func (s *EmailVerifierChainSuite) TestFirstDecisiveStepWins() {
+	s.first.On("Check", mock.Anything, "a@example.com").Return(services.EmailAccept, nil).Once()
+	chain := infrastructure.NewEmailVerifierChain(
+		infrastructure.EmailCheckStep{Name: "first", Check: s.first},
+		infrastructure.EmailCheckStep{Name: "second", Check: s.second},
+	)
+
+	ok, err := chain.IsRealEmail("a@example.com")
+
+	s.NoError(err)
+	s.True(ok)
+	s.second.AssertNotCalled(s.T(), "Check", mock.Anything, mock.Anything)
+}
+
+func (s *EmailVerifierChainSuite) TestRejectStopsTheChain() {
+	s.first.On("Check", mock.Anything, "a@example.com").Return(services.EmailContinue, nil).Once()
+	s.second.On("Check", mock.Anything, "a@example.com").Return(services.EmailReject, nil).Once()
+	chain := infrastructure.NewEmailVerifierChain(
+		infrastructure.EmailCheckStep{Name: "first", Check: s.first},
+		infrastructure.EmailCheckStep{Name: "second", Check: s.second},
+	)
+
+	ok, err := chain.IsRealEmail("a@example.com")
+
+	s.NoError(err)
+	s.False(ok)
+}
+
+func (s *EmailVerifierChainSuite) TestFailOpenSkipsFailingStep() {
+	s.first.On("Check", mock.Anything, "a@example.com").Return(services.EmailContinue, errors.New("503")).Once()
+	chain := infrastructure.NewEmailVerifierChain(infrastructure.EmailCheckStep{Name: "provider", Check: s.first, FailOpen: true})
+
+	ok, err := chain.IsRealEmail("a@example.com")
+
+	s.NoError(err)
+	s.True(ok)
+}
+
+func (s *EmailVerifierChainSuite) TestFailClosedReturnsStepError() {
+	s.first.On("Check", mock.Anything, "a@example.com").Return(services.EmailContinue, errors.New("503")).Once()
+	chain := infrastructure.NewEmailVerifierChain(infrastructure.EmailCheckStep{Name: "provider", Check: s.first})
+
+	ok, err := chain.IsRealEmail("a@example.com")
+
+	s.EqualError(err, "provider: 503")
+	s.False(ok)
+}
+
+func (s *EmailVerifierChainSuite) TestStepTimeout() {
+	// A step that blocks past its deadline and then claims success
+	s.first.On("Check", mock.Anything, "a@example.com").Run(func(args mock.Arguments) {
+		<-args.Get(0).(context.Context).Done()
+	}).Return(services.EmailAccept, nil)
+	step := infrastructure.EmailCheckStep{Name: "slow", Check: s.first, Timeout: 20 * time.Millisecond}
+
+	_, err := infrastructure.NewEmailVerifierChain(step).IsRealEmail("a@example.com")
+	s.EqualError(err, "slow: timed out")
+
+	step.FailOpen = true
+	ok, err := infrastructure.NewEmailVerifierChain(step).IsRealEmail("a@example.com")
+	s.NoError(err)
+	s.True(ok)
+}
+
+func (s *EmailVerifierChainSuite) TestSyntaxCheck() {
+	check := infrastructure.SyntaxEmailCheck{}
+	for email, want := range map[string]services.EmailVerdict{
+		"jane.doe+blog@example.co.uk":            services.EmailContinue,
+		"jane@mail-server.example.com":           services.EmailContinue,
+		"Jane <jane@example.com>":                services.EmailReject,
+		"jane@localhost":                         services.EmailReject,
+		"jane@[192.168.0.1]":                     services.EmailReject,
+		"jane..doe@example.com":                  services.EmailReject,
+		`"jane doe"@example.com`:                 services.EmailReject,
+		"jane@-example.com":                      services.EmailReject,
+		"jane@example.c0m":                       services.EmailReject,
+		strings.Repeat("a", 65) + "@example.com": services.EmailReject,
+	} {
+		verdict, err := check.Check(context.Background(), email)
+		s.NoError(err)
+		s.Equal(want, verdict, email)
+	}
+}
+
+func (s *EmailVerifierChainSuite) TestDisposableDomains() {
+	check, err := infrastructure.NewDisposableDomainCheck(strings.NewReader("# in-house list\nthrowaway.test\n"))
+	s.Require().NoError(err)
+
+	for email, want := range map[string]services.EmailVerdict{
+		"jane@mailinator.com":    services.EmailReject,
+		"jane@eu.Mailinator.com": services.EmailReject,
+		"jane@throwaway.test":    services.EmailReject,
+		"jane@example.com":       services.EmailContinue,
+		"jane@notmailinator.com": services.EmailContinue,
+	} {
+		verdict, err := check.Check(context.Background(), email)
+		s.NoError(err)
+		s.Equal(want, verdict, email)
+	}
+}
+
+func (s *EmailVerifierChainSuite) TestEmailListVerify() {
+	responses := map[string]string{
+		"good@example.com": `{"status":"ok","result":"deliverable","mx_record":true}`,
+		"temp@example.com": `{"status":"ok","result":"deliverable","mx_record":true,"disposable":true}`,
+		"bad@example.com":  "invalid",
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("secret") != "key" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Write([]byte(responses[r.URL.Query().Get("email")]))
+	}))
+	defer server.Close()
+	verifier := infrastructure.NewEmailListVerifyVerifier("key")
+	verifier.Endpoint = server.URL
+
+	for email, want := range map[string]services.EmailVerdict{
+		"good@example.com": services.EmailContinue,
+		"temp@example.com": services.EmailReject,
+		"bad@example.com":  services.EmailReject,
+	} {
+		verdict, err := verifier.Check(context.Background(), email)
+		s.NoError(err)
+		s.Equal(want, verdict, email)
+	}
+
+	_, err := verifier.Check(context.Background(), "empty@example.com")
+	s.EqualError(err, "EmailListVerify returned an empty response")
+}
@@ -1,76 +1,104 @@
 package infrastructure
 
 import (
+	"crypto/rand"
+	"encoding/hex"
 	"errors"
-	"os"
-	"sync"
 	"time"
 
 	userpkg "github.com/Amaankaa/Blog-Starter-Project/Domain/user"
 	"github.com/golang-jwt/jwt/v4"
 )
 
-var (
-	jwtSecret     []byte
-	loadSecretOnce sync.Once
+const (
+	accessTokenLifetime  = 15 * time.Minute
+	refreshTokenLifetime = 7 * 24 * time.Hour
 )
 
-func loadJWTSecret() {
-	secret := os.Getenv("JWT_SECRET")
-	if secret == "" {
-		panic("JWT_SECRET not set in environment")
-	}
-	jwtSecret = []byte(secret)
+// JWTService signs tokens with the current key of a SigningKeyRing and names the key in
+// the "kid" header, so tokens can be verified by anyone holding the published JWKS.
+type JWTService struct {
+	keys *SigningKeyRing
+	// legacySecret verifies HS256 tokens issued before asymmetric signing; once those
+	// have expired (refreshTokenLifetime after the switch) it can be dropped
+	legacySecret []byte
 }
 
-type JWTService struct{}
-
-func NewJWTService() *JWTService {
-	loadSecretOnce.Do(loadJWTSecret)
-	return &JWTService{}
+func NewJWTService(keys *SigningKeyRing, legacySecret string) *JWTService {
+	return &JWTService{keys: keys, legacySecret: []byte(legacySecret)}
 }
 
-func (j *JWTService) GenerateToken(userID, username, role string) (userpkg.TokenResult, error) {
-	accessExp := time.Now().Add(15 * time.Minute)
-	accessToken := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
-		"_id":      userID,
-		"username": username,
-		"role":     role,
+func (j *JWTService) GenerateToken(subject userpkg.TokenSubject) (userpkg.TokenResult, error) {
+	key, err := j.keys.signingKey()
+	if err != nil {
+		return userpkg.TokenResult{}, err
+	}
+
+	// The jti lets an access token be revoked before it expires
+	accessID, err := newTokenID()
+	if err != nil {
+		return userpkg.TokenResult{}, err
+	}
+	accessExp := time.Now().Add(accessTokenLifetime)
+	accessTokenString, err := signToken(key, jwt.MapClaims{
+		"typ":      userpkg.TokenTypeAccess,
+		"_id":      subject.UserID,
+		"username": subject.Username,
+		"role":     subject.Role,
+		"sid":      subject.SessionID,
+		"mfa":      subject.MFA,
+		"perms":    subject.Permissions,
+		"pv":       subject.PermissionsVersion,
+		"jti":      accessID,
 		"exp":      accessExp.Unix(),
 	})
-
-	accessTokenString, err := accessToken.SignedString(jwtSecret)
 	if err != nil {
 		return userpkg.TokenResult{}, err
 	}
 
-	refreshExp := time.Now().Add(7 * 24 * time.Hour)
-	refreshToken := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
-		"_id": userID,
+	// Refresh tokens are rotated on every use, so each one needs a unique id; otherwise two
+	// tokens issued to the same user within a second would be identical
+	refreshID, err := newTokenID()
+	if err != nil {
+		return userpkg.TokenResult{}, err
+	}
+	refreshExp := time.Now().Add(refreshTokenLifetime)
+	refreshTokenString, err := signToken(key, jwt.MapClaims{
+		"typ": userpkg.TokenTypeRefresh,
+		"_id": subject.UserID,
+		"sid": subject.SessionID,
+		"jti": refreshID,
 		"exp": refreshExp.Unix(),
 	})
-
-	refreshTokenString, err := refreshToken.SignedString(jwtSecret)
 	if err != nil {
 		return userpkg.TokenResult{}, err
 	}
 
 	return userpkg.TokenResult{
 		AccessToken:      accessTokenString,
+		AccessTokenID:    accessID,
 		RefreshToken:     refreshTokenString,
 		AccessExpiresAt:  accessExp,
 		RefreshExpiresAt: refreshExp,
 	}, nil
 }
 
+func signToken(key ringKey, claims jwt.MapClaims) (string, error) {
+	token := jwt.NewWithClaims(key.method, claims)
+	token.Header["kid"] = key.id
+	return token.SignedString(key.private)
+}
 
-func (j *JWTService) ValidateToken(tokenString string) (map[string]interface{}, error) {
-	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, jwt.ErrSignatureInvalid
-		}
-		return jwtSecret, nil
-	})
+func newTokenID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+func (j *JWTService) ValidateToken(tokenString, tokenType string) (map[string]interface{}, error) {
+	token, err := jwt.Parse(tokenString, j.verificationKey)
 
 	if err != nil || !token.Valid {
 		return nil, errors.New("invalid or expired token")
@@ -80,6 +108,40 @@ func (j *JWTService) ValidateToken(tokenString string) (map[string]interface{},
 	if !ok {
 		return nil, errors.New("invalid token claims")
 	}
+	if claimedTokenType(claims) != tokenType {
+		return nil, errors.New("invalid or expired token")
+	}
 
 	return claims, nil
-}
\ No newline at end of file
+}
+
+// claimedTokenType reads the "typ" claim. Tokens issued before it existed are told apart by
+// shape: only access tokens ever carried a username. Once those have expired
+// (refreshTokenLifetime after the claim was added) the fallback can be dropped.
+func claimedTokenType(claims jwt.MapClaims) string {
+	if typ, ok := claims["typ"].(string); ok {
+		return typ
+	}
+	if _, ok := claims["username"]; ok {
+		return userpkg.TokenTypeAccess
+	}
+	return userpkg.TokenTypeRefresh
+}
+
+// verificationKey picks the key named by the token's kid. The token's alg must match the
+// key's, so a token can't e.g. claim HS256 and be checked against an RSA public key.
+func (j *JWTService) verificationKey(token *jwt.Token) (interface{}, error) {
+	kid, _ := token.Header["kid"].(string)
+	if kid == "" {
+		if len(j.legacySecret) > 0 && token.Method.Alg() == jwt.SigningMethodHS256.Alg() {
+			return j.legacySecret, nil
+		}
+		return nil, jwt.ErrSignatureInvalid
+	}
+
+	key, ok := j.keys.verificationKey(kid)
+	if !ok || token.Method.Alg() != key.method.Alg() {
+		return nil, jwt.ErrSignatureInvalid
+	}
+	return key.public, nil
+}
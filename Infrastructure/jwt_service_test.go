@@ -0,0 +1,141 @@
+package infrastructure_test
+
+import (
+	"crypto/rsa"
+	"encoding/base64"
+	"math/big"
+	"testing"
+	"time"
+
+	signingkeypkg "github.com/Amaankaa/Blog-Starter-Project/Domain/signingkey"
+	userpkg "github.com/Amaankaa/Blog-Starter-Project/Domain/user"
+	infrastructure "github.com/Amaankaa/Blog-Starter-Project/Infrastructure"
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestJWTService(t *testing.T) *infrastructure.JWTService {
+	return infrastructure.NewJWTService(newTestKeyRing(t, &memorySigningKeyRepo{}, signingkeypkg.AlgorithmRS256), "")
+}
+
+func TestJWTService_RefreshTokens(t *testing.T) {
+	svc := newTestJWTService(t)
+
+	first, err := svc.GenerateToken(userpkg.TokenSubject{UserID: "user-1", Username: "jane", Role: "user", SessionID: "session-1"})
+	require.NoError(t, err)
+	second, err := svc.GenerateToken(userpkg.TokenSubject{UserID: "user-1", Username: "jane", Role: "user", SessionID: "session-1", MFA: true})
+	require.NoError(t, err)
+
+	assert.NotEqual(t, first.RefreshToken, second.RefreshToken)
+	claims, err := svc.ValidateToken(second.RefreshToken, userpkg.TokenTypeRefresh)
+	require.NoError(t, err)
+	assert.Equal(t, "user-1", claims["_id"])
+	assert.NotEmpty(t, claims["jti"])
+	assert.Equal(t, "session-1", claims["sid"])
+	assert.NotContains(t, claims, "mfa")
+
+	access, err := svc.ValidateToken(second.AccessToken, userpkg.TokenTypeAccess)
+	require.NoError(t, err)
+	assert.Equal(t, second.AccessTokenID, access["jti"])
+	assert.Equal(t, true, access["mfa"])
+	assert.NotEqual(t, first.AccessTokenID, second.AccessTokenID)
+}
+
+func TestJWTService_TokenTypesAreNotInterchangeable(t *testing.T) {
+	svc := newTestJWTService(t)
+	tokens, err := svc.GenerateToken(userpkg.TokenSubject{UserID: "user-1", Username: "jane", Role: "user", SessionID: "session-1"})
+	require.NoError(t, err)
+
+	_, err = svc.ValidateToken(tokens.RefreshToken, userpkg.TokenTypeAccess)
+	assert.Error(t, err)
+	_, err = svc.ValidateToken(tokens.AccessToken, userpkg.TokenTypeRefresh)
+	assert.Error(t, err)
+}
+
+func TestJWTService_CarriesPermissions(t *testing.T) {
+	svc := newTestJWTService(t)
+
+	tokens, err := svc.GenerateToken(userpkg.TokenSubject{UserID: "user-1", Role: "editor", Permissions: []string{"posts:feature"}, PermissionsVersion: 7})
+	require.NoError(t, err)
+
+	access, err := svc.ValidateToken(tokens.AccessToken, userpkg.TokenTypeAccess)
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{"posts:feature"}, access["perms"])
+	assert.Equal(t, float64(7), access["pv"])
+}
+
+func TestJWTService_Algorithms(t *testing.T) {
+	for _, alg := range []string{signingkeypkg.AlgorithmRS256, signingkeypkg.AlgorithmEdDSA} {
+		t.Run(alg, func(t *testing.T) {
+			ring := newTestKeyRing(t, &memorySigningKeyRepo{}, alg)
+			svc := infrastructure.NewJWTService(ring, "")
+
+			tokens, err := svc.GenerateToken(userpkg.TokenSubject{UserID: "user-1", Username: "jane", Role: "admin"})
+			require.NoError(t, err)
+			parsed, _, err := new(jwt.Parser).ParseUnverified(tokens.AccessToken, jwt.MapClaims{})
+			require.NoError(t, err)
+			assert.Equal(t, alg, parsed.Header["alg"])
+			assert.Equal(t, ring.PublicKeys().Keys[0].KeyID, parsed.Header["kid"])
+
+			claims, err := svc.ValidateToken(tokens.AccessToken, userpkg.TokenTypeAccess)
+			require.NoError(t, err)
+			assert.Equal(t, "admin", claims["role"])
+		})
+	}
+}
+
+func TestJWTService_VerifiableFromJWKS(t *testing.T) {
+	ring := newTestKeyRing(t, &memorySigningKeyRepo{}, signingkeypkg.AlgorithmRS256)
+	tokens, err := infrastructure.NewJWTService(ring, "").GenerateToken(userpkg.TokenSubject{UserID: "user-1", Username: "jane", Role: "user"})
+	require.NoError(t, err)
+
+	// What another service would do with the published set
+	jwk := ring.PublicKeys().Keys[0]
+	n, err := base64.RawURLEncoding.DecodeString(jwk.N)
+	require.NoError(t, err)
+	e, err := base64.RawURLEncoding.DecodeString(jwk.E)
+	require.NoError(t, err)
+	public := &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}
+
+	parsed, err := jwt.Parse(tokens.AccessToken, func(*jwt.Token) (interface{}, error) { return public, nil })
+	require.NoError(t, err)
+	assert.True(t, parsed.Valid)
+}
+
+func TestJWTService_RejectsUnknownKeysAndAlgorithmMismatch(t *testing.T) {
+	svc := newTestJWTService(t)
+	other, err := newTestJWTService(t).GenerateToken(userpkg.TokenSubject{UserID: "user-1", Username: "jane", Role: "admin"})
+	require.NoError(t, err)
+
+	_, err = svc.ValidateToken(other.AccessToken, userpkg.TokenTypeAccess)
+	assert.Error(t, err, "token signed by a key this service doesn't know")
+
+	// An HS256 token naming a real kid must not be checked as if it were an RSA signature
+	own, err := svc.GenerateToken(userpkg.TokenSubject{UserID: "user-1", Username: "jane", Role: "user"})
+	require.NoError(t, err)
+	kid := signingKid(t, own.AccessToken)
+	forged := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{"_id": "user-1", "role": "admin", "exp": time.Now().Add(time.Minute).Unix()})
+	forged.Header["kid"] = kid
+	forgedString, err := forged.SignedString([]byte("guessable"))
+	require.NoError(t, err)
+
+	_, err = svc.ValidateToken(forgedString, userpkg.TokenTypeAccess)
+	assert.Error(t, err)
+}
+
+func TestJWTService_LegacySecret(t *testing.T) {
+	legacy := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{"_id": "user-1", "exp": time.Now().Add(time.Minute).Unix()})
+	legacyString, err := legacy.SignedString([]byte("old-secret"))
+	require.NoError(t, err)
+	ring := newTestKeyRing(t, &memorySigningKeyRepo{}, signingkeypkg.AlgorithmRS256)
+
+	claims, err := infrastructure.NewJWTService(ring, "old-secret").ValidateToken(legacyString, userpkg.TokenTypeRefresh)
+	require.NoError(t, err)
+	assert.Equal(t, "user-1", claims["_id"])
+	_, err = infrastructure.NewJWTService(ring, "old-secret").ValidateToken(legacyString, userpkg.TokenTypeAccess)
+	assert.Error(t, err, "tokens without a typ claim and without a username were refresh tokens")
+
+	_, err = infrastructure.NewJWTService(ring, "").ValidateToken(legacyString, userpkg.TokenTypeRefresh)
+	assert.Error(t, err)
+}
@@ -0,0 +1,147 @@
+package infrastructure
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+// CacheStats is a snapshot of cache counters.
+type CacheStats struct {
+	Hits      uint64 `json:"hits"`
+	Misses    uint64 `json:"misses"`
+	Evictions uint64 `json:"evictions"`
+	Entries   int    `json:"entries"`
+	Capacity  int    `json:"capacity"`
+}
+
+type lruEntry[K comparable, V any] struct {
+	key       K
+	value     V
+	expiresAt time.Time
+}
+
+// LRUCache is a concurrency-safe, size-bounded cache. Entries are evicted
+// least-recently-used first once capacity is reached, and expire after ttl.
+type LRUCache[K comparable, V any] struct {
+	mu       sync.Mutex
+	capacity int
+	ttl      time.Duration
+	ll       *list.List
+	items    map[K]*list.Element
+	stats    CacheStats
+}
+
+// NewLRUCache creates a cache holding at most capacity entries. A zero ttl
+// means entries only leave the cache through eviction or removal.
+func NewLRUCache[K comparable, V any](capacity int, ttl time.Duration) *LRUCache[K, V] {
+	if capacity <= 0 {
+		capacity = 1
+	}
+	return &LRUCache[K, V]{
+		capacity: capacity,
+		ttl:      ttl,
+		ll:       list.New(),
+		items:    make(map[K]*list.Element),
+	}
+}
+
+// Get returns the cached value and whether it was present and fresh.
+func (c *LRUCache[K, V]) Get(key K) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var zero V
+	el, ok := c.items[key]
+	if !ok {
+		c.stats.Misses++
+		return zero, false
+	}
+	entry := el.Value.(*lruEntry[K, V])
+	if c.ttl > 0 && time.Now().After(entry.expiresAt) {
+		c.removeElement(el)
+		c.stats.Misses++
+		return zero, false
+	}
+	c.ll.MoveToFront(el)
+	c.stats.Hits++
+	return entry.value, true
+}
+
+// Set inserts or replaces a value, evicting the least recently used entry if full.
+func (c *LRUCache[K, V]) Set(key K, value V) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var expiresAt time.Time
+	if c.ttl > 0 {
+		expiresAt = time.Now().Add(c.ttl)
+	}
+
+	if el, ok := c.items[key]; ok {
+		entry := el.Value.(*lruEntry[K, V])
+		entry.value = value
+		entry.expiresAt = expiresAt
+		c.ll.MoveToFront(el)
+		return
+	}
+
+	c.items[key] = c.ll.PushFront(&lruEntry[K, V]{key: key, value: value, expiresAt: expiresAt})
+	for c.ll.Len() > c.capacity {
+		c.removeElement(c.ll.Back())
+		c.stats.Evictions++
+	}
+}
+
+// Remove deletes a single key and reports whether it was present.
+func (c *LRUCache[K, V]) Remove(key K) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	el, ok := c.items[key]
+	if ok {
+		c.removeElement(el)
+	}
+	return ok
+}
+
+// RemoveFunc deletes every entry for which match returns true and reports how many were removed.
+func (c *LRUCache[K, V]) RemoveFunc(match func(key K, value V) bool) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	removed := 0
+	for el := c.ll.Front(); el != nil; {
+		next := el.Next()
+		entry := el.Value.(*lruEntry[K, V])
+		if match(entry.key, entry.value) {
+			c.removeElement(el)
+			removed++
+		}
+		el = next
+	}
+	return removed
+}
+
+// Len returns the number of entries, including ones that have expired but not yet been collected.
+func (c *LRUCache[K, V]) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.ll.Len()
+}
+
+// Stats returns a snapshot of the cache counters.
+func (c *LRUCache[K, V]) Stats() CacheStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	stats := c.stats
+	stats.Entries = c.ll.Len()
+	stats.Capacity = c.capacity
+	return stats
+}
+
+func (c *LRUCache[K, V]) removeElement(el *list.Element) {
+	c.ll.Remove(el)
+	delete(c.items, el.Value.(*lruEntry[K, V]).key)
+}
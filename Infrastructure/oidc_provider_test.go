@@ -0,0 +1,138 @@
+package infrastructure_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	infrastructure "github.com/Amaankaa/Blog-Starter-Project/Infrastructure"
+	"github.com/Amaankaa/Blog-Starter-Project/Infrastructure/oidctest"
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testRedirectURL = "http://blog.test/auth/oidc/stub/callback"
+
+func newTestOIDCProvider(stub *oidctest.Provider) *infrastructure.OIDCProvider {
+	return infrastructure.NewOIDCProvider(infrastructure.OIDCProviderConfig{
+		Name:         "stub",
+		Issuer:       stub.Issuer(),
+		ClientID:     stub.ClientID,
+		ClientSecret: stub.ClientSecret,
+		RedirectURL:  testRedirectURL,
+	}, nil)
+}
+
+// authorize follows the provider's authorization URL and returns the code sent to the callback
+func authorize(t *testing.T, p *infrastructure.OIDCProvider, state, nonce, verifier string) string {
+	t.Helper()
+	challenge := sha256.Sum256([]byte(verifier))
+	authURL, err := p.AuthCodeURL(context.Background(), state, nonce, base64.RawURLEncoding.EncodeToString(challenge[:]))
+	require.NoError(t, err)
+
+	client := &http.Client{CheckRedirect: func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse }}
+	resp, err := client.Get(authURL)
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, http.StatusFound, resp.StatusCode)
+
+	callback, err := url.Parse(resp.Header.Get("Location"))
+	require.NoError(t, err)
+	assert.Equal(t, state, callback.Query().Get("state"))
+	return callback.Query().Get("code")
+}
+
+func TestOIDCProvider_Exchange(t *testing.T) {
+	stub := oidctest.NewProvider(t, oidctest.User{Subject: "alice-1", Email: "alice@example.com", EmailVerified: true, Name: "Alice"})
+	p := newTestOIDCProvider(stub)
+
+	code := authorize(t, p, "state", "nonce", "verifier")
+	identity, err := p.Exchange(context.Background(), code, "verifier", "nonce")
+	require.NoError(t, err)
+	assert.Equal(t, "stub", identity.Provider)
+	assert.Equal(t, "alice-1", identity.Subject)
+	assert.Equal(t, "alice@example.com", identity.Email)
+	assert.True(t, identity.EmailVerified)
+	assert.Equal(t, "Alice", identity.Name)
+}
+
+func TestOIDCProvider_Exchange_WrongVerifier(t *testing.T) {
+	stub := oidctest.NewProvider(t, oidctest.User{Subject: "alice-1"})
+	p := newTestOIDCProvider(stub)
+
+	code := authorize(t, p, "state", "nonce", "verifier")
+	_, err := p.Exchange(context.Background(), code, "another-verifier", "nonce")
+	assert.Error(t, err)
+}
+
+func TestOIDCProvider_Exchange_RejectsBadIDTokens(t *testing.T) {
+	for name, tamper := range map[string]func(jwt.MapClaims){
+		"nonce":    func(c jwt.MapClaims) { c["nonce"] = "replayed" },
+		"audience": func(c jwt.MapClaims) { c["aud"] = "another-client" },
+		"issuer":   func(c jwt.MapClaims) { c["iss"] = "https://evil.example" },
+		"expired":  func(c jwt.MapClaims) { c["exp"] = 1 },
+		"subject":  func(c jwt.MapClaims) { delete(c, "sub") },
+	} {
+		t.Run(name, func(t *testing.T) {
+			stub := oidctest.NewProvider(t, oidctest.User{Subject: "alice-1", Email: "alice@example.com", EmailVerified: true})
+			stub.TamperClaims = tamper
+			p := newTestOIDCProvider(stub)
+
+			code := authorize(t, p, "state", "nonce", "verifier")
+			_, err := p.Exchange(context.Background(), code, "verifier", "nonce")
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestOIDCProvider_DiscoveryIssuerMismatch(t *testing.T) {
+	stub := oidctest.NewProvider(t, oidctest.User{Subject: "alice-1"})
+	p := infrastructure.NewOIDCProvider(infrastructure.OIDCProviderConfig{
+		Name:     "stub",
+		Issuer:   stub.Issuer() + "/",
+		ClientID: stub.ClientID,
+	}, nil)
+
+	_, err := p.AuthCodeURL(context.Background(), "s", "n", "c")
+	assert.Error(t, err)
+}
+
+func TestGitHubProvider_Exchange_UsesPrimaryEmail(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/login/oauth/access_token", func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		if r.PostForm.Get("code") != "good-code" {
+			w.Write([]byte(`{"error":"bad_verification_code"}`))
+			return
+		}
+		w.Write([]byte(`{"access_token":"gho_test"}`))
+	})
+	mux.HandleFunc("/user", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer gho_test", r.Header.Get("Authorization"))
+		w.Write([]byte(`{"id":42,"login":"octo","name":"Octo Cat"}`))
+	})
+	mux.HandleFunc("/user/emails", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`[{"email":"old@example.com","primary":false,"verified":true},{"email":"octo@example.com","primary":true,"verified":true}]`))
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	p := infrastructure.NewGitHubProvider("id", "secret", testRedirectURL)
+	p.TokenURL = server.URL + "/login/oauth/access_token"
+	p.APIURL = server.URL
+
+	identity, err := p.Exchange(context.Background(), "good-code", "verifier", "")
+	require.NoError(t, err)
+	assert.Equal(t, "42", identity.Subject)
+	assert.Equal(t, "octo", identity.Username)
+	assert.Equal(t, "octo@example.com", identity.Email)
+	assert.True(t, identity.EmailVerified)
+
+	_, err = p.Exchange(context.Background(), "bad-code", "verifier", "")
+	assert.Error(t, err)
+}
@@ -0,0 +1,214 @@
+// Package oidctest runs a minimal OpenID Connect provider on httptest for end-to-end tests
+// of the login flow. The authorization endpoint signs User in without asking.
+package oidctest
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+)
+
+const keyID = "oidctest-key"
+
+// User is who signs in at the provider
+type User struct {
+	Subject       string
+	Email         string
+	EmailVerified bool
+	Name          string
+}
+
+type Provider struct {
+	*httptest.Server
+	ClientID     string
+	ClientSecret string
+
+	// TamperClaims, if set, may change the id_token claims before they are signed
+	TamperClaims func(jwt.MapClaims)
+
+	mu    sync.Mutex
+	user  User
+	key   *rsa.PrivateKey
+	codes map[string]authRequest
+}
+
+type authRequest struct {
+	user          User
+	nonce         string
+	redirectURI   string
+	codeChallenge string
+}
+
+// NewProvider starts a provider that is shut down when the test ends
+func NewProvider(t testing.TB, user User) *Provider {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := &Provider{
+		ClientID:     "test-client",
+		ClientSecret: "test-secret",
+		user:         user,
+		key:          key,
+		codes:        map[string]authRequest{},
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", p.discovery)
+	mux.HandleFunc("/authorize", p.authorize)
+	mux.HandleFunc("/token", p.token)
+	mux.HandleFunc("/jwks", p.jwks)
+	p.Server = httptest.NewServer(mux)
+	t.Cleanup(p.Close)
+	return p
+}
+
+// Issuer is the provider's issuer identifier
+func (p *Provider) Issuer() string {
+	return p.URL
+}
+
+// SetUser changes who signs in next
+func (p *Provider) SetUser(user User) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.user = user
+}
+
+func (p *Provider) discovery(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"issuer":                                p.URL,
+		"authorization_endpoint":                p.URL + "/authorize",
+		"token_endpoint":                        p.URL + "/token",
+		"jwks_uri":                              p.URL + "/jwks",
+		"response_types_supported":              []string{"code"},
+		"id_token_signing_alg_values_supported": []string{"RS256"},
+		"code_challenge_methods_supported":      []string{"S256"},
+	})
+}
+
+func (p *Provider) authorize(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	redirectURI := q.Get("redirect_uri")
+	if q.Get("client_id") != p.ClientID || redirectURI == "" {
+		http.Error(w, "unknown client", http.StatusBadRequest)
+		return
+	}
+	if q.Get("response_type") != "code" || q.Get("code_challenge_method") != "S256" || q.Get("code_challenge") == "" {
+		http.Error(w, "unsupported request", http.StatusBadRequest)
+		return
+	}
+
+	code := randomString()
+	p.mu.Lock()
+	p.codes[code] = authRequest{
+		user:          p.user,
+		nonce:         q.Get("nonce"),
+		redirectURI:   redirectURI,
+		codeChallenge: q.Get("code_challenge"),
+	}
+	p.mu.Unlock()
+
+	callback, err := url.Parse(redirectURI)
+	if err != nil {
+		http.Error(w, "bad redirect_uri", http.StatusBadRequest)
+		return
+	}
+	params := callback.Query()
+	params.Set("code", code)
+	params.Set("state", q.Get("state"))
+	callback.RawQuery = params.Encode()
+	http.Redirect(w, r, callback.String(), http.StatusFound)
+}
+
+func (p *Provider) token(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost || r.ParseForm() != nil {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_request"})
+		return
+	}
+	if r.PostForm.Get("client_id") != p.ClientID || r.PostForm.Get("client_secret") != p.ClientSecret {
+		writeJSON(w, http.StatusUnauthorized, map[string]string{"error": "invalid_client"})
+		return
+	}
+
+	p.mu.Lock()
+	req, ok := p.codes[r.PostForm.Get("code")]
+	delete(p.codes, r.PostForm.Get("code"))
+	p.mu.Unlock()
+
+	verifier := sha256.Sum256([]byte(r.PostForm.Get("code_verifier")))
+	if !ok || r.PostForm.Get("grant_type") != "authorization_code" ||
+		r.PostForm.Get("redirect_uri") != req.redirectURI ||
+		base64.RawURLEncoding.EncodeToString(verifier[:]) != req.codeChallenge {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_grant"})
+		return
+	}
+
+	now := time.Now()
+	claims := jwt.MapClaims{
+		"iss":            p.URL,
+		"sub":            req.user.Subject,
+		"aud":            p.ClientID,
+		"iat":            now.Unix(),
+		"exp":            now.Add(5 * time.Minute).Unix(),
+		"nonce":          req.nonce,
+		"email":          req.user.Email,
+		"email_verified": req.user.EmailVerified,
+		"name":           req.user.Name,
+	}
+	if p.TamperClaims != nil {
+		p.TamperClaims(claims)
+	}
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = keyID
+	idToken, err := token.SignedString(p.key)
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "server_error"})
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"access_token": randomString(),
+		"token_type":   "Bearer",
+		"expires_in":   300,
+		"id_token":     idToken,
+	})
+}
+
+func (p *Provider) jwks(w http.ResponseWriter, r *http.Request) {
+	pub := p.key.PublicKey
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"keys": []map[string]string{{
+			"kty": "RSA",
+			"kid": keyID,
+			"use": "sig",
+			"alg": "RS256",
+			"n":   base64.RawURLEncoding.EncodeToString(pub.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(pub.E)).Bytes()),
+		}},
+	})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func randomString() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
@@ -1,23 +1,114 @@
 package infrastructure
 
 import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
 	"golang.org/x/crypto/bcrypt"
 )
 
-type PasswordService struct{}
+var (
+	ErrPasswordMismatch  = errors.New("password does not match")
+	ErrUnknownHashFormat = errors.New("unknown password hash format")
+)
+
+// Argon2Params are the argon2id settings for new hashes. Every hash records the settings it
+// was made with, so they can be raised at any time: older hashes still verify, and are
+// upgraded at the user's next login.
+type Argon2Params struct {
+	Memory      uint32 // KiB
+	Iterations  uint32
+	Parallelism uint8
+	SaltLength  uint32
+	KeyLength   uint32
+}
+
+// DefaultArgon2Params are OWASP's recommended minimum for argon2id
+var DefaultArgon2Params = Argon2Params{Memory: 19 * 1024, Iterations: 2, Parallelism: 1, SaltLength: 16, KeyLength: 32}
+
+var argon2Encoding = base64.RawStdEncoding
+
+// PasswordService hashes with argon2id, in the PHC string format
+// "$argon2id$v=19$m=<KiB>,t=<iterations>,p=<parallelism>$<salt>$<hash>". It still accepts
+// the bcrypt hashes ("$2a$", "$2b$", "$2y$") of accounts created before the switch.
+type PasswordService struct {
+	params Argon2Params
+}
 
-func NewPasswordService() *PasswordService {
-	return &PasswordService{}
+func NewPasswordService(params Argon2Params) *PasswordService {
+	return &PasswordService{params: params}
 }
 
 func (ps *PasswordService) HashPassword(password string) (string, error) {
-	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
-	if err != nil {
+	salt := make([]byte, ps.params.SaltLength)
+	if _, err := rand.Read(salt); err != nil {
 		return "", err
 	}
-	return string(hashedPassword), nil
+	key := argon2.IDKey([]byte(password), salt, ps.params.Iterations, ps.params.Memory, ps.params.Parallelism, ps.params.KeyLength)
+	return fmt.Sprintf("$argon2id$v=%d$m=%d,t=%d,p=%d$%s$%s",
+		argon2.Version, ps.params.Memory, ps.params.Iterations, ps.params.Parallelism,
+		argon2Encoding.EncodeToString(salt), argon2Encoding.EncodeToString(key)), nil
 }
 
+// ComparePassword returns ErrPasswordMismatch for a wrong password
 func (ps *PasswordService) ComparePassword(hashedPassword, password string) error {
-	return bcrypt.CompareHashAndPassword([]byte(hashedPassword), []byte(password))
-}
\ No newline at end of file
+	if isBcryptHash(hashedPassword) {
+		err := bcrypt.CompareHashAndPassword([]byte(hashedPassword), []byte(password))
+		if errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) {
+			return ErrPasswordMismatch
+		}
+		return err
+	}
+
+	params, salt, key, err := parseArgon2Hash(hashedPassword)
+	if err != nil {
+		return err
+	}
+	candidate := argon2.IDKey([]byte(password), salt, params.Iterations, params.Memory, params.Parallelism, params.KeyLength)
+	if subtle.ConstantTimeCompare(candidate, key) != 1 {
+		return ErrPasswordMismatch
+	}
+	return nil
+}
+
+// NeedsRehash reports whether hashedPassword was made with another algorithm or other
+// settings than new hashes get
+func (ps *PasswordService) NeedsRehash(hashedPassword string) bool {
+	params, _, _, err := parseArgon2Hash(hashedPassword)
+	return err != nil || params != ps.params
+}
+
+func isBcryptHash(hash string) bool {
+	return strings.HasPrefix(hash, "$2a$") || strings.HasPrefix(hash, "$2b$") || strings.HasPrefix(hash, "$2y$")
+}
+
+func parseArgon2Hash(hash string) (Argon2Params, []byte, []byte, error) {
+	// "", "argon2id", "v=19", "m=...,t=...,p=...", salt, key
+	parts := strings.Split(hash, "$")
+	if len(parts) != 6 || parts[0] != "" || parts[1] != "argon2id" {
+		return Argon2Params{}, nil, nil, ErrUnknownHashFormat
+	}
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil || version != argon2.Version {
+		return Argon2Params{}, nil, nil, ErrUnknownHashFormat
+	}
+	var params Argon2Params
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &params.Memory, &params.Iterations, &params.Parallelism); err != nil {
+		return Argon2Params{}, nil, nil, ErrUnknownHashFormat
+	}
+	salt, err := argon2Encoding.DecodeString(parts[4])
+	if err != nil {
+		return Argon2Params{}, nil, nil, ErrUnknownHashFormat
+	}
+	key, err := argon2Encoding.DecodeString(parts[5])
+	if err != nil || len(key) == 0 || params.Iterations == 0 || params.Parallelism == 0 {
+		return Argon2Params{}, nil, nil, ErrUnknownHashFormat
+	}
+	params.SaltLength, params.KeyLength = uint32(len(salt)), uint32(len(key))
+	return params, salt, key, nil
+}
@@ -0,0 +1,78 @@
+package infrastructure_test
+
+import (
+	"strings"
+	"testing"
+
+	infrastructure "github.com/Amaankaa/Blog-Starter-Project/Infrastructure"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// testArgon2Params keep the tests fast; the format and checks don't depend on the cost
+var testArgon2Params = infrastructure.Argon2Params{Memory: 64, Iterations: 1, Parallelism: 1, SaltLength: 16, KeyLength: 32}
+
+func TestPasswordService_HashesWithArgon2id(t *testing.T) {
+	svc := infrastructure.NewPasswordService(testArgon2Params)
+
+	hash, err := svc.HashPassword("correct horse")
+	require.NoError(t, err)
+
+	assert.True(t, strings.HasPrefix(hash, "$argon2id$v=19$m=64,t=1,p=1$"), hash)
+	assert.NoError(t, svc.ComparePassword(hash, "correct horse"))
+	assert.ErrorIs(t, svc.ComparePassword(hash, "wrong horse"), infrastructure.ErrPasswordMismatch)
+	assert.False(t, svc.NeedsRehash(hash))
+
+	again, err := svc.HashPassword("correct horse")
+	require.NoError(t, err)
+	assert.NotEqual(t, hash, again, "every hash gets its own salt")
+}
+
+func TestPasswordService_NoTruncation(t *testing.T) {
+	svc := infrastructure.NewPasswordService(testArgon2Params)
+	long := strings.Repeat("a", 72)
+
+	hash, err := svc.HashPassword(long + "1")
+	require.NoError(t, err)
+
+	assert.ErrorIs(t, svc.ComparePassword(hash, long+"2"), infrastructure.ErrPasswordMismatch,
+		"bcrypt ignored everything after 72 bytes")
+}
+
+func TestPasswordService_AcceptsBcryptHashes(t *testing.T) {
+	svc := infrastructure.NewPasswordService(testArgon2Params)
+	legacy, err := bcrypt.GenerateFromPassword([]byte("correct horse"), bcrypt.MinCost)
+	require.NoError(t, err)
+
+	assert.NoError(t, svc.ComparePassword(string(legacy), "correct horse"))
+	assert.ErrorIs(t, svc.ComparePassword(string(legacy), "wrong horse"), infrastructure.ErrPasswordMismatch)
+	assert.True(t, svc.NeedsRehash(string(legacy)))
+}
+
+func TestPasswordService_RehashesWhenTheSettingsChange(t *testing.T) {
+	hash, err := infrastructure.NewPasswordService(testArgon2Params).HashPassword("correct horse")
+	require.NoError(t, err)
+
+	stronger := testArgon2Params
+	stronger.Iterations = 2
+	svc := infrastructure.NewPasswordService(stronger)
+
+	assert.NoError(t, svc.ComparePassword(hash, "correct horse"), "old settings are read from the hash")
+	assert.True(t, svc.NeedsRehash(hash))
+}
+
+func TestPasswordService_RejectsUnknownFormats(t *testing.T) {
+	svc := infrastructure.NewPasswordService(testArgon2Params)
+	for _, hash := range []string{
+		"",
+		"plaintext",
+		"$argon2i$v=19$m=64,t=1,p=1$c2FsdHNhbHQ$aGFzaGhhc2g",
+		"$argon2id$v=16$m=64,t=1,p=1$c2FsdHNhbHQ$aGFzaGhhc2g",
+		"$argon2id$v=19$m=64,t=0,p=1$c2FsdHNhbHQ$aGFzaGhhc2g",
+		"$argon2id$v=19$m=64,t=1,p=1$c2FsdHNhbHQ$",
+	} {
+		assert.ErrorIs(t, svc.ComparePassword(hash, "anything"), infrastructure.ErrUnknownHashFormat, hash)
+		assert.True(t, svc.NeedsRehash(hash), hash)
+	}
+}
@@ -0,0 +1,558 @@
+package infrastructure
+
+import (
+	"bufio"
+	_ "embed"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	passwordpkg "github.com/Amaankaa/Blog-Starter-Project/Domain/password"
+)
+
+//go:embed data/common_passwords.txt
+var bundledCommonPasswords string
+
+// The constants of zxcvbn's guess model
+const (
+	bruteforceCardinality = 10
+	// Splitting a password into one more part costs at least this many guesses, so an attacker
+	// gains nothing from a decomposition into many tiny patterns
+	minGuessesBeforeGrowingSequence = 10000
+	minSubmatchGuessesSingleChar    = 10
+	minSubmatchGuessesMultiChar     = 50
+	minYearSpace                    = 20
+	// keyboardStartingPositions and keyboardAverageDegree describe a QWERTY layout
+	keyboardStartingPositions = 94
+	keyboardAverageDegree     = 4.6
+)
+
+var (
+	// l33tTables undo common substitutions; "1" and "|" stand for either "i" or "l"
+	l33tTables = []map[rune]rune{
+		{'4': 'a', '@': 'a', '8': 'b', '(': 'c', '{': 'c', '[': 'c', '<': 'c', '3': 'e', '6': 'g', '9': 'g',
+			'1': 'i', '!': 'i', '|': 'i', '0': 'o', '$': 's', '5': 's', '+': 't', '7': 't', '%': 'x', '2': 'z'},
+		{'4': 'a', '@': 'a', '3': 'e', '1': 'l', '|': 'l', '0': 'o', '$': 's', '5': 's', '7': 't'},
+	}
+	keyboardRows = []string{"`1234567890-=", "qwertyuiop[]\\", "asdfghjkl;'", "zxcvbnm,./"}
+	// keyboardShifts maps shifted characters to the key they are typed with
+	keyboardShifts  = map[rune]rune{}
+	keyboardKeys    = map[rune][2]int{} // key -> row, column
+	separatedDateRe = regexp.MustCompile(`(\d{1,4})([\s/\\_.-])(\d{1,2})([\s/\\_.-])(\d{1,4})`)
+)
+
+func init() {
+	for row, keys := range keyboardRows {
+		for col, key := range keys {
+			keyboardKeys[key] = [2]int{row, col}
+		}
+	}
+	unshifted, shifted := "`1234567890-=[]\\;',./", "~!@#$%^&*()_+{}|:\"<>?"
+	for i, r := range []rune(shifted) {
+		keyboardShifts[r] = []rune(unshifted)[i]
+	}
+}
+
+// passwordMatch is a guessable part of a password: runes i to j, inclusive
+type passwordMatch struct {
+	i, j    int
+	guesses float64
+	warning string
+}
+
+// StrengthEstimator follows zxcvbn: it finds every part of a password that matches a pattern
+// an attacker would try (common passwords, the user's own details, keyboard rows, sequences,
+// repeats and dates), then looks for the split into parts and unmatched characters that takes
+// the fewest guesses
+type StrengthEstimator struct {
+	ranked  map[string]int // common password -> rank, 1 being the most common
+	longest int
+}
+
+// NewStrengthEstimator loads the bundled list of common passwords, one per line, most common
+// first; "#" starts a comment
+func NewStrengthEstimator() *StrengthEstimator {
+	e := &StrengthEstimator{ranked: make(map[string]int)}
+	scanner := bufio.NewScanner(strings.NewReader(bundledCommonPasswords))
+	for scanner.Scan() {
+		line := strings.ToLower(strings.TrimSpace(scanner.Text()))
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if _, ok := e.ranked[line]; !ok {
+			e.ranked[line] = len(e.ranked) + 1
+			e.longest = max(e.longest, len([]rune(line)))
+		}
+	}
+	return e
+}
+
+func (e *StrengthEstimator) Estimate(password string, userInputs []string) passwordpkg.Strength {
+	est := &estimation{
+		StrengthEstimator: e,
+		userInputs:        make(map[string]int),
+		memo:              make(map[string]float64),
+		year:              time.Now().Year(),
+	}
+	for _, input := range userInputs {
+		input = strings.ToLower(strings.TrimSpace(input))
+		if _, ok := est.userInputs[input]; input != "" && !ok {
+			est.userInputs[input] = len(est.userInputs) + 1
+		}
+	}
+
+	guesses, sequence := est.mostGuessable([]rune(password))
+	strength := passwordpkg.Strength{Guesses: guesses, Score: guessesToScore(guesses)}
+	// The longest pattern says most about why the password is weak
+	longest := -1
+	for _, m := range sequence {
+		if m.warning != "" && m.j-m.i > longest {
+			strength.Warning, longest = m.warning, m.j-m.i
+		}
+	}
+	return strength
+}
+
+// estimation holds the state of one Estimate call
+type estimation struct {
+	*StrengthEstimator
+	userInputs map[string]int
+	memo       map[string]float64 // guesses of the bases of repeats
+	year       int
+}
+
+// mostGuessable returns the fewest guesses needed for password, and the matches and
+// bruteforced runs it splits into
+func (est *estimation) mostGuessable(password []rune) (float64, []passwordMatch) {
+	n := len(password)
+	if n == 0 {
+		return 1, nil
+	}
+	byEnd := make([][]passwordMatch, n)
+	for _, m := range est.matches(password) {
+		byEnd[m.j] = append(byEnd[m.j], m)
+	}
+
+	// best[k][l] is the lowest product of the guesses of l parts covering password[:k+1]
+	type cell struct {
+		product float64
+		match   passwordMatch
+		ok      bool
+	}
+	best := make([][]cell, n)
+	for k := range best {
+		best[k] = make([]cell, n+1)
+		candidates := append([]passwordMatch(nil), byEnd[k]...)
+		for i := 0; i <= k; i++ {
+			candidates = append(candidates, passwordMatch{i: i, j: k, guesses: math.Pow(bruteforceCardinality, float64(k-i+1))})
+		}
+		for _, m := range candidates {
+			guesses := m.guesses
+			if m.j-m.i+1 < n {
+				floor := float64(minSubmatchGuessesMultiChar)
+				if m.i == m.j {
+					floor = minSubmatchGuessesSingleChar
+				}
+				guesses = math.Max(guesses, floor)
+			}
+			update := func(l int, product float64) {
+				if c := best[k][l]; !c.ok || product < c.product {
+					best[k][l] = cell{product: product, match: m, ok: true}
+				}
+			}
+			if m.i == 0 {
+				update(1, guesses)
+				continue
+			}
+			for l, c := range best[m.i-1] {
+				if c.ok && l < n {
+					update(l+1, c.product*guesses)
+				}
+			}
+		}
+	}
+
+	// An attacker also has to guess how many parts there are and in which order
+	guesses, parts := math.Inf(1), 0
+	for l, c := range best[n-1] {
+		if !c.ok {
+			continue
+		}
+		total := factorial(l)*c.product + math.Pow(minGuessesBeforeGrowingSequence, float64(l-1))
+		if total < guesses {
+			guesses, parts = total, l
+		}
+	}
+	sequence := make([]passwordMatch, parts)
+	for k, l := n-1, parts; l > 0; l-- {
+		sequence[l-1] = best[k][l].match
+		k = best[k][l].match.i - 1
+	}
+	return guesses, sequence
+}
+
+func (est *estimation) matches(password []rune) []passwordMatch {
+	var matches []passwordMatch
+	matches = append(matches, est.dictionaryMatches(password)...)
+	matches = append(matches, est.repeatMatches(password)...)
+	matches = append(matches, sequenceMatches(password)...)
+	matches = append(matches, keyboardMatches(password)...)
+	matches = append(matches, est.dateMatches(password)...)
+	return matches
+}
+
+// dictionaryMatches finds common passwords and user inputs, also reversed or in l33t speak
+func (est *estimation) dictionaryMatches(password []rune) []passwordMatch {
+	longest := est.longest
+	for input := range est.userInputs {
+		longest = max(longest, len([]rune(input)))
+	}
+	lower := make([]rune, len(password))
+	for i, r := range password {
+		lower[i] = unicode.ToLower(r)
+	}
+
+	var matches []passwordMatch
+	for i := range lower {
+		for j := i + 2; j < len(lower) && j-i < longest; j++ {
+			token := lower[i : j+1]
+			variations := uppercaseVariations(password[i : j+1])
+			add := func(word string, multiplier float64) {
+				if rank, warning, ok := est.lookup(word, j-i+1 == len(password)); ok {
+					matches = append(matches, passwordMatch{i: i, j: j, guesses: float64(rank) * variations * multiplier, warning: warning})
+				}
+			}
+			add(string(token), 1)
+			if reversed := reverseRunes(token); reversed != string(token) {
+				add(reversed, 2)
+			}
+			for _, table := range l33tTables {
+				if word, subs := unl33t(token, table); subs > 0 {
+					add(word, math.Pow(2, float64(subs)))
+				}
+			}
+		}
+	}
+	return matches
+}
+
+func (est *estimation) lookup(word string, whole bool) (int, string, bool) {
+	if rank, ok := est.userInputs[word]; ok {
+		return rank, "it contains details about you, like your name", true
+	}
+	rank, ok := est.ranked[word]
+	if !ok {
+		return 0, "", false
+	}
+	switch {
+	case !whole:
+		return rank, "it is similar to a commonly used password", true
+	case rank <= 10:
+		return rank, "this is a top-10 common password", true
+	case rank <= 100:
+		return rank, "this is a top-100 common password", true
+	}
+	return rank, "this is a very common password", true
+}
+
+// repeatMatches finds the longest repeat starting at each position, like "aaa" or "abcabc"
+func (est *estimation) repeatMatches(password []rune) []passwordMatch {
+	var matches []passwordMatch
+	for i := range password {
+		bestBase, bestCount := 0, 0
+		for base := 1; i+2*base <= len(password); base++ {
+			count := 1
+			for i+(count+1)*base <= len(password) && string(password[i+count*base:i+(count+1)*base]) == string(password[i:i+base]) {
+				count++
+			}
+			if count > 1 && base*count > bestBase*bestCount {
+				bestBase, bestCount = base, count
+			}
+		}
+		if bestCount == 0 {
+			continue
+		}
+		base := string(password[i : i+bestBase])
+		guesses, ok := est.memo[base]
+		if !ok {
+			guesses, _ = est.mostGuessable(password[i : i+bestBase])
+			est.memo[base] = guesses
+		}
+		warning := `repeats like "abcabcabc" are only slightly harder to guess than "abc"`
+		if bestBase == 1 {
+			warning = `repeats like "aaa" are easy to guess`
+		}
+		matches = append(matches, passwordMatch{i: i, j: i + bestBase*bestCount - 1, guesses: guesses * float64(bestCount), warning: warning})
+	}
+	return matches
+}
+
+// sequenceMatches finds runs of letters or digits with a constant step, like "abc" or "8642"
+func sequenceMatches(password []rune) []passwordMatch {
+	var matches []passwordMatch
+	for i := 0; i+2 < len(password); {
+		delta := password[i+1] - password[i]
+		class := charClass(password[i])
+		j := i + 1
+		for class != 0 && delta != 0 && delta >= -5 && delta <= 5 && j < len(password) &&
+			charClass(password[j]) == class && password[j]-password[j-1] == delta {
+			j++
+		}
+		if j-i < 3 {
+			i++
+			continue
+		}
+		base := 26.0
+		switch {
+		case strings.ContainsRune("aAzZ019", password[i]):
+			base = 4 // obvious starts
+		case class == 'd':
+			base = 10
+		}
+		if delta < 0 {
+			base *= 2
+		}
+		matches = append(matches, passwordMatch{i: i, j: j - 1, guesses: base * float64(j-i), warning: "sequences like abc or 6543 are easy to guess"})
+		i = j - 1
+	}
+	return matches
+}
+
+func charClass(r rune) rune {
+	switch {
+	case r >= 'a' && r <= 'z':
+		return 'l'
+	case r >= 'A' && r <= 'Z':
+		return 'u'
+	case r >= '0' && r <= '9':
+		return 'd'
+	}
+	return 0
+}
+
+// keyboardMatches finds straight runs along a row of a QWERTY keyboard, like "asdf" or "!@#$"
+func keyboardMatches(password []rune) []passwordMatch {
+	keys := make([]rune, len(password))
+	shifted := make([]bool, len(password))
+	for i, r := range password {
+		if unshifted, ok := keyboardShifts[r]; ok {
+			keys[i], shifted[i] = unshifted, true
+		} else {
+			keys[i], shifted[i] = unicode.ToLower(r), unicode.IsUpper(r)
+		}
+	}
+
+	var matches []passwordMatch
+	for i := 0; i+2 < len(keys); {
+		first, ok := keyboardKeys[keys[i]]
+		second, ok2 := keyboardKeys[keys[i+1]]
+		direction := second[1] - first[1]
+		if !ok || !ok2 || first[0] != second[0] || (direction != 1 && direction != -1) {
+			i++
+			continue
+		}
+		j := i + 2
+		for j < len(keys) {
+			next, ok := keyboardKeys[keys[j]]
+			prev := keyboardKeys[keys[j-1]]
+			if !ok || next[0] != prev[0] || next[1]-prev[1] != direction {
+				break
+			}
+			j++
+		}
+		if j-i < 3 {
+			i++
+			continue
+		}
+		guesses := float64(j-i-1) * keyboardStartingPositions * keyboardAverageDegree
+		for _, s := range shifted[i:j] {
+			if s {
+				guesses *= 2
+				break
+			}
+		}
+		matches = append(matches, passwordMatch{i: i, j: j - 1, guesses: guesses, warning: "straight rows of keys are easy to guess"})
+		i = j - 1
+	}
+	return matches
+}
+
+// dateMatches finds years and dates, written with separators ("1/2/1990") or without
+// ("19900102", "010290")
+func (est *estimation) dateMatches(password []rune) []passwordMatch {
+	var matches []passwordMatch
+	for start := 0; start < len(password); {
+		if password[start] < '0' || password[start] > '9' {
+			start++
+			continue
+		}
+		end := start
+		for end < len(password) && password[end] >= '0' && password[end] <= '9' {
+			end++
+		}
+		for i := start; i < end; i++ {
+			if i+4 <= end {
+				if year, _ := strconv.Atoi(string(password[i : i+4])); year >= 1900 && year <= 2099 {
+					matches = append(matches, passwordMatch{i: i, j: i + 3, guesses: est.yearSpace(year), warning: "recent years are easy to guess"})
+				}
+			}
+			for _, length := range []int{6, 8} {
+				if i+length > end {
+					continue
+				}
+				if year, ok := compactDateYear(string(password[i : i+length])); ok {
+					matches = append(matches, passwordMatch{i: i, j: i + length - 1, guesses: 365 * est.yearSpace(year), warning: "dates are often easy to guess"})
+				}
+			}
+		}
+		start = end
+	}
+
+	text := string(password)
+	for _, loc := range separatedDateRe.FindAllStringSubmatchIndex(text, -1) {
+		if text[loc[4]:loc[5]] != text[loc[8]:loc[9]] {
+			continue
+		}
+		year, ok := dateYear(text[loc[2]:loc[3]], text[loc[6]:loc[7]], text[loc[10]:loc[11]])
+		if !ok {
+			continue
+		}
+		// Offsets are in bytes; the password may have multi-byte characters before the date
+		i := len([]rune(text[:loc[0]]))
+		j := i + len([]rune(text[loc[0]:loc[1]])) - 1
+		matches = append(matches, passwordMatch{i: i, j: j, guesses: 365 * 4 * est.yearSpace(year), warning: "dates are often easy to guess"})
+	}
+	return matches
+}
+
+func (est *estimation) yearSpace(year int) float64 {
+	return math.Max(math.Abs(float64(year-est.year)), minYearSpace)
+}
+
+// compactDateYear reports whether digits read as a date without separators, and its year
+func compactDateYear(digits string) (int, bool) {
+	splits := [][3]int{{2, 2, 2}} // six digits: ddmmyy, mmddyy or yymmdd
+	if len(digits) == 8 {
+		splits = [][3]int{{2, 2, 4}, {4, 2, 2}}
+	}
+	for _, split := range splits {
+		a, b := digits[:split[0]], digits[split[0]:split[0]+split[1]]
+		c := digits[split[0]+split[1]:]
+		if year, ok := dateYear(a, b, c); ok {
+			return year, true
+		}
+	}
+	return 0, false
+}
+
+// dateYear tries the parts as day-month-year, month-day-year and year-month-day
+func dateYear(first, second, third string) (int, bool) {
+	a, _ := strconv.Atoi(first)
+	b, _ := strconv.Atoi(second)
+	c, _ := strconv.Atoi(third)
+	candidates := [][3]int{{a, b, c}, {b, a, c}, {c, b, a}} // day, month, year
+	yearDigits := []int{len(third), len(third), len(first)}
+	for k, candidate := range candidates {
+		day, month, year := candidate[0], candidate[1], candidate[2]
+		switch yearDigits[k] {
+		case 2:
+			if year > 50 {
+				year += 1900
+			} else {
+				year += 2000
+			}
+		case 4:
+		default:
+			continue
+		}
+		if day >= 1 && day <= 31 && month >= 1 && month <= 12 && year >= 1900 && year <= 2099 {
+			return year, true
+		}
+	}
+	return 0, false
+}
+
+// uppercaseVariations is how many ways the capitals of a word could have been placed; only
+// capitalizing the first or last letter, or every letter, barely helps
+func uppercaseVariations(word []rune) float64 {
+	upper, lower := 0, 0
+	for _, r := range word {
+		switch {
+		case unicode.IsUpper(r):
+			upper++
+		case unicode.IsLower(r):
+			lower++
+		}
+	}
+	switch {
+	case upper == 0:
+		return 1
+	case lower == 0,
+		upper == 1 && unicode.IsUpper(word[0]),
+		upper == 1 && unicode.IsUpper(word[len(word)-1]):
+		return 2
+	}
+	variations := 0.0
+	for i := 1; i <= min(upper, lower); i++ {
+		variations += binomial(upper+lower, i)
+	}
+	return variations
+}
+
+func unl33t(word []rune, table map[rune]rune) (string, int) {
+	out := make([]rune, len(word))
+	subs := 0
+	for i, r := range word {
+		if letter, ok := table[r]; ok {
+			out[i] = letter
+			subs++
+		} else {
+			out[i] = r
+		}
+	}
+	return string(out), subs
+}
+
+func reverseRunes(word []rune) string {
+	out := make([]rune, len(word))
+	for i, r := range word {
+		out[len(word)-1-i] = r
+	}
+	return string(out)
+}
+
+func binomial(n, k int) float64 {
+	result := 1.0
+	for i := 1; i <= k; i++ {
+		result = result * float64(n-k+i) / float64(i)
+	}
+	return result
+}
+
+func factorial(n int) float64 {
+	result := 1.0
+	for i := 2; i <= n; i++ {
+		result *= float64(i)
+	}
+	return result
+}
+
+// guessesToScore uses zxcvbn's thresholds, with a small margin so that an exact power of ten
+// counts as the lower score
+func guessesToScore(guesses float64) int {
+	const delta = 5
+	switch {
+	case guesses < 1e3+delta:
+		return 0
+	case guesses < 1e6+delta:
+		return 1
+	case guesses < 1e8+delta:
+		return 2
+	case guesses < 1e10+delta:
+		return 3
+	}
+	return 4
+}
@@ -0,0 +1,50 @@
+package infrastructure_test
+
+import (
+	"strings"
+	"testing"
+
+	infrastructure "github.com/Amaankaa/Blog-Starter-Project/Infrastructure"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStrengthEstimator_WeakPatterns(t *testing.T) {
+	estimator := infrastructure.NewStrengthEstimator()
+	for password, warning := range map[string]string{
+		"password":      "this is a top-10 common password",
+		"Password1!":    "this is a very common password",
+		"P@ssw0rd":      "this is a top-10 common password",
+		"drowssap":      "this is a top-10 common password",
+		"abcdefgh":      "sequences like abc or 6543 are easy to guess",
+		"zxcvbnm,./":    "straight rows of keys are easy to guess",
+		"aaaaaaaaaaaa":  `repeats like "aaa" are easy to guess`,
+		"19900102":      "dates are often easy to guess",
+		"01/02/1990":    "dates are often easy to guess",
+		"janesmith1990": "it contains details about you, like your name",
+	} {
+		strength := estimator.Estimate(password, []string{"janesmith", "jane", "smith"})
+		assert.Less(t, strength.Score, 3, password)
+		assert.Equal(t, warning, strength.Warning, password)
+	}
+}
+
+func TestStrengthEstimator_StrongPasswords(t *testing.T) {
+	estimator := infrastructure.NewStrengthEstimator()
+	for _, password := range []string{"k7#Qv9!zLp2@Wm", "correcthorsebatterystaple", "mauve-Otter-42-kettle"} {
+		strength := estimator.Estimate(password, nil)
+		assert.GreaterOrEqual(t, strength.Score, 3, password)
+	}
+}
+
+func TestStrengthEstimator_CommonPasswordsAreWeakInAnyCase(t *testing.T) {
+	estimator := infrastructure.NewStrengthEstimator()
+	weak := estimator.Estimate("qwerty", nil)
+	shouted := estimator.Estimate("QWERTY", nil)
+	assert.Equal(t, 0, shouted.Score)
+	assert.Equal(t, 2*(weak.Guesses-1), shouted.Guesses-1, "capitalizing every letter only doubles the guesses")
+}
+
+func TestStrengthEstimator_LongInputStaysCheap(t *testing.T) {
+	strength := infrastructure.NewStrengthEstimator().Estimate(strings.Repeat("ab", 64), nil)
+	assert.Less(t, strength.Score, 3)
+}
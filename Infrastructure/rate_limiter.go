@@ -1,19 +1,99 @@
 package infrastructure
 
 import (
+	"context"
+	"log"
+	"math"
 	"net/http"
-	// "time"
+	"strconv"
+	"sync"
+	"time"
 
+	ratelimitpkg "github.com/Amaankaa/Blog-Starter-Project/Domain/ratelimit"
 	"github.com/gin-gonic/gin"
-	"golang.org/x/time/rate"
 )
 
-// rateLimitMiddleware is a simple IP-based rate limiter for Gin.
-func RateLimitMiddleware(limit rate.Limit, burst int) gin.HandlerFunc {
-	limiter := rate.NewLimiter(limit, burst)
+// DefaultRateLimitKeys bounds the clients an in-memory store keeps counters for
+const DefaultRateLimitKeys = 100000
 
+type tokenBucket struct {
+	tokens  float64
+	updated time.Time
+}
+
+// MemoryRateLimitStore keeps a token bucket per client in this instance only. Clients idle
+// for long lose their bucket first once the store is full, which costs them nothing: an
+// idle bucket has refilled anyway.
+type MemoryRateLimitStore struct {
+	mu      sync.Mutex
+	buckets *LRUCache[string, tokenBucket]
+}
+
+func NewMemoryRateLimitStore(capacity int) *MemoryRateLimitStore {
+	return &MemoryRateLimitStore{buckets: NewLRUCache[string, tokenBucket](capacity, 0)}
+}
+
+func (s *MemoryRateLimitStore) Take(_ context.Context, key string, policy ratelimitpkg.Policy, now time.Time) (ratelimitpkg.Decision, error) {
+	capacity := float64(policy.Limit)
+	perSecond := capacity / policy.Period.Seconds()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	bucket, ok := s.buckets.Get(key)
+	if !ok {
+		bucket = tokenBucket{tokens: capacity, updated: now}
+	}
+	if elapsed := now.Sub(bucket.updated).Seconds(); elapsed > 0 {
+		bucket.tokens = math.Min(capacity, bucket.tokens+elapsed*perSecond)
+		bucket.updated = now
+	}
+
+	decision := ratelimitpkg.Decision{Allowed: bucket.tokens >= 1}
+	if decision.Allowed {
+		bucket.tokens--
+	} else {
+		decision.RetryAfter = secondsToDuration((1 - bucket.tokens) / perSecond)
+	}
+	decision.Remaining = int(bucket.tokens)
+	decision.ResetAfter = secondsToDuration((capacity - bucket.tokens) / perSecond)
+	s.buckets.Set(key, bucket)
+	return decision, nil
+}
+
+func secondsToDuration(s float64) time.Duration {
+	return time.Duration(s * float64(time.Second))
+}
+
+// RateLimiter limits each client separately: by signed-in user, whether through a session or a
+// personal access token, or else by IP address. Routes that need the user have to run it after
+// AuthMiddleware.
+type RateLimiter struct {
+	store ratelimitpkg.IRateLimitStore
+}
+
+func NewRateLimiter(store ratelimitpkg.IRateLimitStore) *RateLimiter {
+	return &RateLimiter{store: store}
+}
+
+// Limit applies policy to the route. Every response carries the RateLimit-* headers of the
+// draft IETF standard; rejected ones also get Retry-After.
+func (rl *RateLimiter) Limit(policy ratelimitpkg.Policy) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		if !limiter.Allow() {
+		decision, err := rl.store.Take(c.Request.Context(), policy.Name+":"+clientKey(c), policy, time.Now())
+		if err != nil {
+			// An unavailable store shouldn't take the API down with it
+			log.Printf("rate limit: %v", err)
+			c.Next()
+			return
+		}
+
+		c.Header("RateLimit-Policy", policy.String())
+		c.Header("RateLimit-Limit", strconv.Itoa(policy.Limit))
+		c.Header("RateLimit-Remaining", strconv.Itoa(decision.Remaining))
+		c.Header("RateLimit-Reset", ceilSeconds(decision.ResetAfter))
+		if !decision.Allowed {
+			c.Header("Retry-After", ceilSeconds(decision.RetryAfter))
 			c.AbortWithStatusJSON(http.StatusTooManyRequests, gin.H{"error": "Too many requests. Please try again later."})
 			return
 		}
@@ -21,11 +101,15 @@ func RateLimitMiddleware(limit rate.Limit, burst int) gin.HandlerFunc {
 	}
 }
 
-func NewRateLimiter(r float64, b int) gin.HandlerFunc {
-	return RateLimitMiddleware(rate.Limit(r), b)
+// clientKey counts all of a user's tokens together; otherwise minting more tokens would buy
+// more requests
+func clientKey(c *gin.Context) string {
+	if userID := c.GetString("user_id"); userID != "" {
+		return "user:" + userID
+	}
+	return "ip:" + c.ClientIP()
 }
 
-const (
-	RateLimit  = 1
-	BurstLimit = 5
-)
+func ceilSeconds(d time.Duration) string {
+	return strconv.Itoa(int(math.Ceil(d.Seconds())))
+}
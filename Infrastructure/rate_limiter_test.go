@@ -0,0 +1,203 @@
+package infrastructure_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	ratelimitpkg "github.com/Amaankaa/Blog-Starter-Project/Domain/ratelimit"
+	infrastructure "github.com/Amaankaa/Blog-Starter-Project/Infrastructure"
+	"github.com/Amaankaa/Blog-Starter-Project/mocks"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+var testRatePolicy = ratelimitpkg.Policy{Name: "test", Limit: 3, Period: 3 * time.Second}
+
+type MemoryRateLimitStoreSuite struct {
+	suite.Suite
+	ctx   context.Context
+	store *infrastructure.MemoryRateLimitStore
+	now   time.Time
+}
+
+func (s *MemoryRateLimitStoreSuite) SetupTest() {
+	s.ctx = context.Background()
+	s.store = infrastructure.NewMemoryRateLimitStore(2)
+	s.now = time.Date(2025, time.January, 15, 9, 30, 0, 0, time.UTC)
+}
+
+func TestMemoryRateLimitStoreSuite(t *testing.T) {
+	suite.Run(t, new(MemoryRateLimitStoreSuite))
+}
+
+func (s *MemoryRateLimitStoreSuite) take(key string, at time.Time) ratelimitpkg.Decision {
+	decision, err := s.store.Take(s.ctx, key, testRatePolicy, at)
+	s.Require().NoError(err)
+	return decision
+}
+
+func (s *MemoryRateLimitStoreSuite) TestBurstThenRefill() {
+	for want := 2; want >= 0; want-- {
+		d := s.take("a", s.now)
+		s.True(d.Allowed)
+		s.Equal(want, d.Remaining)
+	}
+
+	d := s.take("a", s.now)
+	s.False(d.Allowed)
+	s.Equal(time.Second, d.RetryAfter)
+	s.Equal(3*time.Second, d.ResetAfter)
+
+	d = s.take("a", s.now.Add(time.Second))
+	s.True(d.Allowed)
+	s.Equal(0, d.Remaining)
+}
+
+func (s *MemoryRateLimitStoreSuite) TestClientsAreLimitedSeparately() {
+	for i := 0; i < 3; i++ {
+		s.take("a", s.now)
+	}
+	s.False(s.take("a", s.now).Allowed)
+	s.True(s.take("b", s.now).Allowed)
+}
+
+func (s *MemoryRateLimitStoreSuite) TestEvictedClientStartsOver() {
+	for i := 0; i < 3; i++ {
+		s.take("a", s.now)
+	}
+	s.take("b", s.now)
+	s.take("c", s.now) // the store holds two clients, so "a" goes
+
+	s.True(s.take("a", s.now).Allowed)
+}
+
+type RateLimiterSuite struct {
+	suite.Suite
+	router *gin.Engine
+}
+
+func (s *RateLimiterSuite) SetupTest() {
+	limiter := infrastructure.NewRateLimiter(infrastructure.NewMemoryRateLimitStore(10))
+	s.router = gin.New()
+	s.Require().NoError(s.router.SetTrustedProxies([]string{"10.0.0.0/8"}))
+	s.router.Use(func(c *gin.Context) {
+		if user := c.GetHeader("X-Test-User"); user != "" {
+			c.Set("user_id", user)
+		}
+		if token := c.GetHeader("X-Test-Token"); token != "" {
+			c.Set("token_id", token)
+		}
+		c.Next()
+	})
+	s.router.GET("/limited", limiter.Limit(ratelimitpkg.Policy{Name: "test", Limit: 2, Period: time.Minute}), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+}
+
+func TestRateLimiterSuite(t *testing.T) {
+	suite.Run(t, new(RateLimiterSuite))
+}
+
+func (s *RateLimiterSuite) get(user string) *httptest.ResponseRecorder {
+	return s.getFrom("203.0.113.7:1234", "", user)
+}
+
+func (s *RateLimiterSuite) getFrom(remoteAddr, forwardedFor, user string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, "/limited", nil)
+	req.RemoteAddr = remoteAddr
+	if forwardedFor != "" {
+		req.Header.Set("X-Forwarded-For", forwardedFor)
+	}
+	if user != "" {
+		req.Header.Set("X-Test-User", user)
+	}
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+	return w
+}
+
+func (s *RateLimiterSuite) TestSetsHeaders() {
+	w := s.get("")
+
+	s.Equal(http.StatusOK, w.Code)
+	s.Equal("2;w=60", w.Header().Get("RateLimit-Policy"))
+	s.Equal("2", w.Header().Get("RateLimit-Limit"))
+	s.Equal("1", w.Header().Get("RateLimit-Remaining"))
+	s.Equal("30", w.Header().Get("RateLimit-Reset"))
+	s.Empty(w.Header().Get("Retry-After"))
+}
+
+func (s *RateLimiterSuite) TestRejectsWithRetryAfter() {
+	s.get("")
+	s.get("")
+	w := s.get("")
+
+	s.Equal(http.StatusTooManyRequests, w.Code)
+	s.Equal("0", w.Header().Get("RateLimit-Remaining"))
+	s.Equal("30", w.Header().Get("Retry-After"))
+}
+
+func (s *RateLimiterSuite) TestUsersBehindOneAddressAreLimitedSeparately() {
+	s.get("user-1")
+	s.get("user-1")
+
+	s.Equal(http.StatusTooManyRequests, s.get("user-1").Code)
+	s.Equal(http.StatusOK, s.get("user-2").Code)
+	s.Equal(http.StatusOK, s.get("").Code)
+}
+
+func (s *RateLimiterSuite) TestTokensOfOneUserShareTheUsersLimit() {
+	s.getWithToken("user-1", "token-a")
+	s.get("user-1")
+
+	s.Equal(http.StatusTooManyRequests, s.getWithToken("user-1", "token-b").Code)
+	s.Equal(http.StatusOK, s.getWithToken("user-2", "token-c").Code)
+}
+
+func (s *RateLimiterSuite) getWithToken(user, token string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, "/limited", nil)
+	req.RemoteAddr = "203.0.113.7:1234"
+	req.Header.Set("X-Test-User", user)
+	req.Header.Set("X-Test-Token", token)
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+	return w
+}
+
+func (s *RateLimiterSuite) TestForgedForwardedForCountsAgainstTheRealAddress() {
+	s.get("")
+	s.getFrom("203.0.113.7:1234", "198.51.100.1", "")
+
+	w := s.getFrom("203.0.113.7:1234", "198.51.100.2", "")
+
+	s.Equal(http.StatusTooManyRequests, w.Code)
+}
+
+func (s *RateLimiterSuite) TestTrustedProxyForwardsTheClientAddress() {
+	s.get("")
+	// The client prepended a forged address; the trusted proxy appended the real one
+	s.getFrom("10.0.0.5:443", "198.51.100.1, 203.0.113.7", "")
+
+	s.Equal(http.StatusTooManyRequests, s.getFrom("10.0.0.5:443", "198.51.100.2, 203.0.113.7", "").Code)
+	s.Equal(http.StatusOK, s.getFrom("10.0.0.5:443", "198.51.100.3", "").Code)
+}
+
+func (s *RateLimiterSuite) TestStoreErrorLetsRequestsThrough() {
+	store := mocks.NewIRateLimitStore(s.T())
+	store.On("Take", mock.Anything, "test:ip:203.0.113.7", testRatePolicy, mock.Anything).
+		Return(ratelimitpkg.Decision{}, errors.New("store down")).Once()
+	s.router = gin.New()
+	s.router.GET("/limited", infrastructure.NewRateLimiter(store).Limit(testRatePolicy), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	w := s.get("")
+
+	s.Equal(http.StatusOK, w.Code)
+	s.Empty(w.Header().Get("RateLimit-Limit"))
+}
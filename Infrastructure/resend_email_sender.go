@@ -0,0 +1,67 @@
+package infrastructure
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/mail"
+	"net/url"
+	"time"
+
+	"github.com/Amaankaa/Blog-Starter-Project/Domain/services"
+	"github.com/resend/resend-go/v2"
+)
+
+type ResendConfig struct {
+	APIKey    string
+	FromEmail string
+	FromName  string
+	BaseURL   string // defaults to the resend-go client's base URL
+}
+
+type ResendEmailSender struct {
+	client *resend.Client
+	from   string
+}
+
+func NewResendEmailSender(cfg ResendConfig) (*ResendEmailSender, error) {
+	if cfg.APIKey == "" || cfg.FromEmail == "" {
+		return nil, errors.New("resend: API key and sender address are required")
+	}
+
+	client := resend.NewCustomClient(&http.Client{Timeout: 15 * time.Second}, cfg.APIKey)
+	if cfg.BaseURL != "" {
+		baseURL, err := url.Parse(cfg.BaseURL)
+		if err != nil {
+			return nil, errors.New("resend: invalid base URL")
+		}
+		client.BaseURL = baseURL
+	}
+
+	from := mail.Address{Name: cfg.FromName, Address: cfg.FromEmail}
+	return &ResendEmailSender{
+		client: client,
+		from:   from.String(),
+	}, nil
+}
+
+func (r *ResendEmailSender) SendEmail(msg services.EmailMessage) error {
+	if err := validateEmailMessage(msg); err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+
+	_, err := r.client.Emails.SendWithContext(ctx, &resend.SendEmailRequest{
+		From:    r.from,
+		To:      []string{msg.To},
+		Subject: msg.Subject,
+		Html:    msg.HTML,
+		Text:    msg.Text,
+	})
+	if err != nil {
+		return errors.New("failed to send email: " + err.Error())
+	}
+	return nil
+}
@@ -0,0 +1,339 @@
+package infrastructure
+
+import (
+	"context"
+	"crypto"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"log"
+	"math/big"
+	"sort"
+	"sync"
+	"time"
+
+	signingkeypkg "github.com/Amaankaa/Blog-Starter-Project/Domain/signingkey"
+	"github.com/golang-jwt/jwt/v4"
+)
+
+// SigningKeyPolicy controls how signing keys are generated and rotated
+type SigningKeyPolicy struct {
+	Algorithm        string        // algorithm of newly generated keys
+	RotationInterval time.Duration // how long a key signs before the next one takes over
+	// PublishAhead is how long a new key is listed in the JWKS before it signs anything,
+	// so that verifiers caching the JWKS already know it when the first token arrives
+	PublishAhead time.Duration
+}
+
+var DefaultSigningKeyPolicy = SigningKeyPolicy{
+	Algorithm:        signingkeypkg.AlgorithmRS256,
+	RotationInterval: 30 * 24 * time.Hour,
+	PublishAhead:     24 * time.Hour,
+}
+
+type ringKey struct {
+	id          string
+	method      jwt.SigningMethod
+	private     crypto.Signer
+	public      crypto.PublicKey
+	activatesAt time.Time
+	expiresAt   time.Time
+}
+
+// SigningKeyRing holds the JWT signing keys shared by all instances through the repository.
+// Any instance may rotate: a new key is generated once the newest one is due to be replaced,
+// and stays valid for verification until every token it can have signed has expired.
+// Private keys are stored encrypted, so a copy of the database alone can't sign tokens.
+type SigningKeyRing struct {
+	repo   signingkeypkg.ISigningKeyRepository
+	policy SigningKeyPolicy
+	aead   cipher.AEAD
+	mu     sync.RWMutex
+	keys   []ringKey // sorted by activatesAt
+}
+
+// NewSigningKeyRing encrypts private keys with an AES-256 key derived from secret. Every
+// instance needs the same secret; keys stored under another one can't be loaded.
+func NewSigningKeyRing(repo signingkeypkg.ISigningKeyRepository, policy SigningKeyPolicy, secret string) (*SigningKeyRing, error) {
+	if _, err := signingMethod(policy.Algorithm); err != nil {
+		return nil, err
+	}
+	if policy.RotationInterval <= policy.PublishAhead {
+		return nil, errors.New("key rotation interval must be longer than the publish-ahead period")
+	}
+	if secret == "" {
+		return nil, errors.New("a secret to encrypt signing keys with is required")
+	}
+	sum := sha256.Sum256([]byte(secret))
+	block, err := aes.NewCipher(sum[:])
+	if err != nil {
+		return nil, err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	return &SigningKeyRing{repo: repo, policy: policy, aead: aead}, nil
+}
+
+// Refresh reloads the keys from the repository
+func (r *SigningKeyRing) Refresh(ctx context.Context) error {
+	stored, err := r.repo.ListKeys(ctx)
+	if err != nil {
+		return err
+	}
+
+	r.mu.RLock()
+	parsed := make(map[string]ringKey, len(r.keys))
+	for _, k := range r.keys {
+		parsed[k.id] = k
+	}
+	r.mu.RUnlock()
+
+	keys := make([]ringKey, 0, len(stored))
+	for _, s := range stored {
+		if k, ok := parsed[s.ID]; ok {
+			// Parsing is the expensive part; the schedule is always taken from the record
+			k.activatesAt, k.expiresAt = s.ActivatesAt, s.ExpiresAt
+			keys = append(keys, k)
+			continue
+		}
+		var k ringKey
+		der, err := r.privateKeyDER(s)
+		if err == nil {
+			k, err = parseSigningKey(s, der)
+		}
+		if err != nil {
+			// One bad record must not take down token verification
+			log.Printf("jwt keys: skipping key %s: %v", s.ID, err)
+			continue
+		}
+		if len(s.EncryptedPrivateKey) == 0 {
+			// Stored before keys were encrypted
+			if err := r.repo.SealKey(ctx, s.ID, r.seal(s, der)); err != nil {
+				log.Printf("jwt keys: failed to encrypt key %s: %v", s.ID, err)
+			}
+		}
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i].activatesAt.Before(keys[j].activatesAt) })
+
+	r.mu.Lock()
+	r.keys = keys
+	r.mu.Unlock()
+	return nil
+}
+
+// Rotate generates a key when there is none or when the newest one is due to be replaced
+func (r *SigningKeyRing) Rotate(ctx context.Context) error {
+	if err := r.Refresh(ctx); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	r.mu.RLock()
+	var newest *ringKey
+	if len(r.keys) > 0 {
+		newest = &r.keys[len(r.keys)-1]
+	}
+	r.mu.RUnlock()
+
+	switch {
+	case newest == nil:
+		// Nothing to sign with yet, so the first key is used right away
+		if err := r.generate(ctx, now); err != nil {
+			return err
+		}
+	case !now.Before(newest.activatesAt.Add(r.policy.RotationInterval - r.policy.PublishAhead)):
+		if err := r.generate(ctx, now.Add(r.policy.PublishAhead)); err != nil {
+			return err
+		}
+	default:
+		return nil
+	}
+	return r.Refresh(ctx)
+}
+
+// Run rotates and picks up keys generated by other instances every interval until ctx is
+// cancelled. The interval must be well below PublishAhead.
+func (r *SigningKeyRing) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		if err := r.Rotate(ctx); err != nil {
+			log.Printf("jwt keys: rotation failed: %v", err)
+		}
+	}
+}
+
+func (r *SigningKeyRing) generate(ctx context.Context, activatesAt time.Time) error {
+	var private crypto.Signer
+	var err error
+	switch r.policy.Algorithm {
+	case signingkeypkg.AlgorithmEdDSA:
+		_, private, err = ed25519.GenerateKey(rand.Reader)
+	default:
+		private, err = rsa.GenerateKey(rand.Reader, 2048)
+	}
+	if err != nil {
+		return err
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(private)
+	if err != nil {
+		return err
+	}
+	id := make([]byte, 8)
+	if _, err := rand.Read(id); err != nil {
+		return err
+	}
+
+	key := signingkeypkg.SigningKey{
+		ID:          hex.EncodeToString(id),
+		Algorithm:   r.policy.Algorithm,
+		CreatedAt:   time.Now(),
+		ActivatesAt: activatesAt,
+		// Signs until about ActivatesAt+RotationInterval; the extra PublishAhead covers a late rotation
+		ExpiresAt: activatesAt.Add(r.policy.RotationInterval + r.policy.PublishAhead + refreshTokenLifetime),
+	}
+	key.EncryptedPrivateKey = r.seal(key, der)
+	return r.repo.InsertKey(ctx, key)
+}
+
+// seal encrypts a PKCS #8 key for its record. The record's ID and algorithm are authenticated
+// with it, so an encrypted key can't be moved to another record.
+func (r *SigningKeyRing) seal(key signingkeypkg.SigningKey, der []byte) []byte {
+	nonce := make([]byte, r.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		panic(err) // crypto/rand doesn't fail on supported platforms
+	}
+	return r.aead.Seal(nonce, nonce, der, sealedKeyData(key))
+}
+
+// privateKeyDER returns the record's PKCS #8 key, decrypting it unless it predates encryption
+func (r *SigningKeyRing) privateKeyDER(key signingkeypkg.SigningKey) ([]byte, error) {
+	if len(key.EncryptedPrivateKey) == 0 {
+		block, _ := pem.Decode([]byte(key.PrivateKeyPEM))
+		if block == nil {
+			return nil, errors.New("invalid PEM")
+		}
+		return block.Bytes, nil
+	}
+	sealed := key.EncryptedPrivateKey
+	if len(sealed) < r.aead.NonceSize() {
+		return nil, errors.New("encrypted key is too short")
+	}
+	der, err := r.aead.Open(nil, sealed[:r.aead.NonceSize()], sealed[r.aead.NonceSize():], sealedKeyData(key))
+	if err != nil {
+		return nil, errors.New("cannot decrypt key: wrong signing key secret or altered record")
+	}
+	return der, nil
+}
+
+func sealedKeyData(key signingkeypkg.SigningKey) []byte {
+	return []byte(key.ID + "|" + key.Algorithm)
+}
+
+// signingKey returns the newest key that has activated, or the oldest pending one if
+// none has (e.g. the ring was down for longer than a key's lifetime)
+func (r *SigningKeyRing) signingKey() (ringKey, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	now := time.Now()
+	for i := len(r.keys) - 1; i >= 0; i-- {
+		if !r.keys[i].activatesAt.After(now) {
+			return r.keys[i], nil
+		}
+	}
+	if len(r.keys) > 0 {
+		return r.keys[0], nil
+	}
+	return ringKey{}, errors.New("no signing key available")
+}
+
+func (r *SigningKeyRing) verificationKey(kid string) (ringKey, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	for _, k := range r.keys {
+		if k.id == kid && time.Now().Before(k.expiresAt) {
+			return k, true
+		}
+	}
+	return ringKey{}, false
+}
+
+// PublicKeys lists every key that may have signed a still-valid token, plus the pending one
+func (r *SigningKeyRing) PublicKeys() signingkeypkg.JSONWebKeySet {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	set := signingkeypkg.JSONWebKeySet{Keys: make([]signingkeypkg.JSONWebKey, 0, len(r.keys))}
+	for _, k := range r.keys {
+		jwk := signingkeypkg.JSONWebKey{Use: "sig", Algorithm: k.method.Alg(), KeyID: k.id}
+		switch pub := k.public.(type) {
+		case *rsa.PublicKey:
+			jwk.KeyType = "RSA"
+			jwk.N = base64.RawURLEncoding.EncodeToString(pub.N.Bytes())
+			jwk.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(pub.E)).Bytes())
+		case ed25519.PublicKey:
+			jwk.KeyType = "OKP"
+			jwk.Curve = "Ed25519"
+			jwk.X = base64.RawURLEncoding.EncodeToString(pub)
+		}
+		set.Keys = append(set.Keys, jwk)
+	}
+	return set
+}
+
+func parseSigningKey(s signingkeypkg.SigningKey, der []byte) (ringKey, error) {
+	method, err := signingMethod(s.Algorithm)
+	if err != nil {
+		return ringKey{}, err
+	}
+	parsed, err := x509.ParsePKCS8PrivateKey(der)
+	if err != nil {
+		return ringKey{}, err
+	}
+
+	k := ringKey{id: s.ID, method: method, activatesAt: s.ActivatesAt, expiresAt: s.ExpiresAt}
+	switch priv := parsed.(type) {
+	case *rsa.PrivateKey:
+		if s.Algorithm != signingkeypkg.AlgorithmRS256 {
+			return ringKey{}, fmt.Errorf("RSA key stored as %s", s.Algorithm)
+		}
+		k.private, k.public = priv, &priv.PublicKey
+	case ed25519.PrivateKey:
+		if s.Algorithm != signingkeypkg.AlgorithmEdDSA {
+			return ringKey{}, fmt.Errorf("Ed25519 key stored as %s", s.Algorithm)
+		}
+		k.private, k.public = priv, priv.Public()
+	default:
+		return ringKey{}, fmt.Errorf("unsupported key type %T", parsed)
+	}
+	return k, nil
+}
+
+func signingMethod(algorithm string) (jwt.SigningMethod, error) {
+	switch algorithm {
+	case signingkeypkg.AlgorithmRS256:
+		return jwt.SigningMethodRS256, nil
+	case signingkeypkg.AlgorithmEdDSA:
+		return jwt.SigningMethodEdDSA, nil
+	}
+	return nil, fmt.Errorf("unsupported JWT signing algorithm %q", algorithm)
+}
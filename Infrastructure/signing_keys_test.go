@@ -0,0 +1,238 @@
+package infrastructure_test
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"sync"
+	"testing"
+	"time"
+
+	signingkeypkg "github.com/Amaankaa/Blog-Starter-Project/Domain/signingkey"
+	userpkg "github.com/Amaankaa/Blog-Starter-Project/Domain/user"
+	infrastructure "github.com/Amaankaa/Blog-Starter-Project/Infrastructure"
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+// memorySigningKeyRepo is a shared key store, standing in for the collection all instances use
+type memorySigningKeyRepo struct {
+	mu   sync.Mutex
+	keys []signingkeypkg.SigningKey
+}
+
+func (m *memorySigningKeyRepo) ListKeys(ctx context.Context) ([]signingkeypkg.SigningKey, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var keys []signingkeypkg.SigningKey
+	for _, k := range m.keys {
+		if k.ExpiresAt.After(time.Now()) {
+			keys = append(keys, k)
+		}
+	}
+	return keys, nil
+}
+
+func (m *memorySigningKeyRepo) InsertKey(ctx context.Context, key signingkeypkg.SigningKey) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.keys = append(m.keys, key)
+	return nil
+}
+
+func (m *memorySigningKeyRepo) SealKey(ctx context.Context, id string, encrypted []byte) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for i := range m.keys {
+		if m.keys[i].ID == id {
+			m.keys[i].EncryptedPrivateKey = encrypted
+			m.keys[i].PrivateKeyPEM = ""
+		}
+	}
+	return nil
+}
+
+// shift moves every key's timeline back by d, as if d had passed
+func (m *memorySigningKeyRepo) shift(d time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for i := range m.keys {
+		m.keys[i].CreatedAt = m.keys[i].CreatedAt.Add(-d)
+		m.keys[i].ActivatesAt = m.keys[i].ActivatesAt.Add(-d)
+		m.keys[i].ExpiresAt = m.keys[i].ExpiresAt.Add(-d)
+	}
+}
+
+const testSigningKeySecret = "test signing key secret"
+
+func newTestKeyRing(t *testing.T, repo *memorySigningKeyRepo, algorithm string) *infrastructure.SigningKeyRing {
+	policy := infrastructure.DefaultSigningKeyPolicy
+	policy.Algorithm = algorithm
+	ring, err := infrastructure.NewSigningKeyRing(repo, policy, testSigningKeySecret)
+	require.NoError(t, err)
+	require.NoError(t, ring.Rotate(context.Background()))
+	return ring
+}
+
+// signingKid returns the kid a token was signed with
+func signingKid(t *testing.T, token string) string {
+	parsed, _, err := new(jwt.Parser).ParseUnverified(token, jwt.MapClaims{})
+	require.NoError(t, err)
+	kid, _ := parsed.Header["kid"].(string)
+	return kid
+}
+
+type SigningKeyRingSuite struct {
+	suite.Suite
+	repo *memorySigningKeyRepo
+	ring *infrastructure.SigningKeyRing
+	jwt  *infrastructure.JWTService
+}
+
+func (s *SigningKeyRingSuite) SetupTest() {
+	s.repo = &memorySigningKeyRepo{}
+	s.ring = newTestKeyRing(s.T(), s.repo, signingkeypkg.AlgorithmRS256)
+	s.jwt = infrastructure.NewJWTService(s.ring, "")
+}
+
+func TestSigningKeyRingSuite(t *testing.T) {
+	suite.Run(t, new(SigningKeyRingSuite))
+}
+
+func (s *SigningKeyRingSuite) sign() string {
+	tokens, err := s.jwt.GenerateToken(userpkg.TokenSubject{UserID: "user-1", Username: "jane", Role: "user", SessionID: "session-1"})
+	s.Require().NoError(err)
+	return tokens.AccessToken
+}
+
+func (s *SigningKeyRingSuite) TestBootstrapsOneActiveKey() {
+	s.Require().NoError(s.ring.Rotate(context.Background()))
+
+	s.Len(s.repo.keys, 1)
+	s.Equal(s.repo.keys[0].ID, signingKid(s.T(), s.sign()))
+}
+
+func (s *SigningKeyRingSuite) TestRotationPublishesAheadThenSwitches() {
+	policy := infrastructure.DefaultSigningKeyPolicy
+	oldKid := s.repo.keys[0].ID
+	oldToken := s.sign()
+
+	// Just before the current key is due: nothing happens
+	s.repo.shift(policy.RotationInterval - policy.PublishAhead - time.Hour)
+	s.Require().NoError(s.ring.Rotate(context.Background()))
+	s.Len(s.repo.keys, 1)
+
+	// Due: the next key is published but not used yet
+	s.repo.shift(time.Hour)
+	s.Require().NoError(s.ring.Rotate(context.Background()))
+	s.Require().Len(s.repo.keys, 2)
+	newKid := s.repo.keys[1].ID
+	s.Len(s.ring.PublicKeys().Keys, 2)
+	s.Equal(oldKid, signingKid(s.T(), s.sign()))
+
+	// Once it activates it signs, and the old key still verifies what it signed
+	s.repo.shift(policy.PublishAhead)
+	s.Require().NoError(s.ring.Refresh(context.Background()))
+	s.Equal(newKid, signingKid(s.T(), s.sign()))
+	_, err := s.jwt.ValidateToken(oldToken, userpkg.TokenTypeAccess)
+	s.NoError(err)
+}
+
+func (s *SigningKeyRingSuite) TestExpiredKeysAreDropped() {
+	oldToken := s.sign()
+	policy := infrastructure.DefaultSigningKeyPolicy
+
+	// Long enough for the original key to expire; the ring has to start over
+	s.repo.shift(policy.RotationInterval + policy.PublishAhead + 8*24*time.Hour)
+	s.Require().NoError(s.ring.Rotate(context.Background()))
+
+	s.Len(s.ring.PublicKeys().Keys, 1)
+	_, err := s.jwt.ValidateToken(oldToken, userpkg.TokenTypeAccess)
+	s.Error(err)
+	_, err = s.jwt.ValidateToken(s.sign(), userpkg.TokenTypeAccess)
+	s.NoError(err)
+}
+
+func (s *SigningKeyRingSuite) TestPicksUpKeysFromOtherInstances() {
+	other := newTestKeyRing(s.T(), s.repo, signingkeypkg.AlgorithmRS256)
+	s.repo.shift(infrastructure.DefaultSigningKeyPolicy.RotationInterval)
+	s.Require().NoError(other.Rotate(context.Background()))
+	token, err := infrastructure.NewJWTService(other, "").GenerateToken(userpkg.TokenSubject{UserID: "user-1", Username: "jane", Role: "user"})
+	s.Require().NoError(err)
+
+	s.Require().NoError(s.ring.Refresh(context.Background()))
+
+	_, err = s.jwt.ValidateToken(token.AccessToken, userpkg.TokenTypeAccess)
+	s.NoError(err)
+}
+
+func (s *SigningKeyRingSuite) TestPublicKeys() {
+	rsaKey := s.ring.PublicKeys().Keys[0]
+	s.Equal("RSA", rsaKey.KeyType)
+	s.Equal("RS256", rsaKey.Algorithm)
+	s.Equal("sig", rsaKey.Use)
+	s.Equal("AQAB", rsaKey.E)
+	s.NotEmpty(rsaKey.N)
+
+	edRing := newTestKeyRing(s.T(), &memorySigningKeyRepo{}, signingkeypkg.AlgorithmEdDSA)
+	edKey := edRing.PublicKeys().Keys[0]
+	s.Equal("OKP", edKey.KeyType)
+	s.Equal("EdDSA", edKey.Algorithm)
+	s.Equal("Ed25519", edKey.Curve)
+	s.Len(edKey.X, 43) // 32 bytes, unpadded base64url
+}
+
+func (s *SigningKeyRingSuite) TestPrivateKeysAreStoredEncrypted() {
+	stored := s.repo.keys[0]
+	s.Empty(stored.PrivateKeyPEM)
+	s.NotEmpty(stored.EncryptedPrivateKey)
+
+	other, err := infrastructure.NewSigningKeyRing(s.repo, infrastructure.DefaultSigningKeyPolicy, "another secret")
+	s.Require().NoError(err)
+	s.Require().NoError(other.Refresh(context.Background()))
+	s.Empty(other.PublicKeys().Keys, "keys stored under another secret can't be loaded")
+}
+
+func (s *SigningKeyRingSuite) TestPlaintextKeysAreEncryptedInPlace() {
+	_, private, err := ed25519.GenerateKey(rand.Reader)
+	s.Require().NoError(err)
+	der, err := x509.MarshalPKCS8PrivateKey(private)
+	s.Require().NoError(err)
+	now := time.Now()
+	repo := &memorySigningKeyRepo{keys: []signingkeypkg.SigningKey{{
+		ID:            "legacy",
+		Algorithm:     signingkeypkg.AlgorithmEdDSA,
+		PrivateKeyPEM: string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})),
+		CreatedAt:     now.Add(-time.Hour),
+		ActivatesAt:   now.Add(-time.Hour),
+		ExpiresAt:     now.Add(24 * time.Hour),
+	}}}
+
+	ring := newTestKeyRing(s.T(), repo, signingkeypkg.AlgorithmEdDSA)
+
+	s.Require().Len(repo.keys, 1)
+	s.Empty(repo.keys[0].PrivateKeyPEM)
+	s.NotEmpty(repo.keys[0].EncryptedPrivateKey)
+	token, err := infrastructure.NewJWTService(ring, "").GenerateToken(userpkg.TokenSubject{UserID: "user-1", Username: "jane", Role: "user"})
+	s.Require().NoError(err)
+	s.Equal("legacy", signingKid(s.T(), token.AccessToken))
+
+	// A fresh instance reads the encrypted record
+	restarted := newTestKeyRing(s.T(), repo, signingkeypkg.AlgorithmEdDSA)
+	_, err = infrastructure.NewJWTService(restarted, "").ValidateToken(token.AccessToken, userpkg.TokenTypeAccess)
+	s.NoError(err)
+}
+
+func (s *SigningKeyRingSuite) TestPolicyValidation() {
+	_, err := infrastructure.NewSigningKeyRing(s.repo, infrastructure.SigningKeyPolicy{Algorithm: "HS256", RotationInterval: time.Hour}, testSigningKeySecret)
+	s.EqualError(err, `unsupported JWT signing algorithm "HS256"`)
+
+	_, err = infrastructure.NewSigningKeyRing(s.repo, infrastructure.SigningKeyPolicy{Algorithm: "RS256", RotationInterval: time.Hour, PublishAhead: time.Hour}, testSigningKeySecret)
+	s.Error(err)
+
+	_, err = infrastructure.NewSigningKeyRing(s.repo, infrastructure.DefaultSigningKeyPolicy, "")
+	s.Error(err)
+}
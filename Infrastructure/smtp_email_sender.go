@@ -0,0 +1,189 @@
+package infrastructure
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/tls"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"net/textproto"
+	"strconv"
+	"time"
+
+	"github.com/Amaankaa/Blog-Starter-Project/Domain/services"
+)
+
+type SMTPConfig struct {
+	Host      string
+	Port      int // 465 uses implicit TLS; other ports upgrade with STARTTLS when the server offers it
+	Username  string
+	Password  string // only used together with Username
+	FromEmail string
+	FromName  string
+}
+
+// SMTPEmailSender delivers through any SMTP server, including local catchers such as MailHog
+type SMTPEmailSender struct {
+	cfg     SMTPConfig
+	timeout time.Duration
+}
+
+func NewSMTPEmailSender(cfg SMTPConfig) (*SMTPEmailSender, error) {
+	if cfg.Host == "" || cfg.FromEmail == "" {
+		return nil, errors.New("smtp: host and sender address are required")
+	}
+	if cfg.Port == 0 {
+		cfg.Port = 587
+	}
+	return &SMTPEmailSender{cfg: cfg, timeout: 15 * time.Second}, nil
+}
+
+func (s *SMTPEmailSender) SendEmail(msg services.EmailMessage) error {
+	if err := validateEmailMessage(msg); err != nil {
+		return err
+	}
+	body, err := s.buildMessage(msg)
+	if err != nil {
+		return err
+	}
+
+	client, err := s.dial()
+	if err != nil {
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+	defer client.Close()
+
+	if err := s.deliver(client, msg.To, body); err != nil {
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+	return client.Quit()
+}
+
+func (s *SMTPEmailSender) dial() (*smtp.Client, error) {
+	addr := net.JoinHostPort(s.cfg.Host, strconv.Itoa(s.cfg.Port))
+	dialer := &net.Dialer{Timeout: s.timeout}
+	tlsConfig := &tls.Config{ServerName: s.cfg.Host}
+
+	var conn net.Conn
+	var err error
+	if s.cfg.Port == 465 {
+		conn, err = tls.DialWithDialer(dialer, "tcp", addr, tlsConfig)
+	} else {
+		conn, err = dialer.Dial("tcp", addr)
+	}
+	if err != nil {
+		return nil, err
+	}
+	// Bounds the whole conversation, not only the connect
+	conn.SetDeadline(time.Now().Add(s.timeout))
+
+	client, err := smtp.NewClient(conn, s.cfg.Host)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	if ok, _ := client.Extension("STARTTLS"); ok && s.cfg.Port != 465 {
+		if err := client.StartTLS(tlsConfig); err != nil {
+			client.Close()
+			return nil, err
+		}
+	}
+	if s.cfg.Username != "" {
+		// PlainAuth refuses to send credentials over an unencrypted connection to a remote host
+		if err := client.Auth(smtp.PlainAuth("", s.cfg.Username, s.cfg.Password, s.cfg.Host)); err != nil {
+			client.Close()
+			return nil, err
+		}
+	}
+	return client, nil
+}
+
+func (s *SMTPEmailSender) deliver(client *smtp.Client, to string, body []byte) error {
+	if err := client.Mail(s.cfg.FromEmail); err != nil {
+		return err
+	}
+	if err := client.Rcpt(to); err != nil {
+		return err
+	}
+	w, err := client.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(body); err != nil {
+		w.Close()
+		return err
+	}
+	return w.Close()
+}
+
+// buildMessage renders msg as a MIME message, multipart/alternative when it has both bodies
+func (s *SMTPEmailSender) buildMessage(msg services.EmailMessage) ([]byte, error) {
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return nil, err
+	}
+	from := mail.Address{Name: s.cfg.FromName, Address: s.cfg.FromEmail}
+
+	var buf bytes.Buffer
+	header := func(k, v string) { fmt.Fprintf(&buf, "%s: %s\r\n", k, v) }
+	header("From", from.String())
+	header("To", msg.To)
+	header("Subject", mime.QEncoding.Encode("utf-8", msg.Subject))
+	header("Date", time.Now().Format(time.RFC1123Z))
+	header("Message-ID", "<"+hex.EncodeToString(id)+"@"+s.cfg.Host+">")
+	header("MIME-Version", "1.0")
+
+	if msg.HTML == "" || msg.Text == "" {
+		contentType, content := "text/plain; charset=utf-8", msg.Text
+		if msg.HTML != "" {
+			contentType, content = "text/html; charset=utf-8", msg.HTML
+		}
+		header("Content-Type", contentType)
+		header("Content-Transfer-Encoding", "quoted-printable")
+		buf.WriteString("\r\n")
+		if err := writeQuotedPrintable(&buf, content); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	}
+
+	parts := multipart.NewWriter(&buf)
+	header("Content-Type", "multipart/alternative; boundary="+parts.Boundary())
+	buf.WriteString("\r\n")
+	// Clients show the last part they understand, so HTML goes after the text fallback
+	for _, part := range []struct{ contentType, content string }{
+		{"text/plain; charset=utf-8", msg.Text},
+		{"text/html; charset=utf-8", msg.HTML},
+	} {
+		w, err := parts.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {part.contentType},
+			"Content-Transfer-Encoding": {"quoted-printable"},
+		})
+		if err != nil {
+			return nil, err
+		}
+		if err := writeQuotedPrintable(w, part.content); err != nil {
+			return nil, err
+		}
+	}
+	if err := parts.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func writeQuotedPrintable(w io.Writer, content string) error {
+	qp := quotedprintable.NewWriter(w)
+	if _, err := qp.Write([]byte(content)); err != nil {
+		return err
+	}
+	return qp.Close()
+}
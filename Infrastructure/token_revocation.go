@@ -0,0 +1,103 @@
+package infrastructure
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	userpkg "github.com/Amaankaa/Blog-Starter-Project/Domain/user"
+)
+
+// syncOverlap re-reads recent entries on every sync so that a revocation written by an
+// instance with a slightly late clock is not skipped
+const syncOverlap = time.Minute
+
+// AccessTokenRevocationList keeps the revoked access token ids in memory so that the auth
+// middleware can check them without a database round trip. Revocations are stored in the
+// repository and every instance polls it: a token revoked on one instance is rejected there
+// immediately and everywhere else after the next sync.
+type AccessTokenRevocationList struct {
+	repo     userpkg.IRevokedTokenRepository
+	mu       sync.RWMutex
+	revoked  map[string]time.Time // jti -> token expiry
+	lastSync time.Time
+}
+
+func NewAccessTokenRevocationList(repo userpkg.IRevokedTokenRepository) *AccessTokenRevocationList {
+	return &AccessTokenRevocationList{
+		repo:    repo,
+		revoked: make(map[string]time.Time),
+	}
+}
+
+func (l *AccessTokenRevocationList) Revoke(ctx context.Context, tokens ...userpkg.RevokedToken) error {
+	now := time.Now()
+	for i := range tokens {
+		tokens[i].RevokedAt = now
+	}
+	if err := l.repo.StoreRevokedTokens(ctx, tokens); err != nil {
+		return err
+	}
+	l.add(tokens)
+	return nil
+}
+
+func (l *AccessTokenRevocationList) IsRevoked(jti string) bool {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	expiresAt, ok := l.revoked[jti]
+	return ok && time.Now().Before(expiresAt)
+}
+
+// Sync loads revocations made since the previous sync and forgets expired ones
+func (l *AccessTokenRevocationList) Sync(ctx context.Context) error {
+	l.mu.RLock()
+	since := l.lastSync
+	l.mu.RUnlock()
+	if !since.IsZero() {
+		since = since.Add(-syncOverlap)
+	}
+
+	started := time.Now()
+	tokens, err := l.repo.ListRevokedSince(ctx, since)
+	if err != nil {
+		return err
+	}
+	l.add(tokens)
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for jti, expiresAt := range l.revoked {
+		if !started.Before(expiresAt) {
+			delete(l.revoked, jti)
+		}
+	}
+	l.lastSync = started
+	return nil
+}
+
+// Run syncs every interval until ctx is cancelled
+func (l *AccessTokenRevocationList) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		if err := l.Sync(ctx); err != nil {
+			log.Printf("token revocation: sync failed: %v", err)
+		}
+	}
+}
+
+func (l *AccessTokenRevocationList) add(tokens []userpkg.RevokedToken) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for _, t := range tokens {
+		l.revoked[t.ID] = t.ExpiresAt
+	}
+}
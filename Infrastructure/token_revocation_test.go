@@ -0,0 +1,101 @@
+package infrastructure_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	userpkg "github.com/Amaankaa/Blog-Starter-Project/Domain/user"
+	infrastructure "github.com/Amaankaa/Blog-Starter-Project/Infrastructure"
+	"github.com/Amaankaa/Blog-Starter-Project/mocks"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type TokenRevocationSuite struct {
+	suite.Suite
+	repo *mocks.IRevokedTokenRepository
+	list *infrastructure.AccessTokenRevocationList
+}
+
+func (s *TokenRevocationSuite) SetupTest() {
+	s.repo = mocks.NewIRevokedTokenRepository(s.T())
+	s.list = infrastructure.NewAccessTokenRevocationList(s.repo)
+}
+
+func TestTokenRevocationSuite(t *testing.T) {
+	suite.Run(t, new(TokenRevocationSuite))
+}
+
+func (s *TokenRevocationSuite) TestRevokeIsVisibleImmediately() {
+	s.repo.On("StoreRevokedTokens", mock.Anything, mock.MatchedBy(func(tokens []userpkg.RevokedToken) bool {
+		return len(tokens) == 1 && tokens[0].ID == "jti-1" && !tokens[0].RevokedAt.IsZero()
+	})).Return(nil).Once()
+
+	err := s.list.Revoke(context.Background(), userpkg.RevokedToken{ID: "jti-1", ExpiresAt: time.Now().Add(time.Minute)})
+
+	s.NoError(err)
+	s.True(s.list.IsRevoked("jti-1"))
+	s.False(s.list.IsRevoked("jti-2"))
+}
+
+func (s *TokenRevocationSuite) TestFailedRevokeIsNotCached() {
+	s.repo.On("StoreRevokedTokens", mock.Anything, mock.Anything).Return(errors.New("db down")).Once()
+
+	err := s.list.Revoke(context.Background(), userpkg.RevokedToken{ID: "jti-1", ExpiresAt: time.Now().Add(time.Minute)})
+
+	s.EqualError(err, "db down")
+	s.False(s.list.IsRevoked("jti-1"))
+}
+
+func (s *TokenRevocationSuite) TestSyncPicksUpOtherInstancesAndForgetsExpired() {
+	s.repo.On("ListRevokedSince", mock.Anything, time.Time{}).Return([]userpkg.RevokedToken{
+		{ID: "short", ExpiresAt: time.Now().Add(30 * time.Millisecond)},
+	}, nil).Once()
+	s.Require().NoError(s.list.Sync(context.Background()))
+	s.True(s.list.IsRevoked("short"))
+
+	// Later syncs only ask for recent entries, with some overlap for clock skew
+	s.repo.On("ListRevokedSince", mock.Anything, mock.MatchedBy(func(since time.Time) bool {
+		return !since.IsZero() && time.Since(since) > time.Minute
+	})).Return([]userpkg.RevokedToken{
+		{ID: "remote", ExpiresAt: time.Now().Add(time.Minute)},
+	}, nil).Once()
+	time.Sleep(40 * time.Millisecond)
+	s.Require().NoError(s.list.Sync(context.Background()))
+
+	s.False(s.list.IsRevoked("short"))
+	s.True(s.list.IsRevoked("remote"))
+}
+
+func (s *TokenRevocationSuite) TestMiddlewareRejectsRevokedTokens() {
+	jwtService := newTestJWTService(s.T())
+	tokens, err := jwtService.GenerateToken(userpkg.TokenSubject{UserID: "user-1", Username: "jane", Role: "admin", SessionID: "session-1"})
+	s.Require().NoError(err)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/me", infrastructure.NewAuthMiddleware(jwtService, s.list, mocks.NewIMFAPolicy(s.T()), mocks.NewIPATAuthenticator(s.T()), mocks.NewIPermissionResolver(s.T())).AuthMiddleware(), func(c *gin.Context) {
+		c.String(http.StatusOK, c.GetString("role"))
+	})
+	call := func() *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/me", nil)
+		req.Header.Set("Authorization", "Bearer "+tokens.AccessToken)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	s.Equal(http.StatusOK, call().Code)
+
+	s.repo.On("StoreRevokedTokens", mock.Anything, mock.Anything).Return(nil).Once()
+	s.Require().NoError(s.list.Revoke(context.Background(), userpkg.RevokedToken{ID: tokens.AccessTokenID, ExpiresAt: tokens.AccessExpiresAt}))
+
+	w := call()
+	s.Equal(http.StatusUnauthorized, w.Code)
+	s.Contains(w.Body.String(), "token has been revoked")
+}
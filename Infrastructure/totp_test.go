@@ -0,0 +1,67 @@
+package infrastructure_test
+
+import (
+	"encoding/base32"
+	"net/url"
+	"testing"
+	"time"
+
+	infrastructure "github.com/Amaankaa/Blog-Starter-Project/Infrastructure"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// The SHA-1 test vectors of RFC 6238 appendix B, truncated to six digits
+var rfc6238Secret = base32.StdEncoding.EncodeToString([]byte("12345678901234567890"))
+
+func TestGenerateTOTPCode_RFC6238Vectors(t *testing.T) {
+	for unix, want := range map[int64]string{
+		59:          "287082",
+		1111111109:  "081804",
+		1111111111:  "050471",
+		1234567890:  "005924",
+		2000000000:  "279037",
+		20000000000: "353130",
+	} {
+		code, err := infrastructure.GenerateTOTPCode(rfc6238Secret, time.Unix(unix, 0))
+		require.NoError(t, err)
+		assert.Equal(t, want, code, "at %d", unix)
+	}
+}
+
+func TestTOTPService_Validate(t *testing.T) {
+	svc := infrastructure.NewTOTPService("Blog")
+	secret, err := svc.GenerateSecret()
+	require.NoError(t, err)
+	now := time.Unix(1700000000, 0)
+	code, err := infrastructure.GenerateTOTPCode(secret, now)
+	require.NoError(t, err)
+
+	step, ok := svc.Validate(secret, code, now)
+	assert.True(t, ok)
+	assert.Equal(t, now.Unix()/30, step)
+
+	// One step of clock drift either way is tolerated, more is not
+	_, ok = svc.Validate(secret, code, now.Add(30*time.Second))
+	assert.True(t, ok)
+	_, ok = svc.Validate(secret, code, now.Add(-30*time.Second))
+	assert.True(t, ok)
+	_, ok = svc.Validate(secret, code, now.Add(90*time.Second))
+	assert.False(t, ok)
+
+	_, ok = svc.Validate(secret, "12345", now)
+	assert.False(t, ok)
+	_, ok = svc.Validate("not base32!", code, now)
+	assert.False(t, ok)
+}
+
+func TestTOTPService_ProvisioningURI(t *testing.T) {
+	uri, err := url.Parse(infrastructure.NewTOTPService("Blog Starter").ProvisioningURI("JBSWY3DPEHPK3PXP", "jane"))
+	require.NoError(t, err)
+
+	assert.Equal(t, "otpauth", uri.Scheme)
+	assert.Equal(t, "totp", uri.Host)
+	assert.Equal(t, "/Blog Starter:jane", uri.Path)
+	assert.Equal(t, "JBSWY3DPEHPK3PXP", uri.Query().Get("secret"))
+	assert.Equal(t, "Blog Starter", uri.Query().Get("issuer"))
+}
@@ -0,0 +1,110 @@
+package infrastructure
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// WebhookSignatureHeader carries "t=<unix seconds>,v1=<hex HMAC-SHA256 of "<t>.<body>">".
+// Including the timestamp in the signed content lets receivers reject replayed requests.
+const WebhookSignatureHeader = "X-Webhook-Signature"
+
+type WebhookSender struct {
+	client *http.Client
+}
+
+func NewWebhookSender(timeout time.Duration) *WebhookSender {
+	return &WebhookSender{
+		client: &http.Client{
+			Timeout: timeout,
+			// Never follow redirects: a receiver must not be able to bounce signed payloads elsewhere
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+	}
+}
+
+func (ws *WebhookSender) Send(ctx context.Context, url, secret string, headers map[string]string, payload []byte) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(payload))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "Blog-Starter-Webhooks/1.0")
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+	req.Header.Set(WebhookSignatureHeader, SignWebhookPayload(secret, time.Now(), payload))
+
+	resp, err := ws.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	// Drain a bounded amount so the connection can be reused
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("webhook receiver returned %s", resp.Status)
+	}
+	return resp.StatusCode, nil
+}
+
+// SignWebhookPayload builds the signature header value for payload sent at ts.
+func SignWebhookPayload(secret string, ts time.Time, payload []byte) string {
+	t := strconv.FormatInt(ts.Unix(), 10)
+	return "t=" + t + ",v1=" + webhookMAC(secret, t, payload)
+}
+
+// VerifyWebhookSignature checks a signature header against payload, rejecting
+// timestamps older than tolerance. Receivers written in Go can use it directly.
+func VerifyWebhookSignature(secret, header string, payload []byte, tolerance time.Duration) error {
+	var t, sig string
+	for _, part := range strings.Split(header, ",") {
+		k, v, ok := strings.Cut(strings.TrimSpace(part), "=")
+		if !ok {
+			continue
+		}
+		switch k {
+		case "t":
+			t = v
+		case "v1":
+			sig = v
+		}
+	}
+	if t == "" || sig == "" {
+		return errors.New("malformed signature header")
+	}
+
+	unix, err := strconv.ParseInt(t, 10, 64)
+	if err != nil {
+		return errors.New("malformed signature timestamp")
+	}
+	if tolerance > 0 && time.Since(time.Unix(unix, 0)) > tolerance {
+		return errors.New("signature timestamp outside tolerance")
+	}
+
+	if !hmac.Equal([]byte(sig), []byte(webhookMAC(secret, t, payload))) {
+		return errors.New("signature mismatch")
+	}
+	return nil
+}
+
+func webhookMAC(secret, t string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(t))
+	mac.Write([]byte("."))
+	mac.Write(payload)
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"math"
+	"time"
 
 	blogpkg "github.com/Amaankaa/Blog-Starter-Project/Domain/blog"
 	"go.mongodb.org/mongo-driver/bson"
@@ -89,9 +90,24 @@ func (br *BlogRepository) GetAllBlogs(ctx context.Context, pagination blogpkg.Pa
 	}, nil
 }
 
+// UpdateBlog writes the client-editable fields of blog. Server-owned fields
+// (likes, views, author, creation time) are never overwritten here.
 func (br *BlogRepository) UpdateBlog(id string, blog *blogpkg.Blog) (*blogpkg.Blog, error) {
 	filter := bson.M{"id": id}
-	update := bson.M{"$set": blog}
+	tags := blog.Tags
+	if tags == nil {
+		tags = []string{}
+	}
+	update := bson.M{
+		"$set": bson.M{
+			"title":      blog.Title,
+			"content":    blog.Content,
+			"tags":       tags,
+			"updated_at": blog.UpdatedAt,
+		},
+		// A review only covers the version the editor read
+		"$unset": bson.M{"review": ""},
+	}
 	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
 	var updatedBlog blogpkg.Blog
 	result := br.blogCollection.FindOneAndUpdate(br.ctx, filter, update, opts)
@@ -195,9 +211,11 @@ func (br *BlogRepository) FilterByTags(ctx context.Context, tags []string, pagin
 	}, nil
 }
 
+// Likes, comments and views are part of a post's JSON, so the writes below move updated_at too;
+// otherwise If-Modified-Since would keep answering 304 for a post that has changed.
 func (br *BlogRepository) AddLike(ctx context.Context, blogID string, userID string) error {
 	filter := bson.M{"id": blogID}
-	update := bson.M{"$addToSet": bson.M{"likes": userID}}
+	update := bson.M{"$addToSet": bson.M{"likes": userID}, "$set": bson.M{"updated_at": time.Now()}}
 	_, err := br.blogCollection.UpdateOne(ctx, filter, update)
 	if err != nil {
 		return err
@@ -207,7 +225,7 @@ func (br *BlogRepository) AddLike(ctx context.Context, blogID string, userID str
 
 func (br *BlogRepository) RemoveLike(ctx context.Context, blogID string, userID string) error {
 	filter := bson.M{"id": blogID}
-	update := bson.M{"$pull": bson.M{"likes": userID}}
+	update := bson.M{"$pull": bson.M{"likes": userID}, "$set": bson.M{"updated_at": time.Now()}}
 	_, err := br.blogCollection.UpdateOne(ctx, filter, update)
 	if err != nil {
 		return err
@@ -223,7 +241,7 @@ func (br *BlogRepository) AddComment(ctx context.Context, comment *blogpkg.Comme
 		return nil, err
 	}
 	// Update the blog to include the new comment ID
-	update := bson.M{"$push": bson.M{"comments": comment.ID}}
+	update := bson.M{"$push": bson.M{"comments": comment.ID}, "$set": bson.M{"updated_at": time.Now()}}
 	result, err := br.blogCollection.UpdateOne(ctx, bson.M{"id": comment.BlogID.Hex()}, update)
 	if err != nil {
 		return nil, err
@@ -234,10 +252,68 @@ func (br *BlogRepository) AddComment(ctx context.Context, comment *blogpkg.Comme
 	return comment, nil
 }
 
+func (br *BlogRepository) GetComment(ctx context.Context, blogID string, commentID string) (*blogpkg.Comment, error) {
+	filter, err := commentFilter(blogID, commentID)
+	if err != nil {
+		return nil, err
+	}
+	var comment blogpkg.Comment
+	err = br.commentCollection.FindOne(ctx, filter).Decode(&comment)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, blogpkg.ErrCommentNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &comment, nil
+}
+
+func (br *BlogRepository) DeleteComment(ctx context.Context, blogID string, commentID string) error {
+	filter, err := commentFilter(blogID, commentID)
+	if err != nil {
+		return err
+	}
+	res, err := br.commentCollection.DeleteOne(ctx, filter)
+	if err != nil {
+		return err
+	}
+	if res.DeletedCount == 0 {
+		return blogpkg.ErrCommentNotFound
+	}
+	_, err = br.blogCollection.UpdateOne(ctx, bson.M{"id": blogID}, bson.M{
+		"$pull": bson.M{"comments": filter["id"]},
+		"$set":  bson.M{"updated_at": time.Now()},
+	})
+	return err
+}
+
+// commentFilter matches a comment only under its own blog; malformed IDs match nothing
+func commentFilter(blogID, commentID string) (bson.M, error) {
+	blogOID, err := primitive.ObjectIDFromHex(blogID)
+	if err != nil {
+		return nil, blogpkg.ErrCommentNotFound
+	}
+	commentOID, err := primitive.ObjectIDFromHex(commentID)
+	if err != nil {
+		return nil, blogpkg.ErrCommentNotFound
+	}
+	return bson.M{"id": commentOID, "blog_id": blogOID}, nil
+}
+
+func (br *BlogRepository) SetReview(ctx context.Context, blogID string, review blogpkg.Review) (*blogpkg.Blog, error) {
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+	var blog blogpkg.Blog
+	err := br.blogCollection.FindOneAndUpdate(ctx, bson.M{"id": blogID}, bson.M{"$set": bson.M{"review": review, "updated_at": review.ReviewedAt}}, opts).Decode(&blog)
+	if err != nil {
+		return nil, err
+	}
+	return &blog, nil
+}
+
 func (br *BlogRepository) UpdateViewCount(ctx context.Context, blogID string) error {
 
 	filter := bson.M{"id": blogID}
-	update := bson.M{"$inc": bson.M{"views": 1}}
+	update := bson.M{"$inc": bson.M{"views": 1}, "$set": bson.M{"updated_at": time.Now()}}
 	_, err := br.blogCollection.UpdateOne(ctx, filter, update)
 	if err != nil {
 		return err
@@ -254,3 +330,66 @@ func (br *BlogRepository) FindBlogByID(id string) (*blogpkg.Blog, error) {
 	}
 	return &blog, nil
 }
+
+// GetBlogsByIDs fetches the given blogs, preserving the order of ids and skipping missing ones
+func (br *BlogRepository) GetBlogsByIDs(ctx context.Context, ids []string) ([]blogpkg.Blog, error) {
+	if len(ids) == 0 {
+		return []blogpkg.Blog{}, nil
+	}
+	cursor, err := br.blogCollection.Find(ctx, bson.M{"id": bson.M{"$in": ids}})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var found []blogpkg.Blog
+	if err = cursor.All(ctx, &found); err != nil {
+		return nil, err
+	}
+
+	byID := make(map[string]blogpkg.Blog, len(found))
+	for _, b := range found {
+		byID[b.ID] = b
+	}
+	blogs := make([]blogpkg.Blog, 0, len(found))
+	for _, id := range ids {
+		if b, ok := byID[id]; ok {
+			blogs = append(blogs, b)
+		}
+	}
+	return blogs, nil
+}
+
+// ListBlogsExcluding returns newest-first blogs not in excludeIDs, along with how many such blogs exist.
+// A non-positive limit only counts.
+func (br *BlogRepository) ListBlogsExcluding(ctx context.Context, excludeIDs []string, skip, limit int64) ([]blogpkg.Blog, int64, error) {
+	filter := bson.M{}
+	if len(excludeIDs) > 0 {
+		filter["id"] = bson.M{"$nin": excludeIDs}
+	}
+
+	total, err := br.blogCollection.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+	if limit <= 0 {
+		return []blogpkg.Blog{}, total, nil
+	}
+
+	findOptions := options.Find().
+		SetLimit(limit).
+		SetSkip(skip).
+		SetSort(bson.D{{Key: "created_at", Value: -1}})
+
+	cursor, err := br.blogCollection.Find(ctx, filter, findOptions)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer cursor.Close(ctx)
+
+	var blogs []blogpkg.Blog
+	if err = cursor.All(ctx, &blogs); err != nil {
+		return nil, 0, err
+	}
+	return blogs, total, nil
+}
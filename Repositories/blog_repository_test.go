@@ -249,6 +249,40 @@ func (s *blogRepositoryTestSuite) TestUpdateBlog_Success() {
 	assert.ElementsMatch(updated.Tags, found.Tags)
 }
 
+func (s *blogRepositoryTestSuite) TestUpdateBlog_KeepsServerOwnedFields() {
+	assert := assert.New(s.T())
+	created := time.Now().Add(-time.Hour).Truncate(time.Millisecond)
+	blog := &blogpkg.Blog{
+		ID:        "id-1",
+		Title:     "Original Title",
+		Content:   "Original Content",
+		AuthorID:  "author-1",
+		Likes:     []string{"user-1"},
+		Views:     7,
+		CreatedAt: created,
+		UpdatedAt: created,
+	}
+	_, err := s.blogRepo.CreateBlog(blog)
+	assert.NoError(err)
+
+	// A client-supplied body trying to reset counters and ownership
+	updated := &blogpkg.Blog{
+		Title:     "Updated Title",
+		Content:   "Updated Content",
+		AuthorID:  "attacker",
+		Likes:     []string{},
+		Views:     0,
+		UpdatedAt: time.Now(),
+	}
+	result, err := s.blogRepo.UpdateBlog(blog.ID, updated)
+	assert.NoError(err)
+	assert.Equal("Updated Title", result.Title)
+	assert.Equal("author-1", result.AuthorID)
+	assert.Equal([]string{"user-1"}, result.Likes)
+	assert.Equal(7, result.Views)
+	assert.True(created.Equal(result.CreatedAt.Truncate(time.Millisecond)))
+}
+
 func (s *blogRepositoryTestSuite) TestUpdateBlog_NotFound() {
 	assert := assert.New(s.T())
 	updated := &blogpkg.Blog{
@@ -264,6 +298,41 @@ func (s *blogRepositoryTestSuite) TestUpdateBlog_NotFound() {
 	assert.Error(err)
 }
 
+func (s *blogRepositoryTestSuite) TestGetBlogsByIDs_PreservesOrder() {
+	assert := assert.New(s.T())
+	for _, id := range []string{"a", "b", "c"} {
+		_, err := s.blogRepo.CreateBlog(&blogpkg.Blog{ID: id, Title: id, CreatedAt: time.Now()})
+		assert.NoError(err)
+	}
+
+	blogs, err := s.blogRepo.GetBlogsByIDs(s.ctx, []string{"c", "missing", "a"})
+	assert.NoError(err)
+	assert.Len(blogs, 2)
+	assert.Equal("c", blogs[0].ID)
+	assert.Equal("a", blogs[1].ID)
+}
+
+func (s *blogRepositoryTestSuite) TestListBlogsExcluding() {
+	assert := assert.New(s.T())
+	base := time.Now()
+	for i, id := range []string{"a", "b", "c", "d"} {
+		_, err := s.blogRepo.CreateBlog(&blogpkg.Blog{ID: id, Title: id, CreatedAt: base.Add(time.Duration(i) * time.Minute)})
+		assert.NoError(err)
+	}
+
+	blogs, total, err := s.blogRepo.ListBlogsExcluding(s.ctx, []string{"c"}, 1, 5)
+	assert.NoError(err)
+	assert.Equal(int64(3), total)
+	assert.Len(blogs, 2)
+	assert.Equal("b", blogs[0].ID)
+	assert.Equal("a", blogs[1].ID)
+
+	blogs, total, err = s.blogRepo.ListBlogsExcluding(s.ctx, nil, 0, 0)
+	assert.NoError(err)
+	assert.Equal(int64(4), total)
+	assert.Empty(blogs)
+}
+
 func (s *blogRepositoryTestSuite) TestDeleteBlog_Success() {
 	assert := assert.New(s.T())
 	// Insert a blog
@@ -550,6 +619,35 @@ func (s *blogRepositoryTestSuite) TestUpdateViewCount() {
 	assert.NoError(err)
 }
 
+func (s *blogRepositoryTestSuite) TestLikesCommentsAndViewsMoveUpdatedAt() {
+	assert := assert.New(s.T())
+	blogOID := primitive.NewObjectID()
+	old := time.Now().Add(-time.Hour).Truncate(time.Millisecond)
+	blog := &blogpkg.Blog{ID: blogOID.Hex(), Title: "T", Content: "C", CreatedAt: old, UpdatedAt: old}
+	_, err := s.blogRepo.CreateBlog(blog)
+	assert.NoError(err)
+
+	writes := map[string]func() error{
+		"like":   func() error { return s.blogRepo.AddLike(s.ctx, blog.ID, "user-1") },
+		"unlike": func() error { return s.blogRepo.RemoveLike(s.ctx, blog.ID, "user-1") },
+		"view":   func() error { return s.blogRepo.UpdateViewCount(s.ctx, blog.ID) },
+		"comment": func() error {
+			_, err := s.blogRepo.AddComment(s.ctx, &blogpkg.Comment{BlogID: blogOID, Content: "hi", CreatedAt: time.Now()})
+			return err
+		},
+	}
+	for name, write := range writes {
+		_, err := s.blogCollection.UpdateOne(s.ctx, bson.M{"id": blog.ID}, bson.M{"$set": bson.M{"updated_at": old}})
+		assert.NoError(err)
+
+		assert.NoError(write(), name)
+
+		var found blogpkg.Blog
+		assert.NoError(s.blogCollection.FindOne(s.ctx, bson.M{"id": blog.ID}).Decode(&found))
+		assert.True(found.UpdatedAt.After(old), name)
+	}
+}
+
 func (s *blogRepositoryTestSuite) TestFindBlogByID() {
 	assert := assert.New(s.T())
 	// Insert a blog
@@ -0,0 +1,349 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	blogpkg "github.com/Amaankaa/Blog-Starter-Project/Domain/blog"
+	infrastructure "github.com/Amaankaa/Blog-Starter-Project/Infrastructure"
+)
+
+type listingKind int
+
+const (
+	listingAll listingKind = iota
+	listingSearch
+	listingTags
+)
+
+// cachedListing is a cached page together with the IDs it contains,
+// so writes to a single blog only drop the pages that show it.
+type cachedListing struct {
+	kind     listingKind
+	response blogpkg.PaginationResponse
+	blogIDs  map[string]struct{}
+}
+
+// CachedBlogRepository decorates an IBlogRepository with an in-process LRU cache
+// for reads. Every write that goes through it invalidates exactly the entries it can affect.
+type CachedBlogRepository struct {
+	inner         blogpkg.IBlogRepository
+	blogs         *infrastructure.LRUCache[string, blogpkg.Blog]
+	listings      *infrastructure.LRUCache[string, cachedListing]
+	invalidations atomic.Uint64
+	// generation is bumped on every write so a read that raced with it does not
+	// repopulate the cache with data loaded before the write.
+	generation atomic.Uint64
+	// fillMu is held exclusively while invalidating and shared while storing, so a store can't
+	// land between a write's generation bump and its removals
+	fillMu sync.RWMutex
+}
+
+func NewCachedBlogRepository(inner blogpkg.IBlogRepository, capacity int, ttl time.Duration) *CachedBlogRepository {
+	return &CachedBlogRepository{
+		inner:    inner,
+		blogs:    infrastructure.NewLRUCache[string, blogpkg.Blog](capacity, ttl),
+		listings: infrastructure.NewLRUCache[string, cachedListing](capacity, ttl),
+	}
+}
+
+func (cr *CachedBlogRepository) CreateBlog(blog *blogpkg.Blog) (*blogpkg.Blog, error) {
+	created, err := cr.inner.CreateBlog(blog)
+	if err != nil {
+		return nil, err
+	}
+	// A new post shifts every page and total
+	cr.invalidateListings(func(cachedListing) bool { return true })
+	return created, nil
+}
+
+func (cr *CachedBlogRepository) GetBlogByID(id string) (*blogpkg.Blog, error) {
+	return cr.getBlog(id, cr.inner.GetBlogByID)
+}
+
+func (cr *CachedBlogRepository) FindBlogByID(id string) (*blogpkg.Blog, error) {
+	return cr.getBlog(id, cr.inner.FindBlogByID)
+}
+
+func (cr *CachedBlogRepository) GetAllBlogs(ctx context.Context, pagination blogpkg.PaginationRequest) (blogpkg.PaginationResponse, error) {
+	key := listingKey(listingAll, "", pagination)
+	return cr.getListing(key, listingAll, func() (blogpkg.PaginationResponse, error) {
+		return cr.inner.GetAllBlogs(ctx, pagination)
+	})
+}
+
+func (cr *CachedBlogRepository) SearchBlogs(ctx context.Context, query string, pagination blogpkg.PaginationRequest) (blogpkg.PaginationResponse, error) {
+	key := listingKey(listingSearch, query, pagination)
+	return cr.getListing(key, listingSearch, func() (blogpkg.PaginationResponse, error) {
+		return cr.inner.SearchBlogs(ctx, query, pagination)
+	})
+}
+
+func (cr *CachedBlogRepository) FilterByTags(ctx context.Context, tags []string, pagination blogpkg.PaginationRequest) (blogpkg.PaginationResponse, error) {
+	key := listingKey(listingTags, tagsKey(tags), pagination)
+	return cr.getListing(key, listingTags, func() (blogpkg.PaginationResponse, error) {
+		return cr.inner.FilterByTags(ctx, tags, pagination)
+	})
+}
+
+// GetBlogsByIDs serves cached posts directly and loads only the missing ones.
+func (cr *CachedBlogRepository) GetBlogsByIDs(ctx context.Context, ids []string) ([]blogpkg.Blog, error) {
+	found := make(map[string]blogpkg.Blog, len(ids))
+	var missing []string
+	for _, id := range ids {
+		if cached, ok := cr.blogs.Get(id); ok {
+			found[id] = *cloneBlog(&cached)
+		} else {
+			missing = append(missing, id)
+		}
+	}
+
+	if len(missing) > 0 {
+		gen := cr.generation.Load()
+		loaded, err := cr.inner.GetBlogsByIDs(ctx, missing)
+		if err != nil {
+			return nil, err
+		}
+		for i := range loaded {
+			found[loaded[i].ID] = loaded[i]
+		}
+		cr.storeIfCurrent(gen, func() {
+			for i := range loaded {
+				cr.blogs.Set(loaded[i].ID, *cloneBlog(&loaded[i]))
+			}
+		})
+	}
+
+	blogs := make([]blogpkg.Blog, 0, len(found))
+	for _, id := range ids {
+		if b, ok := found[id]; ok {
+			blogs = append(blogs, b)
+		}
+	}
+	return blogs, nil
+}
+
+func (cr *CachedBlogRepository) ListBlogsExcluding(ctx context.Context, excludeIDs []string, skip, limit int64) ([]blogpkg.Blog, int64, error) {
+	key := fmt.Sprintf("excluding|%s|%d|%d", strings.Join(excludeIDs, ","), skip, limit)
+	page, err := cr.getListing(key, listingAll, func() (blogpkg.PaginationResponse, error) {
+		blogs, total, err := cr.inner.ListBlogsExcluding(ctx, excludeIDs, skip, limit)
+		return blogpkg.PaginationResponse{Data: blogs, Total: total}, err
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+	return page.Data, page.Total, nil
+}
+
+func (cr *CachedBlogRepository) UpdateBlog(id string, blog *blogpkg.Blog) (*blogpkg.Blog, error) {
+	updated, err := cr.inner.UpdateBlog(id, blog)
+	if err != nil {
+		return nil, err
+	}
+	// Title, content and tags decide search and tag membership, so those pages may gain
+	// or lose the post. Creation order is unchanged, so plain listings only need the pages showing it.
+	cr.invalidateBlog(id)
+	cr.invalidateListings(func(l cachedListing) bool {
+		return l.kind != listingAll || l.contains(id)
+	})
+	return updated, nil
+}
+
+func (cr *CachedBlogRepository) DeleteBlog(id string) error {
+	if err := cr.inner.DeleteBlog(id); err != nil {
+		return err
+	}
+	cr.invalidateBlog(id)
+	cr.invalidateListings(func(cachedListing) bool { return true })
+	return nil
+}
+
+func (cr *CachedBlogRepository) AddLike(ctx context.Context, blogID string, userID string) error {
+	if err := cr.inner.AddLike(ctx, blogID, userID); err != nil {
+		return err
+	}
+	cr.invalidateBlogEverywhere(blogID)
+	return nil
+}
+
+func (cr *CachedBlogRepository) RemoveLike(ctx context.Context, blogID string, userID string) error {
+	if err := cr.inner.RemoveLike(ctx, blogID, userID); err != nil {
+		return err
+	}
+	cr.invalidateBlogEverywhere(blogID)
+	return nil
+}
+
+func (cr *CachedBlogRepository) AddComment(ctx context.Context, comment *blogpkg.Comment) (*blogpkg.Comment, error) {
+	created, err := cr.inner.AddComment(ctx, comment)
+	if err != nil {
+		return nil, err
+	}
+	cr.invalidateBlogEverywhere(comment.BlogID.Hex())
+	return created, nil
+}
+
+func (cr *CachedBlogRepository) GetComment(ctx context.Context, blogID string, commentID string) (*blogpkg.Comment, error) {
+	return cr.inner.GetComment(ctx, blogID, commentID)
+}
+
+func (cr *CachedBlogRepository) DeleteComment(ctx context.Context, blogID string, commentID string) error {
+	if err := cr.inner.DeleteComment(ctx, blogID, commentID); err != nil {
+		return err
+	}
+	cr.invalidateBlogEverywhere(blogID)
+	return nil
+}
+
+func (cr *CachedBlogRepository) SetReview(ctx context.Context, blogID string, review blogpkg.Review) (*blogpkg.Blog, error) {
+	reviewed, err := cr.inner.SetReview(ctx, blogID, review)
+	if err != nil {
+		return nil, err
+	}
+	cr.invalidateBlogEverywhere(blogID)
+	return reviewed, nil
+}
+
+// UpdateViewCount leaves the cache alone, so cached view counts lag by up to the TTL.
+// Invalidating on every view would keep popular posts out of the cache, and the generation
+// bump would stop listings from being cached at all while anyone is reading.
+func (cr *CachedBlogRepository) UpdateViewCount(ctx context.Context, blogID string) error {
+	return cr.inner.UpdateViewCount(ctx, blogID)
+}
+
+// CacheStats returns the combined counters of the blog and listing caches.
+func (cr *CachedBlogRepository) CacheStats() blogpkg.CacheStats {
+	b, l := cr.blogs.Stats(), cr.listings.Stats()
+	stats := blogpkg.CacheStats{
+		Hits:          b.Hits + l.Hits,
+		Misses:        b.Misses + l.Misses,
+		Evictions:     b.Evictions + l.Evictions,
+		Invalidations: cr.invalidations.Load(),
+		Entries:       b.Entries + l.Entries,
+	}
+	if lookups := stats.Hits + stats.Misses; lookups > 0 {
+		stats.HitRatio = float64(stats.Hits) / float64(lookups)
+	}
+	return stats
+}
+
+func (cr *CachedBlogRepository) getBlog(id string, load func(string) (*blogpkg.Blog, error)) (*blogpkg.Blog, error) {
+	if cached, ok := cr.blogs.Get(id); ok {
+		return cloneBlog(&cached), nil
+	}
+	gen := cr.generation.Load()
+	blog, err := load(id)
+	if err != nil {
+		return nil, err
+	}
+	if blog != nil {
+		cr.storeIfCurrent(gen, func() { cr.blogs.Set(id, *cloneBlog(blog)) })
+	}
+	return blog, nil
+}
+
+func (cr *CachedBlogRepository) getListing(key string, kind listingKind, load func() (blogpkg.PaginationResponse, error)) (blogpkg.PaginationResponse, error) {
+	if cached, ok := cr.listings.Get(key); ok {
+		return clonePage(cached.response), nil
+	}
+	gen := cr.generation.Load()
+	page, err := load()
+	if err != nil {
+		return blogpkg.PaginationResponse{}, err
+	}
+	ids := make(map[string]struct{}, len(page.Data))
+	for _, b := range page.Data {
+		ids[b.ID] = struct{}{}
+	}
+	cr.storeIfCurrent(gen, func() {
+		cr.listings.Set(key, cachedListing{kind: kind, response: clonePage(page), blogIDs: ids})
+	})
+	return page, nil
+}
+
+// storeIfCurrent runs store unless a write has invalidated the cache since gen was read
+func (cr *CachedBlogRepository) storeIfCurrent(gen uint64, store func()) {
+	cr.fillMu.RLock()
+	defer cr.fillMu.RUnlock()
+	if cr.generation.Load() == gen {
+		store()
+	}
+}
+
+// invalidateBlogEverywhere drops the cached post and every page that displays it.
+func (cr *CachedBlogRepository) invalidateBlogEverywhere(id string) {
+	cr.invalidateBlog(id)
+	cr.invalidateListings(func(l cachedListing) bool { return l.contains(id) })
+}
+
+func (cr *CachedBlogRepository) invalidateBlog(id string) {
+	cr.fillMu.Lock()
+	defer cr.fillMu.Unlock()
+	cr.generation.Add(1)
+	if cr.blogs.Remove(id) {
+		cr.invalidations.Add(1)
+	}
+}
+
+func (cr *CachedBlogRepository) invalidateListings(match func(cachedListing) bool) {
+	cr.fillMu.Lock()
+	defer cr.fillMu.Unlock()
+	cr.generation.Add(1)
+	removed := cr.listings.RemoveFunc(func(_ string, l cachedListing) bool { return match(l) })
+	cr.invalidations.Add(uint64(removed))
+}
+
+func (l cachedListing) contains(id string) bool {
+	_, ok := l.blogIDs[id]
+	return ok
+}
+
+func listingKey(kind listingKind, query string, p blogpkg.PaginationRequest) string {
+	return fmt.Sprintf("%d|%s|%d|%d", kind, query, p.Page, p.Limit)
+}
+
+// tagsKey quotes each tag so that a tag containing the separator can't pass for two tags
+func tagsKey(tags []string) string {
+	quoted := make([]string, len(tags))
+	for i, tag := range tags {
+		quoted[i] = strconv.Quote(tag)
+	}
+	return strings.Join(quoted, ",")
+}
+
+// Callers such as BlogUsecase.GetBlogByID mutate returned blogs, so the cache never hands out its own copy.
+func cloneBlog(b *blogpkg.Blog) *blogpkg.Blog {
+	c := *b
+	c.Tags = cloneStrings(b.Tags)
+	c.Likes = cloneStrings(b.Likes)
+	if b.Review != nil {
+		review := *b.Review
+		c.Review = &review
+	}
+	return &c
+}
+
+// cloneStrings keeps the nil/empty distinction so JSON output is unchanged.
+func cloneStrings(s []string) []string {
+	if s == nil {
+		return nil
+	}
+	return append(make([]string, 0, len(s)), s...)
+}
+
+func clonePage(p blogpkg.PaginationResponse) blogpkg.PaginationResponse {
+	if p.Data == nil {
+		return p
+	}
+	data := make([]blogpkg.Blog, len(p.Data))
+	for i := range p.Data {
+		data[i] = *cloneBlog(&p.Data[i])
+	}
+	p.Data = data
+	return p
+}
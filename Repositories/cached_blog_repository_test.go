@@ -0,0 +1,226 @@
+package repositories_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	blogpkg "github.com/Amaankaa/Blog-Starter-Project/Domain/blog"
+	repositories "github.com/Amaankaa/Blog-Starter-Project/Repositories"
+	"github.com/Amaankaa/Blog-Starter-Project/mocks"
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type cachedBlogRepositoryTestSuite struct {
+	suite.Suite
+	ctx   context.Context
+	inner *mocks.IBlogRepository
+	repo  *repositories.CachedBlogRepository
+}
+
+func TestCachedBlogRepositoryTestSuite(t *testing.T) {
+	suite.Run(t, new(cachedBlogRepositoryTestSuite))
+}
+
+func (s *cachedBlogRepositoryTestSuite) SetupTest() {
+	s.ctx = context.Background()
+	s.inner = mocks.NewIBlogRepository(s.T())
+	s.repo = repositories.NewCachedBlogRepository(s.inner, 10, time.Minute)
+}
+
+func page(ids ...string) blogpkg.PaginationResponse {
+	data := make([]blogpkg.Blog, len(ids))
+	for i, id := range ids {
+		data[i] = blogpkg.Blog{ID: id, Title: "Blog " + id, Likes: []string{}}
+	}
+	return blogpkg.PaginationResponse{Data: data, Total: int64(len(ids)), Page: 1, Limit: 10, TotalPages: 1}
+}
+
+func (s *cachedBlogRepositoryTestSuite) TestGetAllBlogs_CachesPerPagination() {
+	p1 := blogpkg.PaginationRequest{Page: 1, Limit: 10}
+	p2 := blogpkg.PaginationRequest{Page: 2, Limit: 10}
+	s.inner.On("GetAllBlogs", s.ctx, p1).Return(page("a", "b"), nil).Once()
+	s.inner.On("GetAllBlogs", s.ctx, p2).Return(page("c"), nil).Once()
+
+	for i := 0; i < 3; i++ {
+		res, err := s.repo.GetAllBlogs(s.ctx, p1)
+		s.NoError(err)
+		s.Len(res.Data, 2)
+	}
+	res, err := s.repo.GetAllBlogs(s.ctx, p2)
+	s.NoError(err)
+	s.Len(res.Data, 1)
+
+	stats := s.repo.CacheStats()
+	s.Equal(uint64(2), stats.Hits)
+	s.Equal(uint64(2), stats.Misses)
+	s.Equal(2, stats.Entries)
+	s.InDelta(0.5, stats.HitRatio, 0.001)
+}
+
+func (s *cachedBlogRepositoryTestSuite) TestErrorsAreNotCached() {
+	p := blogpkg.PaginationRequest{Page: 1, Limit: 10}
+	s.inner.On("SearchBlogs", s.ctx, "go", p).Return(blogpkg.PaginationResponse{}, errors.New("db down")).Once()
+	s.inner.On("SearchBlogs", s.ctx, "go", p).Return(page("a"), nil).Once()
+
+	_, err := s.repo.SearchBlogs(s.ctx, "go", p)
+	s.Error(err)
+	res, err := s.repo.SearchBlogs(s.ctx, "go", p)
+	s.NoError(err)
+	s.Len(res.Data, 1)
+}
+
+func (s *cachedBlogRepositoryTestSuite) TestReturnedBlogsAreCopies() {
+	s.inner.On("GetBlogByID", "a").Return(&blogpkg.Blog{ID: "a", Views: 1, Likes: []string{}}, nil).Once()
+
+	first, err := s.repo.GetBlogByID("a")
+	s.NoError(err)
+	first.Views++
+	first.Likes = append(first.Likes, "u1")
+
+	second, err := s.repo.GetBlogByID("a")
+	s.NoError(err)
+	s.Equal(1, second.Views)
+	s.Empty(second.Likes)
+	s.NotNil(second.Likes)
+}
+
+func (s *cachedBlogRepositoryTestSuite) TestLikeInvalidatesOnlyAffectedEntries() {
+	p := blogpkg.PaginationRequest{Page: 1, Limit: 10}
+	tags := []string{"go"}
+	s.inner.On("GetAllBlogs", s.ctx, p).Return(page("a", "b"), nil).Twice()
+	s.inner.On("FilterByTags", s.ctx, tags, p).Return(page("c"), nil).Once()
+	s.inner.On("FindBlogByID", "a").Return(&blogpkg.Blog{ID: "a"}, nil).Twice()
+	s.inner.On("FindBlogByID", "c").Return(&blogpkg.Blog{ID: "c"}, nil).Once()
+	s.inner.On("AddLike", s.ctx, "a", "u1").Return(nil).Once()
+
+	s.repo.GetAllBlogs(s.ctx, p)
+	s.repo.FilterByTags(s.ctx, tags, p)
+	s.repo.FindBlogByID("a")
+	s.repo.FindBlogByID("c")
+
+	s.NoError(s.repo.AddLike(s.ctx, "a", "u1"))
+
+	// entries showing "a" are reloaded, the others are still served from cache
+	s.repo.GetAllBlogs(s.ctx, p)
+	s.repo.FilterByTags(s.ctx, tags, p)
+	s.repo.FindBlogByID("a")
+	s.repo.FindBlogByID("c")
+	s.Equal(uint64(2), s.repo.CacheStats().Invalidations)
+}
+
+func (s *cachedBlogRepositoryTestSuite) TestCommentInvalidatesBlog() {
+	blogOID := primitive.NewObjectID()
+	id := blogOID.Hex()
+	comment := &blogpkg.Comment{BlogID: blogOID, Content: "hi"}
+	s.inner.On("GetBlogByID", id).Return(&blogpkg.Blog{ID: id}, nil).Twice()
+	s.inner.On("AddComment", s.ctx, comment).Return(comment, nil).Once()
+
+	s.repo.GetBlogByID(id)
+	_, err := s.repo.AddComment(s.ctx, comment)
+	s.NoError(err)
+	s.repo.GetBlogByID(id)
+}
+
+func (s *cachedBlogRepositoryTestSuite) TestViewsKeepTheCache() {
+	p := blogpkg.PaginationRequest{Page: 1, Limit: 10}
+	s.inner.On("GetAllBlogs", s.ctx, p).Return(page("a"), nil).Once()
+	s.inner.On("GetBlogByID", "a").Return(&blogpkg.Blog{ID: "a"}, nil).Once()
+	s.inner.On("UpdateViewCount", s.ctx, "a").Return(nil).Once()
+
+	s.repo.GetAllBlogs(s.ctx, p)
+	s.repo.GetBlogByID("a")
+	s.NoError(s.repo.UpdateViewCount(s.ctx, "a"))
+	s.repo.GetAllBlogs(s.ctx, p)
+	s.repo.GetBlogByID("a")
+
+	s.Zero(s.repo.CacheStats().Invalidations)
+}
+
+func (s *cachedBlogRepositoryTestSuite) TestTagListingsAreKeyedUnambiguously() {
+	p := blogpkg.PaginationRequest{Page: 1, Limit: 10}
+	s.inner.On("FilterByTags", s.ctx, []string{"a,b"}, p).Return(page("x"), nil).Once()
+	s.inner.On("FilterByTags", s.ctx, []string{"a", "b"}, p).Return(page("y"), nil).Once()
+
+	first, err := s.repo.FilterByTags(s.ctx, []string{"a,b"}, p)
+	s.NoError(err)
+	second, err := s.repo.FilterByTags(s.ctx, []string{"a", "b"}, p)
+	s.NoError(err)
+
+	s.Equal("x", first.Data[0].ID)
+	s.Equal("y", second.Data[0].ID)
+}
+
+func (s *cachedBlogRepositoryTestSuite) TestUpdateInvalidatesSearchAndTagListings() {
+	p := blogpkg.PaginationRequest{Page: 1, Limit: 10}
+	tags := []string{"go"}
+	updated := &blogpkg.Blog{ID: "a", Title: "New", Tags: tags}
+	s.inner.On("GetAllBlogs", s.ctx, p).Return(page("b"), nil).Once()
+	s.inner.On("FilterByTags", s.ctx, tags, p).Return(page("b"), nil).Once()
+	s.inner.On("FilterByTags", s.ctx, tags, p).Return(page("a", "b"), nil).Once()
+	s.inner.On("UpdateBlog", "a", updated).Return(updated, nil).Once()
+
+	s.repo.GetAllBlogs(s.ctx, p)
+	s.repo.FilterByTags(s.ctx, tags, p)
+	_, err := s.repo.UpdateBlog("a", updated)
+	s.NoError(err)
+
+	// "a" may now match the tag filter, but it is not on the cached page of all blogs
+	s.repo.GetAllBlogs(s.ctx, p)
+	res, _ := s.repo.FilterByTags(s.ctx, tags, p)
+	s.Len(res.Data, 2)
+}
+
+func (s *cachedBlogRepositoryTestSuite) TestCreateAndDeleteInvalidateAllListings() {
+	p := blogpkg.PaginationRequest{Page: 1, Limit: 10}
+	blog := &blogpkg.Blog{Title: "New"}
+	s.inner.On("GetAllBlogs", s.ctx, p).Return(page("a"), nil).Times(3)
+	s.inner.On("CreateBlog", blog).Return(&blogpkg.Blog{ID: "n", Title: "New"}, nil).Once()
+	s.inner.On("DeleteBlog", "z").Return(nil).Once()
+
+	s.repo.GetAllBlogs(s.ctx, p)
+	_, err := s.repo.CreateBlog(blog)
+	s.NoError(err)
+	s.repo.GetAllBlogs(s.ctx, p)
+	s.NoError(s.repo.DeleteBlog("z"))
+	s.repo.GetAllBlogs(s.ctx, p)
+}
+
+func (s *cachedBlogRepositoryTestSuite) TestFailedWriteKeepsCache() {
+	s.inner.On("FindBlogByID", "a").Return(&blogpkg.Blog{ID: "a"}, nil).Once()
+	s.inner.On("RemoveLike", s.ctx, "a", "u1").Return(errors.New("write failed")).Once()
+
+	s.repo.FindBlogByID("a")
+	s.Error(s.repo.RemoveLike(s.ctx, "a", "u1"))
+	s.repo.FindBlogByID("a")
+}
+
+func (s *cachedBlogRepositoryTestSuite) TestEvictsLeastRecentlyUsed() {
+	repo := repositories.NewCachedBlogRepository(s.inner, 2, time.Minute)
+	for _, id := range []string{"a", "b", "c"} {
+		s.inner.On("FindBlogByID", id).Return(&blogpkg.Blog{ID: id}, nil).Once()
+	}
+	s.inner.On("FindBlogByID", "a").Return(&blogpkg.Blog{ID: "a"}, nil).Once()
+
+	repo.FindBlogByID("a")
+	repo.FindBlogByID("b")
+	repo.FindBlogByID("c") // evicts "a"
+	repo.FindBlogByID("c")
+	repo.FindBlogByID("a")
+
+	stats := repo.CacheStats()
+	s.Equal(uint64(2), stats.Evictions)
+	s.Equal(2, stats.Entries)
+}
+
+func (s *cachedBlogRepositoryTestSuite) TestEntriesExpire() {
+	repo := repositories.NewCachedBlogRepository(s.inner, 10, 20*time.Millisecond)
+	s.inner.On("FindBlogByID", "a").Return(&blogpkg.Blog{ID: "a"}, nil).Twice()
+
+	repo.FindBlogByID("a")
+	repo.FindBlogByID("a")
+	time.Sleep(30 * time.Millisecond)
+	repo.FindBlogByID("a")
+}
@@ -0,0 +1,62 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+
+	emaildomainpkg "github.com/Amaankaa/Blog-Starter-Project/Domain/emaildomain"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// DomainRuleRepository keys rules by domain, so a domain has at most one rule
+type DomainRuleRepository struct {
+	collection *mongo.Collection
+}
+
+func NewDomainRuleRepository(collection *mongo.Collection) *DomainRuleRepository {
+	return &DomainRuleRepository{collection: collection}
+}
+
+func (r *DomainRuleRepository) UpsertRule(ctx context.Context, rule emaildomainpkg.DomainRule) (emaildomainpkg.DomainRule, error) {
+	_, err := r.collection.ReplaceOne(ctx, bson.M{"_id": rule.Domain}, rule, options.Replace().SetUpsert(true))
+	if err != nil {
+		return emaildomainpkg.DomainRule{}, err
+	}
+	return rule, nil
+}
+
+func (r *DomainRuleRepository) ListRules(ctx context.Context) ([]emaildomainpkg.DomainRule, error) {
+	return r.find(ctx, bson.M{})
+}
+
+func (r *DomainRuleRepository) DeleteRule(ctx context.Context, domain string) error {
+	res, err := r.collection.DeleteOne(ctx, bson.M{"_id": domain})
+	if err != nil {
+		return err
+	}
+	if res.DeletedCount == 0 {
+		return errors.New("domain rule not found")
+	}
+	return nil
+}
+
+func (r *DomainRuleRepository) FindRules(ctx context.Context, domains []string) ([]emaildomainpkg.DomainRule, error) {
+	return r.find(ctx, bson.M{"_id": bson.M{"$in": domains}})
+}
+
+func (r *DomainRuleRepository) find(ctx context.Context, filter bson.M) ([]emaildomainpkg.DomainRule, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "_id", Value: 1}})
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	rules := []emaildomainpkg.DomainRule{}
+	if err := cursor.All(ctx, &rules); err != nil {
+		return nil, err
+	}
+	return rules, nil
+}
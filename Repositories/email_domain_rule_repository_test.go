@@ -0,0 +1,87 @@
+package repositories_test
+
+import (
+	"context"
+	"log"
+	"os"
+	"testing"
+	"time"
+
+	emaildomainpkg "github.com/Amaankaa/Blog-Starter-Project/Domain/emaildomain"
+	repositories "github.com/Amaankaa/Blog-Starter-Project/Repositories"
+	"github.com/joho/godotenv"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const testDomainRuleCollection = "test_email_domain_rules"
+
+type domainRuleRepositoryTestSuite struct {
+	suite.Suite
+	client     *mongo.Client
+	ctx        context.Context
+	cancel     context.CancelFunc
+	collection *mongo.Collection
+	repo       *repositories.DomainRuleRepository
+}
+
+func TestDomainRuleRepositoryTestSuite(t *testing.T) {
+	suite.Run(t, new(domainRuleRepositoryTestSuite))
+}
+
+func (s *domainRuleRepositoryTestSuite) SetupSuite() {
+	err := godotenv.Load("../.env")
+	if err != nil {
+		log.Fatal("Error loading .env file")
+	}
+
+	client, err := mongo.Connect(context.Background(), options.Client().ApplyURI(os.Getenv("MONGODB_URI")))
+	s.Require().NoError(err)
+
+	s.client = client
+	s.collection = client.Database("test_blog_db").Collection(testDomainRuleCollection)
+	s.repo = repositories.NewDomainRuleRepository(s.collection)
+	s.ctx, s.cancel = context.WithTimeout(context.Background(), 10*time.Second)
+}
+
+func (s *domainRuleRepositoryTestSuite) TearDownSuite() {
+	s.collection.Drop(s.ctx)
+	s.cancel()
+	s.client.Disconnect(s.ctx)
+}
+
+func (s *domainRuleRepositoryTestSuite) SetupTest() {
+	_, err := s.collection.DeleteMany(s.ctx, bson.M{})
+	s.Require().NoError(err)
+}
+
+func (s *domainRuleRepositoryTestSuite) TestUpsertReplacesRuleForSameDomain() {
+	assert := assert.New(s.T())
+	_, err := s.repo.UpsertRule(s.ctx, emaildomainpkg.DomainRule{Domain: "example.com", Action: emaildomainpkg.ActionDeny})
+	assert.NoError(err)
+	_, err = s.repo.UpsertRule(s.ctx, emaildomainpkg.DomainRule{Domain: "example.com", Action: emaildomainpkg.ActionAllow})
+	assert.NoError(err)
+
+	rules, err := s.repo.ListRules(s.ctx)
+	assert.NoError(err)
+	assert.Len(rules, 1)
+	assert.Equal(emaildomainpkg.ActionAllow, rules[0].Action)
+}
+
+func (s *domainRuleRepositoryTestSuite) TestFindRulesAndDelete() {
+	assert := assert.New(s.T())
+	for _, domain := range []string{"example.com", "mail.example.com", "other.org"} {
+		_, err := s.repo.UpsertRule(s.ctx, emaildomainpkg.DomainRule{Domain: domain, Action: emaildomainpkg.ActionDeny})
+		assert.NoError(err)
+	}
+
+	rules, err := s.repo.FindRules(s.ctx, []string{"mail.example.com", "example.com"})
+	assert.NoError(err)
+	assert.Len(rules, 2)
+
+	assert.NoError(s.repo.DeleteRule(s.ctx, "other.org"))
+	assert.EqualError(s.repo.DeleteRule(s.ctx, "other.org"), "domain rule not found")
+}
@@ -0,0 +1,150 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	emailjobpkg "github.com/Amaankaa/Blog-Starter-Project/Domain/emailjob"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type EmailJobRepository struct {
+	jobs *mongo.Collection
+	dead *mongo.Collection
+}
+
+func NewEmailJobRepository(jobs, dead *mongo.Collection) *EmailJobRepository {
+	return &EmailJobRepository{jobs: jobs, dead: dead}
+}
+
+// EnsureIndexes creates the index workers use to find due jobs and lets MongoDB drop
+// dead letters once they are past retention
+func (r *EmailJobRepository) EnsureIndexes(ctx context.Context) error {
+	_, err := r.jobs.Indexes().CreateOne(ctx, mongo.IndexModel{Keys: bson.D{{Key: "run_at", Value: 1}}})
+	if err != nil {
+		return err
+	}
+	_, err = r.dead.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "failed_at", Value: 1}},
+		Options: options.Index().SetExpireAfterSeconds(int32(emailjobpkg.DeadLetterRetention.Seconds())),
+	})
+	return err
+}
+
+func (r *EmailJobRepository) Enqueue(ctx context.Context, job emailjobpkg.EmailJob) (emailjobpkg.EmailJob, error) {
+	job.ID = primitive.NewObjectID()
+	if _, err := r.jobs.InsertOne(ctx, job); err != nil {
+		return emailjobpkg.EmailJob{}, err
+	}
+	return job, nil
+}
+
+func (r *EmailJobRepository) ClaimNext(ctx context.Context, now time.Time, lease time.Duration) (emailjobpkg.EmailJob, bool, error) {
+	update := bson.M{"$set": bson.M{"run_at": now.Add(lease)}}
+	opts := options.FindOneAndUpdate().
+		SetSort(bson.D{{Key: "run_at", Value: 1}}).
+		SetReturnDocument(options.After)
+
+	var job emailjobpkg.EmailJob
+	err := r.jobs.FindOneAndUpdate(ctx, bson.M{"run_at": bson.M{"$lte": now}}, update, opts).Decode(&job)
+	if err == mongo.ErrNoDocuments {
+		return emailjobpkg.EmailJob{}, false, nil
+	}
+	if err != nil {
+		return emailjobpkg.EmailJob{}, false, err
+	}
+	return job, true, nil
+}
+
+// Complete removes a delivered job; successful jobs are not kept
+func (r *EmailJobRepository) Complete(ctx context.Context, id string) error {
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+	_, err = r.jobs.DeleteOne(ctx, bson.M{"_id": oid})
+	return err
+}
+
+func (r *EmailJobRepository) Reschedule(ctx context.Context, job emailjobpkg.EmailJob) error {
+	update := bson.M{"$set": bson.M{
+		"attempts":   job.Attempts,
+		"last_error": job.LastError,
+		"run_at":     job.RunAt,
+		"updated_at": job.UpdatedAt,
+	}}
+	res, err := r.jobs.UpdateOne(ctx, bson.M{"_id": job.ID}, update)
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount == 0 {
+		return errors.New("email job not found")
+	}
+	return nil
+}
+
+// Bury copies the job to the dead-letter collection before removing it from the
+// queue, so a crash in between can at worst leave a duplicate, never lose the job.
+func (r *EmailJobRepository) Bury(ctx context.Context, job emailjobpkg.EmailJob) error {
+	_, err := r.dead.ReplaceOne(ctx, bson.M{"_id": job.ID}, job, options.Replace().SetUpsert(true))
+	if err != nil {
+		return err
+	}
+	_, err = r.jobs.DeleteOne(ctx, bson.M{"_id": job.ID})
+	return err
+}
+
+func (r *EmailJobRepository) ListQueued(ctx context.Context, limit int64) ([]emailjobpkg.EmailJob, error) {
+	return r.list(ctx, r.jobs, bson.D{{Key: "run_at", Value: 1}}, limit)
+}
+
+func (r *EmailJobRepository) ListDead(ctx context.Context, limit int64) ([]emailjobpkg.EmailJob, error) {
+	return r.list(ctx, r.dead, bson.D{{Key: "failed_at", Value: -1}}, limit)
+}
+
+func (r *EmailJobRepository) Requeue(ctx context.Context, id string, now time.Time) (emailjobpkg.EmailJob, error) {
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return emailjobpkg.EmailJob{}, err
+	}
+
+	var job emailjobpkg.EmailJob
+	err = r.dead.FindOne(ctx, bson.M{"_id": oid}).Decode(&job)
+	if err == mongo.ErrNoDocuments {
+		return emailjobpkg.EmailJob{}, errors.New("dead letter not found")
+	}
+	if err != nil {
+		return emailjobpkg.EmailJob{}, err
+	}
+
+	job.Attempts = 0
+	job.RunAt = now
+	job.UpdatedAt = now
+	job.FailedAt = nil
+	// LastError is kept so the first retry still shows why the job had failed
+	if _, err := r.jobs.ReplaceOne(ctx, bson.M{"_id": job.ID}, job, options.Replace().SetUpsert(true)); err != nil {
+		return emailjobpkg.EmailJob{}, err
+	}
+	if _, err := r.dead.DeleteOne(ctx, bson.M{"_id": job.ID}); err != nil {
+		return emailjobpkg.EmailJob{}, err
+	}
+	return job, nil
+}
+
+func (r *EmailJobRepository) list(ctx context.Context, collection *mongo.Collection, sort bson.D, limit int64) ([]emailjobpkg.EmailJob, error) {
+	cursor, err := collection.Find(ctx, bson.M{}, options.Find().SetSort(sort).SetLimit(limit))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	jobs := []emailjobpkg.EmailJob{}
+	if err := cursor.All(ctx, &jobs); err != nil {
+		return nil, err
+	}
+	return jobs, nil
+}
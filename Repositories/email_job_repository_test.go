@@ -0,0 +1,137 @@
+package repositories_test
+
+import (
+	"context"
+	"log"
+	"os"
+	"testing"
+	"time"
+
+	emailjobpkg "github.com/Amaankaa/Blog-Starter-Project/Domain/emailjob"
+	repositories "github.com/Amaankaa/Blog-Starter-Project/Repositories"
+	"github.com/joho/godotenv"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type emailJobRepositoryTestSuite struct {
+	suite.Suite
+	client *mongo.Client
+	ctx    context.Context
+	cancel context.CancelFunc
+	jobs   *mongo.Collection
+	dead   *mongo.Collection
+	repo   *repositories.EmailJobRepository
+}
+
+func TestEmailJobRepositoryTestSuite(t *testing.T) {
+	suite.Run(t, new(emailJobRepositoryTestSuite))
+}
+
+func (s *emailJobRepositoryTestSuite) SetupSuite() {
+	err := godotenv.Load("../.env")
+	if err != nil {
+		log.Fatal("Error loading .env file")
+	}
+
+	client, err := mongo.Connect(context.Background(), options.Client().ApplyURI(os.Getenv("MONGODB_URI")))
+	s.Require().NoError(err)
+
+	s.client = client
+	db := client.Database("test_blog_db")
+	s.jobs = db.Collection("test_email_jobs")
+	s.dead = db.Collection("test_email_dead_letters")
+	s.repo = repositories.NewEmailJobRepository(s.jobs, s.dead)
+	s.ctx, s.cancel = context.WithTimeout(context.Background(), 10*time.Second)
+}
+
+func (s *emailJobRepositoryTestSuite) TearDownSuite() {
+	s.jobs.Drop(s.ctx)
+	s.dead.Drop(s.ctx)
+	s.cancel()
+	s.client.Disconnect(s.ctx)
+}
+
+func (s *emailJobRepositoryTestSuite) SetupTest() {
+	_, err := s.jobs.DeleteMany(s.ctx, bson.M{})
+	s.Require().NoError(err)
+	_, err = s.dead.DeleteMany(s.ctx, bson.M{})
+	s.Require().NoError(err)
+}
+
+func (s *emailJobRepositoryTestSuite) TestClaimNext_LeasesDueJobs() {
+	assert := assert.New(s.T())
+	now := time.Now()
+	due, err := s.repo.Enqueue(s.ctx, emailjobpkg.EmailJob{To: "a@example.com", RunAt: now.Add(-time.Second)})
+	assert.NoError(err)
+	_, err = s.repo.Enqueue(s.ctx, emailjobpkg.EmailJob{To: "b@example.com", RunAt: now.Add(time.Hour)})
+	assert.NoError(err)
+
+	claimed, ok, err := s.repo.ClaimNext(s.ctx, now, time.Minute)
+	assert.NoError(err)
+	assert.True(ok)
+	assert.Equal(due.ID, claimed.ID)
+
+	_, ok, err = s.repo.ClaimNext(s.ctx, now, time.Minute)
+	assert.NoError(err)
+	assert.False(ok)
+
+	assert.NoError(s.repo.Complete(s.ctx, claimed.ID.Hex()))
+	queued, err := s.repo.ListQueued(s.ctx, 10)
+	assert.NoError(err)
+	assert.Len(queued, 1)
+}
+
+func (s *emailJobRepositoryTestSuite) TestBuryAndRequeue() {
+	assert := assert.New(s.T())
+	job, err := s.repo.Enqueue(s.ctx, emailjobpkg.EmailJob{To: "a@example.com", Text: "code", RunAt: time.Now()})
+	assert.NoError(err)
+
+	failedAt := time.Now()
+	job.Attempts = 6
+	job.LastError = "brevo 503"
+	job.FailedAt = &failedAt
+	assert.NoError(s.repo.Bury(s.ctx, job))
+
+	queued, _ := s.repo.ListQueued(s.ctx, 10)
+	assert.Empty(queued)
+	dead, err := s.repo.ListDead(s.ctx, 10)
+	assert.NoError(err)
+	assert.Len(dead, 1)
+	assert.Equal("brevo 503", dead[0].LastError)
+
+	requeued, err := s.repo.Requeue(s.ctx, job.ID.Hex(), time.Now())
+	assert.NoError(err)
+	assert.Equal(0, requeued.Attempts)
+	assert.Nil(requeued.FailedAt)
+
+	dead, _ = s.repo.ListDead(s.ctx, 10)
+	assert.Empty(dead)
+	queued, _ = s.repo.ListQueued(s.ctx, 10)
+	assert.Len(queued, 1)
+	assert.Equal("code", queued[0].Text)
+
+	_, err = s.repo.Requeue(s.ctx, job.ID.Hex(), time.Now())
+	assert.EqualError(err, "dead letter not found")
+}
+
+func (s *emailJobRepositoryTestSuite) TestEnsureIndexes_ExpiresDeadLetters() {
+	assert := assert.New(s.T())
+	assert.NoError(s.repo.EnsureIndexes(s.ctx))
+
+	cursor, err := s.dead.Indexes().List(s.ctx)
+	assert.NoError(err)
+	var indexes []bson.M
+	assert.NoError(cursor.All(s.ctx, &indexes))
+
+	ttl := map[string]interface{}{}
+	for _, index := range indexes {
+		if expire, ok := index["expireAfterSeconds"]; ok {
+			ttl[index["name"].(string)] = expire
+		}
+	}
+	assert.Equal(map[string]interface{}{"failed_at_1": int32(emailjobpkg.DeadLetterRetention.Seconds())}, ttl)
+}
@@ -0,0 +1,120 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	identitypkg "github.com/Amaankaa/Blog-Starter-Project/Domain/identity"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type IdentityRepository struct {
+	identities *mongo.Collection
+	states     *mongo.Collection
+}
+
+func NewIdentityRepository(identities, states *mongo.Collection) *IdentityRepository {
+	return &IdentityRepository{identities: identities, states: states}
+}
+
+// EnsureIndexes makes each external account linkable once and lets MongoDB drop
+// abandoned authorization states
+func (r *IdentityRepository) EnsureIndexes(ctx context.Context) error {
+	_, err := r.identities.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "provider", Value: 1}, {Key: "subject", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		{Keys: bson.D{{Key: "user_id", Value: 1}}},
+	})
+	if err != nil {
+		return err
+	}
+	_, err = r.states.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "expires_at", Value: 1}},
+		Options: options.Index().SetExpireAfterSeconds(0),
+	})
+	return err
+}
+
+func (r *IdentityRepository) FindIdentity(ctx context.Context, provider, subject string) (identitypkg.Identity, error) {
+	var identity identitypkg.Identity
+	err := r.identities.FindOne(ctx, bson.M{"provider": provider, "subject": subject}).Decode(&identity)
+	if err == mongo.ErrNoDocuments {
+		return identitypkg.Identity{}, identitypkg.ErrIdentityNotFound
+	}
+	return identity, err
+}
+
+func (r *IdentityRepository) ListIdentities(ctx context.Context, userID string) ([]identitypkg.Identity, error) {
+	oid, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, err
+	}
+	cursor, err := r.identities.Find(ctx, bson.M{"user_id": oid}, options.Find().SetSort(bson.D{{Key: "linked_at", Value: 1}}))
+	if err != nil {
+		return nil, err
+	}
+	identities := []identitypkg.Identity{}
+	if err := cursor.All(ctx, &identities); err != nil {
+		return nil, err
+	}
+	return identities, nil
+}
+
+func (r *IdentityRepository) CreateIdentity(ctx context.Context, identity identitypkg.Identity) (identitypkg.Identity, error) {
+	identity.ID = primitive.NewObjectID()
+	_, err := r.identities.InsertOne(ctx, identity)
+	if mongo.IsDuplicateKeyError(err) {
+		return identitypkg.Identity{}, identitypkg.ErrIdentityTaken
+	}
+	if err != nil {
+		return identitypkg.Identity{}, err
+	}
+	return identity, nil
+}
+
+func (r *IdentityRepository) TouchIdentity(ctx context.Context, identityID string) error {
+	oid, err := primitive.ObjectIDFromHex(identityID)
+	if err != nil {
+		return err
+	}
+	_, err = r.identities.UpdateOne(ctx, bson.M{"_id": oid}, bson.M{"$set": bson.M{"last_login_at": time.Now()}})
+	return err
+}
+
+func (r *IdentityRepository) DeleteIdentity(ctx context.Context, userID, identityID string) error {
+	uid, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return identitypkg.ErrIdentityNotFound
+	}
+	oid, err := primitive.ObjectIDFromHex(identityID)
+	if err != nil {
+		return identitypkg.ErrIdentityNotFound
+	}
+	res, err := r.identities.DeleteOne(ctx, bson.M{"_id": oid, "user_id": uid})
+	if err != nil {
+		return err
+	}
+	if res.DeletedCount == 0 {
+		return identitypkg.ErrIdentityNotFound
+	}
+	return nil
+}
+
+func (r *IdentityRepository) StoreState(ctx context.Context, state identitypkg.AuthState) error {
+	_, err := r.states.InsertOne(ctx, state)
+	return err
+}
+
+func (r *IdentityRepository) ConsumeState(ctx context.Context, id string) (identitypkg.AuthState, error) {
+	var state identitypkg.AuthState
+	err := r.states.FindOneAndDelete(ctx, bson.M{"_id": id}).Decode(&state)
+	if err == mongo.ErrNoDocuments {
+		return identitypkg.AuthState{}, identitypkg.ErrStateNotFound
+	}
+	return state, err
+}
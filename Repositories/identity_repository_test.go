@@ -0,0 +1,106 @@
+package repositories_test
+
+import (
+	"context"
+	"log"
+	"os"
+	"testing"
+	"time"
+
+	identitypkg "github.com/Amaankaa/Blog-Starter-Project/Domain/identity"
+	repositories "github.com/Amaankaa/Blog-Starter-Project/Repositories"
+	"github.com/joho/godotenv"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type identityRepositoryTestSuite struct {
+	suite.Suite
+	client      *mongo.Client
+	ctx         context.Context
+	cancel      context.CancelFunc
+	collections []*mongo.Collection
+	repo        *repositories.IdentityRepository
+}
+
+func TestIdentityRepositoryTestSuite(t *testing.T) {
+	suite.Run(t, new(identityRepositoryTestSuite))
+}
+
+func (s *identityRepositoryTestSuite) SetupSuite() {
+	err := godotenv.Load("../.env")
+	if err != nil {
+		log.Fatal("Error loading .env file")
+	}
+
+	client, err := mongo.Connect(context.Background(), options.Client().ApplyURI(os.Getenv("MONGODB_URI")))
+	s.Require().NoError(err)
+
+	db := client.Database("test_blog_db")
+	s.client = client
+	s.collections = []*mongo.Collection{db.Collection("test_identities"), db.Collection("test_oidc_states")}
+	s.repo = repositories.NewIdentityRepository(s.collections[0], s.collections[1])
+	s.ctx, s.cancel = context.WithTimeout(context.Background(), 10*time.Second)
+	s.Require().NoError(s.repo.EnsureIndexes(s.ctx))
+}
+
+func (s *identityRepositoryTestSuite) TearDownSuite() {
+	for _, c := range s.collections {
+		c.Drop(s.ctx)
+	}
+	s.cancel()
+	s.client.Disconnect(s.ctx)
+}
+
+func (s *identityRepositoryTestSuite) SetupTest() {
+	for _, c := range s.collections {
+		_, err := c.DeleteMany(s.ctx, bson.M{})
+		s.Require().NoError(err)
+	}
+}
+
+func (s *identityRepositoryTestSuite) TestCreateIdentity_RejectsSecondLink() {
+	assert := assert.New(s.T())
+	identity := identitypkg.Identity{UserID: primitive.NewObjectID(), Provider: "google", Subject: "123", LinkedAt: time.Now()}
+
+	created, err := s.repo.CreateIdentity(s.ctx, identity)
+	assert.NoError(err)
+	found, err := s.repo.FindIdentity(s.ctx, "google", "123")
+	assert.NoError(err)
+	assert.Equal(created.ID, found.ID)
+
+	identity.UserID = primitive.NewObjectID()
+	_, err = s.repo.CreateIdentity(s.ctx, identity)
+	assert.ErrorIs(err, identitypkg.ErrIdentityTaken)
+}
+
+func (s *identityRepositoryTestSuite) TestDeleteIdentity_OnlyOwn() {
+	assert := assert.New(s.T())
+	owner := primitive.NewObjectID()
+	created, err := s.repo.CreateIdentity(s.ctx, identitypkg.Identity{UserID: owner, Provider: "github", Subject: "7"})
+	assert.NoError(err)
+
+	err = s.repo.DeleteIdentity(s.ctx, primitive.NewObjectID().Hex(), created.ID.Hex())
+	assert.ErrorIs(err, identitypkg.ErrIdentityNotFound)
+
+	assert.NoError(s.repo.DeleteIdentity(s.ctx, owner.Hex(), created.ID.Hex()))
+	identities, err := s.repo.ListIdentities(s.ctx, owner.Hex())
+	assert.NoError(err)
+	assert.Empty(identities)
+}
+
+func (s *identityRepositoryTestSuite) TestConsumeState_SingleUse() {
+	assert := assert.New(s.T())
+	assert.NoError(s.repo.StoreState(s.ctx, identitypkg.AuthState{ID: "h", Provider: "google", Nonce: "n", ExpiresAt: time.Now().Add(time.Minute)}))
+
+	state, err := s.repo.ConsumeState(s.ctx, "h")
+	assert.NoError(err)
+	assert.Equal("n", state.Nonce)
+
+	_, err = s.repo.ConsumeState(s.ctx, "h")
+	assert.ErrorIs(err, identitypkg.ErrStateNotFound)
+}
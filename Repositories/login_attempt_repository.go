@@ -0,0 +1,94 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	lockoutpkg "github.com/Amaankaa/Blog-Starter-Project/Domain/lockout"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type LoginAttemptRepository struct {
+	counters     *mongo.Collection
+	unlockTokens *mongo.Collection
+}
+
+func NewLoginAttemptRepository(counters, unlockTokens *mongo.Collection) *LoginAttemptRepository {
+	return &LoginAttemptRepository{
+		counters:     counters,
+		unlockTokens: unlockTokens,
+	}
+}
+
+// EnsureIndexes lets MongoDB forget counters once their window or lockout has passed, and
+// drop unused unlock links
+func (r *LoginAttemptRepository) EnsureIndexes(ctx context.Context) error {
+	expiry := mongo.IndexModel{
+		Keys:    bson.D{{Key: "expires_at", Value: 1}},
+		Options: options.Index().SetExpireAfterSeconds(0),
+	}
+	if _, err := r.counters.Indexes().CreateOne(ctx, expiry); err != nil {
+		return err
+	}
+	_, err := r.unlockTokens.Indexes().CreateOne(ctx, expiry)
+	return err
+}
+
+func (r *LoginAttemptRepository) GetCounter(ctx context.Context, key string) (lockoutpkg.Counter, error) {
+	var counter lockoutpkg.Counter
+	err := r.counters.FindOne(ctx, bson.M{"_id": key}).Decode(&counter)
+	if err == mongo.ErrNoDocuments {
+		return lockoutpkg.Counter{Key: key}, nil
+	}
+	return counter, err
+}
+
+func (r *LoginAttemptRepository) RecordFailure(ctx context.Context, key string, at time.Time, window time.Duration) (lockoutpkg.Counter, error) {
+	// A counter kept alive by a lockout outlives the window; max keeps that expiry
+	var counter lockoutpkg.Counter
+	err := r.counters.FindOneAndUpdate(ctx,
+		bson.M{"_id": key},
+		bson.M{
+			"$inc": bson.M{"failures": 1},
+			"$set": bson.M{"last_failure_at": at},
+			"$max": bson.M{"expires_at": at.Add(window)},
+		},
+		options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After),
+	).Decode(&counter)
+	return counter, err
+}
+
+func (r *LoginAttemptRepository) Lock(ctx context.Context, key string, until time.Time) (bool, error) {
+	res, err := r.counters.UpdateOne(ctx,
+		bson.M{"_id": key, "$or": bson.A{
+			bson.M{"locked_until": bson.M{"$exists": false}},
+			bson.M{"locked_until": bson.M{"$lte": time.Now()}},
+		}},
+		bson.M{"$set": bson.M{"failures": 0, "locked_until": until, "expires_at": until}},
+	)
+	if err != nil {
+		return false, err
+	}
+	return res.ModifiedCount == 1, nil
+}
+
+func (r *LoginAttemptRepository) ResetCounter(ctx context.Context, key string) error {
+	_, err := r.counters.DeleteOne(ctx, bson.M{"_id": key})
+	return err
+}
+
+func (r *LoginAttemptRepository) StoreUnlockToken(ctx context.Context, token lockoutpkg.UnlockToken) error {
+	_, err := r.unlockTokens.InsertOne(ctx, token)
+	return err
+}
+
+func (r *LoginAttemptRepository) ConsumeUnlockToken(ctx context.Context, id string) (lockoutpkg.UnlockToken, error) {
+	var token lockoutpkg.UnlockToken
+	err := r.unlockTokens.FindOneAndDelete(ctx, bson.M{"_id": id}).Decode(&token)
+	if err == mongo.ErrNoDocuments {
+		return lockoutpkg.UnlockToken{}, lockoutpkg.ErrUnlockTokenInvalid
+	}
+	return token, err
+}
@@ -0,0 +1,108 @@
+package repositories_test
+
+import (
+	"context"
+	"log"
+	"os"
+	"testing"
+	"time"
+
+	lockoutpkg "github.com/Amaankaa/Blog-Starter-Project/Domain/lockout"
+	repositories "github.com/Amaankaa/Blog-Starter-Project/Repositories"
+	"github.com/joho/godotenv"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type loginAttemptRepositoryTestSuite struct {
+	suite.Suite
+	client       *mongo.Client
+	ctx          context.Context
+	cancel       context.CancelFunc
+	counters     *mongo.Collection
+	unlockTokens *mongo.Collection
+	repo         *repositories.LoginAttemptRepository
+}
+
+func TestLoginAttemptRepositoryTestSuite(t *testing.T) {
+	suite.Run(t, new(loginAttemptRepositoryTestSuite))
+}
+
+func (s *loginAttemptRepositoryTestSuite) SetupSuite() {
+	err := godotenv.Load("../.env")
+	if err != nil {
+		log.Fatal("Error loading .env file")
+	}
+
+	client, err := mongo.Connect(context.Background(), options.Client().ApplyURI(os.Getenv("MONGODB_URI")))
+	s.Require().NoError(err)
+
+	s.client = client
+	db := client.Database("test_blog_db")
+	s.counters = db.Collection("test_login_attempts")
+	s.unlockTokens = db.Collection("test_unlock_tokens")
+	s.repo = repositories.NewLoginAttemptRepository(s.counters, s.unlockTokens)
+	s.ctx, s.cancel = context.WithTimeout(context.Background(), 10*time.Second)
+	s.Require().NoError(s.repo.EnsureIndexes(s.ctx))
+}
+
+func (s *loginAttemptRepositoryTestSuite) TearDownSuite() {
+	s.counters.Drop(s.ctx)
+	s.unlockTokens.Drop(s.ctx)
+	s.cancel()
+	s.client.Disconnect(s.ctx)
+}
+
+func (s *loginAttemptRepositoryTestSuite) SetupTest() {
+	_, err := s.counters.DeleteMany(s.ctx, bson.M{})
+	s.Require().NoError(err)
+	_, err = s.unlockTokens.DeleteMany(s.ctx, bson.M{})
+	s.Require().NoError(err)
+}
+
+func (s *loginAttemptRepositoryTestSuite) TestRecordFailureLockAndReset() {
+	assert := assert.New(s.T())
+	key := lockoutpkg.AccountKey("user-1")
+
+	empty, err := s.repo.GetCounter(s.ctx, key)
+	assert.NoError(err)
+	assert.Zero(empty.Failures)
+
+	now := time.Now()
+	s.repo.RecordFailure(s.ctx, key, now, time.Minute)
+	counter, err := s.repo.RecordFailure(s.ctx, key, now, time.Minute)
+	assert.NoError(err)
+	assert.Equal(2, counter.Failures)
+
+	locked, err := s.repo.Lock(s.ctx, key, now.Add(time.Hour))
+	assert.NoError(err)
+	assert.True(locked)
+	locked, err = s.repo.Lock(s.ctx, key, now.Add(time.Hour))
+	assert.NoError(err)
+	assert.False(locked, "an account is only locked once")
+
+	counter, err = s.repo.GetCounter(s.ctx, key)
+	assert.NoError(err)
+	assert.Zero(counter.Failures)
+	assert.WithinDuration(now.Add(time.Hour), counter.LockedUntil, time.Second)
+
+	assert.NoError(s.repo.ResetCounter(s.ctx, key))
+	counter, err = s.repo.GetCounter(s.ctx, key)
+	assert.NoError(err)
+	assert.True(counter.LockedUntil.IsZero())
+}
+
+func (s *loginAttemptRepositoryTestSuite) TestUnlockTokensAreSingleUse() {
+	assert := assert.New(s.T())
+	err := s.repo.StoreUnlockToken(s.ctx, lockoutpkg.UnlockToken{ID: "hash", UserID: "user-1", ExpiresAt: time.Now().Add(time.Hour)})
+	assert.NoError(err)
+
+	token, err := s.repo.ConsumeUnlockToken(s.ctx, "hash")
+	assert.NoError(err)
+	assert.Equal("user-1", token.UserID)
+	_, err = s.repo.ConsumeUnlockToken(s.ctx, "hash")
+	assert.ErrorIs(err, lockoutpkg.ErrUnlockTokenInvalid)
+}
@@ -0,0 +1,94 @@
+package repositories
+
+import (
+	"context"
+
+	mfapkg "github.com/Amaankaa/Blog-Starter-Project/Domain/mfa"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// mfaPolicyID is the _id of the single policy document
+const mfaPolicyID = "mfa"
+
+type MFARepository struct {
+	enrollments *mongo.Collection
+	settings    *mongo.Collection
+}
+
+func NewMFARepository(enrollments, settings *mongo.Collection) *MFARepository {
+	return &MFARepository{
+		enrollments: enrollments,
+		settings:    settings,
+	}
+}
+
+func (r *MFARepository) FindEnrollment(ctx context.Context, userID string) (mfapkg.Enrollment, error) {
+	var enrollment mfapkg.Enrollment
+	err := r.enrollments.FindOne(ctx, bson.M{"_id": userID}).Decode(&enrollment)
+	if err == mongo.ErrNoDocuments {
+		return mfapkg.Enrollment{}, mfapkg.ErrNotEnrolled
+	}
+	return enrollment, err
+}
+
+func (r *MFARepository) SaveEnrollment(ctx context.Context, enrollment mfapkg.Enrollment) error {
+	_, err := r.enrollments.ReplaceOne(ctx, bson.M{"_id": enrollment.UserID}, enrollment, options.Replace().SetUpsert(true))
+	return err
+}
+
+func (r *MFARepository) DeleteEnrollment(ctx context.Context, userID string) error {
+	_, err := r.enrollments.DeleteOne(ctx, bson.M{"_id": userID})
+	return err
+}
+
+func (r *MFARepository) RecordUsedStep(ctx context.Context, userID string, step int64) (bool, error) {
+	// Conditional, so two requests racing with the same code can't both succeed
+	res, err := r.enrollments.UpdateOne(ctx,
+		bson.M{"_id": userID, "last_used_step": bson.M{"$lt": step}},
+		bson.M{"$set": bson.M{"last_used_step": step}},
+	)
+	if err != nil {
+		return false, err
+	}
+	return res.ModifiedCount == 1, nil
+}
+
+func (r *MFARepository) ConsumeRecoveryCode(ctx context.Context, userID, codeHash string) (bool, error) {
+	res, err := r.enrollments.UpdateOne(ctx,
+		bson.M{"_id": userID, "recovery_codes": codeHash},
+		bson.M{"$pull": bson.M{"recovery_codes": codeHash}},
+	)
+	if err != nil {
+		return false, err
+	}
+	return res.ModifiedCount == 1, nil
+}
+
+func (r *MFARepository) ReplaceRecoveryCodes(ctx context.Context, userID string, codeHashes []string) error {
+	_, err := r.enrollments.UpdateOne(ctx,
+		bson.M{"_id": userID},
+		bson.M{"$set": bson.M{"recovery_codes": codeHashes}},
+	)
+	return err
+}
+
+// GetPolicy returns the zero policy until an admin saves one
+func (r *MFARepository) GetPolicy(ctx context.Context) (mfapkg.Policy, error) {
+	var policy mfapkg.Policy
+	err := r.settings.FindOne(ctx, bson.M{"_id": mfaPolicyID}).Decode(&policy)
+	if err == mongo.ErrNoDocuments {
+		return mfapkg.Policy{}, nil
+	}
+	return policy, err
+}
+
+func (r *MFARepository) SavePolicy(ctx context.Context, policy mfapkg.Policy) error {
+	_, err := r.settings.UpdateOne(ctx,
+		bson.M{"_id": mfaPolicyID},
+		bson.M{"$set": bson.M{"require_for_admins": policy.RequireForAdmins}},
+		options.Update().SetUpsert(true),
+	)
+	return err
+}
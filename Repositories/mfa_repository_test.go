@@ -0,0 +1,108 @@
+package repositories_test
+
+import (
+	"context"
+	"log"
+	"os"
+	"testing"
+	"time"
+
+	mfapkg "github.com/Amaankaa/Blog-Starter-Project/Domain/mfa"
+	repositories "github.com/Amaankaa/Blog-Starter-Project/Repositories"
+	"github.com/joho/godotenv"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type mfaRepositoryTestSuite struct {
+	suite.Suite
+	client      *mongo.Client
+	ctx         context.Context
+	cancel      context.CancelFunc
+	collections []*mongo.Collection
+	repo        *repositories.MFARepository
+}
+
+func TestMFARepositoryTestSuite(t *testing.T) {
+	suite.Run(t, new(mfaRepositoryTestSuite))
+}
+
+func (s *mfaRepositoryTestSuite) SetupSuite() {
+	err := godotenv.Load("../.env")
+	if err != nil {
+		log.Fatal("Error loading .env file")
+	}
+
+	client, err := mongo.Connect(context.Background(), options.Client().ApplyURI(os.Getenv("MONGODB_URI")))
+	s.Require().NoError(err)
+
+	db := client.Database("test_blog_db")
+	s.client = client
+	s.collections = []*mongo.Collection{db.Collection("test_mfa_enrollments"), db.Collection("test_security_settings")}
+	s.repo = repositories.NewMFARepository(s.collections[0], s.collections[1])
+	s.ctx, s.cancel = context.WithTimeout(context.Background(), 10*time.Second)
+}
+
+func (s *mfaRepositoryTestSuite) TearDownSuite() {
+	for _, c := range s.collections {
+		c.Drop(s.ctx)
+	}
+	s.cancel()
+	s.client.Disconnect(s.ctx)
+}
+
+func (s *mfaRepositoryTestSuite) SetupTest() {
+	for _, c := range s.collections {
+		_, err := c.DeleteMany(s.ctx, bson.M{})
+		s.Require().NoError(err)
+	}
+}
+
+func (s *mfaRepositoryTestSuite) TestFindEnrollment_NotEnrolled() {
+	_, err := s.repo.FindEnrollment(s.ctx, "u1")
+	assert.ErrorIs(s.T(), err, mfapkg.ErrNotEnrolled)
+}
+
+func (s *mfaRepositoryTestSuite) TestRecordUsedStep_RejectsReplay() {
+	assert := assert.New(s.T())
+	assert.NoError(s.repo.SaveEnrollment(s.ctx, mfapkg.Enrollment{UserID: "u1", Secret: "S", Enabled: true, LastUsedStep: 10}))
+
+	ok, err := s.repo.RecordUsedStep(s.ctx, "u1", 11)
+	assert.NoError(err)
+	assert.True(ok)
+
+	ok, err = s.repo.RecordUsedStep(s.ctx, "u1", 11)
+	assert.NoError(err)
+	assert.False(ok)
+}
+
+func (s *mfaRepositoryTestSuite) TestConsumeRecoveryCode_OnlyOnce() {
+	assert := assert.New(s.T())
+	assert.NoError(s.repo.SaveEnrollment(s.ctx, mfapkg.Enrollment{UserID: "u1", Enabled: true, RecoveryCodes: []string{"a", "b"}}))
+
+	ok, err := s.repo.ConsumeRecoveryCode(s.ctx, "u1", "a")
+	assert.NoError(err)
+	assert.True(ok)
+	ok, err = s.repo.ConsumeRecoveryCode(s.ctx, "u1", "a")
+	assert.NoError(err)
+	assert.False(ok)
+
+	enrollment, err := s.repo.FindEnrollment(s.ctx, "u1")
+	assert.NoError(err)
+	assert.Equal([]string{"b"}, enrollment.RecoveryCodes)
+}
+
+func (s *mfaRepositoryTestSuite) TestPolicy_DefaultsToOff() {
+	assert := assert.New(s.T())
+	policy, err := s.repo.GetPolicy(s.ctx)
+	assert.NoError(err)
+	assert.False(policy.RequireForAdmins)
+
+	assert.NoError(s.repo.SavePolicy(s.ctx, mfapkg.Policy{RequireForAdmins: true}))
+	policy, err = s.repo.GetPolicy(s.ctx)
+	assert.NoError(err)
+	assert.True(policy.RequireForAdmins)
+}
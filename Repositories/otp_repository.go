@@ -0,0 +1,112 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	otppkg "github.com/Amaankaa/Blog-Starter-Project/Domain/otp"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type OTPRepository struct {
+	collection *mongo.Collection
+}
+
+func NewOTPRepository(collection *mongo.Collection) *OTPRepository {
+	return &OTPRepository{collection: collection}
+}
+
+// EnsureIndexes lets MongoDB drop challenges once they expire, and finds exchanged ones by token
+func (r *OTPRepository) EnsureIndexes(ctx context.Context) error {
+	_, err := r.collection.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "expires_at", Value: 1}},
+			Options: options.Index().SetExpireAfterSeconds(0),
+		},
+		{
+			Keys:    bson.D{{Key: "token_hash", Value: 1}},
+			Options: options.Index().SetSparse(true),
+		},
+	})
+	return err
+}
+
+func (r *OTPRepository) Issue(ctx context.Context, challenge otppkg.Challenge, sentBefore time.Time) (bool, error) {
+	// A challenge sent too recently doesn't match, so the upsert collides with it on _id
+	_, err := r.collection.ReplaceOne(ctx,
+		bson.M{"_id": challenge.ID, "sent_at": bson.M{"$lte": sentBefore}},
+		challenge,
+		options.Replace().SetUpsert(true),
+	)
+	if mongo.IsDuplicateKeyError(err) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+func (r *OTPRepository) Find(ctx context.Context, id string) (otppkg.Challenge, error) {
+	var challenge otppkg.Challenge
+	err := r.collection.FindOne(ctx, bson.M{"_id": id}).Decode(&challenge)
+	if err == mongo.ErrNoDocuments {
+		return otppkg.Challenge{}, otppkg.ErrChallengeNotFound
+	}
+	return challenge, err
+}
+
+func (r *OTPRepository) TakeAttempt(ctx context.Context, id string, maxAttempts int) (otppkg.Challenge, error) {
+	var challenge otppkg.Challenge
+	err := r.collection.FindOneAndUpdate(ctx,
+		bson.M{"_id": id, "attempt_count": bson.M{"$lt": maxAttempts}, "token_hash": bson.M{"$exists": false}},
+		bson.M{"$inc": bson.M{"attempt_count": 1}},
+		options.FindOneAndUpdate().SetReturnDocument(options.After),
+	).Decode(&challenge)
+	if err != mongo.ErrNoDocuments {
+		return challenge, err
+	}
+
+	// Nothing matched: tell a used up challenge from a missing or exchanged one
+	existing, err := r.Find(ctx, id)
+	if err != nil {
+		return otppkg.Challenge{}, err
+	}
+	if existing.TokenHash != "" {
+		return otppkg.Challenge{}, otppkg.ErrChallengeNotFound
+	}
+	return otppkg.Challenge{}, otppkg.ErrTooManyAttempts
+}
+
+func (r *OTPRepository) Delete(ctx context.Context, id string) error {
+	_, err := r.collection.DeleteOne(ctx, bson.M{"_id": id})
+	return err
+}
+
+func (r *OTPRepository) Exchange(ctx context.Context, id, tokenHash string, expiresAt time.Time) error {
+	_, err := r.collection.UpdateOne(ctx,
+		bson.M{"_id": id},
+		bson.M{
+			"$set":   bson.M{"token_hash": tokenHash, "expires_at": expiresAt},
+			"$unset": bson.M{"code_hash": ""},
+		},
+	)
+	return err
+}
+
+func (r *OTPRepository) FindByToken(ctx context.Context, purpose otppkg.Purpose, tokenHash string) (otppkg.Challenge, error) {
+	var challenge otppkg.Challenge
+	err := r.collection.FindOne(ctx, bson.M{"purpose": purpose, "token_hash": tokenHash}).Decode(&challenge)
+	if err == mongo.ErrNoDocuments {
+		return otppkg.Challenge{}, otppkg.ErrInvalidToken
+	}
+	return challenge, err
+}
+
+func (r *OTPRepository) ConsumeToken(ctx context.Context, purpose otppkg.Purpose, tokenHash string) (otppkg.Challenge, error) {
+	var challenge otppkg.Challenge
+	err := r.collection.FindOneAndDelete(ctx, bson.M{"purpose": purpose, "token_hash": tokenHash}).Decode(&challenge)
+	if err == mongo.ErrNoDocuments {
+		return otppkg.Challenge{}, otppkg.ErrInvalidToken
+	}
+	return challenge, err
+}
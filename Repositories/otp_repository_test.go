@@ -0,0 +1,167 @@
+package repositories_test
+
+import (
+	"context"
+	"log"
+	"os"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	otppkg "github.com/Amaankaa/Blog-Starter-Project/Domain/otp"
+	repositories "github.com/Amaankaa/Blog-Starter-Project/Repositories"
+	"github.com/joho/godotenv"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type otpRepositoryTestSuite struct {
+	suite.Suite
+	client     *mongo.Client
+	ctx        context.Context
+	cancel     context.CancelFunc
+	collection *mongo.Collection
+	repo       *repositories.OTPRepository
+}
+
+func TestOTPRepositoryTestSuite(t *testing.T) {
+	suite.Run(t, new(otpRepositoryTestSuite))
+}
+
+func (s *otpRepositoryTestSuite) SetupSuite() {
+	err := godotenv.Load("../.env")
+	if err != nil {
+		log.Fatal("Error loading .env file")
+	}
+
+	client, err := mongo.Connect(context.Background(), options.Client().ApplyURI(os.Getenv("MONGODB_URI")))
+	s.Require().NoError(err)
+
+	s.client = client
+	s.collection = client.Database("test_blog_db").Collection("test_otp_challenges")
+	s.repo = repositories.NewOTPRepository(s.collection)
+	s.ctx, s.cancel = context.WithTimeout(context.Background(), 10*time.Second)
+	s.Require().NoError(s.repo.EnsureIndexes(s.ctx))
+}
+
+func (s *otpRepositoryTestSuite) TearDownSuite() {
+	s.collection.Drop(s.ctx)
+	s.cancel()
+	s.client.Disconnect(s.ctx)
+}
+
+func (s *otpRepositoryTestSuite) SetupTest() {
+	_, err := s.collection.DeleteMany(s.ctx, bson.M{})
+	s.Require().NoError(err)
+}
+
+func (s *otpRepositoryTestSuite) challenge(sentAt time.Time) otppkg.Challenge {
+	return otppkg.Challenge{
+		ID:        otppkg.ChallengeID(otppkg.PurposePasswordReset, "jane@example.com"),
+		Purpose:   otppkg.PurposePasswordReset,
+		Subject:   "jane@example.com",
+		CodeHash:  "code-hash",
+		SentAt:    sentAt,
+		ExpiresAt: sentAt.Add(10 * time.Minute),
+	}
+}
+
+func (s *otpRepositoryTestSuite) TestIssueHonoursTheCooldown() {
+	assert := assert.New(s.T())
+	now := time.Now()
+
+	issued, err := s.repo.Issue(s.ctx, s.challenge(now), now.Add(-time.Minute))
+	assert.NoError(err)
+	assert.True(issued)
+
+	issued, err = s.repo.Issue(s.ctx, s.challenge(now.Add(time.Second)), now.Add(-time.Minute))
+	assert.NoError(err)
+	assert.False(issued, "the first code was sent within the cooldown")
+
+	later := now.Add(2 * time.Minute)
+	next := s.challenge(later)
+	next.CodeHash = "new-hash"
+	issued, err = s.repo.Issue(s.ctx, next, later.Add(-time.Minute))
+	assert.NoError(err)
+	assert.True(issued)
+
+	stored, err := s.repo.Find(s.ctx, next.ID)
+	assert.NoError(err)
+	assert.Equal("new-hash", stored.CodeHash)
+}
+
+func (s *otpRepositoryTestSuite) TestAttemptsAndDelete() {
+	assert := assert.New(s.T())
+	challenge := s.challenge(time.Now())
+	s.repo.Issue(s.ctx, challenge, time.Now())
+
+	taken, err := s.repo.TakeAttempt(s.ctx, challenge.ID, 2)
+	assert.NoError(err)
+	assert.Equal(1, taken.AttemptCount)
+	_, err = s.repo.TakeAttempt(s.ctx, challenge.ID, 2)
+	assert.NoError(err)
+	_, err = s.repo.TakeAttempt(s.ctx, challenge.ID, 2)
+	assert.ErrorIs(err, otppkg.ErrTooManyAttempts)
+
+	assert.NoError(s.repo.Delete(s.ctx, challenge.ID))
+	_, err = s.repo.Find(s.ctx, challenge.ID)
+	assert.ErrorIs(err, otppkg.ErrChallengeNotFound)
+}
+
+func (s *otpRepositoryTestSuite) TestConcurrentAttemptsStopAtTheLimit() {
+	challenge := s.challenge(time.Now())
+	s.repo.Issue(s.ctx, challenge, time.Now())
+
+	var taken atomic.Int32
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := s.repo.TakeAttempt(s.ctx, challenge.ID, 5); err == nil {
+				taken.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+
+	s.Equal(int32(5), taken.Load())
+	stored, err := s.repo.Find(s.ctx, challenge.ID)
+	s.NoError(err)
+	s.Equal(5, stored.AttemptCount)
+}
+
+func (s *otpRepositoryTestSuite) TestExchangedTokensAreSingleUse() {
+	assert := assert.New(s.T())
+	challenge := s.challenge(time.Now())
+	s.repo.Issue(s.ctx, challenge, time.Now())
+
+	expiresAt := time.Now().Add(15 * time.Minute)
+	assert.NoError(s.repo.Exchange(s.ctx, challenge.ID, "token-hash", expiresAt))
+	exchanged, err := s.repo.Find(s.ctx, challenge.ID)
+	assert.NoError(err)
+	assert.Empty(exchanged.CodeHash)
+	_, err = s.repo.TakeAttempt(s.ctx, challenge.ID, 5)
+	assert.ErrorIs(err, otppkg.ErrChallengeNotFound, "an exchanged challenge takes no more answers")
+
+	found, err := s.repo.FindByToken(s.ctx, otppkg.PurposePasswordReset, "token-hash")
+	assert.NoError(err)
+	assert.Equal("jane@example.com", found.Subject)
+
+	_, err = s.repo.ConsumeToken(s.ctx, otppkg.PurposeEmailChange, "token-hash")
+	assert.ErrorIs(err, otppkg.ErrInvalidToken, "tokens only work for their own purpose")
+
+	consumed, err := s.repo.ConsumeToken(s.ctx, otppkg.PurposePasswordReset, "token-hash")
+	assert.NoError(err)
+	assert.Equal("jane@example.com", consumed.Subject)
+	assert.WithinDuration(expiresAt, consumed.ExpiresAt, time.Second)
+
+	_, err = s.repo.ConsumeToken(s.ctx, otppkg.PurposePasswordReset, "token-hash")
+	assert.ErrorIs(err, otppkg.ErrInvalidToken)
+	_, err = s.repo.FindByToken(s.ctx, otppkg.PurposePasswordReset, "token-hash")
+	assert.ErrorIs(err, otppkg.ErrInvalidToken)
+}
@@ -0,0 +1,84 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	outboxpkg "github.com/Amaankaa/Blog-Starter-Project/Domain/outbox"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type OutboxRepository struct {
+	collection *mongo.Collection
+}
+
+func NewOutboxRepository(collection *mongo.Collection) *OutboxRepository {
+	return &OutboxRepository{collection: collection}
+}
+
+// EnsureIndexes creates the unique idempotency key index and the index used to find due messages
+func (r *OutboxRepository) EnsureIndexes(ctx context.Context) error {
+	_, err := r.collection.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.D{{Key: "idempotency_key", Value: 1}}, Options: options.Index().SetUnique(true)},
+		{Keys: bson.D{{Key: "status", Value: 1}, {Key: "next_attempt_at", Value: 1}}},
+	})
+	return err
+}
+
+func (r *OutboxRepository) Enqueue(ctx context.Context, msg outboxpkg.Message) error {
+	if msg.IdempotencyKey == "" {
+		return errors.New("outbox message needs an idempotency key")
+	}
+	_, err := r.collection.UpdateOne(ctx,
+		bson.M{"idempotency_key": msg.IdempotencyKey},
+		bson.M{"$setOnInsert": msg},
+		options.Update().SetUpsert(true),
+	)
+	if mongo.IsDuplicateKeyError(err) {
+		// a concurrent enqueue with the same key won the race
+		return nil
+	}
+	return err
+}
+
+func (r *OutboxRepository) ClaimDue(ctx context.Context, now time.Time, lease time.Duration) (outboxpkg.Message, bool, error) {
+	filter := bson.M{
+		"status":          outboxpkg.StatusPending,
+		"next_attempt_at": bson.M{"$lte": now},
+	}
+	update := bson.M{"$set": bson.M{"next_attempt_at": now.Add(lease)}}
+	opts := options.FindOneAndUpdate().
+		SetSort(bson.D{{Key: "next_attempt_at", Value: 1}}).
+		SetReturnDocument(options.After)
+
+	var msg outboxpkg.Message
+	err := r.collection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&msg)
+	if err == mongo.ErrNoDocuments {
+		return outboxpkg.Message{}, false, nil
+	}
+	if err != nil {
+		return outboxpkg.Message{}, false, err
+	}
+	return msg, true, nil
+}
+
+func (r *OutboxRepository) UpdateMessage(ctx context.Context, msg outboxpkg.Message) error {
+	update := bson.M{"$set": bson.M{
+		"status":          msg.Status,
+		"attempts":        msg.Attempts,
+		"last_error":      msg.LastError,
+		"next_attempt_at": msg.NextAttemptAt,
+		"processed_at":    msg.ProcessedAt,
+	}}
+	res, err := r.collection.UpdateOne(ctx, bson.M{"_id": msg.ID}, update)
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount == 0 {
+		return errors.New("outbox message not found")
+	}
+	return nil
+}
@@ -0,0 +1,105 @@
+package repositories_test
+
+import (
+	"context"
+	"log"
+	"os"
+	"testing"
+	"time"
+
+	outboxpkg "github.com/Amaankaa/Blog-Starter-Project/Domain/outbox"
+	repositories "github.com/Amaankaa/Blog-Starter-Project/Repositories"
+	"github.com/joho/godotenv"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const testOutboxCollection = "test_outbox"
+
+type outboxRepositoryTestSuite struct {
+	suite.Suite
+	client     *mongo.Client
+	ctx        context.Context
+	cancel     context.CancelFunc
+	collection *mongo.Collection
+	repo       *repositories.OutboxRepository
+}
+
+func TestOutboxRepositoryTestSuite(t *testing.T) {
+	suite.Run(t, new(outboxRepositoryTestSuite))
+}
+
+func (s *outboxRepositoryTestSuite) SetupSuite() {
+	err := godotenv.Load("../.env")
+	if err != nil {
+		log.Fatal("Error loading .env file")
+	}
+
+	client, err := mongo.Connect(context.Background(), options.Client().ApplyURI(os.Getenv("MONGODB_URI")))
+	s.Require().NoError(err)
+
+	s.client = client
+	s.collection = client.Database("test_blog_db").Collection(testOutboxCollection)
+	s.repo = repositories.NewOutboxRepository(s.collection)
+	s.ctx, s.cancel = context.WithTimeout(context.Background(), 10*time.Second)
+	s.Require().NoError(s.repo.EnsureIndexes(s.ctx))
+}
+
+func (s *outboxRepositoryTestSuite) TearDownSuite() {
+	s.collection.Drop(s.ctx)
+	s.cancel()
+	s.client.Disconnect(s.ctx)
+}
+
+func (s *outboxRepositoryTestSuite) SetupTest() {
+	_, err := s.collection.DeleteMany(s.ctx, bson.M{})
+	s.Require().NoError(err)
+}
+
+func (s *outboxRepositoryTestSuite) TestEnqueue_IsIdempotent() {
+	assert := assert.New(s.T())
+	msg := outboxpkg.Message{Kind: outboxpkg.KindVerificationEmail, IdempotencyKey: "verification-email:u1", Payload: `{"email":"a@example.com"}`, Status: outboxpkg.StatusPending, NextAttemptAt: time.Now()}
+
+	assert.NoError(s.repo.Enqueue(s.ctx, msg))
+	msg.Payload = `{"email":"b@example.com"}`
+	assert.NoError(s.repo.Enqueue(s.ctx, msg))
+
+	count, err := s.collection.CountDocuments(s.ctx, bson.M{})
+	assert.NoError(err)
+	assert.Equal(int64(1), count)
+
+	claimed, ok, err := s.repo.ClaimDue(s.ctx, time.Now(), time.Minute)
+	assert.NoError(err)
+	assert.True(ok)
+	assert.Equal(`{"email":"a@example.com"}`, claimed.Payload)
+}
+
+func (s *outboxRepositoryTestSuite) TestEnqueue_RequiresKey() {
+	s.Error(s.repo.Enqueue(s.ctx, outboxpkg.Message{Kind: outboxpkg.KindVerificationEmail}))
+}
+
+func (s *outboxRepositoryTestSuite) TestClaimDue_SkipsLeasedAndProcessedMessages() {
+	assert := assert.New(s.T())
+	now := time.Now()
+	assert.NoError(s.repo.Enqueue(s.ctx, outboxpkg.Message{Kind: "k", IdempotencyKey: "due", Status: outboxpkg.StatusPending, NextAttemptAt: now.Add(-time.Second)}))
+	assert.NoError(s.repo.Enqueue(s.ctx, outboxpkg.Message{Kind: "k", IdempotencyKey: "later", Status: outboxpkg.StatusPending, NextAttemptAt: now.Add(time.Hour)}))
+	assert.NoError(s.repo.Enqueue(s.ctx, outboxpkg.Message{Kind: "k", IdempotencyKey: "sent", Status: outboxpkg.StatusSent, NextAttemptAt: now.Add(-time.Hour)}))
+
+	claimed, ok, err := s.repo.ClaimDue(s.ctx, now, time.Minute)
+	assert.NoError(err)
+	assert.True(ok)
+	assert.Equal("due", claimed.IdempotencyKey)
+
+	claimed.Status = outboxpkg.StatusSent
+	claimed.Attempts = 1
+	processed := now
+	claimed.ProcessedAt = &processed
+	assert.NoError(s.repo.UpdateMessage(s.ctx, claimed))
+
+	_, ok, err = s.repo.ClaimDue(s.ctx, now.Add(2*time.Minute), time.Minute)
+	assert.NoError(err)
+	assert.False(ok)
+}
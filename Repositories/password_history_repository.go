@@ -0,0 +1,71 @@
+package repositories
+
+import (
+	"context"
+
+	passwordpkg "github.com/Amaankaa/Blog-Starter-Project/Domain/password"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type PasswordHistoryRepository struct {
+	collection *mongo.Collection
+}
+
+func NewPasswordHistoryRepository(collection *mongo.Collection) *PasswordHistoryRepository {
+	return &PasswordHistoryRepository{collection: collection}
+}
+
+// EnsureIndexes backs reading a user's history newest first
+func (r *PasswordHistoryRepository) EnsureIndexes(ctx context.Context) error {
+	_, err := r.collection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "retired_at", Value: -1}},
+	})
+	return err
+}
+
+func (r *PasswordHistoryRepository) Add(ctx context.Context, entry passwordpkg.HistoryEntry, keep int) error {
+	if _, err := r.collection.InsertOne(ctx, entry); err != nil {
+		return err
+	}
+
+	cursor, err := r.collection.Find(ctx, bson.M{"user_id": entry.UserID}, options.Find().
+		SetSort(bson.D{{Key: "retired_at", Value: -1}}).
+		SetSkip(int64(keep)).
+		SetProjection(bson.M{"_id": 1}))
+	if err != nil {
+		return err
+	}
+	var old []struct {
+		ID primitive.ObjectID `bson:"_id"`
+	}
+	if err := cursor.All(ctx, &old); err != nil || len(old) == 0 {
+		return err
+	}
+	ids := make([]primitive.ObjectID, len(old))
+	for i, o := range old {
+		ids[i] = o.ID
+	}
+	_, err = r.collection.DeleteMany(ctx, bson.M{"_id": bson.M{"$in": ids}})
+	return err
+}
+
+func (r *PasswordHistoryRepository) Recent(ctx context.Context, userID string, limit int) ([]string, error) {
+	cursor, err := r.collection.Find(ctx, bson.M{"user_id": userID}, options.Find().
+		SetSort(bson.D{{Key: "retired_at", Value: -1}}).
+		SetLimit(int64(limit)))
+	if err != nil {
+		return nil, err
+	}
+	var entries []passwordpkg.HistoryEntry
+	if err := cursor.All(ctx, &entries); err != nil {
+		return nil, err
+	}
+	hashes := make([]string, len(entries))
+	for i, entry := range entries {
+		hashes[i] = entry.Hash
+	}
+	return hashes, nil
+}
@@ -0,0 +1,80 @@
+package repositories_test
+
+import (
+	"context"
+	"log"
+	"os"
+	"testing"
+	"time"
+
+	passwordpkg "github.com/Amaankaa/Blog-Starter-Project/Domain/password"
+	repositories "github.com/Amaankaa/Blog-Starter-Project/Repositories"
+	"github.com/joho/godotenv"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type passwordHistoryRepositoryTestSuite struct {
+	suite.Suite
+	client     *mongo.Client
+	ctx        context.Context
+	cancel     context.CancelFunc
+	collection *mongo.Collection
+	repo       *repositories.PasswordHistoryRepository
+}
+
+func TestPasswordHistoryRepositoryTestSuite(t *testing.T) {
+	suite.Run(t, new(passwordHistoryRepositoryTestSuite))
+}
+
+func (s *passwordHistoryRepositoryTestSuite) SetupSuite() {
+	err := godotenv.Load("../.env")
+	if err != nil {
+		log.Fatal("Error loading .env file")
+	}
+
+	client, err := mongo.Connect(context.Background(), options.Client().ApplyURI(os.Getenv("MONGODB_URI")))
+	s.Require().NoError(err)
+
+	s.client = client
+	s.collection = client.Database("test_blog_db").Collection("test_password_history")
+	s.repo = repositories.NewPasswordHistoryRepository(s.collection)
+	s.ctx, s.cancel = context.WithTimeout(context.Background(), 10*time.Second)
+	s.Require().NoError(s.repo.EnsureIndexes(s.ctx))
+}
+
+func (s *passwordHistoryRepositoryTestSuite) TearDownSuite() {
+	s.collection.Drop(s.ctx)
+	s.cancel()
+	s.client.Disconnect(s.ctx)
+}
+
+func (s *passwordHistoryRepositoryTestSuite) SetupTest() {
+	_, err := s.collection.DeleteMany(s.ctx, bson.M{})
+	s.Require().NoError(err)
+}
+
+func (s *passwordHistoryRepositoryTestSuite) TestAddKeepsTheNewestEntries() {
+	assert := assert.New(s.T())
+	start := time.Now()
+	for i, hash := range []string{"first", "second", "third", "fourth"} {
+		entry := passwordpkg.HistoryEntry{UserID: "user-1", Hash: hash, RetiredAt: start.Add(time.Duration(i) * time.Minute)}
+		assert.NoError(s.repo.Add(s.ctx, entry, 3))
+	}
+	assert.NoError(s.repo.Add(s.ctx, passwordpkg.HistoryEntry{UserID: "user-2", Hash: "other", RetiredAt: start}, 3))
+
+	hashes, err := s.repo.Recent(s.ctx, "user-1", 10)
+	assert.NoError(err)
+	assert.Equal([]string{"fourth", "third", "second"}, hashes)
+
+	hashes, err = s.repo.Recent(s.ctx, "user-1", 1)
+	assert.NoError(err)
+	assert.Equal([]string{"fourth"}, hashes)
+
+	hashes, err = s.repo.Recent(s.ctx, "user-2", 10)
+	assert.NoError(err)
+	assert.Equal([]string{"other"}, hashes)
+}
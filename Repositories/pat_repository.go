@@ -0,0 +1,114 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	patpkg "github.com/Amaankaa/Blog-Starter-Project/Domain/pat"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type PATRepository struct {
+	collection *mongo.Collection
+}
+
+func NewPATRepository(collection *mongo.Collection) *PATRepository {
+	return &PATRepository{collection: collection}
+}
+
+// EnsureIndexes backs the per-request lookup by hash and lets MongoDB drop expired tokens
+func (r *PATRepository) EnsureIndexes(ctx context.Context) error {
+	_, err := r.collection.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "token_hash", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		{Keys: bson.D{{Key: "user_id", Value: 1}}},
+		{
+			Keys:    bson.D{{Key: "expires_at", Value: 1}},
+			Options: options.Index().SetExpireAfterSeconds(0),
+		},
+	})
+	return err
+}
+
+func (r *PATRepository) Create(ctx context.Context, token patpkg.PersonalAccessToken) (patpkg.PersonalAccessToken, error) {
+	token.ID = primitive.NewObjectID()
+	if _, err := r.collection.InsertOne(ctx, token); err != nil {
+		return patpkg.PersonalAccessToken{}, err
+	}
+	return token, nil
+}
+
+func (r *PATRepository) FindByHash(ctx context.Context, tokenHash string) (patpkg.PersonalAccessToken, error) {
+	var token patpkg.PersonalAccessToken
+	err := r.collection.FindOne(ctx, bson.M{"token_hash": tokenHash}).Decode(&token)
+	if err == mongo.ErrNoDocuments {
+		return patpkg.PersonalAccessToken{}, patpkg.ErrTokenNotFound
+	}
+	return token, err
+}
+
+func (r *PATRepository) ListByUser(ctx context.Context, userID string) ([]patpkg.PersonalAccessToken, error) {
+	oid, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, err
+	}
+	cursor, err := r.collection.Find(ctx, bson.M{"user_id": oid}, options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}}))
+	if err != nil {
+		return nil, err
+	}
+	tokens := []patpkg.PersonalAccessToken{}
+	if err := cursor.All(ctx, &tokens); err != nil {
+		return nil, err
+	}
+	return tokens, nil
+}
+
+func (r *PATRepository) CountByUser(ctx context.Context, userID string) (int64, error) {
+	oid, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return 0, err
+	}
+	return r.collection.CountDocuments(ctx, bson.M{"user_id": oid})
+}
+
+func (r *PATRepository) Delete(ctx context.Context, userID, tokenID string) error {
+	uid, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return patpkg.ErrTokenNotFound
+	}
+	oid, err := primitive.ObjectIDFromHex(tokenID)
+	if err != nil {
+		return patpkg.ErrTokenNotFound
+	}
+	res, err := r.collection.DeleteOne(ctx, bson.M{"_id": oid, "user_id": uid})
+	if err != nil {
+		return err
+	}
+	if res.DeletedCount == 0 {
+		return patpkg.ErrTokenNotFound
+	}
+	return nil
+}
+
+func (r *PATRepository) DeleteByUser(ctx context.Context, userID string) error {
+	uid, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return err
+	}
+	_, err = r.collection.DeleteMany(ctx, bson.M{"user_id": uid})
+	return err
+}
+
+func (r *PATRepository) TouchLastUsed(ctx context.Context, tokenID string, at time.Time) error {
+	oid, err := primitive.ObjectIDFromHex(tokenID)
+	if err != nil {
+		return err
+	}
+	_, err = r.collection.UpdateOne(ctx, bson.M{"_id": oid}, bson.M{"$set": bson.M{"last_used_at": at}})
+	return err
+}
@@ -0,0 +1,110 @@
+package repositories_test
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"testing"
+	"time"
+
+	patpkg "github.com/Amaankaa/Blog-Starter-Project/Domain/pat"
+	repositories "github.com/Amaankaa/Blog-Starter-Project/Repositories"
+	"github.com/joho/godotenv"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type patRepositoryTestSuite struct {
+	suite.Suite
+	client     *mongo.Client
+	ctx        context.Context
+	cancel     context.CancelFunc
+	collection *mongo.Collection
+	repo       *repositories.PATRepository
+}
+
+func TestPATRepositoryTestSuite(t *testing.T) {
+	suite.Run(t, new(patRepositoryTestSuite))
+}
+
+func (s *patRepositoryTestSuite) SetupSuite() {
+	err := godotenv.Load("../.env")
+	if err != nil {
+		log.Fatal("Error loading .env file")
+	}
+
+	client, err := mongo.Connect(context.Background(), options.Client().ApplyURI(os.Getenv("MONGODB_URI")))
+	s.Require().NoError(err)
+
+	s.client = client
+	s.collection = client.Database("test_blog_db").Collection("test_personal_access_tokens")
+	s.repo = repositories.NewPATRepository(s.collection)
+	s.ctx, s.cancel = context.WithTimeout(context.Background(), 10*time.Second)
+	s.Require().NoError(s.repo.EnsureIndexes(s.ctx))
+}
+
+func (s *patRepositoryTestSuite) TearDownSuite() {
+	s.collection.Drop(s.ctx)
+	s.cancel()
+	s.client.Disconnect(s.ctx)
+}
+
+func (s *patRepositoryTestSuite) SetupTest() {
+	_, err := s.collection.DeleteMany(s.ctx, bson.M{})
+	s.Require().NoError(err)
+}
+
+func (s *patRepositoryTestSuite) TestCreateFindAndDelete() {
+	assert := assert.New(s.T())
+	owner := primitive.NewObjectID()
+	created, err := s.repo.Create(s.ctx, patpkg.PersonalAccessToken{
+		UserID:    owner,
+		Name:      "ci",
+		Scopes:    []string{patpkg.ScopeBlogsWrite},
+		TokenHash: "hash",
+		ExpiresAt: time.Now().Add(time.Hour),
+	})
+	assert.NoError(err)
+
+	found, err := s.repo.FindByHash(s.ctx, "hash")
+	assert.NoError(err)
+	assert.Equal(created.ID, found.ID)
+	count, err := s.repo.CountByUser(s.ctx, owner.Hex())
+	assert.NoError(err)
+	assert.EqualValues(1, count)
+
+	err = s.repo.Delete(s.ctx, primitive.NewObjectID().Hex(), created.ID.Hex())
+	assert.ErrorIs(err, patpkg.ErrTokenNotFound)
+	assert.NoError(s.repo.Delete(s.ctx, owner.Hex(), created.ID.Hex()))
+	_, err = s.repo.FindByHash(s.ctx, "hash")
+	assert.ErrorIs(err, patpkg.ErrTokenNotFound)
+}
+
+func (s *patRepositoryTestSuite) TestDeleteByUser() {
+	assert := assert.New(s.T())
+	owner, other := primitive.NewObjectID(), primitive.NewObjectID()
+	for i, userID := range []primitive.ObjectID{owner, owner, other} {
+		_, err := s.repo.Create(s.ctx, patpkg.PersonalAccessToken{
+			UserID:    userID,
+			Name:      "ci",
+			Scopes:    []string{patpkg.ScopeBlogsWrite},
+			TokenHash: fmt.Sprintf("hash-%d", i),
+			ExpiresAt: time.Now().Add(time.Hour),
+		})
+		assert.NoError(err)
+	}
+
+	assert.NoError(s.repo.DeleteByUser(s.ctx, owner.Hex()))
+
+	count, err := s.repo.CountByUser(s.ctx, owner.Hex())
+	assert.NoError(err)
+	assert.EqualValues(0, count)
+	count, err = s.repo.CountByUser(s.ctx, other.Hex())
+	assert.NoError(err)
+	assert.EqualValues(1, count)
+}
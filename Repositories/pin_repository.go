@@ -0,0 +1,86 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	blogpkg "github.com/Amaankaa/Blog-Starter-Project/Domain/blog"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type PinRepository struct {
+	collection *mongo.Collection
+}
+
+func NewPinRepository(collection *mongo.Collection) *PinRepository {
+	return &PinRepository{collection: collection}
+}
+
+// UpsertPin creates the pin or updates its position and expiry if the post is already pinned in that scope
+func (r *PinRepository) UpsertPin(ctx context.Context, pin blogpkg.Pin) (blogpkg.Pin, error) {
+	filter := bson.M{"scope": pin.Scope, "owner_id": pin.OwnerID, "blog_id": pin.BlogID}
+	set := bson.M{
+		"pinned_by": pin.PinnedBy,
+		"position":  pin.Position,
+	}
+	update := bson.M{
+		"$set":         set,
+		"$setOnInsert": bson.M{"created_at": pin.CreatedAt},
+	}
+	if pin.ExpiresAt != nil {
+		set["expires_at"] = *pin.ExpiresAt
+	} else {
+		update["$unset"] = bson.M{"expires_at": ""}
+	}
+
+	opts := options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After)
+	var stored blogpkg.Pin
+	if err := r.collection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&stored); err != nil {
+		return blogpkg.Pin{}, err
+	}
+	return stored, nil
+}
+
+func (r *PinRepository) DeletePin(ctx context.Context, scope blogpkg.PinScope, ownerID, blogID string) error {
+	res, err := r.collection.DeleteOne(ctx, bson.M{"scope": scope, "owner_id": ownerID, "blog_id": blogID})
+	if err != nil {
+		return err
+	}
+	if res.DeletedCount == 0 {
+		return errors.New("pin not found")
+	}
+	return nil
+}
+
+func (r *PinRepository) DeletePinsOfBlog(ctx context.Context, blogID string) error {
+	_, err := r.collection.DeleteMany(ctx, bson.M{"blog_id": blogID})
+	return err
+}
+
+// ListActivePins returns unexpired pins of a scope ordered by position, oldest pin first on ties
+func (r *PinRepository) ListActivePins(ctx context.Context, scope blogpkg.PinScope, ownerID string, now time.Time) ([]blogpkg.Pin, error) {
+	filter := bson.M{
+		"scope":    scope,
+		"owner_id": ownerID,
+		"$or": []bson.M{
+			{"expires_at": bson.M{"$exists": false}},
+			{"expires_at": bson.M{"$gt": now}},
+		},
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "position", Value: 1}, {Key: "created_at", Value: 1}})
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	pins := []blogpkg.Pin{}
+	if err = cursor.All(ctx, &pins); err != nil {
+		return nil, err
+	}
+	return pins, nil
+}
@@ -0,0 +1,136 @@
+package repositories_test
+
+import (
+	"context"
+	"log"
+	"os"
+	"testing"
+	"time"
+
+	blogpkg "github.com/Amaankaa/Blog-Starter-Project/Domain/blog"
+	repositories "github.com/Amaankaa/Blog-Starter-Project/Repositories"
+	"github.com/joho/godotenv"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const testPinCollection = "test_pins"
+
+type pinRepositoryTestSuite struct {
+	suite.Suite
+	client     *mongo.Client
+	ctx        context.Context
+	cancel     context.CancelFunc
+	collection *mongo.Collection
+	repo       *repositories.PinRepository
+}
+
+func TestPinRepositoryTestSuite(t *testing.T) {
+	suite.Run(t, new(pinRepositoryTestSuite))
+}
+
+func (s *pinRepositoryTestSuite) SetupSuite() {
+	err := godotenv.Load("../.env")
+	if err != nil {
+		log.Fatal("Error loading .env file")
+	}
+
+	client, err := mongo.Connect(context.Background(), options.Client().ApplyURI(os.Getenv("MONGODB_URI")))
+	s.Require().NoError(err)
+
+	s.client = client
+	s.collection = client.Database("test_blog_db").Collection(testPinCollection)
+	s.repo = repositories.NewPinRepository(s.collection)
+	s.ctx, s.cancel = context.WithTimeout(context.Background(), 10*time.Second)
+}
+
+func (s *pinRepositoryTestSuite) TearDownSuite() {
+	s.collection.Drop(s.ctx)
+	s.cancel()
+	s.client.Disconnect(s.ctx)
+}
+
+func (s *pinRepositoryTestSuite) SetupTest() {
+	_, err := s.collection.DeleteMany(s.ctx, bson.M{})
+	s.Require().NoError(err)
+}
+
+func (s *pinRepositoryTestSuite) TestUpsertPin_UpdatesExisting() {
+	assert := assert.New(s.T())
+	expires := time.Now().Add(time.Hour).Truncate(time.Millisecond)
+	pin := blogpkg.Pin{BlogID: "b1", Scope: blogpkg.PinScopeGlobal, PinnedBy: "admin", Position: 3, ExpiresAt: &expires, CreatedAt: time.Now()}
+
+	first, err := s.repo.UpsertPin(s.ctx, pin)
+	assert.NoError(err)
+	assert.False(first.ID.IsZero())
+
+	pin.Position = 1
+	pin.ExpiresAt = nil
+	second, err := s.repo.UpsertPin(s.ctx, pin)
+	assert.NoError(err)
+	assert.Equal(first.ID, second.ID)
+	assert.Equal(1, second.Position)
+	assert.Nil(second.ExpiresAt)
+
+	count, _ := s.collection.CountDocuments(s.ctx, bson.M{})
+	assert.Equal(int64(1), count)
+}
+
+func (s *pinRepositoryTestSuite) TestListActivePins_OrdersAndSkipsExpired() {
+	assert := assert.New(s.T())
+	now := time.Now()
+	past := now.Add(-time.Minute)
+	future := now.Add(time.Hour)
+	pins := []blogpkg.Pin{
+		{BlogID: "second", Scope: blogpkg.PinScopeProfile, OwnerID: "u1", Position: 2, CreatedAt: now},
+		{BlogID: "first", Scope: blogpkg.PinScopeProfile, OwnerID: "u1", Position: 1, ExpiresAt: &future, CreatedAt: now},
+		{BlogID: "expired", Scope: blogpkg.PinScopeProfile, OwnerID: "u1", Position: 0, ExpiresAt: &past, CreatedAt: now},
+		{BlogID: "other-owner", Scope: blogpkg.PinScopeProfile, OwnerID: "u2", Position: 0, CreatedAt: now},
+		{BlogID: "global", Scope: blogpkg.PinScopeGlobal, Position: 0, CreatedAt: now},
+	}
+	for _, p := range pins {
+		_, err := s.repo.UpsertPin(s.ctx, p)
+		assert.NoError(err)
+	}
+
+	active, err := s.repo.ListActivePins(s.ctx, blogpkg.PinScopeProfile, "u1", now)
+	assert.NoError(err)
+	assert.Len(active, 2)
+	assert.Equal("first", active[0].BlogID)
+	assert.Equal("second", active[1].BlogID)
+}
+
+func (s *pinRepositoryTestSuite) TestDeletePin() {
+	assert := assert.New(s.T())
+	_, err := s.repo.UpsertPin(s.ctx, blogpkg.Pin{BlogID: "b1", Scope: blogpkg.PinScopeGlobal, CreatedAt: time.Now()})
+	assert.NoError(err)
+
+	assert.NoError(s.repo.DeletePin(s.ctx, blogpkg.PinScopeGlobal, "", "b1"))
+	assert.EqualError(s.repo.DeletePin(s.ctx, blogpkg.PinScopeGlobal, "", "b1"), "pin not found")
+}
+
+func (s *pinRepositoryTestSuite) TestDeletePinsOfBlog() {
+	assert := assert.New(s.T())
+	for _, pin := range []blogpkg.Pin{
+		{BlogID: "b1", Scope: blogpkg.PinScopeGlobal},
+		{BlogID: "b1", Scope: blogpkg.PinScopeProfile, OwnerID: "author-1"},
+		{BlogID: "b2", Scope: blogpkg.PinScopeProfile, OwnerID: "author-1"},
+	} {
+		pin.CreatedAt = time.Now()
+		_, err := s.repo.UpsertPin(s.ctx, pin)
+		assert.NoError(err)
+	}
+
+	assert.NoError(s.repo.DeletePinsOfBlog(s.ctx, "b1"))
+
+	global, err := s.repo.ListActivePins(s.ctx, blogpkg.PinScopeGlobal, "", time.Now())
+	assert.NoError(err)
+	assert.Empty(global)
+	profile, err := s.repo.ListActivePins(s.ctx, blogpkg.PinScopeProfile, "author-1", time.Now())
+	assert.NoError(err)
+	assert.Len(profile, 1)
+	assert.Equal("b2", profile[0].BlogID)
+}
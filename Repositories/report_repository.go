@@ -0,0 +1,76 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	blogpkg "github.com/Amaankaa/Blog-Starter-Project/Domain/blog"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type ReportRepository struct {
+	collection *mongo.Collection
+}
+
+func NewReportRepository(collection *mongo.Collection) *ReportRepository {
+	return &ReportRepository{collection: collection}
+}
+
+// EnsureIndexes backs the moderators' queue, which lists one status oldest first
+func (r *ReportRepository) EnsureIndexes(ctx context.Context) error {
+	_, err := r.collection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "status", Value: 1}, {Key: "created_at", Value: 1}},
+	})
+	return err
+}
+
+func (r *ReportRepository) CreateReport(ctx context.Context, report blogpkg.Report) (blogpkg.Report, error) {
+	report.ID = primitive.NewObjectID()
+	if _, err := r.collection.InsertOne(ctx, report); err != nil {
+		return blogpkg.Report{}, err
+	}
+	return report, nil
+}
+
+func (r *ReportRepository) ListReports(ctx context.Context, status blogpkg.ReportStatus, limit int64) ([]blogpkg.Report, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}}).SetLimit(limit)
+	cursor, err := r.collection.Find(ctx, bson.M{"status": status}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	reports := []blogpkg.Report{}
+	if err = cursor.All(ctx, &reports); err != nil {
+		return nil, err
+	}
+	return reports, nil
+}
+
+// HandleReport only matches open reports, so two moderators can't both close the same one
+func (r *ReportRepository) HandleReport(ctx context.Context, id string, status blogpkg.ReportStatus, handledBy, note string, at time.Time) (blogpkg.Report, error) {
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return blogpkg.Report{}, blogpkg.ErrReportNotFound
+	}
+	update := bson.M{"$set": bson.M{
+		"status":     status,
+		"handled_by": handledBy,
+		"note":       note,
+		"handled_at": at,
+	}}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+	var report blogpkg.Report
+	err = r.collection.FindOneAndUpdate(ctx, bson.M{"_id": oid, "status": blogpkg.ReportOpen}, update, opts).Decode(&report)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return blogpkg.Report{}, blogpkg.ErrReportNotFound
+	}
+	if err != nil {
+		return blogpkg.Report{}, err
+	}
+	return report, nil
+}
@@ -0,0 +1,94 @@
+package repositories_test
+
+import (
+	"context"
+	"log"
+	"os"
+	"testing"
+	"time"
+
+	blogpkg "github.com/Amaankaa/Blog-Starter-Project/Domain/blog"
+	repositories "github.com/Amaankaa/Blog-Starter-Project/Repositories"
+	"github.com/joho/godotenv"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const testReportCollection = "test_reports"
+
+type reportRepositoryTestSuite struct {
+	suite.Suite
+	client     *mongo.Client
+	ctx        context.Context
+	cancel     context.CancelFunc
+	collection *mongo.Collection
+	repo       *repositories.ReportRepository
+}
+
+func TestReportRepositoryTestSuite(t *testing.T) {
+	suite.Run(t, new(reportRepositoryTestSuite))
+}
+
+func (s *reportRepositoryTestSuite) SetupSuite() {
+	err := godotenv.Load("../.env")
+	if err != nil {
+		log.Fatal("Error loading .env file")
+	}
+
+	client, err := mongo.Connect(context.Background(), options.Client().ApplyURI(os.Getenv("MONGODB_URI")))
+	s.Require().NoError(err)
+
+	s.client = client
+	s.collection = client.Database("test_blog_db").Collection(testReportCollection)
+	s.repo = repositories.NewReportRepository(s.collection)
+	s.ctx, s.cancel = context.WithTimeout(context.Background(), 10*time.Second)
+	s.Require().NoError(s.repo.EnsureIndexes(s.ctx))
+}
+
+func (s *reportRepositoryTestSuite) TearDownSuite() {
+	s.collection.Drop(s.ctx)
+	s.cancel()
+	s.client.Disconnect(s.ctx)
+}
+
+func (s *reportRepositoryTestSuite) SetupTest() {
+	_, err := s.collection.DeleteMany(s.ctx, bson.M{})
+	s.Require().NoError(err)
+}
+
+func (s *reportRepositoryTestSuite) TestListReports_OldestFirstByStatus() {
+	assert := assert.New(s.T())
+	now := time.Now()
+	for _, r := range []blogpkg.Report{
+		{BlogID: "newer", Status: blogpkg.ReportOpen, CreatedAt: now},
+		{BlogID: "older", Status: blogpkg.ReportOpen, CreatedAt: now.Add(-time.Hour)},
+		{BlogID: "dismissed", Status: blogpkg.ReportDismissed, CreatedAt: now.Add(-2 * time.Hour)},
+	} {
+		_, err := s.repo.CreateReport(s.ctx, r)
+		assert.NoError(err)
+	}
+
+	open, err := s.repo.ListReports(s.ctx, blogpkg.ReportOpen, 10)
+	assert.NoError(err)
+	assert.Len(open, 2)
+	assert.Equal("older", open[0].BlogID)
+	assert.Equal("newer", open[1].BlogID)
+}
+
+func (s *reportRepositoryTestSuite) TestHandleReport_OnlyOnce() {
+	assert := assert.New(s.T())
+	created, err := s.repo.CreateReport(s.ctx, blogpkg.Report{BlogID: "b1", Status: blogpkg.ReportOpen, CreatedAt: time.Now()})
+	assert.NoError(err)
+
+	handled, err := s.repo.HandleReport(s.ctx, created.ID.Hex(), blogpkg.ReportResolved, "mod-1", "removed", time.Now())
+	assert.NoError(err)
+	assert.Equal(blogpkg.ReportResolved, handled.Status)
+	assert.Equal("mod-1", handled.HandledBy)
+	assert.NotNil(handled.HandledAt)
+
+	_, err = s.repo.HandleReport(s.ctx, created.ID.Hex(), blogpkg.ReportDismissed, "mod-2", "", time.Now())
+	assert.ErrorIs(err, blogpkg.ErrReportNotFound)
+}
@@ -0,0 +1,60 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	userpkg "github.com/Amaankaa/Blog-Starter-Project/Domain/user"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type RevokedTokenRepository struct {
+	collection *mongo.Collection
+}
+
+func NewRevokedTokenRepository(c *mongo.Collection) *RevokedTokenRepository {
+	return &RevokedTokenRepository{collection: c}
+}
+
+// EnsureIndexes creates the index used for syncing and lets MongoDB drop entries once the token expires
+func (r *RevokedTokenRepository) EnsureIndexes(ctx context.Context) error {
+	_, err := r.collection.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.D{{Key: "revoked_at", Value: 1}}},
+		{Keys: bson.D{{Key: "expires_at", Value: 1}}, Options: options.Index().SetExpireAfterSeconds(0)},
+	})
+	return err
+}
+
+func (r *RevokedTokenRepository) StoreRevokedTokens(ctx context.Context, tokens []userpkg.RevokedToken) error {
+	if len(tokens) == 0 {
+		return nil
+	}
+	// Upserts keep revoking the same token twice harmless
+	models := make([]mongo.WriteModel, 0, len(tokens))
+	for _, t := range tokens {
+		models = append(models, mongo.NewReplaceOneModel().
+			SetFilter(bson.M{"_id": t.ID}).
+			SetReplacement(t).
+			SetUpsert(true))
+	}
+	_, err := r.collection.BulkWrite(ctx, models)
+	return err
+}
+
+func (r *RevokedTokenRepository) ListRevokedSince(ctx context.Context, since time.Time) ([]userpkg.RevokedToken, error) {
+	filter := bson.M{
+		"revoked_at": bson.M{"$gte": since},
+		"expires_at": bson.M{"$gt": time.Now()},
+	}
+	cursor, err := r.collection.Find(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	tokens := []userpkg.RevokedToken{}
+	if err := cursor.All(ctx, &tokens); err != nil {
+		return nil, err
+	}
+	return tokens, nil
+}
@@ -0,0 +1,92 @@
+package repositories_test
+
+import (
+	"context"
+	"log"
+	"os"
+	"testing"
+	"time"
+
+	userpkg "github.com/Amaankaa/Blog-Starter-Project/Domain/user"
+	repositories "github.com/Amaankaa/Blog-Starter-Project/Repositories"
+	"github.com/joho/godotenv"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const testRevokedTokenCollection = "test_revoked_tokens"
+
+type revokedTokenRepositoryTestSuite struct {
+	suite.Suite
+	client     *mongo.Client
+	ctx        context.Context
+	cancel     context.CancelFunc
+	collection *mongo.Collection
+	repo       *repositories.RevokedTokenRepository
+}
+
+func TestRevokedTokenRepositoryTestSuite(t *testing.T) {
+	suite.Run(t, new(revokedTokenRepositoryTestSuite))
+}
+
+func (s *revokedTokenRepositoryTestSuite) SetupSuite() {
+	err := godotenv.Load("../.env")
+	if err != nil {
+		log.Fatal("Error loading .env file")
+	}
+
+	client, err := mongo.Connect(context.Background(), options.Client().ApplyURI(os.Getenv("MONGODB_URI")))
+	s.Require().NoError(err)
+
+	s.client = client
+	s.collection = client.Database("test_blog_db").Collection(testRevokedTokenCollection)
+	s.repo = repositories.NewRevokedTokenRepository(s.collection)
+	s.ctx, s.cancel = context.WithTimeout(context.Background(), 10*time.Second)
+	s.Require().NoError(s.repo.EnsureIndexes(s.ctx))
+}
+
+func (s *revokedTokenRepositoryTestSuite) TearDownSuite() {
+	s.collection.Drop(s.ctx)
+	s.cancel()
+	s.client.Disconnect(s.ctx)
+}
+
+func (s *revokedTokenRepositoryTestSuite) SetupTest() {
+	_, err := s.collection.DeleteMany(s.ctx, bson.M{})
+	s.Require().NoError(err)
+}
+
+func (s *revokedTokenRepositoryTestSuite) TestStoreRevokedTokens_IsIdempotent() {
+	assert := assert.New(s.T())
+	token := userpkg.RevokedToken{ID: "jti-1", UserID: "u1", RevokedAt: time.Now(), ExpiresAt: time.Now().Add(time.Minute)}
+
+	assert.NoError(s.repo.StoreRevokedTokens(s.ctx, []userpkg.RevokedToken{token}))
+	assert.NoError(s.repo.StoreRevokedTokens(s.ctx, []userpkg.RevokedToken{token}))
+	assert.NoError(s.repo.StoreRevokedTokens(s.ctx, nil))
+
+	count, err := s.collection.CountDocuments(s.ctx, bson.M{})
+	assert.NoError(err)
+	assert.Equal(int64(1), count)
+}
+
+func (s *revokedTokenRepositoryTestSuite) TestListRevokedSince() {
+	assert := assert.New(s.T())
+	now := time.Now()
+	assert.NoError(s.repo.StoreRevokedTokens(s.ctx, []userpkg.RevokedToken{
+		{ID: "old", UserID: "u1", RevokedAt: now.Add(-time.Hour), ExpiresAt: now.Add(time.Minute)},
+		{ID: "new", UserID: "u1", RevokedAt: now, ExpiresAt: now.Add(time.Minute)},
+		{ID: "expired", UserID: "u1", RevokedAt: now, ExpiresAt: now.Add(-time.Second)},
+	}))
+
+	tokens, err := s.repo.ListRevokedSince(s.ctx, now.Add(-time.Minute))
+	assert.NoError(err)
+	s.Require().Len(tokens, 1)
+	assert.Equal("new", tokens[0].ID)
+
+	tokens, err = s.repo.ListRevokedSince(s.ctx, time.Time{})
+	assert.NoError(err)
+	assert.Len(tokens, 2)
+}
@@ -0,0 +1,99 @@
+package repositories
+
+import (
+	"context"
+
+	rolepkg "github.com/Amaankaa/Blog-Starter-Project/Domain/role"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// roleMatrixVersionID is the _id of the version counter in the settings collection
+const roleMatrixVersionID = "roles"
+
+type RoleRepository struct {
+	roles    *mongo.Collection
+	settings *mongo.Collection
+	users    *mongo.Collection
+}
+
+func NewRoleRepository(roles, settings, users *mongo.Collection) *RoleRepository {
+	return &RoleRepository{roles: roles, settings: settings, users: users}
+}
+
+func (r *RoleRepository) ListRoles(ctx context.Context) ([]rolepkg.Role, error) {
+	cursor, err := r.roles.Find(ctx, bson.M{}, options.Find().SetSort(bson.D{{Key: "_id", Value: 1}}))
+	if err != nil {
+		return nil, err
+	}
+	roles := []rolepkg.Role{}
+	if err := cursor.All(ctx, &roles); err != nil {
+		return nil, err
+	}
+	return roles, nil
+}
+
+func (r *RoleRepository) FindRole(ctx context.Context, name string) (rolepkg.Role, error) {
+	var role rolepkg.Role
+	err := r.roles.FindOne(ctx, bson.M{"_id": name}).Decode(&role)
+	if err == mongo.ErrNoDocuments {
+		return rolepkg.Role{}, rolepkg.ErrRoleNotFound
+	}
+	return role, err
+}
+
+func (r *RoleRepository) InsertRoleIfMissing(ctx context.Context, role rolepkg.Role) (bool, error) {
+	res, err := r.roles.UpdateOne(ctx,
+		bson.M{"_id": role.Name},
+		bson.M{"$setOnInsert": role},
+		options.Update().SetUpsert(true),
+	)
+	if err != nil {
+		return false, err
+	}
+	return res.UpsertedCount > 0, nil
+}
+
+func (r *RoleRepository) SaveRole(ctx context.Context, role rolepkg.Role) error {
+	_, err := r.roles.ReplaceOne(ctx, bson.M{"_id": role.Name}, role, options.Replace().SetUpsert(true))
+	return err
+}
+
+func (r *RoleRepository) DeleteRole(ctx context.Context, name string) error {
+	res, err := r.roles.DeleteOne(ctx, bson.M{"_id": name})
+	if err != nil {
+		return err
+	}
+	if res.DeletedCount == 0 {
+		return rolepkg.ErrRoleNotFound
+	}
+	return nil
+}
+
+func (r *RoleRepository) CountUsersWithRole(ctx context.Context, name string) (int64, error) {
+	return r.users.CountDocuments(ctx, bson.M{"role": name})
+}
+
+func (r *RoleRepository) Version(ctx context.Context) (int64, error) {
+	var doc struct {
+		Version int64 `bson:"version"`
+	}
+	err := r.settings.FindOne(ctx, bson.M{"_id": roleMatrixVersionID}).Decode(&doc)
+	if err == mongo.ErrNoDocuments {
+		return 0, nil
+	}
+	return doc.Version, err
+}
+
+func (r *RoleRepository) BumpVersion(ctx context.Context) (int64, error) {
+	var doc struct {
+		Version int64 `bson:"version"`
+	}
+	err := r.settings.FindOneAndUpdate(ctx,
+		bson.M{"_id": roleMatrixVersionID},
+		bson.M{"$inc": bson.M{"version": 1}},
+		options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After),
+	).Decode(&doc)
+	return doc.Version, err
+}
@@ -0,0 +1,100 @@
+package repositories_test
+
+import (
+	"context"
+	"log"
+	"os"
+	"testing"
+	"time"
+
+	rolepkg "github.com/Amaankaa/Blog-Starter-Project/Domain/role"
+	repositories "github.com/Amaankaa/Blog-Starter-Project/Repositories"
+	"github.com/joho/godotenv"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type roleRepositoryTestSuite struct {
+	suite.Suite
+	client      *mongo.Client
+	ctx         context.Context
+	cancel      context.CancelFunc
+	collections []*mongo.Collection
+	repo        *repositories.RoleRepository
+}
+
+func TestRoleRepositoryTestSuite(t *testing.T) {
+	suite.Run(t, new(roleRepositoryTestSuite))
+}
+
+func (s *roleRepositoryTestSuite) SetupSuite() {
+	err := godotenv.Load("../.env")
+	if err != nil {
+		log.Fatal("Error loading .env file")
+	}
+
+	client, err := mongo.Connect(context.Background(), options.Client().ApplyURI(os.Getenv("MONGODB_URI")))
+	s.Require().NoError(err)
+
+	db := client.Database("test_blog_db")
+	s.client = client
+	s.collections = []*mongo.Collection{db.Collection("test_roles"), db.Collection("test_role_settings"), db.Collection("test_role_users")}
+	s.repo = repositories.NewRoleRepository(s.collections[0], s.collections[1], s.collections[2])
+	s.ctx, s.cancel = context.WithTimeout(context.Background(), 10*time.Second)
+}
+
+func (s *roleRepositoryTestSuite) TearDownSuite() {
+	for _, c := range s.collections {
+		c.Drop(s.ctx)
+	}
+	s.cancel()
+	s.client.Disconnect(s.ctx)
+}
+
+func (s *roleRepositoryTestSuite) SetupTest() {
+	for _, c := range s.collections {
+		_, err := c.DeleteMany(s.ctx, bson.M{})
+		s.Require().NoError(err)
+	}
+}
+
+func (s *roleRepositoryTestSuite) TestInsertRoleIfMissing_KeepsEdits() {
+	assert := assert.New(s.T())
+	inserted, err := s.repo.InsertRoleIfMissing(s.ctx, rolepkg.Role{Name: "editor", Permissions: []string{"posts:feature"}})
+	assert.NoError(err)
+	assert.True(inserted)
+	assert.NoError(s.repo.SaveRole(s.ctx, rolepkg.Role{Name: "editor", Permissions: []string{}}))
+
+	inserted, err = s.repo.InsertRoleIfMissing(s.ctx, rolepkg.Role{Name: "editor", Permissions: []string{"posts:feature"}})
+	assert.NoError(err)
+	assert.False(inserted)
+	role, err := s.repo.FindRole(s.ctx, "editor")
+	assert.NoError(err)
+	assert.Empty(role.Permissions)
+}
+
+func (s *roleRepositoryTestSuite) TestBumpVersion() {
+	assert := assert.New(s.T())
+	v, err := s.repo.Version(s.ctx)
+	assert.NoError(err)
+	assert.EqualValues(0, v)
+
+	v, err = s.repo.BumpVersion(s.ctx)
+	assert.NoError(err)
+	assert.EqualValues(1, v)
+	v, err = s.repo.Version(s.ctx)
+	assert.NoError(err)
+	assert.EqualValues(1, v)
+}
+
+func (s *roleRepositoryTestSuite) TestCountUsersWithRole() {
+	_, err := s.collections[2].InsertOne(s.ctx, bson.M{"role": "author"})
+	s.Require().NoError(err)
+
+	count, err := s.repo.CountUsersWithRole(s.ctx, "author")
+	s.NoError(err)
+	s.EqualValues(1, count)
+}
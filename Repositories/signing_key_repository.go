@@ -0,0 +1,54 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	signingkeypkg "github.com/Amaankaa/Blog-Starter-Project/Domain/signingkey"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type SigningKeyRepository struct {
+	collection *mongo.Collection
+}
+
+func NewSigningKeyRepository(c *mongo.Collection) *SigningKeyRepository {
+	return &SigningKeyRepository{collection: c}
+}
+
+// EnsureIndexes lets MongoDB drop keys once nothing they signed can still be valid
+func (r *SigningKeyRepository) EnsureIndexes(ctx context.Context) error {
+	_, err := r.collection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "expires_at", Value: 1}},
+		Options: options.Index().SetExpireAfterSeconds(0),
+	})
+	return err
+}
+
+func (r *SigningKeyRepository) ListKeys(ctx context.Context) ([]signingkeypkg.SigningKey, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "activates_at", Value: 1}})
+	cursor, err := r.collection.Find(ctx, bson.M{"expires_at": bson.M{"$gt": time.Now()}}, opts)
+	if err != nil {
+		return nil, err
+	}
+	keys := []signingkeypkg.SigningKey{}
+	if err := cursor.All(ctx, &keys); err != nil {
+		return nil, err
+	}
+	return keys, nil
+}
+
+func (r *SigningKeyRepository) InsertKey(ctx context.Context, key signingkeypkg.SigningKey) error {
+	_, err := r.collection.InsertOne(ctx, key)
+	return err
+}
+
+func (r *SigningKeyRepository) SealKey(ctx context.Context, id string, encrypted []byte) error {
+	_, err := r.collection.UpdateByID(ctx, id, bson.M{
+		"$set":   bson.M{"encrypted_private_key": encrypted},
+		"$unset": bson.M{"private_key_pem": ""},
+	})
+	return err
+}
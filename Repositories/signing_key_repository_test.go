@@ -0,0 +1,97 @@
+package repositories_test
+
+import (
+	"context"
+	"log"
+	"os"
+	"testing"
+	"time"
+
+	signingkeypkg "github.com/Amaankaa/Blog-Starter-Project/Domain/signingkey"
+	repositories "github.com/Amaankaa/Blog-Starter-Project/Repositories"
+	"github.com/joho/godotenv"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const testSigningKeyCollection = "test_jwt_signing_keys"
+
+type signingKeyRepositoryTestSuite struct {
+	suite.Suite
+	client     *mongo.Client
+	ctx        context.Context
+	cancel     context.CancelFunc
+	collection *mongo.Collection
+	repo       *repositories.SigningKeyRepository
+}
+
+func TestSigningKeyRepositoryTestSuite(t *testing.T) {
+	suite.Run(t, new(signingKeyRepositoryTestSuite))
+}
+
+func (s *signingKeyRepositoryTestSuite) SetupSuite() {
+	err := godotenv.Load("../.env")
+	if err != nil {
+		log.Fatal("Error loading .env file")
+	}
+
+	client, err := mongo.Connect(context.Background(), options.Client().ApplyURI(os.Getenv("MONGODB_URI")))
+	s.Require().NoError(err)
+
+	s.client = client
+	s.collection = client.Database("test_blog_db").Collection(testSigningKeyCollection)
+	s.repo = repositories.NewSigningKeyRepository(s.collection)
+	s.ctx, s.cancel = context.WithTimeout(context.Background(), 10*time.Second)
+	s.Require().NoError(s.repo.EnsureIndexes(s.ctx))
+}
+
+func (s *signingKeyRepositoryTestSuite) TearDownSuite() {
+	s.collection.Drop(s.ctx)
+	s.cancel()
+	s.client.Disconnect(s.ctx)
+}
+
+func (s *signingKeyRepositoryTestSuite) SetupTest() {
+	_, err := s.collection.DeleteMany(s.ctx, bson.M{})
+	s.Require().NoError(err)
+}
+
+func (s *signingKeyRepositoryTestSuite) TestListKeys_SkipsExpiredAndSortsByActivation() {
+	assert := assert.New(s.T())
+	now := time.Now()
+	for _, key := range []signingkeypkg.SigningKey{
+		{ID: "next", Algorithm: signingkeypkg.AlgorithmRS256, ActivatesAt: now.Add(time.Hour), ExpiresAt: now.Add(48 * time.Hour)},
+		{ID: "current", Algorithm: signingkeypkg.AlgorithmRS256, ActivatesAt: now.Add(-time.Hour), ExpiresAt: now.Add(24 * time.Hour)},
+		{ID: "expired", Algorithm: signingkeypkg.AlgorithmRS256, ActivatesAt: now.Add(-48 * time.Hour), ExpiresAt: now.Add(-time.Hour)},
+	} {
+		assert.NoError(s.repo.InsertKey(s.ctx, key))
+	}
+
+	keys, err := s.repo.ListKeys(s.ctx)
+	assert.NoError(err)
+	s.Require().Len(keys, 2)
+	assert.Equal("current", keys[0].ID)
+	assert.Equal("next", keys[1].ID)
+}
+
+func (s *signingKeyRepositoryTestSuite) TestSealKey_ReplacesThePlaintextKey() {
+	assert := assert.New(s.T())
+	now := time.Now()
+	assert.NoError(s.repo.InsertKey(s.ctx, signingkeypkg.SigningKey{
+		ID: "legacy", Algorithm: signingkeypkg.AlgorithmEdDSA, PrivateKeyPEM: "plaintext", ActivatesAt: now, ExpiresAt: now.Add(time.Hour),
+	}))
+
+	assert.NoError(s.repo.SealKey(s.ctx, "legacy", []byte("sealed")))
+
+	keys, err := s.repo.ListKeys(s.ctx)
+	assert.NoError(err)
+	s.Require().Len(keys, 1)
+	assert.Empty(keys[0].PrivateKeyPEM)
+	assert.Equal([]byte("sealed"), keys[0].EncryptedPrivateKey)
+	count, err := s.collection.CountDocuments(s.ctx, bson.M{"private_key_pem": bson.M{"$exists": true}})
+	assert.NoError(err)
+	assert.Zero(count)
+}
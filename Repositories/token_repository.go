@@ -2,11 +2,13 @@ package repositories
 
 import (
 	"context"
+	"time"
 
 	tokenpkg "github.com/Amaankaa/Blog-Starter-Project/Domain/user"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 type TokenRepository struct {
@@ -17,6 +19,16 @@ func NewTokenRepository(c *mongo.Collection) *TokenRepository {
 	return &TokenRepository{collection: c}
 }
 
+// EnsureIndexes creates the lookup indexes and lets MongoDB drop tokens once they expire
+func (r *TokenRepository) EnsureIndexes(ctx context.Context) error {
+	_, err := r.collection.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.D{{Key: "refresh_token", Value: 1}}},
+		{Keys: bson.D{{Key: "family_id", Value: 1}}},
+		{Keys: bson.D{{Key: "expires_at", Value: 1}}, Options: options.Index().SetExpireAfterSeconds(0)},
+	})
+	return err
+}
+
 func (r *TokenRepository) StoreToken(ctx context.Context, token tokenpkg.Token) error {
 	_, err := r.collection.InsertOne(ctx, token)
 	return err
@@ -47,3 +59,92 @@ func (r *TokenRepository) DeleteTokensByUserID(ctx context.Context, userID strin
 	_, err = r.collection.DeleteMany(ctx, filter)
 	return err
 }
+
+func (r *TokenRepository) RotateRefreshToken(ctx context.Context, refreshToken string, next tokenpkg.Token) error {
+	// Only one caller can flip rotated_at, so two concurrent refreshes with the same
+	// token cannot both succeed
+	filter := bson.M{"refresh_token": refreshToken, "rotated_at": bson.M{"$exists": false}}
+	update := bson.M{"$set": bson.M{"rotated_at": time.Now(), "family_id": next.FamilyID}}
+	res, err := r.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount == 0 {
+		return tokenpkg.ErrRefreshTokenReused
+	}
+	return r.StoreToken(ctx, next)
+}
+
+func (r *TokenRepository) DeleteTokenFamily(ctx context.Context, familyID string) error {
+	_, err := r.collection.DeleteMany(ctx, bson.M{"family_id": familyID})
+	return err
+}
+
+func (r *TokenRepository) ListSessions(ctx context.Context, userID string) ([]tokenpkg.Token, error) {
+	objID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, err
+	}
+	// Tokens from before sessions existed have no family and can't be revoked one by one
+	filter := bson.M{
+		"user_id":    objID,
+		"family_id":  bson.M{"$ne": ""},
+		"rotated_at": bson.M{"$exists": false},
+		"expires_at": bson.M{"$gt": time.Now()},
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "last_used_at", Value: -1}})
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	tokens := []tokenpkg.Token{}
+	if err := cursor.All(ctx, &tokens); err != nil {
+		return nil, err
+	}
+	return tokens, nil
+}
+
+func (r *TokenRepository) DeleteSession(ctx context.Context, userID, sessionID string) error {
+	objID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return err
+	}
+	res, err := r.collection.DeleteMany(ctx, bson.M{"user_id": objID, "family_id": sessionID})
+	if err != nil {
+		return err
+	}
+	if res.DeletedCount == 0 {
+		return tokenpkg.ErrSessionNotFound
+	}
+	return nil
+}
+
+func (r *TokenRepository) DeleteOtherSessions(ctx context.Context, userID, keepSessionID string) error {
+	objID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return err
+	}
+	_, err = r.collection.DeleteMany(ctx, bson.M{"user_id": objID, "family_id": bson.M{"$ne": keepSessionID}})
+	return err
+}
+
+func (r *TokenRepository) ListActiveAccessTokens(ctx context.Context, userID string) ([]tokenpkg.Token, error) {
+	objID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, err
+	}
+	filter := bson.M{
+		"user_id":           objID,
+		"access_token_id":   bson.M{"$nin": bson.A{nil, ""}},
+		"access_expires_at": bson.M{"$gt": time.Now()},
+	}
+	cursor, err := r.collection.Find(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	tokens := []tokenpkg.Token{}
+	if err := cursor.All(ctx, &tokens); err != nil {
+		return nil, err
+	}
+	return tokens, nil
+}
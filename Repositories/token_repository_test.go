@@ -171,4 +171,145 @@ func (s *tokenRepositoryTestSuite) TestDeleteTokensByUserID() {
 	countAfter, err := s.collection.CountDocuments(s.ctx, bson.M{"user_id": userID})
 	assert.NoError(err)
 	assert.Equal(int64(0), countAfter)
-}
\ No newline at end of file
+}
+func (s *tokenRepositoryTestSuite) TestRotateRefreshToken() {
+	assert := assert.New(s.T())
+
+	userID := primitive.NewObjectID()
+	s.Require().NoError(s.repo.StoreToken(s.ctx, userpkg.Token{
+		UserID:       userID,
+		RefreshToken: "refresh-gen-1",
+		FamilyID:     "family-1",
+		CreatedAt:    time.Now(),
+		ExpiresAt:    time.Now().Add(time.Hour),
+	}))
+	next := userpkg.Token{
+		UserID:       userID,
+		RefreshToken: "refresh-gen-2",
+		FamilyID:     "family-1",
+		CreatedAt:    time.Now(),
+		ExpiresAt:    time.Now().Add(time.Hour),
+	}
+
+	err := s.repo.RotateRefreshToken(s.ctx, "refresh-gen-1", next)
+	assert.NoError(err)
+
+	old, err := s.repo.FindByRefreshToken(s.ctx, "refresh-gen-1")
+	assert.NoError(err)
+	assert.NotNil(old.RotatedAt)
+	current, err := s.repo.FindByRefreshToken(s.ctx, "refresh-gen-2")
+	assert.NoError(err)
+	assert.Nil(current.RotatedAt)
+
+	// The rotated token cannot be rotated a second time
+	next.RefreshToken = "refresh-gen-2b"
+	err = s.repo.RotateRefreshToken(s.ctx, "refresh-gen-1", next)
+	assert.ErrorIs(err, userpkg.ErrRefreshTokenReused)
+	_, err = s.repo.FindByRefreshToken(s.ctx, "refresh-gen-2b")
+	assert.Equal(mongo.ErrNoDocuments, err)
+}
+
+func (s *tokenRepositoryTestSuite) TestDeleteTokenFamily() {
+	assert := assert.New(s.T())
+
+	userID := primitive.NewObjectID()
+	for _, t := range []userpkg.Token{
+		{UserID: userID, RefreshToken: "a-1", FamilyID: "family-a", ExpiresAt: time.Now().Add(time.Hour)},
+		{UserID: userID, RefreshToken: "a-2", FamilyID: "family-a", ExpiresAt: time.Now().Add(time.Hour)},
+		{UserID: userID, RefreshToken: "b-1", FamilyID: "family-b", ExpiresAt: time.Now().Add(time.Hour)},
+	} {
+		s.Require().NoError(s.repo.StoreToken(s.ctx, t))
+	}
+
+	err := s.repo.DeleteTokenFamily(s.ctx, "family-a")
+	assert.NoError(err)
+
+	count, err := s.collection.CountDocuments(s.ctx, bson.M{"user_id": userID})
+	assert.NoError(err)
+	assert.Equal(int64(1), count)
+	_, err = s.repo.FindByRefreshToken(s.ctx, "b-1")
+	assert.NoError(err)
+}
+
+func (s *tokenRepositoryTestSuite) TestListSessions() {
+	assert := assert.New(s.T())
+
+	userID := primitive.NewObjectID()
+	rotatedAt := time.Now()
+	for _, t := range []userpkg.Token{
+		{UserID: userID, RefreshToken: "laptop-1", FamilyID: "laptop", RotatedAt: &rotatedAt, LastUsedAt: time.Now().Add(-time.Hour), ExpiresAt: time.Now().Add(time.Hour)},
+		{UserID: userID, RefreshToken: "laptop-2", FamilyID: "laptop", UserAgent: "Firefox", LastUsedAt: time.Now().Add(-time.Minute), ExpiresAt: time.Now().Add(time.Hour)},
+		{UserID: userID, RefreshToken: "phone-1", FamilyID: "phone", UserAgent: "Safari", LastUsedAt: time.Now(), ExpiresAt: time.Now().Add(time.Hour)},
+		{UserID: userID, RefreshToken: "old-1", FamilyID: "old", ExpiresAt: time.Now().Add(-time.Hour)},
+		{UserID: userID, RefreshToken: "legacy", ExpiresAt: time.Now().Add(time.Hour)},
+		{UserID: primitive.NewObjectID(), RefreshToken: "other-user", FamilyID: "other", ExpiresAt: time.Now().Add(time.Hour)},
+	} {
+		s.Require().NoError(s.repo.StoreToken(s.ctx, t))
+	}
+
+	sessions, err := s.repo.ListSessions(s.ctx, userID.Hex())
+	assert.NoError(err)
+	s.Require().Len(sessions, 2)
+	assert.Equal("phone-1", sessions[0].RefreshToken)
+	assert.Equal("laptop-2", sessions[1].RefreshToken)
+	assert.Equal("Firefox", sessions[1].UserAgent)
+}
+
+func (s *tokenRepositoryTestSuite) TestDeleteSession() {
+	assert := assert.New(s.T())
+
+	userID := primitive.NewObjectID()
+	s.Require().NoError(s.repo.StoreToken(s.ctx, userpkg.Token{UserID: userID, RefreshToken: "laptop-1", FamilyID: "laptop", ExpiresAt: time.Now().Add(time.Hour)}))
+	s.Require().NoError(s.repo.StoreToken(s.ctx, userpkg.Token{UserID: userID, RefreshToken: "phone-1", FamilyID: "phone", ExpiresAt: time.Now().Add(time.Hour)}))
+
+	// Another user's session id must not be revocable
+	err := s.repo.DeleteSession(s.ctx, primitive.NewObjectID().Hex(), "laptop")
+	assert.ErrorIs(err, userpkg.ErrSessionNotFound)
+
+	err = s.repo.DeleteSession(s.ctx, userID.Hex(), "laptop")
+	assert.NoError(err)
+	_, err = s.repo.FindByRefreshToken(s.ctx, "laptop-1")
+	assert.Equal(mongo.ErrNoDocuments, err)
+	_, err = s.repo.FindByRefreshToken(s.ctx, "phone-1")
+	assert.NoError(err)
+}
+
+func (s *tokenRepositoryTestSuite) TestDeleteOtherSessions() {
+	assert := assert.New(s.T())
+
+	userID := primitive.NewObjectID()
+	for _, t := range []userpkg.Token{
+		{UserID: userID, RefreshToken: "laptop-1", FamilyID: "laptop", ExpiresAt: time.Now().Add(time.Hour)},
+		{UserID: userID, RefreshToken: "phone-1", FamilyID: "phone", ExpiresAt: time.Now().Add(time.Hour)},
+		{UserID: userID, RefreshToken: "legacy", ExpiresAt: time.Now().Add(time.Hour)},
+	} {
+		s.Require().NoError(s.repo.StoreToken(s.ctx, t))
+	}
+
+	err := s.repo.DeleteOtherSessions(s.ctx, userID.Hex(), "laptop")
+	assert.NoError(err)
+
+	count, err := s.collection.CountDocuments(s.ctx, bson.M{"user_id": userID})
+	assert.NoError(err)
+	assert.Equal(int64(1), count)
+	_, err = s.repo.FindByRefreshToken(s.ctx, "laptop-1")
+	assert.NoError(err)
+}
+
+func (s *tokenRepositoryTestSuite) TestListActiveAccessTokens() {
+	assert := assert.New(s.T())
+
+	userID := primitive.NewObjectID()
+	for _, t := range []userpkg.Token{
+		{UserID: userID, RefreshToken: "r-1", AccessTokenID: "live", AccessExpiresAt: time.Now().Add(time.Minute), ExpiresAt: time.Now().Add(time.Hour)},
+		{UserID: userID, RefreshToken: "r-2", AccessTokenID: "expired", AccessExpiresAt: time.Now().Add(-time.Minute), ExpiresAt: time.Now().Add(time.Hour)},
+		{UserID: userID, RefreshToken: "legacy", ExpiresAt: time.Now().Add(time.Hour)},
+	} {
+		s.Require().NoError(s.repo.StoreToken(s.ctx, t))
+	}
+
+	tokens, err := s.repo.ListActiveAccessTokens(s.ctx, userID.Hex())
+	assert.NoError(err)
+	s.Require().Len(tokens, 1)
+	assert.Equal("live", tokens[0].AccessTokenID)
+}
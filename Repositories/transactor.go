@@ -0,0 +1,61 @@
+package repositories
+
+import (
+	"context"
+	"sync"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// MongoTransactor runs work in a MongoDB multi-document transaction. Standalone
+// servers do not support transactions; there the work runs without one, which
+// keeps local development working at the cost of atomicity.
+type MongoTransactor struct {
+	client    *mongo.Client
+	mu        sync.Mutex
+	checked   bool
+	supported bool
+}
+
+func NewMongoTransactor(client *mongo.Client) *MongoTransactor {
+	return &MongoTransactor{client: client}
+}
+
+func (t *MongoTransactor) WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if !t.transactionsSupported(ctx) {
+		return fn(ctx)
+	}
+
+	session, err := t.client.StartSession()
+	if err != nil {
+		return err
+	}
+	defer session.EndSession(ctx)
+
+	_, err = session.WithTransaction(ctx, func(sc mongo.SessionContext) (interface{}, error) {
+		return nil, fn(sc)
+	})
+	return err
+}
+
+// transactionsSupported reports whether the deployment is a replica set or sharded
+// cluster. The answer is cached once the server has been reached.
+func (t *MongoTransactor) transactionsSupported(ctx context.Context) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.checked {
+		return t.supported
+	}
+
+	var hello struct {
+		SetName string `bson:"setName"`
+		Msg     string `bson:"msg"`
+	}
+	if err := t.client.Database("admin").RunCommand(ctx, bson.D{{Key: "hello", Value: 1}}).Decode(&hello); err != nil {
+		return false
+	}
+	t.checked = true
+	t.supported = hello.SetName != "" || hello.Msg == "isdbgrid"
+	return t.supported
+}
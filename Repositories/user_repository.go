@@ -84,6 +84,15 @@ func (ur *UserRepository) GetUserByLogin(ctx context.Context, login string) (use
 	return user, err
 }
 
+func (ur *UserRepository) FindByEmail(ctx context.Context, email string) (userpkg.User, error) {
+	var user userpkg.User
+	err := ur.collection.FindOne(ctx, bson.M{"email": email}).Decode(&user)
+	if err == mongo.ErrNoDocuments {
+		return userpkg.User{}, errors.New("user not found")
+	}
+	return user, err
+}
+
 func (ur *UserRepository) FindByID(ctx context.Context, userID string) (userpkg.User, error) {
 	var user userpkg.User
 
@@ -147,6 +156,23 @@ func (ur *UserRepository) UpdateRoleAndPromoter(ctx context.Context, userID stri
 	return nil
 }
 
+func (ur *UserRepository) UpdatePasswordByEmail(ctx context.Context, email, hashedPassword string) error {
+	filter := bson.M{"email": email}
+	update := bson.M{"$set": bson.M{"password": hashedPassword}}
+	_, err := ur.collection.UpdateOne(ctx, filter, update)
+	return err
+}
+
+func (ur *UserRepository) ReplacePasswordHash(ctx context.Context, userID, oldHash, newHash string) error {
+	oid, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return err
+	}
+	filter := bson.M{"_id": oid, "password": oldHash}
+	_, err = ur.collection.UpdateOne(ctx, filter, bson.M{"$set": bson.M{"password": newHash}})
+	return err
+}
+
 // UpdateIsVerifiedByEmail sets a user's verification status by email
 func (ur *UserRepository) UpdateIsVerifiedByEmail(ctx context.Context, email string, verified bool) error {
 	filter := bson.M{"email": email}
@@ -161,6 +187,23 @@ func (ur *UserRepository) UpdateIsVerifiedByEmail(ctx context.Context, email str
 	return nil
 }
 
+// UpdateEmailByID moves a user to a new address, which counts as verified
+func (ur *UserRepository) UpdateEmailByID(ctx context.Context, userID, email string) error {
+	oid, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return err
+	}
+	update := bson.M{"$set": bson.M{"email": email, "isVerified": true, "updatedAt": time.Now()}}
+	res, err := ur.collection.UpdateOne(ctx, bson.M{"_id": oid}, update)
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount == 0 {
+		return errors.New("user not found")
+	}
+	return nil
+}
+
 func (ur *UserRepository) UpdateProfile(ctx context.Context, userID string, updates userpkg.UpdateProfileRequest) (userpkg.User, error) {
 	oid, err := primitive.ObjectIDFromHex(userID)
     if err != nil {
@@ -179,6 +222,9 @@ func (ur *UserRepository) UpdateProfile(ctx context.Context, userID string, upda
     }
     if updates.ProfilePicture != "" {
         updateDoc["$set"].(bson.M)["profilePicture"] = updates.ProfilePicture
+    }
+    if updates.Language != "" {
+        updateDoc["$set"].(bson.M)["language"] = updates.Language
     }
 	// Only update contactInfo if it is not empty
     if !reflect.DeepEqual(updates.ContactInfo, userpkg.ContactInfo{}) {
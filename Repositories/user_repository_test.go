@@ -18,6 +18,8 @@ import (
 	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
+const testUserCollection = "test_users"
+
 type userRepositoryTestSuite struct {
 	suite.Suite
 	db         *mongo.Database
@@ -193,6 +195,44 @@ func (s *userRepositoryTestSuite) TestUpdateIsVerifiedByEmail_NotFound() {
 	s.Contains(err.Error(), "user not found")
 }
 
+func (s *userRepositoryTestSuite) TestUpdateEmailByID() {
+	usr, err := s.repo.CreateUser(s.ctx, userpkg.User{
+		Username: "moving",
+		Password: "pass",
+		Email:    "old@example.com",
+		Fullname: "Moving User",
+	})
+	s.Require().NoError(err)
+
+	s.NoError(s.repo.UpdateEmailByID(s.ctx, usr.ID.Hex(), "new@example.com"))
+
+	updated, err := s.repo.FindByID(s.ctx, usr.ID.Hex())
+	s.Require().NoError(err)
+	s.Equal("new@example.com", updated.Email)
+	s.True(updated.IsVerified)
+}
+
+func (s *userRepositoryTestSuite) TestReplacePasswordHash_OnlyOverTheExpectedHash() {
+	usr, err := s.repo.CreateUser(s.ctx, userpkg.User{
+		Username: "rehashed",
+		Password: "old-hash",
+		Email:    "rehashed@example.com",
+		Fullname: "Rehashed User",
+	})
+	s.Require().NoError(err)
+
+	// The password was changed since the login read "stale-hash"
+	s.NoError(s.repo.ReplacePasswordHash(s.ctx, usr.ID.Hex(), "stale-hash", "upgraded"))
+	stored, err := s.repo.FindByID(s.ctx, usr.ID.Hex())
+	s.Require().NoError(err)
+	s.Equal("old-hash", stored.Password)
+
+	s.NoError(s.repo.ReplacePasswordHash(s.ctx, usr.ID.Hex(), "old-hash", "upgraded"))
+	stored, err = s.repo.FindByID(s.ctx, usr.ID.Hex())
+	s.Require().NoError(err)
+	s.Equal("upgraded", stored.Password)
+}
+
 func (s *userRepositoryTestSuite) TestUpdateProfile_Success() {
 	// Arrange: create a user
 	user := userpkg.User{
@@ -0,0 +1,104 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	webhookpkg "github.com/Amaankaa/Blog-Starter-Project/Domain/webhook"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type DeliveryRepository struct {
+	collection *mongo.Collection
+}
+
+func NewDeliveryRepository(collection *mongo.Collection) *DeliveryRepository {
+	return &DeliveryRepository{collection: collection}
+}
+
+func (r *DeliveryRepository) CreateDelivery(ctx context.Context, delivery webhookpkg.Delivery) (webhookpkg.Delivery, error) {
+	delivery.ID = primitive.NewObjectID()
+	if _, err := r.collection.InsertOne(ctx, delivery); err != nil {
+		return webhookpkg.Delivery{}, err
+	}
+	return delivery, nil
+}
+
+func (r *DeliveryRepository) GetDelivery(ctx context.Context, id string) (webhookpkg.Delivery, error) {
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return webhookpkg.Delivery{}, err
+	}
+	var delivery webhookpkg.Delivery
+	err = r.collection.FindOne(ctx, bson.M{"_id": oid}).Decode(&delivery)
+	if err == mongo.ErrNoDocuments {
+		return webhookpkg.Delivery{}, errors.New("delivery not found")
+	}
+	return delivery, err
+}
+
+// ListDeliveries returns the most recent deliveries of a webhook, newest first
+func (r *DeliveryRepository) ListDeliveries(ctx context.Context, webhookID string, limit int64) ([]webhookpkg.Delivery, error) {
+	oid, err := primitive.ObjectIDFromHex(webhookID)
+	if err != nil {
+		return nil, err
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}}).SetLimit(limit)
+	cursor, err := r.collection.Find(ctx, bson.M{"webhook_id": oid}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	deliveries := []webhookpkg.Delivery{}
+	if err := cursor.All(ctx, &deliveries); err != nil {
+		return nil, err
+	}
+	return deliveries, nil
+}
+
+// ClaimDue leases the oldest due delivery by pushing its next attempt into the future and returns it.
+// If the worker dies mid-send the lease simply runs out and the delivery becomes due again.
+func (r *DeliveryRepository) ClaimDue(ctx context.Context, now time.Time, lease time.Duration) (webhookpkg.Delivery, bool, error) {
+	filter := bson.M{
+		"status":          webhookpkg.DeliveryPending,
+		"next_attempt_at": bson.M{"$lte": now},
+	}
+	update := bson.M{"$set": bson.M{"next_attempt_at": now.Add(lease)}}
+	opts := options.FindOneAndUpdate().
+		SetSort(bson.D{{Key: "next_attempt_at", Value: 1}}).
+		SetReturnDocument(options.After)
+
+	var delivery webhookpkg.Delivery
+	err := r.collection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&delivery)
+	if err == mongo.ErrNoDocuments {
+		return webhookpkg.Delivery{}, false, nil
+	}
+	if err != nil {
+		return webhookpkg.Delivery{}, false, err
+	}
+	return delivery, true, nil
+}
+
+func (r *DeliveryRepository) UpdateDelivery(ctx context.Context, delivery webhookpkg.Delivery) error {
+	update := bson.M{"$set": bson.M{
+		"status":          delivery.Status,
+		"attempts":        delivery.Attempts,
+		"last_error":      delivery.LastError,
+		"response_code":   delivery.ResponseCode,
+		"next_attempt_at": delivery.NextAttemptAt,
+		"updated_at":      delivery.UpdatedAt,
+	}}
+	res, err := r.collection.UpdateOne(ctx, bson.M{"_id": delivery.ID}, update)
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount == 0 {
+		return errors.New("delivery not found")
+	}
+	return nil
+}
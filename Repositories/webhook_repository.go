@@ -0,0 +1,80 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+
+	webhookpkg "github.com/Amaankaa/Blog-Starter-Project/Domain/webhook"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type WebhookRepository struct {
+	collection *mongo.Collection
+}
+
+func NewWebhookRepository(collection *mongo.Collection) *WebhookRepository {
+	return &WebhookRepository{collection: collection}
+}
+
+func (r *WebhookRepository) CreateWebhook(ctx context.Context, hook webhookpkg.Webhook) (webhookpkg.Webhook, error) {
+	hook.ID = primitive.NewObjectID()
+	if _, err := r.collection.InsertOne(ctx, hook); err != nil {
+		return webhookpkg.Webhook{}, err
+	}
+	return hook, nil
+}
+
+func (r *WebhookRepository) GetWebhook(ctx context.Context, id string) (webhookpkg.Webhook, error) {
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return webhookpkg.Webhook{}, err
+	}
+	var hook webhookpkg.Webhook
+	err = r.collection.FindOne(ctx, bson.M{"_id": oid}).Decode(&hook)
+	if err == mongo.ErrNoDocuments {
+		return webhookpkg.Webhook{}, errors.New("webhook not found")
+	}
+	return hook, err
+}
+
+func (r *WebhookRepository) ListWebhooks(ctx context.Context) ([]webhookpkg.Webhook, error) {
+	return r.find(ctx, bson.M{})
+}
+
+func (r *WebhookRepository) DeleteWebhook(ctx context.Context, id string) error {
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+	res, err := r.collection.DeleteOne(ctx, bson.M{"_id": oid})
+	if err != nil {
+		return err
+	}
+	if res.DeletedCount == 0 {
+		return errors.New("webhook not found")
+	}
+	return nil
+}
+
+// FindActiveByEvent returns active webhooks subscribed to eventType
+func (r *WebhookRepository) FindActiveByEvent(ctx context.Context, eventType string) ([]webhookpkg.Webhook, error) {
+	return r.find(ctx, bson.M{"active": true, "events": eventType})
+}
+
+func (r *WebhookRepository) find(ctx context.Context, filter bson.M) ([]webhookpkg.Webhook, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}})
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	hooks := []webhookpkg.Webhook{}
+	if err := cursor.All(ctx, &hooks); err != nil {
+		return nil, err
+	}
+	return hooks, nil
+}
@@ -0,0 +1,139 @@
+package repositories_test
+
+import (
+	"context"
+	"log"
+	"os"
+	"testing"
+	"time"
+
+	webhookpkg "github.com/Amaankaa/Blog-Starter-Project/Domain/webhook"
+	repositories "github.com/Amaankaa/Blog-Starter-Project/Repositories"
+	"github.com/joho/godotenv"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	testWebhookCollection  = "test_webhooks"
+	testDeliveryCollection = "test_webhook_deliveries"
+)
+
+type webhookRepositoryTestSuite struct {
+	suite.Suite
+	client       *mongo.Client
+	ctx          context.Context
+	cancel       context.CancelFunc
+	hooks        *mongo.Collection
+	deliveries   *mongo.Collection
+	webhookRepo  *repositories.WebhookRepository
+	deliveryRepo *repositories.DeliveryRepository
+}
+
+func TestWebhookRepositoryTestSuite(t *testing.T) {
+	suite.Run(t, new(webhookRepositoryTestSuite))
+}
+
+func (s *webhookRepositoryTestSuite) SetupSuite() {
+	err := godotenv.Load("../.env")
+	if err != nil {
+		log.Fatal("Error loading .env file")
+	}
+
+	client, err := mongo.Connect(context.Background(), options.Client().ApplyURI(os.Getenv("MONGODB_URI")))
+	s.Require().NoError(err)
+
+	s.client = client
+	db := client.Database("test_blog_db")
+	s.hooks = db.Collection(testWebhookCollection)
+	s.deliveries = db.Collection(testDeliveryCollection)
+	s.webhookRepo = repositories.NewWebhookRepository(s.hooks)
+	s.deliveryRepo = repositories.NewDeliveryRepository(s.deliveries)
+	s.ctx, s.cancel = context.WithTimeout(context.Background(), 10*time.Second)
+}
+
+func (s *webhookRepositoryTestSuite) TearDownSuite() {
+	s.hooks.Drop(s.ctx)
+	s.deliveries.Drop(s.ctx)
+	s.cancel()
+	s.client.Disconnect(s.ctx)
+}
+
+func (s *webhookRepositoryTestSuite) SetupTest() {
+	_, err := s.hooks.DeleteMany(s.ctx, bson.M{})
+	s.Require().NoError(err)
+	_, err = s.deliveries.DeleteMany(s.ctx, bson.M{})
+	s.Require().NoError(err)
+}
+
+func (s *webhookRepositoryTestSuite) TestFindActiveByEvent() {
+	assert := assert.New(s.T())
+	created, err := s.webhookRepo.CreateWebhook(s.ctx, webhookpkg.Webhook{URL: "https://a.example", Events: []string{webhookpkg.EventBlogCreated}, Active: true})
+	assert.NoError(err)
+	assert.False(created.ID.IsZero())
+	_, err = s.webhookRepo.CreateWebhook(s.ctx, webhookpkg.Webhook{URL: "https://b.example", Events: []string{webhookpkg.EventBlogCreated}, Active: false})
+	assert.NoError(err)
+	_, err = s.webhookRepo.CreateWebhook(s.ctx, webhookpkg.Webhook{URL: "https://c.example", Events: []string{webhookpkg.EventLikeToggled}, Active: true})
+	assert.NoError(err)
+
+	hooks, err := s.webhookRepo.FindActiveByEvent(s.ctx, webhookpkg.EventBlogCreated)
+	assert.NoError(err)
+	assert.Len(hooks, 1)
+	assert.Equal(created.ID, hooks[0].ID)
+}
+
+func (s *webhookRepositoryTestSuite) TestDeleteWebhook_NotFound() {
+	err := s.webhookRepo.DeleteWebhook(s.ctx, primitive.NewObjectID().Hex())
+	s.EqualError(err, "webhook not found")
+}
+
+func (s *webhookRepositoryTestSuite) TestClaimDue_LeasesOldestDueDelivery() {
+	assert := assert.New(s.T())
+	now := time.Now().Truncate(time.Millisecond)
+	hookID := primitive.NewObjectID()
+	older, _ := s.deliveryRepo.CreateDelivery(s.ctx, webhookpkg.Delivery{WebhookID: hookID, Status: webhookpkg.DeliveryPending, NextAttemptAt: now.Add(-time.Minute)})
+	s.deliveryRepo.CreateDelivery(s.ctx, webhookpkg.Delivery{WebhookID: hookID, Status: webhookpkg.DeliveryPending, NextAttemptAt: now.Add(-time.Second)})
+	s.deliveryRepo.CreateDelivery(s.ctx, webhookpkg.Delivery{WebhookID: hookID, Status: webhookpkg.DeliveryPending, NextAttemptAt: now.Add(time.Hour)})
+	s.deliveryRepo.CreateDelivery(s.ctx, webhookpkg.Delivery{WebhookID: hookID, Status: webhookpkg.DeliveryFailed, NextAttemptAt: now.Add(-time.Hour)})
+
+	claimed, ok, err := s.deliveryRepo.ClaimDue(s.ctx, now, time.Minute)
+	assert.NoError(err)
+	assert.True(ok)
+	assert.Equal(older.ID, claimed.ID)
+
+	_, ok, err = s.deliveryRepo.ClaimDue(s.ctx, now, time.Minute)
+	assert.NoError(err)
+	assert.True(ok)
+
+	// the leased deliveries are not handed out again until the lease expires
+	_, ok, err = s.deliveryRepo.ClaimDue(s.ctx, now, time.Minute)
+	assert.NoError(err)
+	assert.False(ok)
+
+	again, ok, err := s.deliveryRepo.ClaimDue(s.ctx, now.Add(2*time.Minute), time.Minute)
+	assert.NoError(err)
+	assert.True(ok)
+	assert.Equal(older.ID, again.ID)
+}
+
+func (s *webhookRepositoryTestSuite) TestUpdateAndListDeliveries() {
+	assert := assert.New(s.T())
+	hookID := primitive.NewObjectID()
+	delivery, err := s.deliveryRepo.CreateDelivery(s.ctx, webhookpkg.Delivery{WebhookID: hookID, Status: webhookpkg.DeliveryPending, CreatedAt: time.Now()})
+	assert.NoError(err)
+
+	delivery.Status = webhookpkg.DeliverySucceeded
+	delivery.Attempts = 1
+	delivery.ResponseCode = 200
+	assert.NoError(s.deliveryRepo.UpdateDelivery(s.ctx, delivery))
+
+	list, err := s.deliveryRepo.ListDeliveries(s.ctx, hookID.Hex(), 10)
+	assert.NoError(err)
+	assert.Len(list, 1)
+	assert.Equal(webhookpkg.DeliverySucceeded, list[0].Status)
+	assert.Equal(200, list[0].ResponseCode)
+}
@@ -3,10 +3,13 @@ package usecases_test
 import (
 	"context"
 	"errors"
+	"fmt"
 	"testing"
 	"time"
 
 	blogpkg "github.com/Amaankaa/Blog-Starter-Project/Domain/blog"
+	eventpkg "github.com/Amaankaa/Blog-Starter-Project/Domain/event"
+	"github.com/Amaankaa/Blog-Starter-Project/Domain/event/eventtest"
 	usecases "github.com/Amaankaa/Blog-Starter-Project/Usecases"
 	"github.com/Amaankaa/Blog-Starter-Project/mocks"
 	"go.mongodb.org/mongo-driver/bson/primitive"
@@ -19,12 +22,16 @@ import (
 type BlogUsecaseSuite struct {
 	suite.Suite
 	blogRepo *mocks.IBlogRepository
+	pinRepo  *mocks.IPinRepository
+	events   *eventtest.Recorder
 	blogUC   *usecases.BlogUsecase
 }
 
 func (s *BlogUsecaseSuite) SetupTest() {
 	s.blogRepo = mocks.NewIBlogRepository(s.T())
-	s.blogUC = usecases.NewBlogUsecase(s.blogRepo)
+	s.pinRepo = mocks.NewIPinRepository(s.T())
+	s.events = eventtest.NewRecorder()
+	s.blogUC = usecases.NewBlogUsecase(s.blogRepo, s.pinRepo, s.events)
 }
 
 func TestBlogUsecaseSuite(t *testing.T) {
@@ -59,6 +66,8 @@ func (s *BlogUsecaseSuite) TestCreateBlog_Success() {
 	assert.NotZero(result.CreatedAt)
 	assert.NotZero(result.UpdatedAt)
 	s.blogRepo.AssertExpectations(s.T())
+	published := eventtest.RequireOne[eventpkg.BlogPublished](s.T(), s.events)
+	assert.Equal(*expectedBlog, published.Blog)
 }
 
 func (s *BlogUsecaseSuite) TestCreateBlog_Error() {
@@ -139,6 +148,107 @@ func (s *BlogUsecaseSuite) TestGetAllBlogs_Error() {
 	s.blogRepo.AssertExpectations(s.T())
 }
 
+func (s *BlogUsecaseSuite) TestGetAllBlogs_MergesPinnedOnFirstPage() {
+	assert := assert.New(s.T())
+	pins := []blogpkg.Pin{{BlogID: "p1", Scope: blogpkg.PinScopeGlobal}, {BlogID: "p2", Scope: blogpkg.PinScopeGlobal}}
+	s.pinRepo.On("ListActivePins", mock.Anything, blogpkg.PinScopeGlobal, "", mock.Anything).Return(pins, nil).Once()
+	s.blogRepo.On("GetBlogsByIDs", mock.Anything, []string{"p1", "p2"}).Return([]blogpkg.Blog{{ID: "p1"}, {ID: "p2"}}, nil).Once()
+	s.blogRepo.On("ListBlogsExcluding", mock.Anything, []string{"p1", "p2"}, int64(0), int64(3)).
+		Return([]blogpkg.Blog{{ID: "a"}, {ID: "b"}, {ID: "c"}}, int64(10), nil).Once()
+
+	resp, err := s.blogUC.GetAllBlogs(context.Background(), blogpkg.PaginationRequest{Page: 1, Limit: 5, IncludePinned: true})
+	assert.NoError(err)
+	assert.Len(resp.Data, 5)
+	assert.Equal("p1", resp.Data[0].ID)
+	assert.True(resp.Data[1].Pinned)
+	assert.False(resp.Data[2].Pinned)
+	assert.Equal(2, resp.Pinned)
+	assert.Equal(int64(12), resp.Total)
+	assert.Equal(3, resp.TotalPages)
+}
+
+func (s *BlogUsecaseSuite) TestGetAllBlogs_PinnedShiftsLaterPages() {
+	assert := assert.New(s.T())
+	pins := []blogpkg.Pin{{BlogID: "p1", Scope: blogpkg.PinScopeGlobal}}
+	s.pinRepo.On("ListActivePins", mock.Anything, blogpkg.PinScopeGlobal, "", mock.Anything).Return(pins, nil).Once()
+	s.blogRepo.On("GetBlogsByIDs", mock.Anything, []string{"p1"}).Return([]blogpkg.Blog{{ID: "p1"}}, nil).Once()
+	// page 2 starts right after the 4 regular posts shown on page 1
+	s.blogRepo.On("ListBlogsExcluding", mock.Anything, []string{"p1"}, int64(4), int64(5)).
+		Return([]blogpkg.Blog{{ID: "e"}}, int64(5), nil).Once()
+
+	resp, err := s.blogUC.GetAllBlogs(context.Background(), blogpkg.PaginationRequest{Page: 2, Limit: 5, IncludePinned: true})
+	assert.NoError(err)
+	assert.Len(resp.Data, 1)
+	assert.Equal(0, resp.Pinned)
+	assert.Equal(int64(6), resp.Total)
+	assert.Equal(2, resp.TotalPages)
+}
+
+func (s *BlogUsecaseSuite) TestPinBlog_ProfileRequiresOwnership() {
+	assert := assert.New(s.T())
+	ctx := context.WithValue(context.Background(), "user_id", "user-2")
+	s.blogRepo.On("FindBlogByID", "blog-1").Return(&blogpkg.Blog{ID: "blog-1", AuthorID: "user-1"}, nil).Once()
+
+	_, err := s.blogUC.PinBlog(ctx, "blog-1", blogpkg.PinScopeProfile, blogpkg.PinRequest{})
+	assert.EqualError(err, "you can only pin your own posts")
+}
+
+func (s *BlogUsecaseSuite) TestPinBlog_Global() {
+	assert := assert.New(s.T())
+	ctx := context.WithValue(context.Background(), "user_id", "admin-1")
+	expires := time.Now().Add(24 * time.Hour)
+	s.blogRepo.On("FindBlogByID", "blog-1").Return(&blogpkg.Blog{ID: "blog-1", AuthorID: "user-1"}, nil).Once()
+	s.pinRepo.On("ListActivePins", ctx, blogpkg.PinScopeGlobal, "", mock.Anything).Return([]blogpkg.Pin{}, nil).Once()
+	s.pinRepo.On("UpsertPin", ctx, mock.MatchedBy(func(p blogpkg.Pin) bool {
+		return p.BlogID == "blog-1" && p.OwnerID == "" && p.PinnedBy == "admin-1" && p.Position == 2 && p.ExpiresAt.Equal(expires)
+	})).Return(blogpkg.Pin{BlogID: "blog-1"}, nil).Once()
+
+	pin, err := s.blogUC.PinBlog(ctx, "blog-1", blogpkg.PinScopeGlobal, blogpkg.PinRequest{Position: 2, ExpiresAt: &expires})
+	assert.NoError(err)
+	assert.Equal("blog-1", pin.BlogID)
+}
+
+func (s *BlogUsecaseSuite) TestPinBlog_LimitReached() {
+	assert := assert.New(s.T())
+	ctx := context.WithValue(context.Background(), "user_id", "user-1")
+	active := make([]blogpkg.Pin, 10)
+	for i := range active {
+		active[i] = blogpkg.Pin{BlogID: fmt.Sprintf("other-%d", i)}
+	}
+	s.blogRepo.On("FindBlogByID", "blog-1").Return(&blogpkg.Blog{ID: "blog-1", AuthorID: "user-1"}, nil).Once()
+	s.pinRepo.On("ListActivePins", ctx, blogpkg.PinScopeProfile, "user-1", mock.Anything).Return(active, nil).Once()
+
+	_, err := s.blogUC.PinBlog(ctx, "blog-1", blogpkg.PinScopeProfile, blogpkg.PinRequest{})
+	assert.EqualError(err, "cannot pin more than 10 posts")
+}
+
+func (s *BlogUsecaseSuite) TestPinBlog_PastExpiry() {
+	assert := assert.New(s.T())
+	ctx := context.WithValue(context.Background(), "user_id", "user-1")
+	past := time.Now().Add(-time.Minute)
+	_, err := s.blogUC.PinBlog(ctx, "blog-1", blogpkg.PinScopeProfile, blogpkg.PinRequest{ExpiresAt: &past})
+	assert.EqualError(err, "expiry must be in the future")
+}
+
+func (s *BlogUsecaseSuite) TestGetPinnedBlogs_SkipsDeletedPosts() {
+	assert := assert.New(s.T())
+	pins := []blogpkg.Pin{{BlogID: "gone"}, {BlogID: "b1"}}
+	s.pinRepo.On("ListActivePins", mock.Anything, blogpkg.PinScopeProfile, "user-1", mock.Anything).Return(pins, nil).Once()
+	s.blogRepo.On("GetBlogsByIDs", mock.Anything, []string{"gone", "b1"}).Return([]blogpkg.Blog{{ID: "b1"}}, nil).Once()
+
+	blogs, err := s.blogUC.GetPinnedBlogs(context.Background(), "user-1")
+	assert.NoError(err)
+	assert.Len(blogs, 1)
+	assert.True(blogs[0].Pinned)
+}
+
+func (s *BlogUsecaseSuite) TestUnpinBlog_Profile() {
+	assert := assert.New(s.T())
+	ctx := context.WithValue(context.Background(), "user_id", "user-1")
+	s.pinRepo.On("DeletePin", ctx, blogpkg.PinScopeProfile, "user-1", "blog-1").Return(nil).Once()
+	assert.NoError(s.blogUC.UnpinBlog(ctx, "blog-1", blogpkg.PinScopeProfile))
+}
+
 func (s *BlogUsecaseSuite) TestGetBlogByID_Success() {
 	assert := assert.New(s.T())
 	id := "blog-1"
@@ -233,12 +343,74 @@ func (s *BlogUsecaseSuite) TestUpdateBlog_Unauthorized() {
 	s.blogRepo.AssertExpectations(s.T())
 }
 
+func (s *BlogUsecaseSuite) TestUpdateBlog_IgnoresServerOwnedFields() {
+	assert := assert.New(s.T())
+	ctx := context.WithValue(context.Background(), "user_id", "author-1")
+	id := "blog-1"
+	oldBlog := &blogpkg.Blog{ID: id, Title: "Old", Content: "Old", AuthorID: "author-1", Likes: []string{"u1"}, Views: 9}
+	updated := &blogpkg.Blog{Title: "New", Content: "New", Likes: []string{}, Views: 1000}
+	s.blogRepo.On("FindBlogByID", id).Return(oldBlog, nil).Once()
+	s.blogRepo.On("UpdateBlog", id, mock.MatchedBy(func(b *blogpkg.Blog) bool {
+		return b.Views == 9 && len(b.Likes) == 1 && b.AuthorID == "author-1"
+	})).Return(oldBlog, nil).Once()
+	_, err := s.blogUC.UpdateBlog(ctx, id, updated)
+	assert.NoError(err)
+}
+
+func (s *BlogUsecaseSuite) TestPatchBlog_OnlyChangesProvidedFields() {
+	assert := assert.New(s.T())
+	ctx := context.WithValue(context.Background(), "user_id", "author-1")
+	id := "blog-1"
+	oldBlog := &blogpkg.Blog{ID: id, Title: "Old Title", Content: "Old Content", AuthorID: "author-1", Tags: []string{"t1"}, Likes: []string{"u1"}, Views: 3}
+	title := "New Title"
+	s.blogRepo.On("FindBlogByID", id).Return(oldBlog, nil).Once()
+	s.blogRepo.On("UpdateBlog", id, mock.MatchedBy(func(b *blogpkg.Blog) bool {
+		return b.Title == "New Title" && b.Content == "Old Content" && len(b.Tags) == 1 && b.Views == 3 && !b.UpdatedAt.IsZero()
+	})).Return(&blogpkg.Blog{ID: id, Title: title, Content: "Old Content"}, nil).Once()
+
+	result, err := s.blogUC.PatchBlog(ctx, id, blogpkg.BlogPatch{Title: &title})
+	assert.NoError(err)
+	assert.Equal(title, result.Title)
+	assert.Equal("Old Title", oldBlog.Title) // the fetched blog is not mutated
+}
+
+func (s *BlogUsecaseSuite) TestPatchBlog_NullTitleRejected() {
+	assert := assert.New(s.T())
+	ctx := context.WithValue(context.Background(), "user_id", "author-1")
+	id := "blog-1"
+	oldBlog := &blogpkg.Blog{ID: id, Title: "Old Title", Content: "Old Content", AuthorID: "author-1"}
+	empty := ""
+	s.blogRepo.On("FindBlogByID", id).Return(oldBlog, nil).Once()
+
+	_, err := s.blogUC.PatchBlog(ctx, id, blogpkg.BlogPatch{Title: &empty})
+	assert.EqualError(err, "blog title is required")
+}
+
+func (s *BlogUsecaseSuite) TestPatchBlog_Unauthorized() {
+	assert := assert.New(s.T())
+	ctx := context.WithValue(context.Background(), "user_id", "someone-else")
+	id := "blog-1"
+	tags := []string{}
+	s.blogRepo.On("FindBlogByID", id).Return(&blogpkg.Blog{ID: id, AuthorID: "author-1"}, nil).Once()
+
+	_, err := s.blogUC.PatchBlog(ctx, id, blogpkg.BlogPatch{Tags: &tags})
+	assert.EqualError(err, "unauthorized to update this blog")
+}
+
+func (s *BlogUsecaseSuite) TestPatchBlog_EmptyPatch() {
+	assert := assert.New(s.T())
+	ctx := context.WithValue(context.Background(), "user_id", "author-1")
+	_, err := s.blogUC.PatchBlog(ctx, "blog-1", blogpkg.BlogPatch{})
+	assert.EqualError(err, "patch contains no changes")
+}
+
 func (s *BlogUsecaseSuite) TestDeleteBlog_Success() {
 	assert := assert.New(s.T())
 	ctx := context.WithValue(context.Background(), "user_id", "author-1")
 	id := "blog-1"
 	blog := &blogpkg.Blog{ID: id, Title: "T", Content: "C", AuthorID: "author-1", Tags: []string{"t1"}, CreatedAt: time.Now(), UpdatedAt: time.Now()}
 	s.blogRepo.On("FindBlogByID", id).Return(blog, nil).Once()
+	s.pinRepo.On("DeletePinsOfBlog", ctx, id).Return(nil).Once()
 	s.blogRepo.On("DeleteBlog", id).Return(nil).Once()
 	err := s.blogUC.DeleteBlog(ctx, id)
 	assert.NoError(err)
@@ -412,6 +584,18 @@ func (s *BlogUsecaseSuite) TestToggleLike_RemoveLike() {
 	err := s.blogUC.ToggleLike(ctx, blogID, userID)
 	assert.NoError(err)
 	s.blogRepo.AssertExpectations(s.T())
+	toggled := eventtest.RequireOne[eventpkg.LikeToggled](s.T(), s.events)
+	assert.Equal(eventpkg.LikeToggled{BlogID: blogID, UserID: userID, Liked: false}, toggled)
+}
+
+func (s *BlogUsecaseSuite) TestToggleLike_FailedWriteIsNotPublished() {
+	ctx := context.Background()
+	blog := &blogpkg.Blog{ID: "blog-1", Likes: []string{}}
+	s.blogRepo.On("FindBlogByID", "blog-1").Return(blog, nil).Once()
+	s.blogRepo.On("AddLike", ctx, "blog-1", "user-1").Return(errors.New("write failed")).Once()
+
+	s.Error(s.blogUC.ToggleLike(ctx, "blog-1", "user-1"))
+	eventtest.AssertNone[eventpkg.LikeToggled](s.T(), s.events)
 }
 
 func (s *BlogUsecaseSuite) TestToggleLike_BlogNotFound() {
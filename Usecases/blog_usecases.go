@@ -4,18 +4,27 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math"
 	"time"
 
 	blogpkg "github.com/Amaankaa/Blog-Starter-Project/Domain/blog"
+	eventpkg "github.com/Amaankaa/Blog-Starter-Project/Domain/event"
 )
 
+// maxPinsPerScope caps how many posts can be featured globally or pinned on one profile
+const maxPinsPerScope = 10
+
 type BlogUsecase struct {
 	blogRepo blogpkg.IBlogRepository
+	pinRepo  blogpkg.IPinRepository
+	events   eventpkg.IPublisher
 }
 
-func NewBlogUsecase(blogRepo blogpkg.IBlogRepository) *BlogUsecase {
+func NewBlogUsecase(blogRepo blogpkg.IBlogRepository, pinRepo blogpkg.IPinRepository, events eventpkg.IPublisher) *BlogUsecase {
 	return &BlogUsecase{
 		blogRepo: blogRepo,
+		pinRepo:  pinRepo,
+		events:   events,
 	}
 }
 func (bu *BlogUsecase) CreateBlog(ctx context.Context, blog *blogpkg.Blog) (*blogpkg.Blog, error) {
@@ -42,6 +51,10 @@ func (bu *BlogUsecase) CreateBlog(ctx context.Context, blog *blogpkg.Blog) (*blo
 		return nil, errors.New("user ID is not a string")
 	}
 
+	// Server-owned fields are never taken from the client
+	blog.ID = ""
+	blog.Likes = []string{}
+	blog.Views = 0
 	blog.AuthorID = authorIDStr
 	blog.CreatedAt = time.Now()
 	blog.UpdatedAt = time.Now()
@@ -50,6 +63,7 @@ func (bu *BlogUsecase) CreateBlog(ctx context.Context, blog *blogpkg.Blog) (*blo
 	if err != nil {
 		return nil, err
 	}
+	bu.events.Publish(ctx, eventpkg.BlogPublished{Blog: *createdBlog})
 	return createdBlog, nil
 }
 
@@ -92,6 +106,10 @@ func (bu *BlogUsecase) GetAllBlogs(ctx context.Context, pagination blogpkg.Pagin
 	// Set default values if not provided
 	pagination = normalizePagination(pagination)
 
+	if pagination.IncludePinned {
+		return bu.getAllBlogsWithPinned(ctx, pagination)
+	}
+
 	result, err := bu.blogRepo.GetAllBlogs(ctx, pagination)
 	if err != nil {
 		return blogpkg.PaginationResponse{}, err
@@ -99,6 +117,53 @@ func (bu *BlogUsecase) GetAllBlogs(ctx context.Context, pagination blogpkg.Pagin
 	return result, nil
 }
 
+// getAllBlogsWithPinned puts featured posts at the top of page 1 and leaves them out of the
+// regular stream, so every post still appears exactly once and totals are unchanged.
+func (bu *BlogUsecase) getAllBlogsWithPinned(ctx context.Context, pagination blogpkg.PaginationRequest) (blogpkg.PaginationResponse, error) {
+	pinned, err := bu.activePinnedBlogs(ctx, blogpkg.PinScopeGlobal, "")
+	if err != nil {
+		return blogpkg.PaginationResponse{}, err
+	}
+	if len(pinned) > pagination.Limit {
+		pinned = pinned[:pagination.Limit]
+	}
+
+	pinnedIDs := make([]string, len(pinned))
+	for i := range pinned {
+		pinnedIDs[i] = pinned[i].ID
+	}
+
+	// Page 1 holds the pinned posts plus the newest others; later pages shift back by len(pinned)
+	skip := int64((pagination.Page-1)*pagination.Limit - len(pinned))
+	limit := int64(pagination.Limit)
+	if pagination.Page == 1 {
+		skip = 0
+		limit = int64(pagination.Limit - len(pinned))
+	}
+
+	regular, rest, err := bu.blogRepo.ListBlogsExcluding(ctx, pinnedIDs, skip, limit)
+	if err != nil {
+		return blogpkg.PaginationResponse{}, err
+	}
+
+	data := regular
+	pinnedCount := 0
+	if pagination.Page == 1 {
+		data = append(pinned, regular...)
+		pinnedCount = len(pinned)
+	}
+	total := rest + int64(len(pinned))
+
+	return blogpkg.PaginationResponse{
+		Data:       data,
+		Total:      total,
+		Page:       pagination.Page,
+		Limit:      pagination.Limit,
+		TotalPages: int(math.Ceil(float64(total) / float64(pagination.Limit))),
+		Pinned:     pinnedCount,
+	}, nil
+}
+
 // UpdateBlog updates an existing blog
 func (bu *BlogUsecase) UpdateBlog(ctx context.Context, id string, blog *blogpkg.Blog) (*blogpkg.Blog, error) {
 	if id == "" {
@@ -143,12 +208,58 @@ func (bu *BlogUsecase) UpdateBlog(ctx context.Context, id string, blog *blogpkg.
 	blog.AuthorID = authorIDStr
 	blog.ID = existingBlog.ID
 	blog.CreatedAt = existingBlog.CreatedAt
+	blog.Likes = existingBlog.Likes
+	blog.Views = existingBlog.Views
 	blog.UpdatedAt = time.Now()
 
 	updatedBlog, err := bu.blogRepo.UpdateBlog(id, blog)
 	if err != nil {
 		return nil, err
 	}
+	bu.events.Publish(ctx, eventpkg.BlogUpdated{Blog: *updatedBlog})
+	return updatedBlog, nil
+}
+
+// PatchBlog applies a merge patch to a blog, changing only the fields it contains
+func (bu *BlogUsecase) PatchBlog(ctx context.Context, id string, patch blogpkg.BlogPatch) (*blogpkg.Blog, error) {
+	if id == "" {
+		return nil, errors.New("blog ID is required")
+	}
+	if patch.IsEmpty() {
+		return nil, errors.New("patch contains no changes")
+	}
+
+	userID, ok := ctx.Value("user_id").(string)
+	if !ok || userID == "" {
+		return nil, errors.New("invalid user ID in context")
+	}
+
+	existingBlog, err := bu.blogRepo.FindBlogByID(id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch existing blog: %w", err)
+	}
+	if existingBlog == nil {
+		return nil, errors.New("blog not found")
+	}
+	if existingBlog.AuthorID != userID {
+		return nil, errors.New("unauthorized to update this blog")
+	}
+
+	patched := *existingBlog
+	patch.Apply(&patched)
+	if patched.Title == "" {
+		return nil, errors.New("blog title is required")
+	}
+	if patched.Content == "" {
+		return nil, errors.New("blog content is required")
+	}
+	patched.UpdatedAt = time.Now()
+
+	updatedBlog, err := bu.blogRepo.UpdateBlog(id, &patched)
+	if err != nil {
+		return nil, err
+	}
+	bu.events.Publish(ctx, eventpkg.BlogUpdated{Blog: *updatedBlog})
 	return updatedBlog, nil
 }
 
@@ -174,10 +285,15 @@ func (bu *BlogUsecase) DeleteBlog(ctx context.Context, id string) error {
 		return errors.New("unauthorized to delete this blog")
 	}
 
+	// Pins go first: left behind, they would hold on to pin slots that nothing can free
+	if err := bu.pinRepo.DeletePinsOfBlog(ctx, id); err != nil {
+		return err
+	}
 	err = bu.blogRepo.DeleteBlog(id)
 	if err != nil {
 		return err
 	}
+	bu.events.Publish(ctx, eventpkg.BlogDeleted{BlogID: id, AuthorID: authorID})
 	return nil
 }
 
@@ -245,13 +361,24 @@ func (bu *BlogUsecase) ToggleLike(ctx context.Context, blogID string, userID str
 	}
 
 	// Check if already liked
+	liked := true
 	for _, id := range blog.Likes {
 		if id == userID {
-			return bu.blogRepo.RemoveLike(ctx, blogID, userID)
+			liked = false
+			break
 		}
 	}
 
-	return bu.blogRepo.AddLike(ctx, blogID, userID)
+	if liked {
+		err = bu.blogRepo.AddLike(ctx, blogID, userID)
+	} else {
+		err = bu.blogRepo.RemoveLike(ctx, blogID, userID)
+	}
+	if err != nil {
+		return err
+	}
+	bu.events.Publish(ctx, eventpkg.LikeToggled{BlogID: blogID, UserID: userID, Liked: liked})
+	return nil
 }
 
 func (bu *BlogUsecase) AddComment(ctx context.Context, comment *blogpkg.Comment, blogID string) (*blogpkg.Comment, error) {
@@ -270,5 +397,127 @@ func (bu *BlogUsecase) AddComment(ctx context.Context, comment *blogpkg.Comment,
 		CreatedAt: time.Now(),
 	}
 
-	return bu.blogRepo.AddComment(ctx, newComment)
+	created, err := bu.blogRepo.AddComment(ctx, newComment)
+	if err != nil {
+		return nil, err
+	}
+	bu.events.Publish(ctx, eventpkg.CommentAdded{Comment: *created})
+	return created, nil
+}
+
+// PinBlog features a post site-wide (global scope, admins only) or pins it on its author's profile
+func (bu *BlogUsecase) PinBlog(ctx context.Context, blogID string, scope blogpkg.PinScope, req blogpkg.PinRequest) (blogpkg.Pin, error) {
+	userID, ok := ctx.Value("user_id").(string)
+	if !ok || userID == "" {
+		return blogpkg.Pin{}, errors.New("invalid user ID in context")
+	}
+	if req.Position < 0 {
+		return blogpkg.Pin{}, errors.New("position cannot be negative")
+	}
+	if req.ExpiresAt != nil && !req.ExpiresAt.After(time.Now()) {
+		return blogpkg.Pin{}, errors.New("expiry must be in the future")
+	}
+
+	blog, err := bu.blogRepo.FindBlogByID(blogID)
+	if err != nil {
+		return blogpkg.Pin{}, fmt.Errorf("failed to fetch blog: %w", err)
+	}
+	if blog == nil {
+		return blogpkg.Pin{}, errors.New("blog not found")
+	}
+
+	ownerID, err := pinOwner(scope, userID)
+	if err != nil {
+		return blogpkg.Pin{}, err
+	}
+	if scope == blogpkg.PinScopeProfile && blog.AuthorID != userID {
+		return blogpkg.Pin{}, errors.New("you can only pin your own posts")
+	}
+
+	active, err := bu.pinRepo.ListActivePins(ctx, scope, ownerID, time.Now())
+	if err != nil {
+		return blogpkg.Pin{}, err
+	}
+	alreadyPinned := false
+	for _, p := range active {
+		if p.BlogID == blogID {
+			alreadyPinned = true
+			break
+		}
+	}
+	if !alreadyPinned && len(active) >= maxPinsPerScope {
+		return blogpkg.Pin{}, fmt.Errorf("cannot pin more than %d posts", maxPinsPerScope)
+	}
+
+	return bu.pinRepo.UpsertPin(ctx, blogpkg.Pin{
+		BlogID:    blogID,
+		Scope:     scope,
+		OwnerID:   ownerID,
+		PinnedBy:  userID,
+		Position:  req.Position,
+		ExpiresAt: req.ExpiresAt,
+		CreatedAt: time.Now(),
+	})
+}
+
+// UnpinBlog removes a global feature or the caller's profile pin
+func (bu *BlogUsecase) UnpinBlog(ctx context.Context, blogID string, scope blogpkg.PinScope) error {
+	userID, ok := ctx.Value("user_id").(string)
+	if !ok || userID == "" {
+		return errors.New("invalid user ID in context")
+	}
+	ownerID, err := pinOwner(scope, userID)
+	if err != nil {
+		return err
+	}
+	return bu.pinRepo.DeletePin(ctx, scope, ownerID, blogID)
+}
+
+// GetFeaturedBlogs returns the globally pinned posts in display order
+func (bu *BlogUsecase) GetFeaturedBlogs(ctx context.Context) ([]blogpkg.Blog, error) {
+	return bu.activePinnedBlogs(ctx, blogpkg.PinScopeGlobal, "")
+}
+
+// GetPinnedBlogs returns the posts an author pinned on their profile
+func (bu *BlogUsecase) GetPinnedBlogs(ctx context.Context, authorID string) ([]blogpkg.Blog, error) {
+	if authorID == "" {
+		return nil, errors.New("author ID is required")
+	}
+	return bu.activePinnedBlogs(ctx, blogpkg.PinScopeProfile, authorID)
+}
+
+func (bu *BlogUsecase) activePinnedBlogs(ctx context.Context, scope blogpkg.PinScope, ownerID string) ([]blogpkg.Blog, error) {
+	pins, err := bu.pinRepo.ListActivePins(ctx, scope, ownerID, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if len(pins) == 0 {
+		return []blogpkg.Blog{}, nil
+	}
+
+	ids := make([]string, len(pins))
+	for i, p := range pins {
+		ids[i] = p.BlogID
+	}
+	// Pins of deleted posts are skipped by the repository
+	blogs, err := bu.blogRepo.GetBlogsByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	for i := range blogs {
+		blogs[i].Pinned = true
+	}
+	return blogs, nil
+}
+
+// pinOwner resolves whose pin list a scope refers to
+func pinOwner(scope blogpkg.PinScope, userID string) (string, error) {
+	switch scope {
+	case blogpkg.PinScopeGlobal:
+		return "", nil
+	case blogpkg.PinScopeProfile:
+		return userID, nil
+	default:
+		return "", errors.New("invalid pin scope")
+	}
 }
@@ -0,0 +1,77 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"strings"
+	"time"
+
+	emaildomainpkg "github.com/Amaankaa/Blog-Starter-Project/Domain/emaildomain"
+	"github.com/Amaankaa/Blog-Starter-Project/Domain/services"
+	utils "github.com/Amaankaa/Blog-Starter-Project/Domain/utils"
+)
+
+var domainNameRegex = regexp.MustCompile(`^([a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?\.)+[a-z]{2,63}$`)
+
+// DomainRuleUsecase manages the admin allow/deny list and is also the step of the
+// email verification chain that applies it
+type DomainRuleUsecase struct {
+	repo emaildomainpkg.IDomainRuleRepository
+}
+
+func NewDomainRuleUsecase(repo emaildomainpkg.IDomainRuleRepository) *DomainRuleUsecase {
+	return &DomainRuleUsecase{repo: repo}
+}
+
+func (du *DomainRuleUsecase) SetRule(ctx context.Context, req emaildomainpkg.SetDomainRuleRequest, createdBy string) (emaildomainpkg.DomainRule, error) {
+	domain := strings.TrimSuffix(strings.ToLower(strings.TrimSpace(req.Domain)), ".")
+	if !domainNameRegex.MatchString(domain) {
+		return emaildomainpkg.DomainRule{}, errors.New("invalid domain name")
+	}
+	if req.Action != emaildomainpkg.ActionAllow && req.Action != emaildomainpkg.ActionDeny {
+		return emaildomainpkg.DomainRule{}, errors.New(`action must be "allow" or "deny"`)
+	}
+
+	return du.repo.UpsertRule(ctx, emaildomainpkg.DomainRule{
+		Domain:    domain,
+		Action:    req.Action,
+		Note:      req.Note,
+		CreatedBy: createdBy,
+		CreatedAt: time.Now(),
+	})
+}
+
+func (du *DomainRuleUsecase) ListRules(ctx context.Context) ([]emaildomainpkg.DomainRule, error) {
+	return du.repo.ListRules(ctx)
+}
+
+func (du *DomainRuleUsecase) DeleteRule(ctx context.Context, domain string) error {
+	return du.repo.DeleteRule(ctx, strings.ToLower(domain))
+}
+
+// Check applies the most specific rule for the address's domain or one of its parents
+func (du *DomainRuleUsecase) Check(ctx context.Context, email string) (services.EmailVerdict, error) {
+	domains := utils.EmailDomainHierarchy(email)
+	if len(domains) == 0 {
+		return services.EmailReject, nil
+	}
+	rules, err := du.repo.FindRules(ctx, domains)
+	if err != nil {
+		return services.EmailContinue, err
+	}
+
+	byDomain := make(map[string]string, len(rules))
+	for _, rule := range rules {
+		byDomain[rule.Domain] = rule.Action
+	}
+	for _, domain := range domains {
+		switch byDomain[domain] {
+		case emaildomainpkg.ActionAllow:
+			return services.EmailAccept, nil
+		case emaildomainpkg.ActionDeny:
+			return services.EmailReject, nil
+		}
+	}
+	return services.EmailContinue, nil
+}
@@ -0,0 +1,83 @@
+package usecases_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	emaildomainpkg "github.com/Amaankaa/Blog-Starter-Project/Domain/emaildomain"
+	"github.com/Amaankaa/Blog-Starter-Project/Domain/services"
+	usecases "github.com/Amaankaa/Blog-Starter-Project/Usecases"
+	"github.com/Amaankaa/Blog-Starter-Project/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type DomainRuleUsecaseSuite struct {
+	suite.Suite
+	ctx     context.Context
+	repo    *mocks.IDomainRuleRepository
+	usecase *usecases.DomainRuleUsecase
+}
+
+func TestDomainRuleUsecaseSuite(t *testing.T) {
+	suite.Run(t, new(DomainRuleUsecaseSuite))
+}
+
+func (s *DomainRuleUsecaseSuite) SetupTest() {
+	s.ctx = context.Background()
+	s.repo = mocks.NewIDomainRuleRepository(s.T())
+	s.usecase = usecases.NewDomainRuleUsecase(s.repo)
+}
+
+func (s *DomainRuleUsecaseSuite) TestSetRule_NormalisesDomain() {
+	s.repo.On("UpsertRule", s.ctx, mock.MatchedBy(func(r emaildomainpkg.DomainRule) bool {
+		return r.Domain == "example.com" && r.Action == emaildomainpkg.ActionDeny && r.CreatedBy == "admin-1" && !r.CreatedAt.IsZero()
+	})).Return(emaildomainpkg.DomainRule{Domain: "example.com"}, nil).Once()
+
+	_, err := s.usecase.SetRule(s.ctx, emaildomainpkg.SetDomainRuleRequest{Domain: " Example.COM. ", Action: "deny"}, "admin-1")
+
+	s.NoError(err)
+}
+
+func (s *DomainRuleUsecaseSuite) TestSetRule_Validation() {
+	_, err := s.usecase.SetRule(s.ctx, emaildomainpkg.SetDomainRuleRequest{Domain: "user@example.com", Action: "deny"}, "admin-1")
+	s.EqualError(err, "invalid domain name")
+
+	_, err = s.usecase.SetRule(s.ctx, emaildomainpkg.SetDomainRuleRequest{Domain: "example.com", Action: "block"}, "admin-1")
+	s.EqualError(err, `action must be "allow" or "deny"`)
+}
+
+func (s *DomainRuleUsecaseSuite) TestCheck_MostSpecificRuleWins() {
+	s.repo.On("FindRules", s.ctx, []string{"eng.corp.example.com", "corp.example.com", "example.com"}).Return([]emaildomainpkg.DomainRule{
+		{Domain: "example.com", Action: emaildomainpkg.ActionDeny},
+		{Domain: "corp.example.com", Action: emaildomainpkg.ActionAllow},
+	}, nil).Once()
+
+	verdict, err := s.usecase.Check(s.ctx, "jane@eng.corp.example.com")
+
+	s.NoError(err)
+	s.Equal(services.EmailAccept, verdict)
+}
+
+func (s *DomainRuleUsecaseSuite) TestCheck_DeniedParentDomain() {
+	s.repo.On("FindRules", s.ctx, []string{"mail.example.com", "example.com"}).Return([]emaildomainpkg.DomainRule{
+		{Domain: "example.com", Action: emaildomainpkg.ActionDeny},
+	}, nil).Once()
+
+	verdict, err := s.usecase.Check(s.ctx, "jane@Mail.Example.com")
+
+	s.NoError(err)
+	s.Equal(services.EmailReject, verdict)
+}
+
+func (s *DomainRuleUsecaseSuite) TestCheck_NoRuleOrLookupFailure() {
+	s.repo.On("FindRules", s.ctx, []string{"example.org"}).Return([]emaildomainpkg.DomainRule{}, nil).Once()
+	verdict, err := s.usecase.Check(s.ctx, "jane@example.org")
+	s.NoError(err)
+	s.Equal(services.EmailContinue, verdict)
+
+	s.repo.On("FindRules", s.ctx, []string{"example.net"}).Return(nil, errors.New("db down")).Once()
+	_, err = s.usecase.Check(s.ctx, "jane@example.net")
+	s.EqualError(err, "db down")
+}
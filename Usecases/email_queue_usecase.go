@@ -0,0 +1,177 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"log"
+	"sync"
+	"time"
+
+	emailjobpkg "github.com/Amaankaa/Blog-Starter-Project/Domain/emailjob"
+	"github.com/Amaankaa/Blog-Starter-Project/Domain/services"
+)
+
+// EmailQueuePolicy configures the email workers and their retry schedule
+type EmailQueuePolicy struct {
+	Workers      int
+	MaxAttempts  int
+	BaseBackoff  time.Duration
+	MaxBackoff   time.Duration
+	Lease        time.Duration // how long a claimed job stays invisible to other workers
+	PollInterval time.Duration
+}
+
+var DefaultEmailQueuePolicy = EmailQueuePolicy{
+	Workers:      4,
+	MaxAttempts:  6,
+	BaseBackoff:  10 * time.Second,
+	MaxBackoff:   30 * time.Minute,
+	Lease:        2 * time.Minute,
+	PollInterval: 5 * time.Second,
+}
+
+// EmailQueueUsecase is an IEmailSender that persists emails instead of sending them
+// inline. Background workers deliver them through the wrapped sender.
+type EmailQueueUsecase struct {
+	repo   emailjobpkg.IEmailJobRepository
+	sender services.IEmailSender
+	policy EmailQueuePolicy
+	wake   chan struct{}
+}
+
+func NewEmailQueueUsecase(repo emailjobpkg.IEmailJobRepository, sender services.IEmailSender, policy EmailQueuePolicy) *EmailQueueUsecase {
+	return &EmailQueueUsecase{
+		repo:   repo,
+		sender: sender,
+		policy: policy,
+		wake:   make(chan struct{}, 1),
+	}
+}
+
+// SendEmail queues the email; it only fails if the job could not be stored
+func (eq *EmailQueueUsecase) SendEmail(msg services.EmailMessage) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	now := time.Now()
+	_, err := eq.repo.Enqueue(ctx, emailjobpkg.EmailJob{
+		To:        msg.To,
+		Subject:   msg.Subject,
+		HTML:      msg.HTML,
+		Text:      msg.Text,
+		RunAt:     now,
+		CreatedAt: now,
+		UpdatedAt: now,
+	})
+	if err != nil {
+		return errors.New("failed to queue email: " + err.Error())
+	}
+
+	eq.notify()
+	return nil
+}
+
+// Run starts the workers and blocks until ctx is cancelled and all of them have stopped
+func (eq *EmailQueueUsecase) Run(ctx context.Context) {
+	var wg sync.WaitGroup
+	for i := 0; i < eq.policy.Workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			eq.work(ctx)
+		}()
+	}
+	wg.Wait()
+}
+
+func (eq *EmailQueueUsecase) work(ctx context.Context) {
+	ticker := time.NewTicker(eq.policy.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		eq.ProcessDue(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-eq.wake:
+		}
+	}
+}
+
+// ProcessDue delivers jobs until none is due and returns how many were attempted
+func (eq *EmailQueueUsecase) ProcessDue(ctx context.Context) int {
+	attempted := 0
+	for ctx.Err() == nil {
+		job, ok, err := eq.repo.ClaimNext(ctx, time.Now(), eq.policy.Lease)
+		if err != nil {
+			log.Printf("email queue: failed to claim job: %v", err)
+			return attempted
+		}
+		if !ok {
+			return attempted
+		}
+		eq.deliver(ctx, job)
+		attempted++
+	}
+	return attempted
+}
+
+func (eq *EmailQueueUsecase) deliver(ctx context.Context, job emailjobpkg.EmailJob) {
+	err := eq.sender.SendEmail(services.EmailMessage{
+		To:      job.To,
+		Subject: job.Subject,
+		HTML:    job.HTML,
+		Text:    job.Text,
+	})
+	if err == nil {
+		if err := eq.repo.Complete(ctx, job.ID.Hex()); err != nil {
+			// The lease expires and the email goes out twice; better than losing it
+			log.Printf("email queue: failed to complete job %s: %v", job.ID.Hex(), err)
+		}
+		return
+	}
+
+	now := time.Now()
+	job.Attempts++
+	job.LastError = err.Error()
+	job.UpdatedAt = now
+
+	if job.Attempts >= eq.policy.MaxAttempts {
+		job.FailedAt = &now
+		if err := eq.repo.Bury(ctx, job); err != nil {
+			log.Printf("email queue: failed to dead-letter job %s: %v", job.ID.Hex(), err)
+		}
+		return
+	}
+
+	job.RunAt = now.Add(exponentialBackoff(eq.policy.BaseBackoff, eq.policy.MaxBackoff, job.Attempts))
+	if err := eq.repo.Reschedule(ctx, job); err != nil {
+		log.Printf("email queue: failed to reschedule job %s: %v", job.ID.Hex(), err)
+	}
+}
+
+func (eq *EmailQueueUsecase) ListQueued(ctx context.Context) ([]emailjobpkg.EmailJob, error) {
+	return eq.repo.ListQueued(ctx, 100)
+}
+
+func (eq *EmailQueueUsecase) ListDeadLetters(ctx context.Context) ([]emailjobpkg.EmailJob, error) {
+	return eq.repo.ListDead(ctx, 100)
+}
+
+// RequeueDeadLetter gives a failed job a fresh set of attempts
+func (eq *EmailQueueUsecase) RequeueDeadLetter(ctx context.Context, id string) (emailjobpkg.EmailJob, error) {
+	job, err := eq.repo.Requeue(ctx, id, time.Now())
+	if err != nil {
+		return emailjobpkg.EmailJob{}, err
+	}
+	eq.notify()
+	return job, nil
+}
+
+func (eq *EmailQueueUsecase) notify() {
+	select {
+	case eq.wake <- struct{}{}:
+	default:
+	}
+}
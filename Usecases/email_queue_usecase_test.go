@@ -0,0 +1,152 @@
+package usecases_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	emailjobpkg "github.com/Amaankaa/Blog-Starter-Project/Domain/emailjob"
+	"github.com/Amaankaa/Blog-Starter-Project/Domain/services"
+	usecases "github.com/Amaankaa/Blog-Starter-Project/Usecases"
+	"github.com/Amaankaa/Blog-Starter-Project/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type EmailQueueUsecaseSuite struct {
+	suite.Suite
+	ctx    context.Context
+	repo   *mocks.IEmailJobRepository
+	sender *mocks.IEmailSender
+	queue  *usecases.EmailQueueUsecase
+}
+
+func TestEmailQueueUsecaseSuite(t *testing.T) {
+	suite.Run(t, new(EmailQueueUsecaseSuite))
+}
+
+func (s *EmailQueueUsecaseSuite) SetupTest() {
+	s.ctx = context.Background()
+	s.repo = mocks.NewIEmailJobRepository(s.T())
+	s.sender = mocks.NewIEmailSender(s.T())
+	s.queue = usecases.NewEmailQueueUsecase(s.repo, s.sender, usecases.EmailQueuePolicy{
+		Workers:      2,
+		MaxAttempts:  3,
+		BaseBackoff:  time.Second,
+		MaxBackoff:   time.Minute,
+		Lease:        time.Minute,
+		PollInterval: time.Hour,
+	})
+}
+
+func (s *EmailQueueUsecaseSuite) claims(jobs ...emailjobpkg.EmailJob) {
+	for _, j := range jobs {
+		s.repo.On("ClaimNext", mock.Anything, mock.Anything, time.Minute).Return(j, true, nil).Once()
+	}
+	s.repo.On("ClaimNext", mock.Anything, mock.Anything, time.Minute).Return(emailjobpkg.EmailJob{}, false, nil).Once()
+}
+
+func job(attempts int) emailjobpkg.EmailJob {
+	return emailjobpkg.EmailJob{ID: primitive.NewObjectID(), To: "a@example.com", Subject: "Hi", HTML: "<p>Your code: 123456</p>", Text: "Your code: 123456", Attempts: attempts}
+}
+
+func emailOf(j emailjobpkg.EmailJob) services.EmailMessage {
+	return services.EmailMessage{To: j.To, Subject: j.Subject, HTML: j.HTML, Text: j.Text}
+}
+
+func (s *EmailQueueUsecaseSuite) TestSendEmail_OnlyEnqueues() {
+	s.repo.On("Enqueue", mock.Anything, mock.MatchedBy(func(j emailjobpkg.EmailJob) bool {
+		return j.To == "a@example.com" && j.Subject == "Hi" && j.HTML == "<p>body</p>" && j.Text == "body" && j.Attempts == 0 && !j.RunAt.IsZero()
+	})).Return(emailjobpkg.EmailJob{}, nil).Once()
+
+	s.NoError(s.queue.SendEmail(services.EmailMessage{To: "a@example.com", Subject: "Hi", HTML: "<p>body</p>", Text: "body"}))
+	s.sender.AssertNotCalled(s.T(), "SendEmail", mock.Anything)
+}
+
+func (s *EmailQueueUsecaseSuite) TestSendEmail_EnqueueFailure() {
+	s.repo.On("Enqueue", mock.Anything, mock.Anything).Return(emailjobpkg.EmailJob{}, errors.New("db down")).Once()
+
+	s.EqualError(s.queue.SendEmail(services.EmailMessage{To: "a@example.com", Subject: "Hi", Text: "body"}), "failed to queue email: db down")
+}
+
+func (s *EmailQueueUsecaseSuite) TestProcessDue_CompletesDeliveredJobs() {
+	j := job(0)
+	s.claims(j)
+	s.sender.On("SendEmail", emailOf(j)).Return(nil).Once()
+	s.repo.On("Complete", s.ctx, j.ID.Hex()).Return(nil).Once()
+
+	s.Equal(1, s.queue.ProcessDue(s.ctx))
+}
+
+func (s *EmailQueueUsecaseSuite) TestProcessDue_ReschedulesWithExponentialBackoff() {
+	j := job(1)
+	s.claims(j)
+	s.sender.On("SendEmail", emailOf(j)).Return(errors.New("brevo 503")).Once()
+	var saved emailjobpkg.EmailJob
+	s.repo.On("Reschedule", s.ctx, mock.Anything).Run(func(args mock.Arguments) {
+		saved = args.Get(1).(emailjobpkg.EmailJob)
+	}).Return(nil).Once()
+
+	before := time.Now()
+	s.queue.ProcessDue(s.ctx)
+
+	s.Equal(2, saved.Attempts)
+	s.Equal("brevo 503", saved.LastError)
+	s.WithinDuration(before.Add(2*time.Second), saved.RunAt, time.Second)
+	s.Nil(saved.FailedAt)
+}
+
+func (s *EmailQueueUsecaseSuite) TestProcessDue_DeadLettersAfterMaxAttempts() {
+	j := job(2)
+	s.claims(j)
+	s.sender.On("SendEmail", emailOf(j)).Return(errors.New("mailbox unavailable")).Once()
+	s.repo.On("Bury", s.ctx, mock.MatchedBy(func(b emailjobpkg.EmailJob) bool {
+		return b.ID == j.ID && b.Attempts == 3 && b.FailedAt != nil && b.LastError == "mailbox unavailable"
+	})).Return(nil).Once()
+
+	s.queue.ProcessDue(s.ctx)
+}
+
+func (s *EmailQueueUsecaseSuite) TestRun_WorkersWakeOnNewJobs() {
+	ctx, cancel := context.WithCancel(s.ctx)
+	j := job(0)
+	sent := make(chan struct{})
+	polled := make(chan struct{}, 2)
+	s.repo.On("ClaimNext", mock.Anything, mock.Anything, time.Minute).Run(func(mock.Arguments) {
+		polled <- struct{}{}
+	}).Return(emailjobpkg.EmailJob{}, false, nil).Times(2)
+	s.repo.On("Enqueue", mock.Anything, mock.Anything).Return(j, nil).Once()
+	s.repo.On("ClaimNext", mock.Anything, mock.Anything, time.Minute).Return(j, true, nil).Once()
+	s.repo.On("ClaimNext", mock.Anything, mock.Anything, time.Minute).Return(emailjobpkg.EmailJob{}, false, nil)
+	s.sender.On("SendEmail", emailOf(j)).Return(nil).Once()
+	s.repo.On("Complete", mock.Anything, j.ID.Hex()).Run(func(mock.Arguments) { close(sent) }).Return(nil).Once()
+
+	done := make(chan struct{})
+	go func() {
+		s.queue.Run(ctx)
+		close(done)
+	}()
+	// both workers have polled the empty queue once; the poll interval is an hour
+	<-polled
+	<-polled
+	s.NoError(s.queue.SendEmail(emailOf(j)))
+
+	select {
+	case <-sent:
+	case <-time.After(time.Second):
+		s.Fail("queued email was not sent")
+	}
+	cancel()
+	<-done
+}
+
+func (s *EmailQueueUsecaseSuite) TestRequeueDeadLetter() {
+	j := job(0)
+	s.repo.On("Requeue", s.ctx, j.ID.Hex(), mock.Anything).Return(j, nil).Once()
+
+	got, err := s.queue.RequeueDeadLetter(s.ctx, j.ID.Hex())
+	s.NoError(err)
+	s.Equal(j.ID, got.ID)
+}
@@ -0,0 +1,323 @@
+package usecases
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"math/big"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	eventpkg "github.com/Amaankaa/Blog-Starter-Project/Domain/event"
+	identitypkg "github.com/Amaankaa/Blog-Starter-Project/Domain/identity"
+	userpkg "github.com/Amaankaa/Blog-Starter-Project/Domain/user"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const authStateLifetime = 10 * time.Minute
+
+var usernameDisallowed = regexp.MustCompile(`[^a-zA-Z0-9_]+`)
+
+// IdentityUsecase signs users in at external providers and manages the identities linked to
+// local accounts. A first login creates a verified account, or links to an existing verified
+// account with the same verified email.
+type IdentityUsecase struct {
+	repo      identitypkg.IIdentityRepository
+	userRepo  userpkg.IUserRepository
+	sessions  identitypkg.ISessionIssuer
+	events    eventpkg.IPublisher
+	providers map[string]identitypkg.IIdentityProvider
+}
+
+func NewIdentityUsecase(
+	repo identitypkg.IIdentityRepository,
+	userRepo userpkg.IUserRepository,
+	sessions identitypkg.ISessionIssuer,
+	events eventpkg.IPublisher,
+	providers ...identitypkg.IIdentityProvider,
+) *IdentityUsecase {
+	byName := make(map[string]identitypkg.IIdentityProvider, len(providers))
+	for _, p := range providers {
+		byName[p.Name()] = p
+	}
+	return &IdentityUsecase{repo: repo, userRepo: userRepo, sessions: sessions, events: events, providers: byName}
+}
+
+func (iu *IdentityUsecase) Providers() []string {
+	names := make([]string, 0, len(iu.providers))
+	for name := range iu.providers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (iu *IdentityUsecase) StartLogin(ctx context.Context, provider string) (string, error) {
+	return iu.start(ctx, provider, "")
+}
+
+func (iu *IdentityUsecase) StartLink(ctx context.Context, provider, userID string) (string, error) {
+	return iu.start(ctx, provider, userID)
+}
+
+func (iu *IdentityUsecase) start(ctx context.Context, provider, linkUserID string) (string, error) {
+	p, ok := iu.providers[provider]
+	if !ok {
+		return "", identitypkg.ErrUnknownProvider
+	}
+
+	state, err := randomToken()
+	if err != nil {
+		return "", err
+	}
+	nonce, err := randomToken()
+	if err != nil {
+		return "", err
+	}
+	verifier, err := randomToken()
+	if err != nil {
+		return "", err
+	}
+	err = iu.repo.StoreState(ctx, identitypkg.AuthState{
+		ID:           hashSecret(state),
+		Provider:     provider,
+		Nonce:        nonce,
+		CodeVerifier: verifier,
+		LinkUserID:   linkUserID,
+		ExpiresAt:    time.Now().Add(authStateLifetime),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	challenge := sha256.Sum256([]byte(verifier))
+	return p.AuthCodeURL(ctx, state, nonce, base64.RawURLEncoding.EncodeToString(challenge[:]))
+}
+
+// Callback finishes a login or link started by StartLogin or StartLink. A login on an account
+// with two-factor authentication returns the MFARequiredError from the session issuer.
+func (iu *IdentityUsecase) Callback(ctx context.Context, provider, code, state string, device userpkg.DeviceInfo) (identitypkg.CallbackResult, error) {
+	p, ok := iu.providers[provider]
+	if !ok {
+		return identitypkg.CallbackResult{}, identitypkg.ErrUnknownProvider
+	}
+	// States are single-use, so a replayed callback fails here
+	st, err := iu.repo.ConsumeState(ctx, hashSecret(state))
+	if err != nil {
+		return identitypkg.CallbackResult{}, err
+	}
+	if st.Provider != provider || time.Now().After(st.ExpiresAt) {
+		return identitypkg.CallbackResult{}, identitypkg.ErrStateNotFound
+	}
+
+	external, err := p.Exchange(ctx, code, st.CodeVerifier, st.Nonce)
+	if err != nil {
+		return identitypkg.CallbackResult{}, err
+	}
+	if st.LinkUserID != "" {
+		return iu.link(ctx, st.LinkUserID, external)
+	}
+	return iu.login(ctx, external, device)
+}
+
+func (iu *IdentityUsecase) link(ctx context.Context, userID string, external identitypkg.ExternalIdentity) (identitypkg.CallbackResult, error) {
+	existing, err := iu.repo.FindIdentity(ctx, external.Provider, external.Subject)
+	if err == nil {
+		if existing.UserID.Hex() != userID {
+			return identitypkg.CallbackResult{}, identitypkg.ErrIdentityTaken
+		}
+		return identitypkg.CallbackResult{Linked: true, Identity: existing}, nil
+	}
+	if !errors.Is(err, identitypkg.ErrIdentityNotFound) {
+		return identitypkg.CallbackResult{}, err
+	}
+
+	uid, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return identitypkg.CallbackResult{}, err
+	}
+	identity, err := iu.repo.CreateIdentity(ctx, newIdentity(uid, external))
+	if err != nil {
+		return identitypkg.CallbackResult{}, err
+	}
+	return identitypkg.CallbackResult{Linked: true, Identity: identity}, nil
+}
+
+func (iu *IdentityUsecase) login(ctx context.Context, external identitypkg.ExternalIdentity, device userpkg.DeviceInfo) (identitypkg.CallbackResult, error) {
+	var user userpkg.User
+	identity, err := iu.repo.FindIdentity(ctx, external.Provider, external.Subject)
+	switch {
+	case err == nil:
+		user, err = iu.userRepo.FindByID(ctx, identity.UserID.Hex())
+		if err != nil {
+			return identitypkg.CallbackResult{}, err
+		}
+		_ = iu.repo.TouchIdentity(ctx, identity.ID.Hex())
+	case errors.Is(err, identitypkg.ErrIdentityNotFound):
+		user, err = iu.userForNewIdentity(ctx, external)
+		if err != nil {
+			return identitypkg.CallbackResult{}, err
+		}
+		identity, err = iu.repo.CreateIdentity(ctx, newIdentity(user.ID, external))
+		if err != nil {
+			return identitypkg.CallbackResult{}, err
+		}
+	default:
+		return identitypkg.CallbackResult{}, err
+	}
+
+	user, accessToken, refreshToken, err := iu.sessions.SignIn(ctx, user, device)
+	if err != nil {
+		return identitypkg.CallbackResult{Identity: identity}, err
+	}
+	return identitypkg.CallbackResult{
+		Identity:     identity,
+		User:         user,
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+	}, nil
+}
+
+// userForNewIdentity finds the account an unknown identity belongs to by its email, or creates one.
+// Only verified emails on both sides are trusted, otherwise anyone could claim an account by
+// registering its address at a provider.
+func (iu *IdentityUsecase) userForNewIdentity(ctx context.Context, external identitypkg.ExternalIdentity) (userpkg.User, error) {
+	if external.Email == "" || !external.EmailVerified {
+		return userpkg.User{}, errors.New("the provider did not confirm your email address")
+	}
+
+	exists, err := iu.userRepo.ExistsByEmail(ctx, external.Email)
+	if err != nil {
+		return userpkg.User{}, err
+	}
+	if exists {
+		user, err := iu.userRepo.FindByEmail(ctx, external.Email)
+		if err != nil {
+			return userpkg.User{}, err
+		}
+		if !user.IsVerified {
+			return userpkg.User{}, errors.New("an unverified account uses this email; verify it first, then link this provider from your settings")
+		}
+		return user, nil
+	}
+
+	username, err := iu.uniqueUsername(ctx, external)
+	if err != nil {
+		return userpkg.User{}, err
+	}
+	count, err := iu.userRepo.CountUsers(ctx)
+	if err != nil {
+		return userpkg.User{}, err
+	}
+	role := "user"
+	if count == 0 {
+		role = "admin"
+	}
+	fullname := external.Name
+	if fullname == "" {
+		fullname = username
+	}
+
+	// No password: the account can only sign in through the provider until the user sets one
+	created, err := iu.userRepo.CreateUser(ctx, userpkg.User{
+		Username: username,
+		Fullname: fullname,
+		Email:    external.Email,
+		Role:     role,
+	})
+	if err != nil {
+		return userpkg.User{}, err
+	}
+	if err := iu.userRepo.UpdateIsVerifiedByEmail(ctx, created.Email, true); err != nil {
+		return userpkg.User{}, err
+	}
+	created.IsVerified = true
+
+	iu.events.Publish(ctx, eventpkg.UserRegistered{
+		UserID:   created.ID.Hex(),
+		Username: created.Username,
+		Email:    created.Email,
+		Role:     created.Role,
+	})
+	return created, nil
+}
+
+// uniqueUsername derives a free username from the provider's suggestion or the email address
+func (iu *IdentityUsecase) uniqueUsername(ctx context.Context, external identitypkg.ExternalIdentity) (string, error) {
+	base := external.Username
+	if base == "" {
+		base, _, _ = strings.Cut(external.Email, "@")
+	}
+	base = usernameDisallowed.ReplaceAllString(base, "")
+	if len(base) > 20 {
+		base = base[:20]
+	}
+	if len(base) < 3 {
+		base = "user"
+	}
+
+	candidate := base
+	for i := 0; i < 5; i++ {
+		taken, err := iu.userRepo.ExistsByUsername(ctx, candidate)
+		if err != nil {
+			return "", err
+		}
+		if !taken {
+			return candidate, nil
+		}
+		n, err := rand.Int(rand.Reader, big.NewInt(10000))
+		if err != nil {
+			return "", err
+		}
+		candidate = fmt.Sprintf("%s%04d", base, n.Int64())
+	}
+	return "", errors.New("could not find a free username")
+}
+
+func (iu *IdentityUsecase) ListIdentities(ctx context.Context, userID string) ([]identitypkg.Identity, error) {
+	return iu.repo.ListIdentities(ctx, userID)
+}
+
+func (iu *IdentityUsecase) Unlink(ctx context.Context, userID, identityID string) error {
+	identities, err := iu.repo.ListIdentities(ctx, userID)
+	if err != nil {
+		return err
+	}
+	found := false
+	for _, identity := range identities {
+		if identity.ID.Hex() == identityID {
+			found = true
+		}
+	}
+	if !found {
+		return identitypkg.ErrIdentityNotFound
+	}
+
+	if len(identities) == 1 {
+		user, err := iu.userRepo.FindByID(ctx, userID)
+		if err != nil {
+			return err
+		}
+		if user.Password == "" {
+			return errors.New("set a password before removing your only way to sign in")
+		}
+	}
+	return iu.repo.DeleteIdentity(ctx, userID, identityID)
+}
+
+func newIdentity(userID primitive.ObjectID, external identitypkg.ExternalIdentity) identitypkg.Identity {
+	now := time.Now()
+	return identitypkg.Identity{
+		UserID:      userID,
+		Provider:    external.Provider,
+		Subject:     external.Subject,
+		Email:       external.Email,
+		LinkedAt:    now,
+		LastLoginAt: now,
+	}
+}
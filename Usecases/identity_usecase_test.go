@@ -0,0 +1,248 @@
+package usecases_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	eventpkg "github.com/Amaankaa/Blog-Starter-Project/Domain/event"
+	"github.com/Amaankaa/Blog-Starter-Project/Domain/event/eventtest"
+	identitypkg "github.com/Amaankaa/Blog-Starter-Project/Domain/identity"
+	userpkg "github.com/Amaankaa/Blog-Starter-Project/Domain/user"
+	usecases "github.com/Amaankaa/Blog-Starter-Project/Usecases"
+	"github.com/Amaankaa/Blog-Starter-Project/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type IdentityUsecaseSuite struct {
+	suite.Suite
+	ctx      context.Context
+	repo     *mocks.IIdentityRepository
+	userRepo *mocks.IUserRepository
+	sessions *mocks.ISessionIssuer
+	provider *mocks.IIdentityProvider
+	events   *eventtest.Recorder
+	usecase  *usecases.IdentityUsecase
+}
+
+func TestIdentityUsecaseSuite(t *testing.T) {
+	suite.Run(t, new(IdentityUsecaseSuite))
+}
+
+func (s *IdentityUsecaseSuite) SetupTest() {
+	s.ctx = context.Background()
+	s.repo = mocks.NewIIdentityRepository(s.T())
+	s.userRepo = mocks.NewIUserRepository(s.T())
+	s.sessions = mocks.NewISessionIssuer(s.T())
+	s.provider = mocks.NewIIdentityProvider(s.T())
+	s.events = eventtest.NewRecorder()
+	s.provider.On("Name").Return("google")
+	s.usecase = usecases.NewIdentityUsecase(s.repo, s.userRepo, s.sessions, s.events, s.provider)
+}
+
+var oidcDevice = userpkg.DeviceInfo{UserAgent: "Firefox"}
+
+// expectState makes ConsumeState return a state for "state" that links to linkUserID, if set
+func (s *IdentityUsecaseSuite) expectState(linkUserID string) {
+	s.repo.On("ConsumeState", s.ctx, sha256Hex("state")).Return(identitypkg.AuthState{
+		Provider:     "google",
+		Nonce:        "nonce",
+		CodeVerifier: "verifier",
+		LinkUserID:   linkUserID,
+		ExpiresAt:    time.Now().Add(time.Minute),
+	}, nil).Once()
+}
+
+func (s *IdentityUsecaseSuite) expectExchange(external identitypkg.ExternalIdentity) {
+	external.Provider = "google"
+	s.provider.On("Exchange", s.ctx, "code", "verifier", "nonce").Return(external, nil).Once()
+}
+
+func (s *IdentityUsecaseSuite) TestStartLogin_StoresHashedState() {
+	var stored identitypkg.AuthState
+	s.repo.On("StoreState", s.ctx, mock.Anything).Run(func(args mock.Arguments) {
+		stored = args.Get(1).(identitypkg.AuthState)
+	}).Return(nil).Once()
+	var state string
+	s.provider.On("AuthCodeURL", s.ctx, mock.Anything, mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		state = args.String(1)
+		s.Equal(stored.Nonce, args.String(2))
+		s.NotEqual(stored.CodeVerifier, args.String(3), "only the challenge leaves the server")
+	}).Return("https://idp/authorize", nil).Once()
+
+	authURL, err := s.usecase.StartLogin(s.ctx, "google")
+
+	s.NoError(err)
+	s.Equal("https://idp/authorize", authURL)
+	s.Equal(sha256Hex(state), stored.ID)
+	s.Empty(stored.LinkUserID)
+}
+
+func (s *IdentityUsecaseSuite) TestStartLogin_UnknownProvider() {
+	_, err := s.usecase.StartLogin(s.ctx, "myspace")
+	s.ErrorIs(err, identitypkg.ErrUnknownProvider)
+}
+
+func (s *IdentityUsecaseSuite) TestCallback_StateForOtherProvider() {
+	s.repo.On("ConsumeState", s.ctx, sha256Hex("state")).Return(identitypkg.AuthState{
+		Provider:  "github",
+		ExpiresAt: time.Now().Add(time.Minute),
+	}, nil).Once()
+
+	_, err := s.usecase.Callback(s.ctx, "google", "code", "state", oidcDevice)
+	s.ErrorIs(err, identitypkg.ErrStateNotFound)
+}
+
+func (s *IdentityUsecaseSuite) TestCallback_KnownIdentityLogsIn() {
+	user := userpkg.User{ID: primitive.NewObjectID(), Username: "jane", IsVerified: true}
+	identity := identitypkg.Identity{ID: primitive.NewObjectID(), UserID: user.ID, Provider: "google", Subject: "sub"}
+	s.expectState("")
+	s.expectExchange(identitypkg.ExternalIdentity{Subject: "sub"})
+	s.repo.On("FindIdentity", s.ctx, "google", "sub").Return(identity, nil).Once()
+	s.userRepo.On("FindByID", s.ctx, user.ID.Hex()).Return(user, nil).Once()
+	s.repo.On("TouchIdentity", s.ctx, identity.ID.Hex()).Return(nil).Once()
+	s.sessions.On("SignIn", s.ctx, user, oidcDevice).Return(user, "access", "refresh", nil).Once()
+
+	result, err := s.usecase.Callback(s.ctx, "google", "code", "state", oidcDevice)
+
+	s.NoError(err)
+	s.False(result.Linked)
+	s.Equal("access", result.AccessToken)
+	s.Equal("refresh", result.RefreshToken)
+}
+
+func (s *IdentityUsecaseSuite) TestCallback_LinksToVerifiedAccountByEmail() {
+	user := userpkg.User{ID: primitive.NewObjectID(), Email: "jane@example.com", IsVerified: true}
+	s.expectState("")
+	s.expectExchange(identitypkg.ExternalIdentity{Subject: "sub", Email: "jane@example.com", EmailVerified: true})
+	s.repo.On("FindIdentity", s.ctx, "google", "sub").Return(identitypkg.Identity{}, identitypkg.ErrIdentityNotFound).Once()
+	s.userRepo.On("ExistsByEmail", s.ctx, "jane@example.com").Return(true, nil).Once()
+	s.userRepo.On("FindByEmail", s.ctx, "jane@example.com").Return(user, nil).Once()
+	s.repo.On("CreateIdentity", s.ctx, mock.MatchedBy(func(i identitypkg.Identity) bool {
+		return i.UserID == user.ID && i.Provider == "google" && i.Subject == "sub"
+	})).Return(identitypkg.Identity{ID: primitive.NewObjectID(), UserID: user.ID}, nil).Once()
+	s.sessions.On("SignIn", s.ctx, user, oidcDevice).Return(user, "access", "refresh", nil).Once()
+
+	_, err := s.usecase.Callback(s.ctx, "google", "code", "state", oidcDevice)
+
+	s.NoError(err)
+	s.Empty(s.events.Events(), "no account was created")
+}
+
+func (s *IdentityUsecaseSuite) TestCallback_RefusesUnverifiedLocalAccount() {
+	s.expectState("")
+	s.expectExchange(identitypkg.ExternalIdentity{Subject: "sub", Email: "jane@example.com", EmailVerified: true})
+	s.repo.On("FindIdentity", s.ctx, "google", "sub").Return(identitypkg.Identity{}, identitypkg.ErrIdentityNotFound).Once()
+	s.userRepo.On("ExistsByEmail", s.ctx, "jane@example.com").Return(true, nil).Once()
+	s.userRepo.On("FindByEmail", s.ctx, "jane@example.com").Return(userpkg.User{ID: primitive.NewObjectID()}, nil).Once()
+
+	_, err := s.usecase.Callback(s.ctx, "google", "code", "state", oidcDevice)
+
+	s.Error(err)
+}
+
+func (s *IdentityUsecaseSuite) TestCallback_RefusesUnverifiedProviderEmail() {
+	s.expectState("")
+	s.expectExchange(identitypkg.ExternalIdentity{Subject: "sub", Email: "jane@example.com"})
+	s.repo.On("FindIdentity", s.ctx, "google", "sub").Return(identitypkg.Identity{}, identitypkg.ErrIdentityNotFound).Once()
+
+	_, err := s.usecase.Callback(s.ctx, "google", "code", "state", oidcDevice)
+
+	s.Error(err)
+	s.userRepo.AssertNotCalled(s.T(), "ExistsByEmail", mock.Anything, mock.Anything)
+}
+
+func (s *IdentityUsecaseSuite) TestCallback_CreatesVerifiedUser() {
+	s.expectState("")
+	s.expectExchange(identitypkg.ExternalIdentity{Subject: "sub", Email: "new@example.com", EmailVerified: true, Name: "New Person", Username: "new.person"})
+	s.repo.On("FindIdentity", s.ctx, "google", "sub").Return(identitypkg.Identity{}, identitypkg.ErrIdentityNotFound).Once()
+	s.userRepo.On("ExistsByEmail", s.ctx, "new@example.com").Return(false, nil).Once()
+	s.userRepo.On("ExistsByUsername", s.ctx, "newperson").Return(true, nil).Once()
+	s.userRepo.On("ExistsByUsername", s.ctx, mock.AnythingOfType("string")).Return(false, nil).Once()
+	s.userRepo.On("CountUsers", s.ctx).Return(int64(3), nil).Once()
+	created := userpkg.User{ID: primitive.NewObjectID(), Email: "new@example.com", Role: "user"}
+	s.userRepo.On("CreateUser", s.ctx, mock.MatchedBy(func(u userpkg.User) bool {
+		return u.Fullname == "New Person" && u.Password == "" && u.Role == "user" && u.Username != "newperson"
+	})).Return(created, nil).Once()
+	s.userRepo.On("UpdateIsVerifiedByEmail", s.ctx, "new@example.com", true).Return(nil).Once()
+	s.repo.On("CreateIdentity", s.ctx, mock.Anything).Return(identitypkg.Identity{UserID: created.ID}, nil).Once()
+	s.sessions.On("SignIn", s.ctx, mock.MatchedBy(func(u userpkg.User) bool { return u.IsVerified }), oidcDevice).
+		Return(created, "access", "refresh", nil).Once()
+
+	_, err := s.usecase.Callback(s.ctx, "google", "code", "state", oidcDevice)
+
+	s.NoError(err)
+	s.Len(s.events.Events(), 1)
+	s.IsType(eventpkg.UserRegistered{}, s.events.Events()[0])
+}
+
+func (s *IdentityUsecaseSuite) TestCallback_PassesMFARequiredThrough() {
+	user := userpkg.User{ID: primitive.NewObjectID()}
+	s.expectState("")
+	s.expectExchange(identitypkg.ExternalIdentity{Subject: "sub"})
+	s.repo.On("FindIdentity", s.ctx, "google", "sub").Return(identitypkg.Identity{UserID: user.ID}, nil).Once()
+	s.userRepo.On("FindByID", s.ctx, user.ID.Hex()).Return(user, nil).Once()
+	s.repo.On("TouchIdentity", s.ctx, mock.Anything).Return(nil).Once()
+	s.sessions.On("SignIn", s.ctx, user, oidcDevice).Return(userpkg.User{}, "", "", &userpkg.MFARequiredError{ChallengeToken: "t"}).Once()
+
+	_, err := s.usecase.Callback(s.ctx, "google", "code", "state", oidcDevice)
+
+	var mfaRequired *userpkg.MFARequiredError
+	s.ErrorAs(err, &mfaRequired)
+}
+
+func (s *IdentityUsecaseSuite) TestCallback_LinkToAnotherUsersIdentity() {
+	s.expectState(primitive.NewObjectID().Hex())
+	s.expectExchange(identitypkg.ExternalIdentity{Subject: "sub"})
+	s.repo.On("FindIdentity", s.ctx, "google", "sub").Return(identitypkg.Identity{UserID: primitive.NewObjectID()}, nil).Once()
+
+	_, err := s.usecase.Callback(s.ctx, "google", "code", "state", oidcDevice)
+
+	s.ErrorIs(err, identitypkg.ErrIdentityTaken)
+}
+
+func (s *IdentityUsecaseSuite) TestCallback_Link() {
+	userID := primitive.NewObjectID()
+	s.expectState(userID.Hex())
+	s.expectExchange(identitypkg.ExternalIdentity{Subject: "sub", Email: "other@example.com"})
+	s.repo.On("FindIdentity", s.ctx, "google", "sub").Return(identitypkg.Identity{}, identitypkg.ErrIdentityNotFound).Once()
+	s.repo.On("CreateIdentity", s.ctx, mock.MatchedBy(func(i identitypkg.Identity) bool { return i.UserID == userID })).
+		Return(identitypkg.Identity{UserID: userID, Provider: "google"}, nil).Once()
+
+	result, err := s.usecase.Callback(s.ctx, "google", "code", "state", oidcDevice)
+
+	s.NoError(err)
+	s.True(result.Linked)
+	s.Empty(result.AccessToken)
+}
+
+func (s *IdentityUsecaseSuite) TestUnlink_KeepsLastWayToSignIn() {
+	userID, identityID := primitive.NewObjectID(), primitive.NewObjectID()
+	s.repo.On("ListIdentities", s.ctx, userID.Hex()).Return([]identitypkg.Identity{{ID: identityID}}, nil).Once()
+	s.userRepo.On("FindByID", s.ctx, userID.Hex()).Return(userpkg.User{ID: userID}, nil).Once()
+
+	err := s.usecase.Unlink(s.ctx, userID.Hex(), identityID.Hex())
+
+	s.Error(err)
+	s.repo.AssertNotCalled(s.T(), "DeleteIdentity", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (s *IdentityUsecaseSuite) TestUnlink_WithPassword() {
+	userID, identityID := primitive.NewObjectID(), primitive.NewObjectID()
+	s.repo.On("ListIdentities", s.ctx, userID.Hex()).Return([]identitypkg.Identity{{ID: identityID}}, nil).Once()
+	s.userRepo.On("FindByID", s.ctx, userID.Hex()).Return(userpkg.User{ID: userID, Password: "hash"}, nil).Once()
+	s.repo.On("DeleteIdentity", s.ctx, userID.Hex(), identityID.Hex()).Return(nil).Once()
+
+	s.NoError(s.usecase.Unlink(s.ctx, userID.Hex(), identityID.Hex()))
+}
+
+func (s *IdentityUsecaseSuite) TestUnlink_NotOwn() {
+	userID := primitive.NewObjectID()
+	s.repo.On("ListIdentities", s.ctx, userID.Hex()).Return([]identitypkg.Identity{{ID: primitive.NewObjectID()}}, nil).Once()
+
+	err := s.usecase.Unlink(s.ctx, userID.Hex(), primitive.NewObjectID().Hex())
+
+	s.ErrorIs(err, identitypkg.ErrIdentityNotFound)
+}
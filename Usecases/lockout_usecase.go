@@ -0,0 +1,177 @@
+package usecases
+
+import (
+	"context"
+	"log"
+	"net/url"
+	"time"
+
+	lockoutpkg "github.com/Amaankaa/Blog-Starter-Project/Domain/lockout"
+	"github.com/Amaankaa/Blog-Starter-Project/Domain/services"
+	userpkg "github.com/Amaankaa/Blog-Starter-Project/Domain/user"
+)
+
+// LockoutUsecase slows down and then locks out repeated failed logins, per account and per
+// IP address. Accounts unlock on their own after the lockout, or through the emailed link.
+type LockoutUsecase struct {
+	repo          lockoutpkg.ILoginAttemptRepository
+	emailSender   services.IEmailSender
+	emailRenderer services.IEmailRenderer
+	policy        lockoutpkg.Policy
+	unlockURL     string
+}
+
+// NewLockoutUsecase takes the page the unlock link points to; it gets the token as the
+// "token" query parameter. Without one the lockout email has no link.
+func NewLockoutUsecase(
+	repo lockoutpkg.ILoginAttemptRepository,
+	emailSender services.IEmailSender,
+	emailRenderer services.IEmailRenderer,
+	policy lockoutpkg.Policy,
+	unlockURL string,
+) *LockoutUsecase {
+	return &LockoutUsecase{
+		repo:          repo,
+		emailSender:   emailSender,
+		emailRenderer: emailRenderer,
+		policy:        policy,
+		unlockURL:     unlockURL,
+	}
+}
+
+func (lu *LockoutUsecase) Check(ctx context.Context, userID, ip string) error {
+	if userID != "" {
+		if err := lu.check(ctx, lockoutpkg.AccountKey(userID), lu.policy.Account); err != nil {
+			return err
+		}
+	}
+	if ip != "" {
+		return lu.check(ctx, lockoutpkg.IPKey(ip), lu.policy.IP)
+	}
+	return nil
+}
+
+func (lu *LockoutUsecase) check(ctx context.Context, key string, limit lockoutpkg.Limit) error {
+	counter, err := lu.repo.GetCounter(ctx, key)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	if counter.LockedUntil.After(now) {
+		return &lockoutpkg.ThrottledError{RetryAfter: counter.LockedUntil.Sub(now), Locked: true}
+	}
+	if wait := counter.LastFailureAt.Add(lu.delay(counter.Failures, limit)).Sub(now); wait > 0 {
+		return &lockoutpkg.ThrottledError{RetryAfter: wait}
+	}
+	return nil
+}
+
+// delay is how long to wait after the latest of failures before trying again
+func (lu *LockoutUsecase) delay(failures int, limit lockoutpkg.Limit) time.Duration {
+	if failures < limit.FreeAttempts {
+		return 0
+	}
+	d := lu.policy.BaseDelay
+	for i := limit.FreeAttempts; i < failures && d < lu.policy.MaxDelay; i++ {
+		d *= 2
+	}
+	if d > lu.policy.MaxDelay {
+		d = lu.policy.MaxDelay
+	}
+	return d
+}
+
+func (lu *LockoutUsecase) RecordFailure(ctx context.Context, user userpkg.User, device userpkg.DeviceInfo) error {
+	now := time.Now()
+	if device.IPAddress != "" {
+		if _, err := lu.fail(ctx, lockoutpkg.IPKey(device.IPAddress), lu.policy.IP, now); err != nil {
+			return err
+		}
+	}
+	if user.ID.IsZero() {
+		return nil
+	}
+
+	locked, err := lu.fail(ctx, lockoutpkg.AccountKey(user.ID.Hex()), lu.policy.Account, now)
+	if err != nil || !locked {
+		return err
+	}
+	if err := lu.sendLockoutEmail(ctx, user, device, now); err != nil {
+		log.Printf("failed to send lockout email to user %s: %v", user.ID.Hex(), err)
+	}
+	return nil
+}
+
+// fail counts a failure and reports whether it locked the key
+func (lu *LockoutUsecase) fail(ctx context.Context, key string, limit lockoutpkg.Limit, now time.Time) (bool, error) {
+	counter, err := lu.repo.RecordFailure(ctx, key, now, lu.policy.Window)
+	if err != nil {
+		return false, err
+	}
+	if counter.Failures < limit.LockAfter {
+		return false, nil
+	}
+	// Concurrent failures may all cross the limit; only one of them locks
+	return lu.repo.Lock(ctx, key, now.Add(lu.policy.LockoutDuration))
+}
+
+func (lu *LockoutUsecase) sendLockoutEmail(ctx context.Context, user userpkg.User, device userpkg.DeviceInfo, now time.Time) error {
+	unlockURL := ""
+	if lu.unlockURL != "" {
+		token, err := randomToken()
+		if err != nil {
+			return err
+		}
+		err = lu.repo.StoreUnlockToken(ctx, lockoutpkg.UnlockToken{
+			ID:        hashSecret(token),
+			UserID:    user.ID.Hex(),
+			ExpiresAt: now.Add(lu.policy.LockoutDuration),
+		})
+		if err != nil {
+			return err
+		}
+		unlockURL = withQueryParam(lu.unlockURL, "token", token)
+	}
+
+	msg, err := lu.emailRenderer.Render(services.TemplateAccountLocked, user.Language, map[string]interface{}{
+		"Name":          user.Fullname,
+		"Time":          now,
+		"LockedMinutes": int(lu.policy.LockoutDuration.Minutes()),
+		"IPAddress":     device.IPAddress,
+		"UserAgent":     device.UserAgent,
+		"UnlockURL":     unlockURL,
+	})
+	if err != nil {
+		return err
+	}
+	msg.To = user.Email
+	return lu.emailSender.SendEmail(msg)
+}
+
+func (lu *LockoutUsecase) RecordSuccess(ctx context.Context, userID string) error {
+	return lu.repo.ResetCounter(ctx, lockoutpkg.AccountKey(userID))
+}
+
+// Unlock lifts the lockout of the account the link was sent for. The addresses the failures
+// came from stay locked.
+func (lu *LockoutUsecase) Unlock(ctx context.Context, token string) error {
+	unlock, err := lu.repo.ConsumeUnlockToken(ctx, hashSecret(token))
+	if err != nil {
+		return err
+	}
+	if time.Now().After(unlock.ExpiresAt) {
+		return lockoutpkg.ErrUnlockTokenInvalid
+	}
+	return lu.repo.ResetCounter(ctx, lockoutpkg.AccountKey(unlock.UserID))
+}
+
+func withQueryParam(rawURL, key, value string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return rawURL
+	}
+	q := u.Query()
+	q.Set(key, value)
+	u.RawQuery = q.Encode()
+	return u.String()
+}
@@ -0,0 +1,151 @@
+package usecases_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	lockoutpkg "github.com/Amaankaa/Blog-Starter-Project/Domain/lockout"
+	"github.com/Amaankaa/Blog-Starter-Project/Domain/services"
+	userpkg "github.com/Amaankaa/Blog-Starter-Project/Domain/user"
+	usecases "github.com/Amaankaa/Blog-Starter-Project/Usecases"
+	"github.com/Amaankaa/Blog-Starter-Project/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+var testLockoutPolicy = lockoutpkg.Policy{
+	Account:         lockoutpkg.Limit{FreeAttempts: 3, LockAfter: 5},
+	IP:              lockoutpkg.Limit{FreeAttempts: 10, LockAfter: 20},
+	BaseDelay:       time.Second,
+	MaxDelay:        8 * time.Second,
+	LockoutDuration: 15 * time.Minute,
+	Window:          15 * time.Minute,
+}
+
+type LockoutUsecaseSuite struct {
+	suite.Suite
+	ctx      context.Context
+	repo     *mocks.ILoginAttemptRepository
+	sender   *mocks.IEmailSender
+	renderer *mocks.IEmailRenderer
+	usecase  *usecases.LockoutUsecase
+	user     userpkg.User
+	device   userpkg.DeviceInfo
+}
+
+func TestLockoutUsecaseSuite(t *testing.T) {
+	suite.Run(t, new(LockoutUsecaseSuite))
+}
+
+func (s *LockoutUsecaseSuite) SetupTest() {
+	s.ctx = context.Background()
+	s.repo = mocks.NewILoginAttemptRepository(s.T())
+	s.sender = mocks.NewIEmailSender(s.T())
+	s.renderer = mocks.NewIEmailRenderer(s.T())
+	s.usecase = usecases.NewLockoutUsecase(s.repo, s.sender, s.renderer, testLockoutPolicy, "https://app.example.com/unlock")
+	s.user = userpkg.User{ID: primitive.NewObjectID(), Email: "jane@example.com", Fullname: "Jane", Language: "es"}
+	s.device = userpkg.DeviceInfo{IPAddress: "203.0.113.7", UserAgent: "curl"}
+}
+
+func (s *LockoutUsecaseSuite) accountKey() string {
+	return lockoutpkg.AccountKey(s.user.ID.Hex())
+}
+
+func (s *LockoutUsecaseSuite) TestCheck_AllowsFreeAttempts() {
+	s.repo.On("GetCounter", s.ctx, s.accountKey()).Return(lockoutpkg.Counter{Failures: 2, LastFailureAt: time.Now()}, nil).Once()
+	s.repo.On("GetCounter", s.ctx, lockoutpkg.IPKey("203.0.113.7")).Return(lockoutpkg.Counter{}, nil).Once()
+
+	s.NoError(s.usecase.Check(s.ctx, s.user.ID.Hex(), "203.0.113.7"))
+}
+
+func (s *LockoutUsecaseSuite) TestCheck_DelaysDoubleUpToTheMaximum() {
+	cases := map[int]time.Duration{3: time.Second, 4: 2 * time.Second, 5: 4 * time.Second, 9: 8 * time.Second}
+	for failures, want := range cases {
+		s.repo.On("GetCounter", s.ctx, s.accountKey()).Return(lockoutpkg.Counter{Failures: failures, LastFailureAt: time.Now()}, nil).Once()
+
+		err := s.usecase.Check(s.ctx, s.user.ID.Hex(), "")
+
+		var throttled *lockoutpkg.ThrottledError
+		s.Require().ErrorAs(err, &throttled, "failures=%d", failures)
+		s.False(throttled.Locked)
+		s.InDelta(want.Seconds(), throttled.RetryAfter.Seconds(), 0.5, "failures=%d", failures)
+	}
+}
+
+func (s *LockoutUsecaseSuite) TestCheck_DelayPassed() {
+	s.repo.On("GetCounter", s.ctx, s.accountKey()).Return(lockoutpkg.Counter{Failures: 3, LastFailureAt: time.Now().Add(-2 * time.Second)}, nil).Once()
+
+	s.NoError(s.usecase.Check(s.ctx, s.user.ID.Hex(), ""))
+}
+
+func (s *LockoutUsecaseSuite) TestCheck_LockedAddress() {
+	s.repo.On("GetCounter", s.ctx, lockoutpkg.IPKey("203.0.113.7")).Return(lockoutpkg.Counter{LockedUntil: time.Now().Add(10 * time.Minute)}, nil).Once()
+
+	err := s.usecase.Check(s.ctx, "", "203.0.113.7")
+
+	var throttled *lockoutpkg.ThrottledError
+	s.Require().ErrorAs(err, &throttled)
+	s.True(throttled.Locked)
+	s.InDelta((10 * time.Minute).Seconds(), throttled.RetryAfter.Seconds(), 1)
+}
+
+func (s *LockoutUsecaseSuite) TestRecordFailure_BelowTheLimit() {
+	s.repo.On("RecordFailure", s.ctx, lockoutpkg.IPKey("203.0.113.7"), mock.Anything, testLockoutPolicy.Window).Return(lockoutpkg.Counter{Failures: 1}, nil).Once()
+	s.repo.On("RecordFailure", s.ctx, s.accountKey(), mock.Anything, testLockoutPolicy.Window).Return(lockoutpkg.Counter{Failures: 4}, nil).Once()
+
+	s.NoError(s.usecase.RecordFailure(s.ctx, s.user, s.device))
+}
+
+func (s *LockoutUsecaseSuite) TestRecordFailure_UnknownLoginOnlyCountsTheAddress() {
+	s.repo.On("RecordFailure", s.ctx, lockoutpkg.IPKey("203.0.113.7"), mock.Anything, testLockoutPolicy.Window).Return(lockoutpkg.Counter{Failures: 1}, nil).Once()
+
+	s.NoError(s.usecase.RecordFailure(s.ctx, userpkg.User{}, s.device))
+}
+
+func (s *LockoutUsecaseSuite) TestRecordFailure_LocksAndEmailsAnUnlockLink() {
+	s.repo.On("RecordFailure", s.ctx, lockoutpkg.IPKey("203.0.113.7"), mock.Anything, testLockoutPolicy.Window).Return(lockoutpkg.Counter{Failures: 5}, nil).Once()
+	s.repo.On("RecordFailure", s.ctx, s.accountKey(), mock.Anything, testLockoutPolicy.Window).Return(lockoutpkg.Counter{Failures: 5}, nil).Once()
+	s.repo.On("Lock", s.ctx, s.accountKey(), mock.Anything).Return(true, nil).Once()
+	var stored lockoutpkg.UnlockToken
+	s.repo.On("StoreUnlockToken", s.ctx, mock.Anything).Run(func(args mock.Arguments) {
+		stored = args.Get(1).(lockoutpkg.UnlockToken)
+	}).Return(nil).Once()
+	var unlockURL string
+	s.renderer.On("Render", services.TemplateAccountLocked, "es", mock.Anything).Run(func(args mock.Arguments) {
+		unlockURL = args.Get(2).(map[string]interface{})["UnlockURL"].(string)
+	}).Return(services.EmailMessage{Subject: "locked"}, nil).Once()
+	s.sender.On("SendEmail", mock.MatchedBy(func(m services.EmailMessage) bool { return m.To == "jane@example.com" })).Return(nil).Once()
+
+	s.NoError(s.usecase.RecordFailure(s.ctx, s.user, s.device))
+
+	s.Equal(s.user.ID.Hex(), stored.UserID)
+	s.True(strings.HasPrefix(unlockURL, "https://app.example.com/unlock?token="))
+	token := strings.TrimPrefix(unlockURL, "https://app.example.com/unlock?token=")
+	s.Equal(sha256Hex(token), stored.ID)
+}
+
+func (s *LockoutUsecaseSuite) TestRecordFailure_AlreadyLockedSendsNoEmail() {
+	s.repo.On("RecordFailure", s.ctx, lockoutpkg.IPKey("203.0.113.7"), mock.Anything, testLockoutPolicy.Window).Return(lockoutpkg.Counter{Failures: 5}, nil).Once()
+	s.repo.On("RecordFailure", s.ctx, s.accountKey(), mock.Anything, testLockoutPolicy.Window).Return(lockoutpkg.Counter{Failures: 6}, nil).Once()
+	s.repo.On("Lock", s.ctx, s.accountKey(), mock.Anything).Return(false, nil).Once()
+
+	s.NoError(s.usecase.RecordFailure(s.ctx, s.user, s.device))
+}
+
+func (s *LockoutUsecaseSuite) TestUnlock_ResetsTheAccount() {
+	s.repo.On("ConsumeUnlockToken", s.ctx, sha256Hex("token")).
+		Return(lockoutpkg.UnlockToken{UserID: s.user.ID.Hex(), ExpiresAt: time.Now().Add(time.Minute)}, nil).Once()
+	s.repo.On("ResetCounter", s.ctx, s.accountKey()).Return(nil).Once()
+
+	s.NoError(s.usecase.Unlock(s.ctx, "token"))
+}
+
+func (s *LockoutUsecaseSuite) TestUnlock_Expired() {
+	s.repo.On("ConsumeUnlockToken", s.ctx, sha256Hex("token")).
+		Return(lockoutpkg.UnlockToken{UserID: s.user.ID.Hex(), ExpiresAt: time.Now().Add(-time.Minute)}, nil).Once()
+
+	s.ErrorIs(s.usecase.Unlock(s.ctx, "token"), lockoutpkg.ErrUnlockTokenInvalid)
+}
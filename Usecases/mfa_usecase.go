@@ -0,0 +1,299 @@
+package usecases
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"log"
+	"math/big"
+	"strings"
+	"time"
+
+	lockoutpkg "github.com/Amaankaa/Blog-Starter-Project/Domain/lockout"
+	mfapkg "github.com/Amaankaa/Blog-Starter-Project/Domain/mfa"
+	otppkg "github.com/Amaankaa/Blog-Starter-Project/Domain/otp"
+	userpkg "github.com/Amaankaa/Blog-Starter-Project/Domain/user"
+)
+
+const recoveryCodeCount = 10
+
+// recoveryCodeAlphabet leaves out characters that are easily confused when typed from paper
+const recoveryCodeAlphabet = "abcdefghjkmnpqrstuvwxyz23456789"
+
+// MFAUsecase manages TOTP enrollment and recovery codes, checks the second step of a login
+// and holds the site-wide two-factor policy
+type MFAUsecase struct {
+	repo       mfapkg.IMFARepository
+	totp       mfapkg.ITOTPService
+	otp        otppkg.IOTPService
+	userRepo   userpkg.IUserRepository
+	loginGuard lockoutpkg.ILoginGuard
+}
+
+func NewMFAUsecase(repo mfapkg.IMFARepository, totp mfapkg.ITOTPService, otp otppkg.IOTPService, userRepo userpkg.IUserRepository, loginGuard lockoutpkg.ILoginGuard) *MFAUsecase {
+	return &MFAUsecase{repo: repo, totp: totp, otp: otp, userRepo: userRepo, loginGuard: loginGuard}
+}
+
+func (mu *MFAUsecase) Status(ctx context.Context, userID string) (mfapkg.Status, error) {
+	enrollment, err := mu.repo.FindEnrollment(ctx, userID)
+	if errors.Is(err, mfapkg.ErrNotEnrolled) {
+		return mfapkg.Status{}, nil
+	}
+	if err != nil {
+		return mfapkg.Status{}, err
+	}
+	if !enrollment.Enabled {
+		return mfapkg.Status{}, nil
+	}
+	return mfapkg.Status{Enabled: true, RecoveryCodesRemaining: len(enrollment.RecoveryCodes)}, nil
+}
+
+func (mu *MFAUsecase) BeginSetup(ctx context.Context, userID, accountName string) (mfapkg.Setup, error) {
+	existing, err := mu.repo.FindEnrollment(ctx, userID)
+	if err != nil && !errors.Is(err, mfapkg.ErrNotEnrolled) {
+		return mfapkg.Setup{}, err
+	}
+	if err == nil && existing.Enabled {
+		return mfapkg.Setup{}, errors.New("two-factor authentication is already enabled")
+	}
+
+	// Starting over replaces any unconfirmed secret
+	secret, err := mu.totp.GenerateSecret()
+	if err != nil {
+		return mfapkg.Setup{}, err
+	}
+	err = mu.repo.SaveEnrollment(ctx, mfapkg.Enrollment{
+		UserID:    userID,
+		Secret:    secret,
+		CreatedAt: time.Now(),
+	})
+	if err != nil {
+		return mfapkg.Setup{}, err
+	}
+	return mfapkg.Setup{Secret: secret, ProvisioningURI: mu.totp.ProvisioningURI(secret, accountName)}, nil
+}
+
+func (mu *MFAUsecase) Enable(ctx context.Context, userID, code string) ([]string, error) {
+	enrollment, err := mu.repo.FindEnrollment(ctx, userID)
+	if errors.Is(err, mfapkg.ErrNotEnrolled) {
+		return nil, errors.New("start two-factor setup first")
+	}
+	if err != nil {
+		return nil, err
+	}
+	if enrollment.Enabled {
+		return nil, errors.New("two-factor authentication is already enabled")
+	}
+
+	// Only a TOTP code proves the app was set up; there are no recovery codes yet
+	step, ok := mu.totp.Validate(enrollment.Secret, strings.TrimSpace(code), time.Now())
+	if !ok {
+		return nil, mfapkg.ErrInvalidCode
+	}
+	codes, hashes, err := generateRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+
+	enrollment.Enabled = true
+	enrollment.EnabledAt = time.Now()
+	enrollment.LastUsedStep = step
+	enrollment.RecoveryCodes = hashes
+	if err := mu.repo.SaveEnrollment(ctx, enrollment); err != nil {
+		return nil, err
+	}
+	return codes, nil
+}
+
+func (mu *MFAUsecase) Disable(ctx context.Context, userID, code string, device userpkg.DeviceInfo) error {
+	if err := mu.verifyGuarded(ctx, userID, code, device); err != nil {
+		return err
+	}
+	return mu.repo.DeleteEnrollment(ctx, userID)
+}
+
+// RegenerateRecoveryCodes replaces every remaining recovery code
+func (mu *MFAUsecase) RegenerateRecoveryCodes(ctx context.Context, userID, code string, device userpkg.DeviceInfo) ([]string, error) {
+	if err := mu.verifyGuarded(ctx, userID, code, device); err != nil {
+		return nil, err
+	}
+	codes, hashes, err := generateRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+	if err := mu.repo.ReplaceRecoveryCodes(ctx, userID, hashes); err != nil {
+		return nil, err
+	}
+	return codes, nil
+}
+
+func (mu *MFAUsecase) IsEnabled(ctx context.Context, userID string) (bool, error) {
+	status, err := mu.Status(ctx, userID)
+	return status.Enabled, err
+}
+
+// StartChallenge keys the login's challenge by the token's hash; the token itself is the
+// client's to keep
+func (mu *MFAUsecase) StartChallenge(ctx context.Context, userID string) (string, time.Time, error) {
+	token, err := randomToken()
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	_, expiresAt, err := mu.otp.Issue(ctx, otppkg.PurposeMFA, hashSecret(token), userID)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	return token, expiresAt, nil
+}
+
+func (mu *MFAUsecase) ChallengeUser(ctx context.Context, challengeToken string) (string, error) {
+	challenge, err := mu.otp.Find(ctx, otppkg.PurposeMFA, hashSecret(challengeToken))
+	if errors.Is(err, otppkg.ErrChallengeNotFound) {
+		return "", mfapkg.ErrChallengeNotFound
+	}
+	if err != nil {
+		return "", err
+	}
+	return challenge.Data, nil
+}
+
+func (mu *MFAUsecase) CompleteChallenge(ctx context.Context, challengeToken, code string) (string, error) {
+	userID := ""
+	challenge, err := mu.otp.Attempt(ctx, otppkg.PurposeMFA, hashSecret(challengeToken), func(challenge otppkg.Challenge) (bool, error) {
+		userID = challenge.Data
+		err := mu.verify(ctx, challenge.Data, code)
+		if errors.Is(err, mfapkg.ErrInvalidCode) {
+			return false, nil
+		}
+		return err == nil, err
+	})
+	switch {
+	case errors.Is(err, otppkg.ErrInvalidCode):
+		return userID, mfapkg.ErrInvalidCode
+	case errors.Is(err, otppkg.ErrChallengeNotFound), errors.Is(err, otppkg.ErrTooManyAttempts):
+		return "", mfapkg.ErrChallengeNotFound
+	case err != nil:
+		return "", err
+	}
+	return challenge.Data, nil
+}
+
+// verifyGuarded is verify for signed-in users changing their settings. A stolen session could
+// otherwise guess codes until it turned two-factor authentication off, so wrong codes count
+// like wrong passwords. The login flow counts its own failures in CompleteMFALogin.
+func (mu *MFAUsecase) verifyGuarded(ctx context.Context, userID, code string, device userpkg.DeviceInfo) error {
+	if err := mu.loginGuard.Check(ctx, userID, device.IPAddress); err != nil {
+		return err
+	}
+	err := mu.verify(ctx, userID, code)
+	if !errors.Is(err, mfapkg.ErrInvalidCode) {
+		return err
+	}
+	user, lookupErr := mu.userRepo.FindByID(ctx, userID)
+	if lookupErr != nil {
+		log.Printf("failed to look up user %s after a wrong two-factor code: %v", userID, lookupErr)
+	}
+	// Without the user only the address is counted
+	if recordErr := mu.loginGuard.RecordFailure(ctx, user, device); recordErr != nil {
+		log.Printf("failed to record a wrong two-factor code from %s: %v", device.IPAddress, recordErr)
+	}
+	return err
+}
+
+// verify accepts a current TOTP code that hasn't been used yet, or an unused recovery code
+func (mu *MFAUsecase) verify(ctx context.Context, userID, code string) error {
+	enrollment, err := mu.repo.FindEnrollment(ctx, userID)
+	if errors.Is(err, mfapkg.ErrNotEnrolled) || (err == nil && !enrollment.Enabled) {
+		return mfapkg.ErrNotEnrolled
+	}
+	if err != nil {
+		return err
+	}
+
+	code = strings.TrimSpace(code)
+	if step, ok := mu.totp.Validate(enrollment.Secret, code, time.Now()); ok {
+		recorded, err := mu.repo.RecordUsedStep(ctx, userID, step)
+		if err != nil {
+			return err
+		}
+		if !recorded {
+			return mfapkg.ErrInvalidCode // replayed
+		}
+		return nil
+	}
+
+	consumed, err := mu.repo.ConsumeRecoveryCode(ctx, userID, hashSecret(normalizeRecoveryCode(code)))
+	if err != nil {
+		return err
+	}
+	if !consumed {
+		return mfapkg.ErrInvalidCode
+	}
+	return nil
+}
+
+func (mu *MFAUsecase) GetPolicy(ctx context.Context) (mfapkg.Policy, error) {
+	return mu.repo.GetPolicy(ctx)
+}
+
+func (mu *MFAUsecase) SetPolicy(ctx context.Context, policy mfapkg.Policy, actorID string) (mfapkg.Policy, error) {
+	if policy.RequireForAdmins {
+		// Otherwise the admin turning it on would be locked out of the admin routes
+		enabled, err := mu.IsEnabled(ctx, actorID)
+		if err != nil {
+			return mfapkg.Policy{}, err
+		}
+		if !enabled {
+			return mfapkg.Policy{}, errors.New("enable two-factor authentication on your own account first")
+		}
+	}
+	if err := mu.repo.SavePolicy(ctx, policy); err != nil {
+		return mfapkg.Policy{}, err
+	}
+	return policy, nil
+}
+
+func (mu *MFAUsecase) AdminsRequireMFA(ctx context.Context) (bool, error) {
+	policy, err := mu.repo.GetPolicy(ctx)
+	return policy.RequireForAdmins, err
+}
+
+// generateRecoveryCodes returns codes formatted for the user ("xxxxx-xxxxx") and their hashes
+func generateRecoveryCodes() ([]string, []string, error) {
+	codes := make([]string, recoveryCodeCount)
+	hashes := make([]string, recoveryCodeCount)
+	for i := range codes {
+		b := make([]byte, 10)
+		for j := range b {
+			n, err := rand.Int(rand.Reader, big.NewInt(int64(len(recoveryCodeAlphabet))))
+			if err != nil {
+				return nil, nil, err
+			}
+			b[j] = recoveryCodeAlphabet[n.Int64()]
+		}
+		codes[i] = string(b[:5]) + "-" + string(b[5:])
+		hashes[i] = hashSecret(string(b))
+	}
+	return codes, hashes, nil
+}
+
+func normalizeRecoveryCode(code string) string {
+	code = strings.ToLower(code)
+	return strings.NewReplacer("-", "", " ", "").Replace(code)
+}
+
+func randomToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// hashSecret is for high-entropy values only; passwords and short codes need a slow hash
+func hashSecret(s string) string {
+	sum := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(sum[:])
+}
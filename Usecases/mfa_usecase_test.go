@@ -0,0 +1,303 @@
+package usecases_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	lockoutpkg "github.com/Amaankaa/Blog-Starter-Project/Domain/lockout"
+	mfapkg "github.com/Amaankaa/Blog-Starter-Project/Domain/mfa"
+	otppkg "github.com/Amaankaa/Blog-Starter-Project/Domain/otp"
+	userpkg "github.com/Amaankaa/Blog-Starter-Project/Domain/user"
+	usecases "github.com/Amaankaa/Blog-Starter-Project/Usecases"
+	"github.com/Amaankaa/Blog-Starter-Project/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type MFAUsecaseSuite struct {
+	suite.Suite
+	ctx     context.Context
+	repo    *mocks.IMFARepository
+	totp    *mocks.ITOTPService
+	otpRepo *mocks.IOTPRepository
+	users   *mocks.IUserRepository
+	guard   *mocks.ILoginGuard
+	usecase *usecases.MFAUsecase
+}
+
+func TestMFAUsecaseSuite(t *testing.T) {
+	suite.Run(t, new(MFAUsecaseSuite))
+}
+
+func (s *MFAUsecaseSuite) SetupTest() {
+	s.ctx = context.Background()
+	s.repo = mocks.NewIMFARepository(s.T())
+	s.totp = mocks.NewITOTPService(s.T())
+	s.otpRepo = mocks.NewIOTPRepository(s.T())
+	otp := usecases.NewOTPUsecase(s.otpRepo, mocks.NewIPasswordService(s.T()), otppkg.DefaultPolicies)
+	s.users = mocks.NewIUserRepository(s.T())
+	s.guard = mocks.NewILoginGuard(s.T())
+	s.usecase = usecases.NewMFAUsecase(s.repo, s.totp, otp, s.users, s.guard)
+}
+
+func sha256Hex(s string) string {
+	sum := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(sum[:])
+}
+
+var settingsDevice = userpkg.DeviceInfo{UserAgent: "test", IPAddress: "203.0.113.9"}
+
+var enabledEnrollment = mfapkg.Enrollment{UserID: "u1", Secret: "SECRET", Enabled: true, RecoveryCodes: []string{"h1", "h2"}}
+
+func (s *MFAUsecaseSuite) TestBeginSetup() {
+	s.repo.On("FindEnrollment", s.ctx, "u1").Return(mfapkg.Enrollment{}, mfapkg.ErrNotEnrolled).Once()
+	s.totp.On("GenerateSecret").Return("SECRET", nil).Once()
+	s.totp.On("ProvisioningURI", "SECRET", "jane").Return("otpauth://totp/Blog:jane?secret=SECRET").Once()
+	s.repo.On("SaveEnrollment", s.ctx, mock.MatchedBy(func(e mfapkg.Enrollment) bool {
+		return e.UserID == "u1" && e.Secret == "SECRET" && !e.Enabled
+	})).Return(nil).Once()
+
+	setup, err := s.usecase.BeginSetup(s.ctx, "u1", "jane")
+
+	s.NoError(err)
+	s.Equal(mfapkg.Setup{Secret: "SECRET", ProvisioningURI: "otpauth://totp/Blog:jane?secret=SECRET"}, setup)
+}
+
+func (s *MFAUsecaseSuite) TestBeginSetup_AlreadyEnabled() {
+	s.repo.On("FindEnrollment", s.ctx, "u1").Return(enabledEnrollment, nil).Once()
+
+	_, err := s.usecase.BeginSetup(s.ctx, "u1", "jane")
+
+	s.EqualError(err, "two-factor authentication is already enabled")
+}
+
+func (s *MFAUsecaseSuite) TestEnable_ReturnsRecoveryCodes() {
+	s.repo.On("FindEnrollment", s.ctx, "u1").Return(mfapkg.Enrollment{UserID: "u1", Secret: "SECRET"}, nil).Once()
+	s.totp.On("Validate", "SECRET", "123456", mock.Anything).Return(int64(42), true).Once()
+	var saved mfapkg.Enrollment
+	s.repo.On("SaveEnrollment", s.ctx, mock.Anything).Run(func(args mock.Arguments) {
+		saved = args.Get(1).(mfapkg.Enrollment)
+	}).Return(nil).Once()
+
+	codes, err := s.usecase.Enable(s.ctx, "u1", " 123456 ")
+
+	s.Require().NoError(err)
+	s.Len(codes, 10)
+	s.Regexp(regexp.MustCompile(`^[a-z2-9]{5}-[a-z2-9]{5}$`), codes[0])
+	s.True(saved.Enabled)
+	s.Equal(int64(42), saved.LastUsedStep)
+	s.Len(saved.RecoveryCodes, 10)
+	s.NotContains(saved.RecoveryCodes, codes[0], "only hashes are stored")
+}
+
+func (s *MFAUsecaseSuite) TestEnable_WrongCode() {
+	s.repo.On("FindEnrollment", s.ctx, "u1").Return(mfapkg.Enrollment{UserID: "u1", Secret: "SECRET"}, nil).Once()
+	s.totp.On("Validate", "SECRET", "000000", mock.Anything).Return(int64(0), false).Once()
+
+	_, err := s.usecase.Enable(s.ctx, "u1", "000000")
+
+	s.ErrorIs(err, mfapkg.ErrInvalidCode)
+}
+
+func (s *MFAUsecaseSuite) TestStartChallenge_StoresOnlyTheHash() {
+	var stored otppkg.Challenge
+	s.otpRepo.On("Issue", s.ctx, mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		stored = args.Get(1).(otppkg.Challenge)
+	}).Return(true, nil).Once()
+
+	token, expiresAt, err := s.usecase.StartChallenge(s.ctx, "u1")
+
+	s.Require().NoError(err)
+	s.Equal(otppkg.PurposeMFA, stored.Purpose)
+	s.Equal(sha256Hex(token), stored.Subject)
+	s.Equal("u1", stored.Data)
+	s.Empty(stored.CodeHash)
+	s.WithinDuration(time.Now().Add(5*time.Minute), expiresAt, time.Second)
+}
+
+func (s *MFAUsecaseSuite) TestChallengeUser_TakesNoAttempt() {
+	id := otppkg.ChallengeID(otppkg.PurposeMFA, sha256Hex("challenge"))
+	s.otpRepo.On("Find", s.ctx, id).Return(otppkg.Challenge{ID: id, Data: "u1", ExpiresAt: time.Now().Add(time.Minute)}, nil).Once()
+
+	userID, err := s.usecase.ChallengeUser(s.ctx, "challenge")
+
+	s.NoError(err)
+	s.Equal("u1", userID)
+	s.otpRepo.AssertNotCalled(s.T(), "TakeAttempt", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (s *MFAUsecaseSuite) TestChallengeUser_Expired() {
+	id := otppkg.ChallengeID(otppkg.PurposeMFA, sha256Hex("challenge"))
+	s.otpRepo.On("Find", s.ctx, id).Return(otppkg.Challenge{ID: id, Data: "u1", ExpiresAt: time.Now().Add(-time.Second)}, nil).Once()
+
+	_, err := s.usecase.ChallengeUser(s.ctx, "challenge")
+
+	s.ErrorIs(err, mfapkg.ErrChallengeNotFound)
+}
+
+func (s *MFAUsecaseSuite) TestCompleteChallenge_WithTOTP() {
+	id := otppkg.ChallengeID(otppkg.PurposeMFA, sha256Hex("challenge"))
+	s.otpRepo.On("TakeAttempt", s.ctx, id, 5).Return(otppkg.Challenge{ID: id, Data: "u1", ExpiresAt: time.Now().Add(time.Minute)}, nil).Once()
+	s.repo.On("FindEnrollment", s.ctx, "u1").Return(enabledEnrollment, nil).Once()
+	s.totp.On("Validate", "SECRET", "123456", mock.Anything).Return(int64(42), true).Once()
+	s.repo.On("RecordUsedStep", s.ctx, "u1", int64(42)).Return(true, nil).Once()
+	s.otpRepo.On("Delete", s.ctx, id).Return(nil).Once()
+
+	userID, err := s.usecase.CompleteChallenge(s.ctx, "challenge", "123456")
+
+	s.NoError(err)
+	s.Equal("u1", userID)
+}
+
+func (s *MFAUsecaseSuite) TestCompleteChallenge_ReplayedTOTPCountsAsWrong() {
+	id := otppkg.ChallengeID(otppkg.PurposeMFA, sha256Hex("challenge"))
+	s.otpRepo.On("TakeAttempt", s.ctx, id, 5).Return(otppkg.Challenge{ID: id, Data: "u1", ExpiresAt: time.Now().Add(time.Minute)}, nil).Once()
+	s.repo.On("FindEnrollment", s.ctx, "u1").Return(enabledEnrollment, nil).Once()
+	s.totp.On("Validate", "SECRET", "123456", mock.Anything).Return(int64(42), true).Once()
+	s.repo.On("RecordUsedStep", s.ctx, "u1", int64(42)).Return(false, nil).Once()
+
+	_, err := s.usecase.CompleteChallenge(s.ctx, "challenge", "123456")
+
+	s.ErrorIs(err, mfapkg.ErrInvalidCode)
+}
+
+func (s *MFAUsecaseSuite) TestCompleteChallenge_WithRecoveryCode() {
+	id := otppkg.ChallengeID(otppkg.PurposeMFA, sha256Hex("challenge"))
+	s.otpRepo.On("TakeAttempt", s.ctx, id, 5).Return(otppkg.Challenge{ID: id, Data: "u1", ExpiresAt: time.Now().Add(time.Minute)}, nil).Once()
+	s.repo.On("FindEnrollment", s.ctx, "u1").Return(enabledEnrollment, nil).Once()
+	s.totp.On("Validate", "SECRET", "ABCDE-FGHJK", mock.Anything).Return(int64(0), false).Once()
+	// Codes are accepted regardless of case and dashes
+	s.repo.On("ConsumeRecoveryCode", s.ctx, "u1", sha256Hex("abcdefghjk")).Return(true, nil).Once()
+	s.otpRepo.On("Delete", s.ctx, id).Return(nil).Once()
+
+	userID, err := s.usecase.CompleteChallenge(s.ctx, "challenge", "ABCDE-FGHJK")
+
+	s.NoError(err)
+	s.Equal("u1", userID)
+}
+
+func (s *MFAUsecaseSuite) TestCompleteChallenge_WrongCode() {
+	id := otppkg.ChallengeID(otppkg.PurposeMFA, sha256Hex("challenge"))
+	s.otpRepo.On("TakeAttempt", s.ctx, id, 5).Return(otppkg.Challenge{ID: id, Data: "u1", ExpiresAt: time.Now().Add(time.Minute)}, nil).Once()
+	s.repo.On("FindEnrollment", s.ctx, "u1").Return(enabledEnrollment, nil).Once()
+	s.totp.On("Validate", "SECRET", "999999", mock.Anything).Return(int64(0), false).Once()
+	s.repo.On("ConsumeRecoveryCode", s.ctx, "u1", mock.Anything).Return(false, nil).Once()
+
+	userID, err := s.usecase.CompleteChallenge(s.ctx, "challenge", "999999")
+
+	s.ErrorIs(err, mfapkg.ErrInvalidCode)
+	s.Equal("u1", userID, "the wrong code counts against the account")
+}
+
+func (s *MFAUsecaseSuite) TestCompleteChallenge_TooManyAttempts() {
+	id := otppkg.ChallengeID(otppkg.PurposeMFA, sha256Hex("challenge"))
+	s.otpRepo.On("TakeAttempt", s.ctx, id, 5).Return(otppkg.Challenge{}, otppkg.ErrTooManyAttempts).Once()
+	s.otpRepo.On("Delete", s.ctx, id).Return(nil).Once()
+
+	_, err := s.usecase.CompleteChallenge(s.ctx, "challenge", "123456")
+
+	s.ErrorIs(err, mfapkg.ErrChallengeNotFound)
+}
+
+func (s *MFAUsecaseSuite) TestCompleteChallenge_Expired() {
+	id := otppkg.ChallengeID(otppkg.PurposeMFA, sha256Hex("challenge"))
+	s.otpRepo.On("TakeAttempt", s.ctx, id, 5).Return(otppkg.Challenge{ID: id, Data: "u1", ExpiresAt: time.Now().Add(-time.Second)}, nil).Once()
+	s.otpRepo.On("Delete", s.ctx, id).Return(nil).Once()
+
+	_, err := s.usecase.CompleteChallenge(s.ctx, "challenge", "123456")
+
+	s.ErrorIs(err, mfapkg.ErrChallengeNotFound)
+}
+
+func (s *MFAUsecaseSuite) TestDisable_RequiresCode() {
+	s.guard.On("Check", s.ctx, "u1", settingsDevice.IPAddress).Return(nil).Once()
+	s.repo.On("FindEnrollment", s.ctx, "u1").Return(enabledEnrollment, nil).Once()
+	s.totp.On("Validate", "SECRET", "123456", mock.Anything).Return(int64(42), true).Once()
+	s.repo.On("RecordUsedStep", s.ctx, "u1", int64(42)).Return(true, nil).Once()
+	s.repo.On("DeleteEnrollment", s.ctx, "u1").Return(nil).Once()
+
+	s.NoError(s.usecase.Disable(s.ctx, "u1", "123456", settingsDevice))
+}
+
+func (s *MFAUsecaseSuite) TestDisable_NotEnrolled() {
+	s.guard.On("Check", s.ctx, "u1", settingsDevice.IPAddress).Return(nil).Once()
+	s.repo.On("FindEnrollment", s.ctx, "u1").Return(mfapkg.Enrollment{UserID: "u1", Secret: "SECRET"}, nil).Once()
+
+	s.ErrorIs(s.usecase.Disable(s.ctx, "u1", "123456", settingsDevice), mfapkg.ErrNotEnrolled)
+}
+
+func (s *MFAUsecaseSuite) TestDisable_WrongCodeCountsAgainstTheAccount() {
+	user := userpkg.User{ID: primitive.NewObjectID(), Email: "jane@example.com"}
+	s.guard.On("Check", s.ctx, "u1", settingsDevice.IPAddress).Return(nil).Once()
+	s.repo.On("FindEnrollment", s.ctx, "u1").Return(enabledEnrollment, nil).Once()
+	s.totp.On("Validate", "SECRET", "000000", mock.Anything).Return(int64(0), false).Once()
+	s.repo.On("ConsumeRecoveryCode", s.ctx, "u1", mock.Anything).Return(false, nil).Once()
+	s.users.On("FindByID", s.ctx, "u1").Return(user, nil).Once()
+	s.guard.On("RecordFailure", s.ctx, user, settingsDevice).Return(nil).Once()
+
+	s.ErrorIs(s.usecase.Disable(s.ctx, "u1", "000000", settingsDevice), mfapkg.ErrInvalidCode)
+}
+
+func (s *MFAUsecaseSuite) TestDisable_ThrottledBeforeTheCodeIsChecked() {
+	s.guard.On("Check", s.ctx, "u1", settingsDevice.IPAddress).Return(&lockoutpkg.ThrottledError{Locked: true}).Once()
+
+	var throttled *lockoutpkg.ThrottledError
+	s.ErrorAs(s.usecase.Disable(s.ctx, "u1", "123456", settingsDevice), &throttled)
+}
+
+func (s *MFAUsecaseSuite) TestRegenerateRecoveryCodes() {
+	s.guard.On("Check", s.ctx, "u1", settingsDevice.IPAddress).Return(nil).Once()
+	s.repo.On("FindEnrollment", s.ctx, "u1").Return(enabledEnrollment, nil).Once()
+	s.totp.On("Validate", "SECRET", "123456", mock.Anything).Return(int64(42), true).Once()
+	s.repo.On("RecordUsedStep", s.ctx, "u1", int64(42)).Return(true, nil).Once()
+	s.repo.On("ReplaceRecoveryCodes", s.ctx, "u1", mock.MatchedBy(func(hashes []string) bool { return len(hashes) == 10 })).Return(nil).Once()
+
+	codes, err := s.usecase.RegenerateRecoveryCodes(s.ctx, "u1", "123456", settingsDevice)
+
+	s.NoError(err)
+	s.Len(codes, 10)
+}
+
+func (s *MFAUsecaseSuite) TestStatus() {
+	s.repo.On("FindEnrollment", s.ctx, "u1").Return(enabledEnrollment, nil).Once()
+	status, err := s.usecase.Status(s.ctx, "u1")
+	s.NoError(err)
+	s.Equal(mfapkg.Status{Enabled: true, RecoveryCodesRemaining: 2}, status)
+
+	s.repo.On("FindEnrollment", s.ctx, "u2").Return(mfapkg.Enrollment{}, mfapkg.ErrNotEnrolled).Once()
+	status, err = s.usecase.Status(s.ctx, "u2")
+	s.NoError(err)
+	s.False(status.Enabled)
+}
+
+func (s *MFAUsecaseSuite) TestSetPolicy_ActorMustHaveMFA() {
+	s.repo.On("FindEnrollment", s.ctx, "admin-1").Return(mfapkg.Enrollment{}, mfapkg.ErrNotEnrolled).Once()
+
+	_, err := s.usecase.SetPolicy(s.ctx, mfapkg.Policy{RequireForAdmins: true}, "admin-1")
+
+	s.EqualError(err, "enable two-factor authentication on your own account first")
+}
+
+func (s *MFAUsecaseSuite) TestSetPolicy() {
+	s.repo.On("FindEnrollment", s.ctx, "admin-1").Return(enabledEnrollment, nil).Once()
+	s.repo.On("SavePolicy", s.ctx, mfapkg.Policy{RequireForAdmins: true}).Return(nil).Once()
+
+	policy, err := s.usecase.SetPolicy(s.ctx, mfapkg.Policy{RequireForAdmins: true}, "admin-1")
+
+	s.NoError(err)
+	s.True(policy.RequireForAdmins)
+}
+
+func (s *MFAUsecaseSuite) TestAdminsRequireMFA() {
+	s.repo.On("GetPolicy", s.ctx).Return(mfapkg.Policy{}, errors.New("db down")).Once()
+
+	_, err := s.usecase.AdminsRequireMFA(s.ctx)
+
+	s.Error(err)
+}
@@ -0,0 +1,132 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	blogpkg "github.com/Amaankaa/Blog-Starter-Project/Domain/blog"
+)
+
+// reportQueueLimit caps how many reports one listing returns
+const reportQueueLimit = 100
+
+// ModerationUsecase lets editors review posts and moderators remove comments and work through reports.
+// Who may call what is decided by the router's permission checks.
+type ModerationUsecase struct {
+	blogRepo   blogpkg.IBlogRepository
+	reportRepo blogpkg.IReportRepository
+}
+
+func NewModerationUsecase(blogRepo blogpkg.IBlogRepository, reportRepo blogpkg.IReportRepository) *ModerationUsecase {
+	return &ModerationUsecase{
+		blogRepo:   blogRepo,
+		reportRepo: reportRepo,
+	}
+}
+
+// ReviewBlog records an editor's verdict on the current version of a post
+func (mu *ModerationUsecase) ReviewBlog(ctx context.Context, blogID string, req blogpkg.ReviewRequest) (*blogpkg.Blog, error) {
+	reviewerID, ok := ctx.Value("user_id").(string)
+	if !ok || reviewerID == "" {
+		return nil, errors.New("invalid user ID in context")
+	}
+	switch req.Status {
+	case blogpkg.ReviewApproved, blogpkg.ReviewChangesRequested:
+	default:
+		return nil, fmt.Errorf("unknown review status %q", req.Status)
+	}
+
+	blog, err := mu.blogRepo.FindBlogByID(blogID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch blog: %w", err)
+	}
+	if blog == nil {
+		return nil, errors.New("blog not found")
+	}
+	if blog.AuthorID == reviewerID {
+		return nil, errors.New("you cannot review your own post")
+	}
+
+	return mu.blogRepo.SetReview(ctx, blogID, blogpkg.Review{
+		Status:     req.Status,
+		ReviewerID: reviewerID,
+		Note:       strings.TrimSpace(req.Note),
+		ReviewedAt: time.Now(),
+	})
+}
+
+func (mu *ModerationUsecase) DeleteComment(ctx context.Context, blogID string, commentID string) error {
+	return mu.blogRepo.DeleteComment(ctx, blogID, commentID)
+}
+
+// ReportContent files a report against a post, or against one of its comments
+func (mu *ModerationUsecase) ReportContent(ctx context.Context, blogID string, req blogpkg.ReportRequest) (blogpkg.Report, error) {
+	reporterID, ok := ctx.Value("user_id").(string)
+	if !ok || reporterID == "" {
+		return blogpkg.Report{}, errors.New("invalid user ID in context")
+	}
+	reason := strings.TrimSpace(req.Reason)
+	if reason == "" {
+		return blogpkg.Report{}, errors.New("a reason is required")
+	}
+
+	blog, err := mu.blogRepo.FindBlogByID(blogID)
+	if err != nil {
+		return blogpkg.Report{}, fmt.Errorf("failed to fetch blog: %w", err)
+	}
+	if blog == nil {
+		return blogpkg.Report{}, errors.New("blog not found")
+	}
+	if req.CommentID != "" {
+		if _, err := mu.blogRepo.GetComment(ctx, blogID, req.CommentID); err != nil {
+			return blogpkg.Report{}, err
+		}
+	}
+
+	return mu.reportRepo.CreateReport(ctx, blogpkg.Report{
+		BlogID:     blogID,
+		CommentID:  req.CommentID,
+		ReporterID: reporterID,
+		Reason:     reason,
+		Status:     blogpkg.ReportOpen,
+		CreatedAt:  time.Now(),
+	})
+}
+
+// ListReports returns the oldest reports with the given status; open ones by default
+func (mu *ModerationUsecase) ListReports(ctx context.Context, status blogpkg.ReportStatus) ([]blogpkg.Report, error) {
+	if status == "" {
+		status = blogpkg.ReportOpen
+	}
+	if err := validateReportStatus(status, true); err != nil {
+		return nil, err
+	}
+	return mu.reportRepo.ListReports(ctx, status, reportQueueLimit)
+}
+
+// HandleReport closes an open report as resolved or dismissed
+func (mu *ModerationUsecase) HandleReport(ctx context.Context, reportID string, req blogpkg.HandleReportRequest) (blogpkg.Report, error) {
+	moderatorID, ok := ctx.Value("user_id").(string)
+	if !ok || moderatorID == "" {
+		return blogpkg.Report{}, errors.New("invalid user ID in context")
+	}
+	if err := validateReportStatus(req.Status, false); err != nil {
+		return blogpkg.Report{}, err
+	}
+	return mu.reportRepo.HandleReport(ctx, reportID, req.Status, moderatorID, strings.TrimSpace(req.Note), time.Now())
+}
+
+func validateReportStatus(status blogpkg.ReportStatus, allowOpen bool) error {
+	switch status {
+	case blogpkg.ReportResolved, blogpkg.ReportDismissed:
+		return nil
+	case blogpkg.ReportOpen:
+		if allowOpen {
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid report status %q", status)
+}
@@ -0,0 +1,101 @@
+package usecases_test
+
+import (
+	"context"
+	"testing"
+
+	blogpkg "github.com/Amaankaa/Blog-Starter-Project/Domain/blog"
+	usecases "github.com/Amaankaa/Blog-Starter-Project/Usecases"
+	"github.com/Amaankaa/Blog-Starter-Project/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type ModerationUsecaseSuite struct {
+	suite.Suite
+	ctx        context.Context
+	blogRepo   *mocks.IBlogRepository
+	reportRepo *mocks.IReportRepository
+	usecase    *usecases.ModerationUsecase
+}
+
+func TestModerationUsecaseSuite(t *testing.T) {
+	suite.Run(t, new(ModerationUsecaseSuite))
+}
+
+func (s *ModerationUsecaseSuite) SetupTest() {
+	s.ctx = context.WithValue(context.Background(), "user_id", "mod-1")
+	s.blogRepo = mocks.NewIBlogRepository(s.T())
+	s.reportRepo = mocks.NewIReportRepository(s.T())
+	s.usecase = usecases.NewModerationUsecase(s.blogRepo, s.reportRepo)
+}
+
+func (s *ModerationUsecaseSuite) TestReviewBlog_RecordsTheReviewer() {
+	s.blogRepo.On("FindBlogByID", "blog-1").Return(&blogpkg.Blog{ID: "blog-1", AuthorID: "author-1"}, nil).Once()
+	s.blogRepo.On("SetReview", s.ctx, "blog-1", mock.MatchedBy(func(r blogpkg.Review) bool {
+		return r.Status == blogpkg.ReviewChangesRequested && r.ReviewerID == "mod-1" && r.Note == "Needs sources" && !r.ReviewedAt.IsZero()
+	})).Return(&blogpkg.Blog{ID: "blog-1"}, nil).Once()
+
+	_, err := s.usecase.ReviewBlog(s.ctx, "blog-1", blogpkg.ReviewRequest{Status: blogpkg.ReviewChangesRequested, Note: " Needs sources "})
+
+	s.NoError(err)
+}
+
+func (s *ModerationUsecaseSuite) TestReviewBlog_RejectsOwnPost() {
+	s.blogRepo.On("FindBlogByID", "blog-1").Return(&blogpkg.Blog{ID: "blog-1", AuthorID: "mod-1"}, nil).Once()
+
+	_, err := s.usecase.ReviewBlog(s.ctx, "blog-1", blogpkg.ReviewRequest{Status: blogpkg.ReviewApproved})
+
+	s.EqualError(err, "you cannot review your own post")
+}
+
+func (s *ModerationUsecaseSuite) TestReviewBlog_UnknownStatus() {
+	_, err := s.usecase.ReviewBlog(s.ctx, "blog-1", blogpkg.ReviewRequest{Status: "published"})
+
+	s.Error(err)
+}
+
+func (s *ModerationUsecaseSuite) TestReportContent_ChecksTheCommentBelongsToThePost() {
+	s.blogRepo.On("FindBlogByID", "blog-1").Return(&blogpkg.Blog{ID: "blog-1"}, nil).Once()
+	s.blogRepo.On("GetComment", s.ctx, "blog-1", "c-9").Return(nil, blogpkg.ErrCommentNotFound).Once()
+
+	_, err := s.usecase.ReportContent(s.ctx, "blog-1", blogpkg.ReportRequest{CommentID: "c-9", Reason: "spam"})
+
+	s.ErrorIs(err, blogpkg.ErrCommentNotFound)
+}
+
+func (s *ModerationUsecaseSuite) TestReportContent_OpensAReport() {
+	s.blogRepo.On("FindBlogByID", "blog-1").Return(&blogpkg.Blog{ID: "blog-1"}, nil).Once()
+	s.reportRepo.On("CreateReport", s.ctx, mock.MatchedBy(func(r blogpkg.Report) bool {
+		return r.BlogID == "blog-1" && r.ReporterID == "mod-1" && r.Reason == "spam" && r.Status == blogpkg.ReportOpen
+	})).Return(blogpkg.Report{BlogID: "blog-1", Status: blogpkg.ReportOpen}, nil).Once()
+
+	report, err := s.usecase.ReportContent(s.ctx, "blog-1", blogpkg.ReportRequest{Reason: " spam "})
+
+	s.NoError(err)
+	s.Equal(blogpkg.ReportOpen, report.Status)
+}
+
+func (s *ModerationUsecaseSuite) TestListReports_DefaultsToOpen() {
+	s.reportRepo.On("ListReports", s.ctx, blogpkg.ReportOpen, int64(100)).Return([]blogpkg.Report{}, nil).Once()
+
+	_, err := s.usecase.ListReports(s.ctx, "")
+
+	s.NoError(err)
+}
+
+func (s *ModerationUsecaseSuite) TestHandleReport_CannotReopen() {
+	_, err := s.usecase.HandleReport(s.ctx, "r-1", blogpkg.HandleReportRequest{Status: blogpkg.ReportOpen})
+
+	s.Error(err)
+}
+
+func (s *ModerationUsecaseSuite) TestHandleReport_RecordsTheModerator() {
+	s.reportRepo.On("HandleReport", s.ctx, "r-1", blogpkg.ReportDismissed, "mod-1", "not spam", mock.Anything).
+		Return(blogpkg.Report{Status: blogpkg.ReportDismissed, HandledBy: "mod-1"}, nil).Once()
+
+	report, err := s.usecase.HandleReport(s.ctx, "r-1", blogpkg.HandleReportRequest{Status: blogpkg.ReportDismissed, Note: "not spam"})
+
+	s.NoError(err)
+	s.Equal("mod-1", report.HandledBy)
+}
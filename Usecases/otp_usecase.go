@@ -0,0 +1,156 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	otppkg "github.com/Amaankaa/Blog-Starter-Project/Domain/otp"
+	userpkg "github.com/Amaankaa/Blog-Starter-Project/Domain/user"
+	utils "github.com/Amaankaa/Blog-Starter-Project/Domain/utils"
+)
+
+// OTPUsecase keeps the one-time challenges of every purpose: how long they last, how often a
+// code may be resent and how many answers one takes
+type OTPUsecase struct {
+	repo     otppkg.IOTPRepository
+	hasher   userpkg.IPasswordService
+	policies map[otppkg.Purpose]otppkg.Policy
+}
+
+// NewOTPUsecase hashes codes with the password hasher: they are short enough that a fast
+// hash of one could be reversed by trying every code, and its comparison takes constant time
+func NewOTPUsecase(repo otppkg.IOTPRepository, hasher userpkg.IPasswordService, policies map[otppkg.Purpose]otppkg.Policy) *OTPUsecase {
+	return &OTPUsecase{repo: repo, hasher: hasher, policies: policies}
+}
+
+func (ou *OTPUsecase) Issue(ctx context.Context, purpose otppkg.Purpose, subject, data string) (string, time.Time, error) {
+	policy := ou.policies[purpose]
+	now := time.Now()
+	challenge := otppkg.Challenge{
+		ID:        otppkg.ChallengeID(purpose, subject),
+		Purpose:   purpose,
+		Subject:   subject,
+		Data:      data,
+		SentAt:    now,
+		ExpiresAt: now.Add(policy.Lifetime),
+	}
+
+	code := ""
+	if policy.CodeLength > 0 {
+		var err error
+		if code, err = utils.GenerateOTP(policy.CodeLength); err != nil {
+			return "", time.Time{}, err
+		}
+		if challenge.CodeHash, err = ou.hasher.HashPassword(code); err != nil {
+			return "", time.Time{}, err
+		}
+	}
+
+	issued, err := ou.repo.Issue(ctx, challenge, now.Add(-policy.ResendCooldown))
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	if !issued {
+		retryAfter := policy.ResendCooldown
+		if previous, err := ou.repo.Find(ctx, challenge.ID); err == nil {
+			retryAfter = previous.SentAt.Add(policy.ResendCooldown).Sub(now)
+		}
+		return "", time.Time{}, &otppkg.CooldownError{RetryAfter: retryAfter}
+	}
+	return code, challenge.ExpiresAt, nil
+}
+
+func (ou *OTPUsecase) Verify(ctx context.Context, purpose otppkg.Purpose, subject, code string) (otppkg.Challenge, error) {
+	return ou.Attempt(ctx, purpose, subject, ou.codeCheck(code))
+}
+
+func (ou *OTPUsecase) Find(ctx context.Context, purpose otppkg.Purpose, subject string) (otppkg.Challenge, error) {
+	challenge, err := ou.repo.Find(ctx, otppkg.ChallengeID(purpose, subject))
+	if err != nil {
+		return otppkg.Challenge{}, err
+	}
+	if time.Now().After(challenge.ExpiresAt) {
+		return otppkg.Challenge{}, otppkg.ErrChallengeNotFound
+	}
+	return challenge, nil
+}
+
+func (ou *OTPUsecase) Attempt(ctx context.Context, purpose otppkg.Purpose, subject string, check func(otppkg.Challenge) (bool, error)) (otppkg.Challenge, error) {
+	challenge, err := ou.attempt(ctx, purpose, subject, check)
+	if err != nil {
+		return otppkg.Challenge{}, err
+	}
+	// A challenge is answered once
+	_ = ou.repo.Delete(ctx, challenge.ID)
+	return challenge, nil
+}
+
+func (ou *OTPUsecase) Exchange(ctx context.Context, purpose otppkg.Purpose, subject, code string, lifetime time.Duration) (string, error) {
+	challenge, err := ou.attempt(ctx, purpose, subject, ou.codeCheck(code))
+	if err != nil {
+		return "", err
+	}
+	token, err := randomToken()
+	if err != nil {
+		return "", err
+	}
+	if err := ou.repo.Exchange(ctx, challenge.ID, hashSecret(token), time.Now().Add(lifetime)); err != nil {
+		return "", err
+	}
+	return token, nil
+}
+
+func (ou *OTPUsecase) Redeem(ctx context.Context, purpose otppkg.Purpose, token string) (otppkg.Challenge, error) {
+	return liveToken(ou.repo.ConsumeToken(ctx, purpose, hashSecret(token)))
+}
+
+func (ou *OTPUsecase) Lookup(ctx context.Context, purpose otppkg.Purpose, token string) (otppkg.Challenge, error) {
+	return liveToken(ou.repo.FindByToken(ctx, purpose, hashSecret(token)))
+}
+
+// liveToken rejects exchanged challenges that expired before MongoDB got to remove them
+func liveToken(challenge otppkg.Challenge, err error) (otppkg.Challenge, error) {
+	if err != nil {
+		return otppkg.Challenge{}, err
+	}
+	if time.Now().After(challenge.ExpiresAt) {
+		return otppkg.Challenge{}, otppkg.ErrInvalidToken
+	}
+	return challenge, nil
+}
+
+// attempt runs check against a live challenge. Every answer, right or wrong, takes one of the
+// challenge's attempts before it is checked; an exchanged challenge is only good for its token.
+func (ou *OTPUsecase) attempt(ctx context.Context, purpose otppkg.Purpose, subject string, check func(otppkg.Challenge) (bool, error)) (otppkg.Challenge, error) {
+	id := otppkg.ChallengeID(purpose, subject)
+	challenge, err := ou.repo.TakeAttempt(ctx, id, ou.policies[purpose].MaxAttempts)
+	if errors.Is(err, otppkg.ErrTooManyAttempts) {
+		_ = ou.repo.Delete(ctx, id)
+	}
+	if err != nil {
+		return otppkg.Challenge{}, err
+	}
+	if time.Now().After(challenge.ExpiresAt) {
+		_ = ou.repo.Delete(ctx, id)
+		return otppkg.Challenge{}, otppkg.ErrChallengeNotFound
+	}
+
+	ok, err := check(challenge)
+	if err != nil {
+		return otppkg.Challenge{}, err
+	}
+	if !ok {
+		return otppkg.Challenge{}, otppkg.ErrInvalidCode
+	}
+	return challenge, nil
+}
+
+func (ou *OTPUsecase) codeCheck(code string) func(otppkg.Challenge) (bool, error) {
+	return func(challenge otppkg.Challenge) (bool, error) {
+		if challenge.CodeHash == "" {
+			return false, nil
+		}
+		return ou.hasher.ComparePassword(challenge.CodeHash, code) == nil, nil
+	}
+}
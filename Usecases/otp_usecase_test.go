@@ -0,0 +1,195 @@
+package usecases_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"sync"
+	"testing"
+	"time"
+
+	otppkg "github.com/Amaankaa/Blog-Starter-Project/Domain/otp"
+	usecases "github.com/Amaankaa/Blog-Starter-Project/Usecases"
+	"github.com/Amaankaa/Blog-Starter-Project/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type OTPUsecaseSuite struct {
+	suite.Suite
+	ctx     context.Context
+	repo    *mocks.IOTPRepository
+	hasher  *mocks.IPasswordService
+	usecase *usecases.OTPUsecase
+	id      string
+}
+
+func TestOTPUsecaseSuite(t *testing.T) {
+	suite.Run(t, new(OTPUsecaseSuite))
+}
+
+func (s *OTPUsecaseSuite) SetupTest() {
+	s.ctx = context.Background()
+	s.repo = mocks.NewIOTPRepository(s.T())
+	s.hasher = mocks.NewIPasswordService(s.T())
+	s.usecase = usecases.NewOTPUsecase(s.repo, s.hasher, otppkg.DefaultPolicies)
+	s.id = otppkg.ChallengeID(otppkg.PurposePasswordReset, "jane@example.com")
+}
+
+func (s *OTPUsecaseSuite) liveChallenge() otppkg.Challenge {
+	return otppkg.Challenge{
+		ID:        s.id,
+		Purpose:   otppkg.PurposePasswordReset,
+		Subject:   "jane@example.com",
+		CodeHash:  "hashed",
+		ExpiresAt: time.Now().Add(time.Minute),
+	}
+}
+
+func (s *OTPUsecaseSuite) TestIssue_StoresOnlyTheHash() {
+	var code string
+	s.hasher.On("HashPassword", mock.Anything).Run(func(args mock.Arguments) {
+		code = args.String(0)
+	}).Return("hashed", nil).Once()
+	var stored otppkg.Challenge
+	var sentBefore time.Time
+	s.repo.On("Issue", s.ctx, mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		stored = args.Get(1).(otppkg.Challenge)
+		sentBefore = args.Get(2).(time.Time)
+	}).Return(true, nil).Once()
+
+	issued, expiresAt, err := s.usecase.Issue(s.ctx, otppkg.PurposePasswordReset, "jane@example.com", "")
+
+	s.Require().NoError(err)
+	s.Regexp(regexp.MustCompile(`^\d{6}$`), issued)
+	s.Equal(code, issued)
+	s.Equal(s.id, stored.ID)
+	s.Equal("hashed", stored.CodeHash)
+	s.WithinDuration(time.Now().Add(10*time.Minute), expiresAt, time.Second)
+	s.WithinDuration(time.Now().Add(-time.Minute), sentBefore, time.Second)
+}
+
+func (s *OTPUsecaseSuite) TestIssue_Cooldown() {
+	s.hasher.On("HashPassword", mock.Anything).Return("hashed", nil).Once()
+	s.repo.On("Issue", s.ctx, mock.Anything, mock.Anything).Return(false, nil).Once()
+	s.repo.On("Find", s.ctx, s.id).Return(otppkg.Challenge{SentAt: time.Now().Add(-20 * time.Second)}, nil).Once()
+
+	_, _, err := s.usecase.Issue(s.ctx, otppkg.PurposePasswordReset, "jane@example.com", "")
+
+	var cooldown *otppkg.CooldownError
+	s.Require().ErrorAs(err, &cooldown)
+	s.InDelta(40, cooldown.RetryAfter.Seconds(), 1)
+}
+
+func (s *OTPUsecaseSuite) TestVerify_ConsumesTheChallenge() {
+	s.repo.On("TakeAttempt", s.ctx, s.id, 5).Return(s.liveChallenge(), nil).Once()
+	s.hasher.On("ComparePassword", "hashed", "123456").Return(nil).Once()
+	s.repo.On("Delete", s.ctx, s.id).Return(nil).Once()
+
+	challenge, err := s.usecase.Verify(s.ctx, otppkg.PurposePasswordReset, "jane@example.com", "123456")
+
+	s.NoError(err)
+	s.Equal("jane@example.com", challenge.Subject)
+}
+
+func (s *OTPUsecaseSuite) TestVerify_WrongCodeCountsAnAttempt() {
+	s.repo.On("TakeAttempt", s.ctx, s.id, 5).Return(s.liveChallenge(), nil).Once()
+	s.hasher.On("ComparePassword", "hashed", "000000").Return(errors.New("mismatch")).Once()
+
+	_, err := s.usecase.Verify(s.ctx, otppkg.PurposePasswordReset, "jane@example.com", "000000")
+
+	s.ErrorIs(err, otppkg.ErrInvalidCode)
+}
+
+func (s *OTPUsecaseSuite) TestVerify_TooManyAttempts() {
+	s.repo.On("TakeAttempt", s.ctx, s.id, 5).Return(otppkg.Challenge{}, otppkg.ErrTooManyAttempts).Once()
+	s.repo.On("Delete", s.ctx, s.id).Return(nil).Once()
+
+	_, err := s.usecase.Verify(s.ctx, otppkg.PurposePasswordReset, "jane@example.com", "123456")
+
+	s.ErrorIs(err, otppkg.ErrTooManyAttempts)
+}
+
+func (s *OTPUsecaseSuite) TestVerify_ConcurrentGuessesShareTheLimit() {
+	// The repository hands out five attempts, however the guesses interleave
+	s.repo.On("TakeAttempt", s.ctx, s.id, 5).Return(s.liveChallenge(), nil).Times(5)
+	s.repo.On("TakeAttempt", s.ctx, s.id, 5).Return(otppkg.Challenge{}, otppkg.ErrTooManyAttempts)
+	s.repo.On("Delete", s.ctx, s.id).Return(nil)
+	s.hasher.On("ComparePassword", "hashed", mock.Anything).Return(errors.New("mismatch")).Times(5)
+
+	errs := make(chan error, 20)
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			_, err := s.usecase.Verify(s.ctx, otppkg.PurposePasswordReset, "jane@example.com", fmt.Sprintf("%06d", i))
+			errs <- err
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+
+	counts := map[error]int{}
+	for err := range errs {
+		counts[err]++
+	}
+	s.Equal(5, counts[otppkg.ErrInvalidCode])
+	s.Equal(15, counts[otppkg.ErrTooManyAttempts])
+}
+
+func (s *OTPUsecaseSuite) TestVerify_Expired() {
+	challenge := s.liveChallenge()
+	challenge.ExpiresAt = time.Now().Add(-time.Second)
+	s.repo.On("TakeAttempt", s.ctx, s.id, 5).Return(challenge, nil).Once()
+	s.repo.On("Delete", s.ctx, s.id).Return(nil).Once()
+
+	_, err := s.usecase.Verify(s.ctx, otppkg.PurposePasswordReset, "jane@example.com", "123456")
+
+	s.ErrorIs(err, otppkg.ErrChallengeNotFound)
+}
+
+func (s *OTPUsecaseSuite) TestExchange_SwapsTheCodeForAToken() {
+	s.repo.On("TakeAttempt", s.ctx, s.id, 5).Return(s.liveChallenge(), nil).Once()
+	s.hasher.On("ComparePassword", "hashed", "123456").Return(nil).Once()
+	var tokenHash string
+	s.repo.On("Exchange", s.ctx, s.id, mock.Anything, mock.MatchedBy(func(t time.Time) bool {
+		return t.After(time.Now().Add(14 * time.Minute))
+	})).Run(func(args mock.Arguments) {
+		tokenHash = args.String(2)
+	}).Return(nil).Once()
+
+	token, err := s.usecase.Exchange(s.ctx, otppkg.PurposePasswordReset, "jane@example.com", "123456", 15*time.Minute)
+
+	s.Require().NoError(err)
+	s.Equal(sha256Hex(token), tokenHash)
+}
+
+func (s *OTPUsecaseSuite) TestExchange_OnlyOnce() {
+	s.repo.On("TakeAttempt", s.ctx, s.id, 5).Return(otppkg.Challenge{}, otppkg.ErrChallengeNotFound).Once()
+
+	_, err := s.usecase.Exchange(s.ctx, otppkg.PurposePasswordReset, "jane@example.com", "123456", 15*time.Minute)
+
+	s.ErrorIs(err, otppkg.ErrChallengeNotFound)
+}
+
+func (s *OTPUsecaseSuite) TestLookup_LeavesTheTokenForRedeem() {
+	s.repo.On("FindByToken", s.ctx, otppkg.PurposePasswordReset, sha256Hex("token")).
+		Return(otppkg.Challenge{Subject: "jane@example.com", ExpiresAt: time.Now().Add(time.Minute)}, nil).Once()
+
+	challenge, err := s.usecase.Lookup(s.ctx, otppkg.PurposePasswordReset, "token")
+
+	s.NoError(err)
+	s.Equal("jane@example.com", challenge.Subject)
+	s.repo.AssertNotCalled(s.T(), "ConsumeToken", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (s *OTPUsecaseSuite) TestRedeem_Expired() {
+	s.repo.On("ConsumeToken", s.ctx, otppkg.PurposePasswordReset, sha256Hex("late")).
+		Return(otppkg.Challenge{Subject: "jane@example.com", ExpiresAt: time.Now().Add(-time.Minute)}, nil).Once()
+
+	_, err := s.usecase.Redeem(s.ctx, otppkg.PurposePasswordReset, "late")
+
+	s.ErrorIs(err, otppkg.ErrInvalidToken)
+}
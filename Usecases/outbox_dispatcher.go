@@ -0,0 +1,130 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	outboxpkg "github.com/Amaankaa/Blog-Starter-Project/Domain/outbox"
+)
+
+// OutboxRetryPolicy controls redelivery of failed outbox messages
+type OutboxRetryPolicy struct {
+	MaxAttempts    int
+	BaseBackoff    time.Duration
+	MaxBackoff     time.Duration
+	HandlerTimeout time.Duration
+}
+
+var DefaultOutboxRetryPolicy = OutboxRetryPolicy{
+	MaxAttempts:    10,
+	BaseBackoff:    5 * time.Second,
+	MaxBackoff:     15 * time.Minute,
+	HandlerTimeout: 30 * time.Second,
+}
+
+// OutboxDispatcher delivers outbox messages at least once by handing them to the
+// handler registered for their kind and retrying failures with exponential backoff.
+type OutboxDispatcher struct {
+	repo     outboxpkg.IOutboxRepository
+	policy   OutboxRetryPolicy
+	mu       sync.RWMutex
+	handlers map[string]outboxpkg.Handler
+}
+
+func NewOutboxDispatcher(repo outboxpkg.IOutboxRepository, policy OutboxRetryPolicy) *OutboxDispatcher {
+	return &OutboxDispatcher{
+		repo:     repo,
+		policy:   policy,
+		handlers: make(map[string]outboxpkg.Handler),
+	}
+}
+
+// Register sets the handler for messages of the given kind
+func (od *OutboxDispatcher) Register(kind string, handler outboxpkg.Handler) {
+	od.mu.Lock()
+	defer od.mu.Unlock()
+	od.handlers[kind] = handler
+}
+
+// Run dispatches due messages every pollInterval until ctx is cancelled
+func (od *OutboxDispatcher) Run(ctx context.Context, pollInterval time.Duration) {
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	for {
+		od.DispatchDue(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// DispatchDue processes every message that is currently due and returns how many were attempted
+func (od *OutboxDispatcher) DispatchDue(ctx context.Context) int {
+	attempted := 0
+	for ctx.Err() == nil {
+		msg, ok, err := od.repo.ClaimDue(ctx, time.Now(), 2*od.policy.HandlerTimeout)
+		if err != nil {
+			log.Printf("outbox: failed to claim message: %v", err)
+			return attempted
+		}
+		if !ok {
+			return attempted
+		}
+		od.process(ctx, msg)
+		attempted++
+	}
+	return attempted
+}
+
+func (od *OutboxDispatcher) process(ctx context.Context, msg outboxpkg.Message) {
+	od.mu.RLock()
+	handler, ok := od.handlers[msg.Kind]
+	od.mu.RUnlock()
+
+	var err error
+	if !ok {
+		err = fmt.Errorf("no handler registered for %q", msg.Kind)
+	} else {
+		handlerCtx, cancel := context.WithTimeout(ctx, od.policy.HandlerTimeout)
+		err = runOutboxHandler(handlerCtx, handler, msg)
+		cancel()
+	}
+
+	now := time.Now()
+	msg.Attempts++
+	switch {
+	case err == nil:
+		msg.Status = outboxpkg.StatusSent
+		msg.LastError = ""
+		msg.ProcessedAt = &now
+	case msg.Attempts >= od.policy.MaxAttempts:
+		msg.Status = outboxpkg.StatusFailed
+		msg.LastError = err.Error()
+		msg.ProcessedAt = &now
+		log.Printf("outbox: giving up on %s message %s after %d attempts: %v", msg.Kind, msg.IdempotencyKey, msg.Attempts, err)
+	default:
+		msg.LastError = err.Error()
+		msg.NextAttemptAt = now.Add(exponentialBackoff(od.policy.BaseBackoff, od.policy.MaxBackoff, msg.Attempts))
+	}
+
+	if err := od.repo.UpdateMessage(ctx, msg); err != nil {
+		// The lease will expire and the message is retried, which handlers must tolerate anyway
+		log.Printf("outbox: failed to record message %s: %v", msg.ID.Hex(), err)
+	}
+}
+
+// runOutboxHandler turns a handler panic into an error so one bad message cannot stop the dispatcher
+func runOutboxHandler(ctx context.Context, handler outboxpkg.Handler, msg outboxpkg.Message) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("handler panicked: %v", r)
+		}
+	}()
+	return handler(ctx, msg)
+}
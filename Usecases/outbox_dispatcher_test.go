@@ -0,0 +1,124 @@
+package usecases_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	outboxpkg "github.com/Amaankaa/Blog-Starter-Project/Domain/outbox"
+	usecases "github.com/Amaankaa/Blog-Starter-Project/Usecases"
+	"github.com/Amaankaa/Blog-Starter-Project/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type OutboxDispatcherSuite struct {
+	suite.Suite
+	ctx        context.Context
+	repo       *mocks.IOutboxRepository
+	dispatcher *usecases.OutboxDispatcher
+	saved      []outboxpkg.Message
+}
+
+func TestOutboxDispatcherSuite(t *testing.T) {
+	suite.Run(t, new(OutboxDispatcherSuite))
+}
+
+func (s *OutboxDispatcherSuite) SetupTest() {
+	s.ctx = context.Background()
+	s.repo = mocks.NewIOutboxRepository(s.T())
+	s.saved = nil
+	s.dispatcher = usecases.NewOutboxDispatcher(s.repo, usecases.OutboxRetryPolicy{
+		MaxAttempts:    3,
+		BaseBackoff:    time.Second,
+		MaxBackoff:     time.Minute,
+		HandlerTimeout: time.Second,
+	})
+}
+
+// expect hands out msgs from ClaimDue and records every UpdateMessage call
+func (s *OutboxDispatcherSuite) expect(msgs ...outboxpkg.Message) {
+	for _, m := range msgs {
+		s.repo.On("ClaimDue", s.ctx, mock.Anything, 2*time.Second).Return(m, true, nil).Once()
+	}
+	s.repo.On("ClaimDue", s.ctx, mock.Anything, 2*time.Second).Return(outboxpkg.Message{}, false, nil).Once()
+	s.repo.On("UpdateMessage", s.ctx, mock.Anything).Run(func(args mock.Arguments) {
+		s.saved = append(s.saved, args.Get(1).(outboxpkg.Message))
+	}).Return(nil).Times(len(msgs))
+}
+
+func message(kind string, attempts int) outboxpkg.Message {
+	return outboxpkg.Message{
+		ID:             primitive.NewObjectID(),
+		Kind:           kind,
+		IdempotencyKey: "key-" + primitive.NewObjectID().Hex(),
+		Status:         outboxpkg.StatusPending,
+		Attempts:       attempts,
+	}
+}
+
+func (s *OutboxDispatcherSuite) TestHandledMessageIsMarkedSent() {
+	msg := message("test.ok", 0)
+	var got outboxpkg.Message
+	s.dispatcher.Register("test.ok", func(_ context.Context, m outboxpkg.Message) error {
+		got = m
+		return nil
+	})
+	s.expect(msg)
+
+	s.Equal(1, s.dispatcher.DispatchDue(s.ctx))
+
+	s.Equal(msg.IdempotencyKey, got.IdempotencyKey)
+	s.Equal(outboxpkg.StatusSent, s.saved[0].Status)
+	s.Equal(1, s.saved[0].Attempts)
+	s.NotNil(s.saved[0].ProcessedAt)
+}
+
+func (s *OutboxDispatcherSuite) TestFailureIsRetriedWithBackoff() {
+	s.dispatcher.Register("test.flaky", func(context.Context, outboxpkg.Message) error {
+		return errors.New("smtp down")
+	})
+	s.expect(message("test.flaky", 1))
+
+	before := time.Now()
+	s.dispatcher.DispatchDue(s.ctx)
+
+	saved := s.saved[0]
+	s.Equal(outboxpkg.StatusPending, saved.Status)
+	s.Equal(2, saved.Attempts)
+	s.Equal("smtp down", saved.LastError)
+	s.WithinDuration(before.Add(2*time.Second), saved.NextAttemptAt, time.Second)
+}
+
+func (s *OutboxDispatcherSuite) TestGivesUpAfterMaxAttempts() {
+	s.dispatcher.Register("test.broken", func(context.Context, outboxpkg.Message) error {
+		return errors.New("still broken")
+	})
+	s.expect(message("test.broken", 2))
+
+	s.dispatcher.DispatchDue(s.ctx)
+	s.Equal(outboxpkg.StatusFailed, s.saved[0].Status)
+	s.Equal(3, s.saved[0].Attempts)
+}
+
+func (s *OutboxDispatcherSuite) TestPanicAndUnknownKindDoNotStopTheBatch() {
+	s.dispatcher.Register("test.panics", func(context.Context, outboxpkg.Message) error {
+		panic("boom")
+	})
+	s.dispatcher.Register("test.ok", func(context.Context, outboxpkg.Message) error { return nil })
+	s.expect(message("test.panics", 0), message("test.unknown", 0), message("test.ok", 0))
+
+	s.Equal(3, s.dispatcher.DispatchDue(s.ctx))
+
+	s.Contains(s.saved[0].LastError, "panicked")
+	s.Contains(s.saved[1].LastError, `no handler registered for "test.unknown"`)
+	s.Equal(outboxpkg.StatusSent, s.saved[2].Status)
+}
+
+func (s *OutboxDispatcherSuite) TestClaimErrorStopsTheBatch() {
+	s.repo.On("ClaimDue", s.ctx, mock.Anything, 2*time.Second).Return(outboxpkg.Message{}, false, errors.New("db down")).Once()
+
+	s.Equal(0, s.dispatcher.DispatchDue(s.ctx))
+}
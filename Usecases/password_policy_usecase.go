@@ -0,0 +1,139 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	passwordpkg "github.com/Amaankaa/Blog-Starter-Project/Domain/password"
+	userpkg "github.com/Amaankaa/Blog-Starter-Project/Domain/user"
+)
+
+// minPersonalDetailLength keeps a two-letter username from ruling out every password with it
+const minPersonalDetailLength = 3
+
+// PasswordPolicyUsecase rejects passwords that are easy to guess, known from breaches, made
+// from the user's own details or used by them before
+type PasswordPolicyUsecase struct {
+	policy    passwordpkg.Policy
+	estimator passwordpkg.IStrengthEstimator
+	breaches  passwordpkg.IBreachCorpus
+	history   passwordpkg.IPasswordHistoryRepository
+	hasher    userpkg.IPasswordService
+}
+
+// NewPasswordPolicyUsecase takes a nil breaches to skip the breach check, for deployments
+// without a copy of the corpus
+func NewPasswordPolicyUsecase(
+	estimator passwordpkg.IStrengthEstimator,
+	breaches passwordpkg.IBreachCorpus,
+	history passwordpkg.IPasswordHistoryRepository,
+	hasher userpkg.IPasswordService,
+	policy passwordpkg.Policy,
+) *PasswordPolicyUsecase {
+	return &PasswordPolicyUsecase{policy: policy, estimator: estimator, breaches: breaches, history: history, hasher: hasher}
+}
+
+func (pu *PasswordPolicyUsecase) Check(ctx context.Context, password string, user userpkg.User) error {
+	length := utf8.RuneCountInString(password)
+	if pu.policy.MaxLength > 0 && length > pu.policy.MaxLength {
+		return &passwordpkg.PolicyError{Reasons: []string{fmt.Sprintf("password must be at most %d characters", pu.policy.MaxLength)}}
+	}
+
+	var reasons []string
+	if length < pu.policy.MinLength {
+		reasons = append(reasons, fmt.Sprintf("password must be at least %d characters", pu.policy.MinLength))
+	}
+
+	lower := strings.ToLower(password)
+	if containsDetail(lower, user.Username) {
+		reasons = append(reasons, "password must not contain your username")
+	}
+	local, _, _ := strings.Cut(user.Email, "@")
+	if containsDetail(lower, local) {
+		reasons = append(reasons, "password must not contain your email address")
+	}
+
+	if strength := pu.estimator.Estimate(password, personalDetails(user)); strength.Score < pu.policy.MinScore {
+		reason := "password is too easy to guess"
+		if strength.Warning != "" {
+			reason += ": " + strength.Warning
+		}
+		reasons = append(reasons, reason)
+	}
+
+	if pu.breaches != nil {
+		breached, err := pu.breaches.IsBreached(ctx, password)
+		if err != nil {
+			return err
+		}
+		if breached {
+			reasons = append(reasons, "password has appeared in a data breach")
+		}
+	}
+
+	reused, err := pu.reused(ctx, password, user)
+	if err != nil {
+		return err
+	}
+	if reused {
+		reasons = append(reasons, fmt.Sprintf("password must not be one of your last %d passwords", pu.policy.HistorySize))
+	}
+
+	if len(reasons) > 0 {
+		return &passwordpkg.PolicyError{Reasons: reasons}
+	}
+	return nil
+}
+
+// reused compares password with the current hash and the ones it replaced
+func (pu *PasswordPolicyUsecase) reused(ctx context.Context, password string, user userpkg.User) (bool, error) {
+	if user.ID.IsZero() || pu.policy.HistorySize <= 0 {
+		return false, nil
+	}
+	var hashes []string
+	if user.Password != "" {
+		hashes = append(hashes, user.Password)
+	}
+	if pu.policy.HistorySize > 1 {
+		previous, err := pu.history.Recent(ctx, user.ID.Hex(), pu.policy.HistorySize-1)
+		if err != nil {
+			return false, err
+		}
+		hashes = append(hashes, previous...)
+	}
+	for _, hash := range hashes {
+		if pu.hasher.ComparePassword(hash, password) == nil {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (pu *PasswordPolicyUsecase) Retire(ctx context.Context, user userpkg.User) error {
+	// The current password is checked separately, so history holds one fewer
+	if user.Password == "" || pu.policy.HistorySize <= 1 {
+		return nil
+	}
+	return pu.history.Add(ctx, passwordpkg.HistoryEntry{
+		UserID:    user.ID.Hex(),
+		Hash:      user.Password,
+		RetiredAt: time.Now(),
+	}, pu.policy.HistorySize-1)
+}
+
+func containsDetail(lowerPassword, detail string) bool {
+	detail = strings.ToLower(strings.TrimSpace(detail))
+	return utf8.RuneCountInString(detail) >= minPersonalDetailLength && strings.Contains(lowerPassword, detail)
+}
+
+// personalDetails are the words an attacker targeting the user would try first
+func personalDetails(user userpkg.User) []string {
+	details := []string{user.Username, user.Email}
+	if local, _, ok := strings.Cut(user.Email, "@"); ok {
+		details = append(details, local)
+	}
+	return append(details, strings.Fields(user.Fullname)...)
+}
@@ -0,0 +1,146 @@
+package usecases_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	passwordpkg "github.com/Amaankaa/Blog-Starter-Project/Domain/password"
+	userpkg "github.com/Amaankaa/Blog-Starter-Project/Domain/user"
+	usecases "github.com/Amaankaa/Blog-Starter-Project/Usecases"
+	"github.com/Amaankaa/Blog-Starter-Project/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type PasswordPolicySuite struct {
+	suite.Suite
+	ctx       context.Context
+	estimator *mocks.IStrengthEstimator
+	breaches  *mocks.IBreachCorpus
+	history   *mocks.IPasswordHistoryRepository
+	hasher    *mocks.IPasswordService
+	usecase   *usecases.PasswordPolicyUsecase
+	user      userpkg.User
+}
+
+func TestPasswordPolicySuite(t *testing.T) {
+	suite.Run(t, new(PasswordPolicySuite))
+}
+
+func (s *PasswordPolicySuite) SetupTest() {
+	s.ctx = context.Background()
+	s.estimator = mocks.NewIStrengthEstimator(s.T())
+	s.breaches = mocks.NewIBreachCorpus(s.T())
+	s.history = mocks.NewIPasswordHistoryRepository(s.T())
+	s.hasher = mocks.NewIPasswordService(s.T())
+	s.usecase = usecases.NewPasswordPolicyUsecase(s.estimator, s.breaches, s.history, s.hasher, passwordpkg.DefaultPolicy)
+	s.user = userpkg.User{
+		ID:       primitive.NewObjectID(),
+		Username: "janedoe",
+		Email:    "jane.smith@example.com",
+		Fullname: "Jane Smith",
+		Password: "current-hash",
+	}
+}
+
+func (s *PasswordPolicySuite) strong() {
+	s.estimator.On("Estimate", mock.Anything, mock.Anything).Return(passwordpkg.Strength{Score: 4}).Once()
+}
+
+func (s *PasswordPolicySuite) reasons(err error) []string {
+	var rejected *passwordpkg.PolicyError
+	s.Require().ErrorAs(err, &rejected)
+	return rejected.Reasons
+}
+
+func (s *PasswordPolicySuite) TestAcceptsAStrongNewPassword() {
+	s.strong()
+	s.breaches.On("IsBreached", s.ctx, "mauve-Otter-42-kettle").Return(false, nil).Once()
+	s.hasher.On("ComparePassword", "current-hash", "mauve-Otter-42-kettle").Return(errors.New("mismatch")).Once()
+	s.history.On("Recent", s.ctx, s.user.ID.Hex(), 4).Return([]string{"old-hash"}, nil).Once()
+	s.hasher.On("ComparePassword", "old-hash", "mauve-Otter-42-kettle").Return(errors.New("mismatch")).Once()
+
+	s.NoError(s.usecase.Check(s.ctx, "mauve-Otter-42-kettle", s.user))
+}
+
+func (s *PasswordPolicySuite) TestListsEveryBrokenRule() {
+	s.estimator.On("Estimate", "Jane.Smith1", []string{"janedoe", "jane.smith@example.com", "jane.smith", "Jane", "Smith"}).
+		Return(passwordpkg.Strength{Score: 1, Warning: "it contains details about you, like your name"}).Once()
+	s.breaches.On("IsBreached", s.ctx, "Jane.Smith1").Return(true, nil).Once()
+	s.user.ID = primitive.NilObjectID // a new user has no history yet
+
+	reasons := s.reasons(s.usecase.Check(s.ctx, "Jane.Smith1", s.user))
+
+	s.Equal([]string{
+		"password must not contain your email address",
+		"password is too easy to guess: it contains details about you, like your name",
+		"password has appeared in a data breach",
+	}, reasons)
+}
+
+func (s *PasswordPolicySuite) TestRejectsTheUsernameInAnyCase() {
+	s.strong()
+	s.breaches.On("IsBreached", s.ctx, mock.Anything).Return(false, nil).Once()
+	s.user.ID = primitive.NilObjectID
+
+	reasons := s.reasons(s.usecase.Check(s.ctx, "xX-JaneDoe-Xx!", s.user))
+
+	s.Equal([]string{"password must not contain your username"}, reasons)
+}
+
+func (s *PasswordPolicySuite) TestTooLongSkipsTheOtherChecks() {
+	long := make([]byte, 129)
+	for i := range long {
+		long[i] = 'x'
+	}
+
+	reasons := s.reasons(s.usecase.Check(s.ctx, string(long), s.user))
+
+	s.Equal([]string{"password must be at most 128 characters"}, reasons)
+}
+
+func (s *PasswordPolicySuite) TestRejectsTheCurrentPassword() {
+	s.strong()
+	s.breaches.On("IsBreached", s.ctx, mock.Anything).Return(false, nil).Once()
+	s.history.On("Recent", s.ctx, s.user.ID.Hex(), 4).Return(nil, nil).Once()
+	s.hasher.On("ComparePassword", "current-hash", "mauve-Otter-42-kettle").Return(nil).Once()
+
+	reasons := s.reasons(s.usecase.Check(s.ctx, "mauve-Otter-42-kettle", s.user))
+
+	s.Equal([]string{"password must not be one of your last 5 passwords"}, reasons)
+}
+
+func (s *PasswordPolicySuite) TestRejectsAnEarlierPassword() {
+	s.strong()
+	s.breaches.On("IsBreached", s.ctx, mock.Anything).Return(false, nil).Once()
+	s.history.On("Recent", s.ctx, s.user.ID.Hex(), 4).Return([]string{"newer-hash", "older-hash"}, nil).Once()
+	s.hasher.On("ComparePassword", "current-hash", mock.Anything).Return(errors.New("mismatch")).Once()
+	s.hasher.On("ComparePassword", "newer-hash", mock.Anything).Return(errors.New("mismatch")).Once()
+	s.hasher.On("ComparePassword", "older-hash", mock.Anything).Return(nil).Once()
+
+	s.Error(s.usecase.Check(s.ctx, "mauve-Otter-42-kettle", s.user))
+}
+
+func (s *PasswordPolicySuite) TestWorksWithoutABreachCorpus() {
+	usecase := usecases.NewPasswordPolicyUsecase(s.estimator, nil, s.history, s.hasher, passwordpkg.DefaultPolicy)
+	s.strong()
+	s.user.ID = primitive.NilObjectID
+
+	s.NoError(usecase.Check(s.ctx, "mauve-Otter-42-kettle", s.user))
+}
+
+func (s *PasswordPolicySuite) TestRetireKeepsOneFewerThanTheHistorySize() {
+	s.history.On("Add", s.ctx, mock.MatchedBy(func(entry passwordpkg.HistoryEntry) bool {
+		return entry.UserID == s.user.ID.Hex() && entry.Hash == "current-hash" && !entry.RetiredAt.IsZero()
+	}), 4).Return(nil).Once()
+
+	s.NoError(s.usecase.Retire(s.ctx, s.user))
+}
+
+func (s *PasswordPolicySuite) TestRetireSkipsAccountsWithoutAPassword() {
+	s.user.Password = "" // signed up with an identity provider
+
+	s.NoError(s.usecase.Retire(s.ctx, s.user))
+}
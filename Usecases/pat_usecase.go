@@ -0,0 +1,148 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	patpkg "github.com/Amaankaa/Blog-Starter-Project/Domain/pat"
+	userpkg "github.com/Amaankaa/Blog-Starter-Project/Domain/user"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	patDefaultLifetimeDays = 30
+	patMaxLifetimeDays     = 365
+	patMaxPerUser          = 50
+	patMaxNameLength       = 100
+	// patTouchInterval limits last-used writes to one per token per interval
+	patTouchInterval = time.Minute
+)
+
+// PATUsecase issues personal access tokens for scripts and CLI tools and authenticates
+// requests made with them
+type PATUsecase struct {
+	repo     patpkg.IPATRepository
+	userRepo userpkg.IUserRepository
+}
+
+func NewPATUsecase(repo patpkg.IPATRepository, userRepo userpkg.IUserRepository) *PATUsecase {
+	return &PATUsecase{repo: repo, userRepo: userRepo}
+}
+
+func (pu *PATUsecase) Create(ctx context.Context, userID string, req patpkg.CreateRequest) (patpkg.CreatedToken, error) {
+	name := strings.TrimSpace(req.Name)
+	if name == "" || len(name) > patMaxNameLength {
+		return patpkg.CreatedToken{}, errors.New("name must be between 1 and 100 characters")
+	}
+	scopes, err := normalizeScopes(req.Scopes)
+	if err != nil {
+		return patpkg.CreatedToken{}, err
+	}
+	days := req.ExpiresInDays
+	if days == 0 {
+		days = patDefaultLifetimeDays
+	}
+	if days < 1 || days > patMaxLifetimeDays {
+		return patpkg.CreatedToken{}, errors.New("expires_in_days must be between 1 and 365")
+	}
+	uid, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return patpkg.CreatedToken{}, err
+	}
+
+	count, err := pu.repo.CountByUser(ctx, userID)
+	if err != nil {
+		return patpkg.CreatedToken{}, err
+	}
+	if count >= patMaxPerUser {
+		return patpkg.CreatedToken{}, errors.New("too many personal access tokens; revoke some first")
+	}
+
+	secret, err := randomToken()
+	if err != nil {
+		return patpkg.CreatedToken{}, err
+	}
+	raw := patpkg.TokenPrefix + secret
+	now := time.Now()
+	token, err := pu.repo.Create(ctx, patpkg.PersonalAccessToken{
+		UserID:    uid,
+		Name:      name,
+		Scopes:    scopes,
+		TokenHash: hashSecret(raw),
+		Hint:      raw[:len(patpkg.TokenPrefix)+4],
+		CreatedAt: now,
+		ExpiresAt: now.AddDate(0, 0, days),
+	})
+	if err != nil {
+		return patpkg.CreatedToken{}, err
+	}
+	return patpkg.CreatedToken{PersonalAccessToken: token, Token: raw}, nil
+}
+
+func (pu *PATUsecase) List(ctx context.Context, userID string) ([]patpkg.PersonalAccessToken, error) {
+	return pu.repo.ListByUser(ctx, userID)
+}
+
+func (pu *PATUsecase) Revoke(ctx context.Context, userID, tokenID string) error {
+	return pu.repo.Delete(ctx, userID, tokenID)
+}
+
+// Authenticate looks the token up by hash and returns its owner with their current role,
+// so a demotion applies to existing tokens immediately
+func (pu *PATUsecase) Authenticate(ctx context.Context, raw string) (patpkg.Principal, error) {
+	if !strings.HasPrefix(raw, patpkg.TokenPrefix) {
+		return patpkg.Principal{}, patpkg.ErrInvalidToken
+	}
+	token, err := pu.repo.FindByHash(ctx, hashSecret(raw))
+	if errors.Is(err, patpkg.ErrTokenNotFound) {
+		return patpkg.Principal{}, patpkg.ErrInvalidToken
+	}
+	if err != nil {
+		return patpkg.Principal{}, err
+	}
+	now := time.Now()
+	if now.After(token.ExpiresAt) {
+		return patpkg.Principal{}, patpkg.ErrInvalidToken
+	}
+
+	user, err := pu.userRepo.FindByID(ctx, token.UserID.Hex())
+	if err != nil {
+		return patpkg.Principal{}, patpkg.ErrInvalidToken
+	}
+	if now.Sub(token.LastUsedAt) > patTouchInterval {
+		_ = pu.repo.TouchLastUsed(ctx, token.ID.Hex(), now)
+	}
+	return patpkg.Principal{
+		TokenID:  token.ID.Hex(),
+		UserID:   user.ID.Hex(),
+		Username: user.Username,
+		Role:     user.Role,
+		Scopes:   token.Scopes,
+	}, nil
+}
+
+// normalizeScopes rejects unknown scopes and drops duplicates
+func normalizeScopes(requested []string) ([]string, error) {
+	seen := make(map[string]bool, len(requested))
+	var scopes []string
+	for _, s := range requested {
+		s = strings.TrimSpace(s)
+		known := false
+		for _, k := range patpkg.Scopes {
+			known = known || s == k
+		}
+		if !known {
+			return nil, errors.New("unknown scope: " + s)
+		}
+		if !seen[s] {
+			seen[s] = true
+			scopes = append(scopes, s)
+		}
+	}
+	if len(scopes) == 0 {
+		return nil, errors.New("at least one scope is required")
+	}
+	return scopes, nil
+}
@@ -0,0 +1,122 @@
+package usecases_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	patpkg "github.com/Amaankaa/Blog-Starter-Project/Domain/pat"
+	userpkg "github.com/Amaankaa/Blog-Starter-Project/Domain/user"
+	usecases "github.com/Amaankaa/Blog-Starter-Project/Usecases"
+	"github.com/Amaankaa/Blog-Starter-Project/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type PATUsecaseSuite struct {
+	suite.Suite
+	ctx      context.Context
+	repo     *mocks.IPATRepository
+	userRepo *mocks.IUserRepository
+	usecase  *usecases.PATUsecase
+	userID   primitive.ObjectID
+}
+
+func TestPATUsecaseSuite(t *testing.T) {
+	suite.Run(t, new(PATUsecaseSuite))
+}
+
+func (s *PATUsecaseSuite) SetupTest() {
+	s.ctx = context.Background()
+	s.repo = mocks.NewIPATRepository(s.T())
+	s.userRepo = mocks.NewIUserRepository(s.T())
+	s.usecase = usecases.NewPATUsecase(s.repo, s.userRepo)
+	s.userID = primitive.NewObjectID()
+}
+
+func (s *PATUsecaseSuite) TestCreate_StoresOnlyTheHash() {
+	s.repo.On("CountByUser", s.ctx, s.userID.Hex()).Return(int64(0), nil).Once()
+	var stored patpkg.PersonalAccessToken
+	s.repo.On("Create", s.ctx, mock.Anything).Run(func(args mock.Arguments) {
+		stored = args.Get(1).(patpkg.PersonalAccessToken)
+	}).Return(func(_ context.Context, t patpkg.PersonalAccessToken) patpkg.PersonalAccessToken { return t }, nil).Once()
+
+	created, err := s.usecase.Create(s.ctx, s.userID.Hex(), patpkg.CreateRequest{
+		Name:   " ci ",
+		Scopes: []string{"blogs:write", "ai:use", "blogs:write"},
+	})
+
+	s.NoError(err)
+	s.True(strings.HasPrefix(created.Token, patpkg.TokenPrefix))
+	s.Equal(sha256Hex(created.Token), stored.TokenHash)
+	s.NotContains(stored.TokenHash, created.Token)
+	s.Equal("ci", stored.Name)
+	s.Equal([]string{"blogs:write", "ai:use"}, stored.Scopes)
+	s.WithinDuration(time.Now().AddDate(0, 0, 30), stored.ExpiresAt, time.Minute)
+	s.True(strings.HasPrefix(created.Token, stored.Hint))
+}
+
+func (s *PATUsecaseSuite) TestCreate_Validation() {
+	for _, req := range []patpkg.CreateRequest{
+		{Name: "ci", Scopes: []string{"admin:all"}},
+		{Name: "ci", Scopes: []string{"blogs:read"}}, // no route required it, so it was dropped
+		{Name: "ci", Scopes: []string{}},
+		{Name: "", Scopes: []string{"blogs:write"}},
+		{Name: "ci", Scopes: []string{"blogs:write"}, ExpiresInDays: 366},
+	} {
+		_, err := s.usecase.Create(s.ctx, s.userID.Hex(), req)
+		s.Error(err, "%+v", req)
+	}
+}
+
+func (s *PATUsecaseSuite) TestCreate_Limit() {
+	s.repo.On("CountByUser", s.ctx, s.userID.Hex()).Return(int64(50), nil).Once()
+
+	_, err := s.usecase.Create(s.ctx, s.userID.Hex(), patpkg.CreateRequest{Name: "ci", Scopes: []string{"blogs:write"}})
+
+	s.Error(err)
+}
+
+func (s *PATUsecaseSuite) TestAuthenticate_UsesCurrentRole() {
+	raw := patpkg.TokenPrefix + "secret"
+	token := patpkg.PersonalAccessToken{ID: primitive.NewObjectID(), UserID: s.userID, Scopes: []string{"blogs:write"}, ExpiresAt: time.Now().Add(time.Hour)}
+	s.repo.On("FindByHash", s.ctx, sha256Hex(raw)).Return(token, nil).Once()
+	s.userRepo.On("FindByID", s.ctx, s.userID.Hex()).Return(userpkg.User{ID: s.userID, Username: "jane", Role: "user"}, nil).Once()
+	s.repo.On("TouchLastUsed", s.ctx, token.ID.Hex(), mock.Anything).Return(nil).Once()
+
+	principal, err := s.usecase.Authenticate(s.ctx, raw)
+
+	s.NoError(err)
+	s.Equal(patpkg.Principal{TokenID: token.ID.Hex(), UserID: s.userID.Hex(), Username: "jane", Role: "user", Scopes: []string{"blogs:write"}}, principal)
+}
+
+func (s *PATUsecaseSuite) TestAuthenticate_SkipsRecentTouch() {
+	raw := patpkg.TokenPrefix + "secret"
+	token := patpkg.PersonalAccessToken{UserID: s.userID, ExpiresAt: time.Now().Add(time.Hour), LastUsedAt: time.Now()}
+	s.repo.On("FindByHash", s.ctx, sha256Hex(raw)).Return(token, nil).Once()
+	s.userRepo.On("FindByID", s.ctx, s.userID.Hex()).Return(userpkg.User{ID: s.userID}, nil).Once()
+
+	_, err := s.usecase.Authenticate(s.ctx, raw)
+
+	s.NoError(err)
+}
+
+func (s *PATUsecaseSuite) TestAuthenticate_Expired() {
+	raw := patpkg.TokenPrefix + "secret"
+	s.repo.On("FindByHash", s.ctx, sha256Hex(raw)).Return(patpkg.PersonalAccessToken{ExpiresAt: time.Now().Add(-time.Second)}, nil).Once()
+
+	_, err := s.usecase.Authenticate(s.ctx, raw)
+
+	s.ErrorIs(err, patpkg.ErrInvalidToken)
+}
+
+func (s *PATUsecaseSuite) TestAuthenticate_Unknown() {
+	raw := patpkg.TokenPrefix + "secret"
+	s.repo.On("FindByHash", s.ctx, sha256Hex(raw)).Return(patpkg.PersonalAccessToken{}, patpkg.ErrTokenNotFound).Once()
+
+	_, err := s.usecase.Authenticate(s.ctx, raw)
+
+	s.ErrorIs(err, patpkg.ErrInvalidToken)
+}
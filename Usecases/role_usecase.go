@@ -0,0 +1,248 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"log"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	rolepkg "github.com/Amaankaa/Blog-Starter-Project/Domain/role"
+)
+
+var roleNamePattern = regexp.MustCompile(`^[a-z][a-z0-9_-]{1,31}$`)
+
+// RoleUsecase manages the role matrix and answers permission lookups from an in-memory copy.
+// Every change bumps the matrix version; other instances reload when they see a new one.
+type RoleUsecase struct {
+	repo rolepkg.IRoleRepository
+
+	mu      sync.RWMutex
+	loaded  bool
+	version int64
+	matrix  map[string][]string
+}
+
+func NewRoleUsecase(repo rolepkg.IRoleRepository) *RoleUsecase {
+	return &RoleUsecase{repo: repo}
+}
+
+// EnsureDefaults creates the built-in roles that don't exist yet
+func (ru *RoleUsecase) EnsureDefaults(ctx context.Context) error {
+	created := false
+	for _, role := range rolepkg.DefaultRoles() {
+		role.UpdatedAt = time.Now()
+		inserted, err := ru.repo.InsertRoleIfMissing(ctx, role)
+		if err != nil {
+			return err
+		}
+		created = created || inserted
+	}
+	if created {
+		if _, err := ru.repo.BumpVersion(ctx); err != nil {
+			return err
+		}
+	}
+	return ru.Reload(ctx)
+}
+
+func (ru *RoleUsecase) ListRoles(ctx context.Context) ([]rolepkg.Role, error) {
+	return ru.repo.ListRoles(ctx)
+}
+
+func (ru *RoleUsecase) CreateRole(ctx context.Context, req rolepkg.RoleRequest) (rolepkg.Role, error) {
+	if !roleNamePattern.MatchString(req.Name) {
+		return rolepkg.Role{}, errors.New("role names are 2-32 lowercase letters, digits, '-' or '_'")
+	}
+	_, err := ru.repo.FindRole(ctx, req.Name)
+	if err == nil {
+		return rolepkg.Role{}, errors.New("role already exists")
+	}
+	if !errors.Is(err, rolepkg.ErrRoleNotFound) {
+		return rolepkg.Role{}, err
+	}
+	permissions, err := validatePermissions(req.Permissions)
+	if err != nil {
+		return rolepkg.Role{}, err
+	}
+	role := rolepkg.Role{
+		Name:        req.Name,
+		Description: strings.TrimSpace(req.Description),
+		Permissions: permissions,
+		UpdatedAt:   time.Now(),
+	}
+	return role, ru.save(ctx, role)
+}
+
+func (ru *RoleUsecase) UpdateRole(ctx context.Context, name string, req rolepkg.RoleRequest) (rolepkg.Role, error) {
+	role, err := ru.repo.FindRole(ctx, name)
+	if err != nil {
+		return rolepkg.Role{}, err
+	}
+	permissions, err := validatePermissions(req.Permissions)
+	if err != nil {
+		return rolepkg.Role{}, err
+	}
+	// Otherwise nobody could undo the change
+	if name == rolepkg.RoleAdmin && !(contains(permissions, rolepkg.PermRolesManage) && contains(permissions, rolepkg.PermUsersManage)) {
+		return rolepkg.Role{}, errors.New("the admin role must keep roles:manage and users:manage")
+	}
+
+	role.Permissions = permissions
+	if req.Description != "" {
+		role.Description = strings.TrimSpace(req.Description)
+	}
+	role.UpdatedAt = time.Now()
+	return role, ru.save(ctx, role)
+}
+
+func (ru *RoleUsecase) DeleteRole(ctx context.Context, name string) error {
+	role, err := ru.repo.FindRole(ctx, name)
+	if err != nil {
+		return err
+	}
+	if role.BuiltIn {
+		return errors.New("built-in roles cannot be deleted")
+	}
+	inUse, err := ru.repo.CountUsersWithRole(ctx, name)
+	if err != nil {
+		return err
+	}
+	if inUse > 0 {
+		return errors.New("role is still assigned to users")
+	}
+	if err := ru.repo.DeleteRole(ctx, name); err != nil {
+		return err
+	}
+	return ru.changed(ctx)
+}
+
+func (ru *RoleUsecase) save(ctx context.Context, role rolepkg.Role) error {
+	if err := ru.repo.SaveRole(ctx, role); err != nil {
+		return err
+	}
+	return ru.changed(ctx)
+}
+
+// changed publishes a new matrix version and applies it here at once
+func (ru *RoleUsecase) changed(ctx context.Context) error {
+	if _, err := ru.repo.BumpVersion(ctx); err != nil {
+		return err
+	}
+	return ru.Reload(ctx)
+}
+
+func (ru *RoleUsecase) Resolve(ctx context.Context, role string) (rolepkg.Grant, error) {
+	if err := ru.ensureLoaded(ctx); err != nil {
+		return rolepkg.Grant{}, err
+	}
+	ru.mu.RLock()
+	defer ru.mu.RUnlock()
+	permissions, ok := ru.matrix[role]
+	if !ok {
+		return rolepkg.Grant{Version: ru.version}, rolepkg.ErrRoleNotFound
+	}
+	return rolepkg.Grant{Permissions: append([]string(nil), permissions...), Version: ru.version}, nil
+}
+
+func (ru *RoleUsecase) CurrentVersion(ctx context.Context) (int64, error) {
+	if err := ru.ensureLoaded(ctx); err != nil {
+		return 0, err
+	}
+	ru.mu.RLock()
+	defer ru.mu.RUnlock()
+	return ru.version, nil
+}
+
+func (ru *RoleUsecase) ensureLoaded(ctx context.Context) error {
+	ru.mu.RLock()
+	loaded := ru.loaded
+	ru.mu.RUnlock()
+	if loaded {
+		return nil
+	}
+	return ru.Reload(ctx)
+}
+
+// Reload reads the whole matrix. The version is read first, so a change racing with the
+// reload is picked up again by the next Sync.
+func (ru *RoleUsecase) Reload(ctx context.Context) error {
+	version, err := ru.repo.Version(ctx)
+	if err != nil {
+		return err
+	}
+	roles, err := ru.repo.ListRoles(ctx)
+	if err != nil {
+		return err
+	}
+	matrix := make(map[string][]string, len(roles))
+	for _, role := range roles {
+		matrix[role.Name] = role.Permissions
+	}
+
+	ru.mu.Lock()
+	defer ru.mu.Unlock()
+	ru.matrix, ru.version, ru.loaded = matrix, version, true
+	return nil
+}
+
+// Sync reloads the matrix if another instance changed it
+func (ru *RoleUsecase) Sync(ctx context.Context) error {
+	version, err := ru.repo.Version(ctx)
+	if err != nil {
+		return err
+	}
+	ru.mu.RLock()
+	current := ru.loaded && ru.version == version
+	ru.mu.RUnlock()
+	if current {
+		return nil
+	}
+	return ru.Reload(ctx)
+}
+
+// Run syncs every interval until ctx is cancelled
+func (ru *RoleUsecase) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		if err := ru.Sync(ctx); err != nil {
+			log.Printf("roles: sync failed: %v", err)
+		}
+	}
+}
+
+// validatePermissions rejects unknown permissions and returns the rest sorted and deduplicated
+func validatePermissions(requested []string) ([]string, error) {
+	seen := make(map[string]bool, len(requested))
+	permissions := []string{}
+	for _, p := range requested {
+		if !rolepkg.IsKnownPermission(p) {
+			return nil, errors.New(rolepkg.ErrUnknownPermission.Error() + ": " + p)
+		}
+		if !seen[p] {
+			seen[p] = true
+			permissions = append(permissions, p)
+		}
+	}
+	sort.Strings(permissions)
+	return permissions, nil
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
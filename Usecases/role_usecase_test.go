@@ -0,0 +1,111 @@
+package usecases_test
+
+import (
+	"context"
+	"testing"
+
+	rolepkg "github.com/Amaankaa/Blog-Starter-Project/Domain/role"
+	usecases "github.com/Amaankaa/Blog-Starter-Project/Usecases"
+	"github.com/Amaankaa/Blog-Starter-Project/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type RoleUsecaseSuite struct {
+	suite.Suite
+	ctx     context.Context
+	repo    *mocks.IRoleRepository
+	usecase *usecases.RoleUsecase
+}
+
+func TestRoleUsecaseSuite(t *testing.T) {
+	suite.Run(t, new(RoleUsecaseSuite))
+}
+
+func (s *RoleUsecaseSuite) SetupTest() {
+	s.ctx = context.Background()
+	s.repo = mocks.NewIRoleRepository(s.T())
+	s.usecase = usecases.NewRoleUsecase(s.repo)
+}
+
+func (s *RoleUsecaseSuite) expectMatrix(version int64, roles ...rolepkg.Role) {
+	s.repo.On("Version", s.ctx).Return(version, nil).Once()
+	s.repo.On("ListRoles", s.ctx).Return(roles, nil).Once()
+}
+
+func (s *RoleUsecaseSuite) TestResolve_LoadsMatrixOnce() {
+	s.expectMatrix(3, rolepkg.Role{Name: "author", Permissions: []string{rolepkg.PermPostsWrite}})
+
+	grant, err := s.usecase.Resolve(s.ctx, "author")
+	s.NoError(err)
+	s.Equal(int64(3), grant.Version)
+	s.True(grant.Has(rolepkg.PermPostsWrite))
+
+	_, err = s.usecase.Resolve(s.ctx, "ghost")
+	s.ErrorIs(err, rolepkg.ErrRoleNotFound)
+}
+
+func (s *RoleUsecaseSuite) TestSync_ReloadsOnlyWhenVersionChanged() {
+	s.expectMatrix(1, rolepkg.Role{Name: "author"})
+	s.Require().NoError(s.usecase.Reload(s.ctx))
+
+	s.repo.On("Version", s.ctx).Return(int64(1), nil).Once()
+	s.NoError(s.usecase.Sync(s.ctx))
+
+	s.repo.On("Version", s.ctx).Return(int64(2), nil).Once()
+	s.expectMatrix(2, rolepkg.Role{Name: "author", Permissions: []string{rolepkg.PermCommentsWrite}})
+	s.NoError(s.usecase.Sync(s.ctx))
+
+	grant, err := s.usecase.Resolve(s.ctx, "author")
+	s.NoError(err)
+	s.Equal(int64(2), grant.Version)
+	s.True(grant.Has(rolepkg.PermCommentsWrite))
+}
+
+func (s *RoleUsecaseSuite) TestCreateRole_RejectsUnknownPermission() {
+	s.repo.On("FindRole", s.ctx, "support").Return(rolepkg.Role{}, rolepkg.ErrRoleNotFound).Once()
+
+	_, err := s.usecase.CreateRole(s.ctx, rolepkg.RoleRequest{Name: "support", Permissions: []string{"posts:delete_all"}})
+	s.ErrorContains(err, rolepkg.ErrUnknownPermission.Error())
+	s.repo.AssertNotCalled(s.T(), "SaveRole", mock.Anything, mock.Anything)
+}
+
+func (s *RoleUsecaseSuite) TestUpdateRole_AdminKeepsManagementPermissions() {
+	s.repo.On("FindRole", s.ctx, rolepkg.RoleAdmin).Return(rolepkg.Role{Name: rolepkg.RoleAdmin, BuiltIn: true}, nil).Once()
+
+	_, err := s.usecase.UpdateRole(s.ctx, rolepkg.RoleAdmin, rolepkg.RoleRequest{Permissions: []string{rolepkg.PermUsersManage}})
+	s.EqualError(err, "the admin role must keep roles:manage and users:manage")
+}
+
+func (s *RoleUsecaseSuite) TestUpdateRole_SavesAndBumpsVersion() {
+	s.repo.On("FindRole", s.ctx, "author").Return(rolepkg.Role{Name: "author", BuiltIn: true}, nil).Once()
+	s.repo.On("SaveRole", s.ctx, mock.MatchedBy(func(r rolepkg.Role) bool {
+		return r.Name == "author" && len(r.Permissions) == 2 && r.Permissions[0] == rolepkg.PermCommentsWrite
+	})).Return(nil).Once()
+	s.repo.On("BumpVersion", s.ctx).Return(int64(5), nil).Once()
+	s.expectMatrix(5, rolepkg.Role{Name: "author", Permissions: []string{rolepkg.PermCommentsWrite, rolepkg.PermPostsWrite}})
+
+	role, err := s.usecase.UpdateRole(s.ctx, "author", rolepkg.RoleRequest{
+		Permissions: []string{rolepkg.PermPostsWrite, rolepkg.PermCommentsWrite, rolepkg.PermPostsWrite},
+	})
+	s.NoError(err)
+	s.Equal([]string{rolepkg.PermCommentsWrite, rolepkg.PermPostsWrite}, role.Permissions)
+
+	version, err := s.usecase.CurrentVersion(s.ctx)
+	s.NoError(err)
+	s.Equal(int64(5), version)
+}
+
+func (s *RoleUsecaseSuite) TestDeleteRole_RefusesBuiltIn() {
+	s.repo.On("FindRole", s.ctx, rolepkg.RoleEditor).Return(rolepkg.Role{Name: rolepkg.RoleEditor, BuiltIn: true}, nil).Once()
+
+	s.EqualError(s.usecase.DeleteRole(s.ctx, rolepkg.RoleEditor), "built-in roles cannot be deleted")
+}
+
+func (s *RoleUsecaseSuite) TestDeleteRole_RefusesRoleInUse() {
+	s.repo.On("FindRole", s.ctx, "support").Return(rolepkg.Role{Name: "support"}, nil).Once()
+	s.repo.On("CountUsersWithRole", s.ctx, "support").Return(int64(2), nil).Once()
+
+	s.EqualError(s.usecase.DeleteRole(s.ctx, "support"), "role is still assigned to users")
+	s.repo.AssertNotCalled(s.T(), "DeleteRole", mock.Anything, mock.Anything)
+}
@@ -3,11 +3,21 @@ package usecases_test
 import (
 	"context"
 	"errors"
+	"fmt"
 	"testing"
 	"time"
 
 	"strings"
 
+	eventpkg "github.com/Amaankaa/Blog-Starter-Project/Domain/event"
+	"github.com/Amaankaa/Blog-Starter-Project/Domain/event/eventtest"
+	lockoutpkg "github.com/Amaankaa/Blog-Starter-Project/Domain/lockout"
+	mfapkg "github.com/Amaankaa/Blog-Starter-Project/Domain/mfa"
+	rolepkg "github.com/Amaankaa/Blog-Starter-Project/Domain/role"
+	outboxpkg "github.com/Amaankaa/Blog-Starter-Project/Domain/outbox"
+	otppkg "github.com/Amaankaa/Blog-Starter-Project/Domain/otp"
+	passwordpkg "github.com/Amaankaa/Blog-Starter-Project/Domain/password"
+	"github.com/Amaankaa/Blog-Starter-Project/Domain/services"
 	userpkg "github.com/Amaankaa/Blog-Starter-Project/Domain/user"
 	usecases "github.com/Amaankaa/Blog-Starter-Project/Usecases"
 	"github.com/Amaankaa/Blog-Starter-Project/mocks"
@@ -21,16 +31,33 @@ type UserUsecaseTestSuite struct {
 	ctx                  context.Context
 	mockUserRepo         *mocks.IUserRepository
 	mockPasswordSvc      *mocks.IPasswordService
+	mockPasswordPolicy   *mocks.IPasswordPolicy
 	mockTokenRepo        *mocks.ITokenRepository
 	mockJWTService       *mocks.IJWTService
+	mockTokenRevoker     *mocks.IAccessTokenRevoker
+	mockPATRepo          *mocks.IPATRepository
+	mockMFA              *mocks.IMFAVerifier
+	mockPermissions      *mocks.IPermissionResolver
+	mockLoginGuard       *mocks.ILoginGuard
 	mockEmailVerifier    *mocks.IEmailVerifier
 	mockEmailSender      *mocks.IEmailSender
-	mockResetRepo        *mocks.IPasswordResetRepository
-	mockVerificationRepo *mocks.IVerificationRepository
+	mockEmailRenderer    *mocks.IEmailRenderer
+	mockOTP              *mocks.IOTPService
 	mockCloudinaryService *mocks.ICloudinaryService
+	mockOutboxRepo       *mocks.IOutboxRepository
+	mockTransactor       *mocks.ITransactor
+	events               *eventtest.Recorder
 	usecase              *usecases.UserUsecase
 }
 
+// tokenSubject matches what GenerateToken is given; an empty sessionID matches any session
+func tokenSubject(userID, username, role, sessionID string, mfa bool) interface{} {
+	return mock.MatchedBy(func(t userpkg.TokenSubject) bool {
+		return t.UserID == userID && t.Username == username && t.Role == role && t.MFA == mfa &&
+			(sessionID == "" || t.SessionID == sessionID) && t.PermissionsVersion == 1
+	})
+}
+
 func TestUserUsecaseTestSuite(t *testing.T) {
 	suite.Run(t, new(UserUsecaseTestSuite))
 }
@@ -40,27 +67,59 @@ func (s *UserUsecaseTestSuite) SetupTest() {
 
 	s.mockUserRepo = new(mocks.IUserRepository)
 	s.mockPasswordSvc = new(mocks.IPasswordService)
+	s.mockPasswordPolicy = new(mocks.IPasswordPolicy)
 	s.mockTokenRepo = new(mocks.ITokenRepository)
 	s.mockJWTService = new(mocks.IJWTService)
+	s.mockTokenRevoker = new(mocks.IAccessTokenRevoker)
+	s.mockPATRepo = new(mocks.IPATRepository)
+	s.mockMFA = new(mocks.IMFAVerifier)
+	s.mockPermissions = new(mocks.IPermissionResolver)
+	s.mockPermissions.On("Resolve", mock.Anything, mock.Anything).Return(rolepkg.Grant{Permissions: []string{rolepkg.PermPostsWrite}, Version: 1}, nil)
+	s.mockLoginGuard = new(mocks.ILoginGuard)
+	s.mockLoginGuard.On("Check", mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
+	s.mockLoginGuard.On("RecordFailure", mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
+	s.mockLoginGuard.On("RecordSuccess", mock.Anything, mock.Anything).Return(nil).Maybe()
 	s.mockEmailVerifier = new(mocks.IEmailVerifier)
 	s.mockEmailSender = new(mocks.IEmailSender)
-	s.mockResetRepo = new(mocks.IPasswordResetRepository)
-	s.mockVerificationRepo = new(mocks.IVerificationRepository)
+	s.mockEmailRenderer = new(mocks.IEmailRenderer)
+	s.mockOTP = new(mocks.IOTPService)
 	s.mockCloudinaryService = new(mocks.ICloudinaryService)
+	s.events = eventtest.NewRecorder()
+	s.mockOutboxRepo = new(mocks.IOutboxRepository)
+	s.mockTransactor = new(mocks.ITransactor)
+	s.mockTransactor.On("WithTransaction", mock.Anything, mock.Anything).Return(func(ctx context.Context, fn func(context.Context) error) error {
+		return fn(ctx)
+	})
 
 	s.usecase = usecases.NewUserUsecase(
 		s.mockUserRepo,
 		s.mockPasswordSvc,
+		s.mockPasswordPolicy,
 		s.mockTokenRepo,
 		s.mockJWTService,
+		s.mockTokenRevoker,
+		s.mockPATRepo,
+		s.mockMFA,
+		s.mockPermissions,
+		s.mockLoginGuard,
 		s.mockEmailVerifier,
 		s.mockEmailSender,
-		s.mockResetRepo,
-		s.mockVerificationRepo,
+		s.mockEmailRenderer,
+		s.mockOTP,
 		s.mockCloudinaryService,
+		s.events,
+		s.mockOutboxRepo,
+		s.mockTransactor,
 	)
 }
 
+// expectRender stubs the renderer so the rendered text is "<template> <language> <code>"
+func (s *UserUsecaseTestSuite) expectRender(name, language string) {
+	s.mockEmailRenderer.On("Render", name, language, mock.Anything).Return(func(name, language string, data map[string]interface{}) (services.EmailMessage, error) {
+		return services.EmailMessage{Subject: name, Text: fmt.Sprintf("%s %s %v", name, language, data["Code"])}, nil
+	}).Once()
+}
+
 func (s *UserUsecaseTestSuite) TestRegisterFirstUserAsAdmin() {
 	// Arrange
 	testUser := userpkg.User{
@@ -68,6 +127,7 @@ func (s *UserUsecaseTestSuite) TestRegisterFirstUserAsAdmin() {
 		Email:    "admin@example.com",
 		Password: "AdminPass123!",
 		Fullname: "Admin User",
+		Language: "es",
 	}
 
 	// Expected user with admin role set
@@ -78,20 +138,18 @@ func (s *UserUsecaseTestSuite) TestRegisterFirstUserAsAdmin() {
 	s.mockEmailVerifier.On("IsRealEmail", testUser.Email).Return(true, nil)
 	s.mockUserRepo.On("ExistsByUsername", s.ctx, testUser.Username).Return(false, nil)
 	s.mockUserRepo.On("ExistsByEmail", s.ctx, testUser.Email).Return(false, nil)
+	s.mockPasswordPolicy.On("Check", s.ctx, testUser.Password, testUser).Return(nil)
 	s.mockPasswordSvc.On("HashPassword", testUser.Password).Return("hashedpassword", nil)
 	s.mockUserRepo.On("CreateUser", s.ctx, mock.Anything).Run(func(args mock.Arguments) {
 		userArg := args.Get(1).(userpkg.User)
 		s.Equal("admin", userArg.Role)
 	}).Return(expectedUser, nil)
 
-	// Mock email sending for verification
-	s.mockEmailSender.On("SendEmail", testUser.Email, "Email Verification Code", mock.MatchedBy(func(body string) bool {
-		return strings.Contains(body, "Your verification OTP:")
-	})).Return(nil)
-
-	// Mock OTP hashing and verification storage
-	s.mockPasswordSvc.On("HashPassword", mock.Anything).Return("hashedOTP", nil)
-	s.mockVerificationRepo.On("StoreVerification", s.ctx, mock.Anything).Return(nil)
+	// The verification email is queued in the outbox, not sent inline
+	s.mockOutboxRepo.On("Enqueue", s.ctx, mock.MatchedBy(func(msg outboxpkg.Message) bool {
+		return msg.Kind == outboxpkg.KindVerificationEmail && strings.Contains(msg.Payload, testUser.Email) &&
+			strings.Contains(msg.Payload, `"language":"es"`)
+	})).Return(nil).Once()
 
 	// Act
 	result, err := s.usecase.RegisterUser(s.ctx, testUser)
@@ -102,6 +160,11 @@ func (s *UserUsecaseTestSuite) TestRegisterFirstUserAsAdmin() {
 	s.Equal("admin", result.Role)
 	s.Empty(result.Password) // Password should be scrubbed
 	s.mockUserRepo.AssertExpectations(s.T())
+	s.mockOutboxRepo.AssertExpectations(s.T())
+	s.mockEmailSender.AssertNotCalled(s.T(), "SendEmail", mock.Anything)
+	registered := eventtest.RequireOne[eventpkg.UserRegistered](s.T(), s.events)
+	s.Equal("admin", registered.Role)
+	s.Equal(testUser.Email, registered.Email)
 }
 
 func (s *UserUsecaseTestSuite) TestRegisterSecondUserAsNormal() {
@@ -121,20 +184,17 @@ func (s *UserUsecaseTestSuite) TestRegisterSecondUserAsNormal() {
 	s.mockEmailVerifier.On("IsRealEmail", testUser.Email).Return(true, nil)
 	s.mockUserRepo.On("ExistsByUsername", s.ctx, testUser.Username).Return(false, nil)
 	s.mockUserRepo.On("ExistsByEmail", s.ctx, testUser.Email).Return(false, nil)
+	s.mockPasswordPolicy.On("Check", s.ctx, testUser.Password, testUser).Return(nil)
 	s.mockPasswordSvc.On("HashPassword", testUser.Password).Return("hashedpassword", nil)
 	s.mockUserRepo.On("CreateUser", s.ctx, mock.Anything).Run(func(args mock.Arguments) {
 		userArg := args.Get(1).(userpkg.User)
 		s.Equal("user", userArg.Role)
 	}).Return(expectedUser, nil)
 
-	// Mock email sending for verification
-	s.mockEmailSender.On("SendEmail", testUser.Email, "Email Verification Code", mock.MatchedBy(func(body string) bool {
-		return strings.Contains(body, "Your verification OTP:")
-	})).Return(nil)
-
-	// Mock OTP hashing and verification storage
-	s.mockPasswordSvc.On("HashPassword", mock.Anything).Return("hashedOTP", nil)
-	s.mockVerificationRepo.On("StoreVerification", s.ctx, mock.Anything).Return(nil)
+	// The verification email is queued in the outbox, not sent inline
+	s.mockOutboxRepo.On("Enqueue", s.ctx, mock.MatchedBy(func(msg outboxpkg.Message) bool {
+		return msg.Kind == outboxpkg.KindVerificationEmail && strings.Contains(msg.Payload, testUser.Email)
+	})).Return(nil).Once()
 
 	// Act
 	result, err := s.usecase.RegisterUser(s.ctx, testUser)
@@ -147,6 +207,57 @@ func (s *UserUsecaseTestSuite) TestRegisterSecondUserAsNormal() {
 	s.mockUserRepo.AssertExpectations(s.T())
 }
 
+func (s *UserUsecaseTestSuite) TestRegisterFailsWhenOutboxWriteFails() {
+	testUser := userpkg.User{
+		Username: "normaluser",
+		Email:    "user@example.com",
+		Password: "UserPass123!",
+		Fullname: "Normal User",
+	}
+
+	s.mockUserRepo.On("CountUsers", s.ctx).Return(int64(1), nil)
+	s.mockEmailVerifier.On("IsRealEmail", testUser.Email).Return(true, nil)
+	s.mockUserRepo.On("ExistsByUsername", s.ctx, testUser.Username).Return(false, nil)
+	s.mockUserRepo.On("ExistsByEmail", s.ctx, testUser.Email).Return(false, nil)
+	s.mockPasswordPolicy.On("Check", s.ctx, testUser.Password, testUser).Return(nil)
+	s.mockPasswordSvc.On("HashPassword", testUser.Password).Return("hashedpassword", nil)
+	s.mockUserRepo.On("CreateUser", s.ctx, mock.Anything).Return(testUser, nil)
+	s.mockOutboxRepo.On("Enqueue", s.ctx, mock.Anything).Return(errors.New("write conflict"))
+
+	_, err := s.usecase.RegisterUser(s.ctx, testUser)
+
+	s.EqualError(err, "write conflict")
+	eventtest.AssertNone[eventpkg.UserRegistered](s.T(), s.events)
+}
+
+func (s *UserUsecaseTestSuite) TestDeliverVerificationEmail_StoresCodeBeforeSending() {
+	msg := outboxpkg.Message{Kind: outboxpkg.KindVerificationEmail, Payload: `{"email":"user@example.com","name":"Jane","language":"es"}`}
+	var stored bool
+	s.mockOTP.On("Issue", s.ctx, otppkg.PurposeVerification, "user@example.com", "").
+		Run(func(mock.Arguments) { stored = true }).
+		Return("482913", time.Now().Add(15*time.Minute), nil)
+	s.mockEmailRenderer.On("Render", services.TemplateVerification, "es", mock.MatchedBy(func(data map[string]interface{}) bool {
+		return data["Name"] == "Jane" && data["Code"] == "482913" && data["ExpiresInMinutes"] == 15
+	})).Return(services.EmailMessage{Subject: "Verifica tu dirección de correo", Text: "code"}, nil).Once()
+	s.mockEmailSender.On("SendEmail", mock.MatchedBy(func(m services.EmailMessage) bool {
+		s.True(stored, "code must be stored before the email is sent")
+		return m.To == "user@example.com" && m.Subject == "Verifica tu dirección de correo"
+	})).Return(nil)
+
+	s.NoError(s.usecase.DeliverVerificationEmail(s.ctx, msg))
+	s.mockEmailSender.AssertExpectations(s.T())
+}
+
+func (s *UserUsecaseTestSuite) TestDeliverVerificationEmail_SendFailureIsReturnedForRetry() {
+	msg := outboxpkg.Message{Kind: outboxpkg.KindVerificationEmail, Payload: `{"email":"user@example.com"}`}
+	s.mockOTP.On("Issue", s.ctx, otppkg.PurposeVerification, "user@example.com", "").Return("482913", time.Now().Add(15*time.Minute), nil)
+	s.expectRender(services.TemplateVerification, "")
+	s.mockEmailSender.On("SendEmail", mock.Anything).Return(errors.New("smtp down"))
+
+	err := s.usecase.DeliverVerificationEmail(s.ctx, msg)
+	s.EqualError(err, "failed to send verification code")
+}
+
 func (s *UserUsecaseTestSuite) TestRejectsInvalidEmailFormat() {
 	// Arrange
 	testUser := userpkg.User{
@@ -177,13 +288,15 @@ func (s *UserUsecaseTestSuite) TestRejectWeakPassword() {
 	s.mockUserRepo.On("ExistsByUsername", s.ctx, testUser.Username).Return(false, nil)
 	s.mockUserRepo.On("ExistsByEmail", s.ctx, testUser.Email).Return(false, nil)
 	s.mockEmailVerifier.On("IsRealEmail", testUser.Email).Return(true, nil)
+	rejected := &passwordpkg.PolicyError{Reasons: []string{"password must be at least 8 characters"}}
+	s.mockPasswordPolicy.On("Check", s.ctx, "weak", testUser).Return(rejected)
 
 	// Act
 	_, err := s.usecase.RegisterUser(s.ctx, testUser)
 
 	// Assert
-	s.Error(err)
-	s.Equal("password must be at least 8 chars, with upper, lower, number, and special char", err.Error())
+	s.ErrorIs(err, rejected)
+	s.mockUserRepo.AssertNotCalled(s.T(), "CreateUser", mock.Anything, mock.Anything)
 }
 
 func (s *UserUsecaseTestSuite) TestRejectDuplicateUsername() {
@@ -293,17 +406,28 @@ func (s *UserUsecaseTestSuite) TestLoginUser_Success() {
 
 	tokenRes := userpkg.TokenResult{
 		AccessToken:      "access_token",
+		AccessTokenID:    "access-jti",
 		RefreshToken:     "refresh_token",
+		AccessExpiresAt:  time.Now().Add(15 * time.Minute),
 		RefreshExpiresAt: time.Now().Add(24 * time.Hour),
 	}
 
 	s.mockUserRepo.On("GetUserByLogin", s.ctx, login).Return(testUser, nil)
 	s.mockPasswordSvc.On("ComparePassword", hashedPassword, password).Return(nil)
-	s.mockJWTService.On("GenerateToken", userID.Hex(), login, "user").Return(tokenRes, nil)
-	s.mockTokenRepo.On("StoreToken", s.ctx, mock.Anything).Return(nil)
+	s.mockPasswordSvc.On("NeedsRehash", hashedPassword).Return(false)
+	s.mockMFA.On("IsEnabled", s.ctx, userID.Hex()).Return(false, nil)
+	device := userpkg.DeviceInfo{UserAgent: "Firefox", IPAddress: "203.0.113.7"}
+	var sessionID string
+	s.mockJWTService.On("GenerateToken", tokenSubject(userID.Hex(), login, "user", "", false)).
+		Run(func(args mock.Arguments) { sessionID = args.Get(0).(userpkg.TokenSubject).SessionID }).
+		Return(tokenRes, nil)
+	s.mockTokenRepo.On("StoreToken", s.ctx, mock.MatchedBy(func(t userpkg.Token) bool {
+		return t.FamilyID != "" && t.FamilyID == sessionID && t.UserAgent == "Firefox" && t.IPAddress == "203.0.113.7" && !t.LastUsedAt.IsZero() &&
+			t.AccessTokenID == "access-jti" && t.AccessExpiresAt.Equal(tokenRes.AccessExpiresAt)
+	})).Return(nil)
 
 	// Act
-	user, accessToken, refreshToken, err := s.usecase.LoginUser(s.ctx, login, password)
+	user, accessToken, refreshToken, err := s.usecase.LoginUser(s.ctx, login, password, device)
 
 	// Assert
 	s.NoError(err)
@@ -315,6 +439,150 @@ func (s *UserUsecaseTestSuite) TestLoginUser_Success() {
 	s.mockPasswordSvc.AssertExpectations(s.T())
 	s.mockJWTService.AssertExpectations(s.T())
 	s.mockTokenRepo.AssertExpectations(s.T())
+	s.mockLoginGuard.AssertCalled(s.T(), "RecordSuccess", s.ctx, userID.Hex())
+}
+
+func (s *UserUsecaseTestSuite) TestLoginUser_MFARequired() {
+	userID := primitive.NewObjectID()
+	testUser := userpkg.User{ID: userID, Username: "jane", Password: "hashed", Role: "admin", IsVerified: true}
+	expiresAt := time.Now().Add(5 * time.Minute)
+
+	s.mockUserRepo.On("GetUserByLogin", s.ctx, "jane").Return(testUser, nil)
+	s.mockPasswordSvc.On("ComparePassword", "hashed", "secret").Return(nil)
+	s.mockPasswordSvc.On("NeedsRehash", "hashed").Return(false)
+	s.mockMFA.On("IsEnabled", s.ctx, userID.Hex()).Return(true, nil)
+	s.mockMFA.On("StartChallenge", s.ctx, userID.Hex()).Return("challenge", expiresAt, nil)
+
+	_, accessToken, _, err := s.usecase.LoginUser(s.ctx, "jane", "secret", userpkg.DeviceInfo{})
+
+	var mfaRequired *userpkg.MFARequiredError
+	s.Require().ErrorAs(err, &mfaRequired)
+	s.Equal("challenge", mfaRequired.ChallengeToken)
+	s.Equal(expiresAt, mfaRequired.ExpiresAt)
+	s.Empty(accessToken)
+	s.mockJWTService.AssertNotCalled(s.T(), "GenerateToken", mock.Anything)
+	s.mockLoginGuard.AssertNotCalled(s.T(), "RecordSuccess", mock.Anything, mock.Anything)
+}
+
+func (s *UserUsecaseTestSuite) TestLoginUser_UpgradesAnOldHash() {
+	userID := primitive.NewObjectID()
+	bcryptHash := "$2a$10$legacyhash"
+	testUser := userpkg.User{ID: userID, Username: "jane", Password: bcryptHash, Role: "user", IsVerified: true}
+
+	s.mockUserRepo.On("GetUserByLogin", s.ctx, "jane").Return(testUser, nil)
+	s.mockPasswordSvc.On("ComparePassword", bcryptHash, "secret").Return(nil)
+	s.mockPasswordSvc.On("NeedsRehash", bcryptHash).Return(true)
+	s.mockPasswordSvc.On("HashPassword", "secret").Return("$argon2id$v=19$new", nil).Once()
+	s.mockUserRepo.On("ReplacePasswordHash", s.ctx, userID.Hex(), bcryptHash, "$argon2id$v=19$new").Return(nil).Once()
+	// The login itself stops at the second factor; the hash is upgraded before it
+	s.mockMFA.On("IsEnabled", s.ctx, userID.Hex()).Return(true, nil)
+	s.mockMFA.On("StartChallenge", s.ctx, userID.Hex()).Return("challenge", time.Now().Add(5*time.Minute), nil)
+
+	_, _, _, err := s.usecase.LoginUser(s.ctx, "jane", "secret", userpkg.DeviceInfo{})
+
+	var mfaRequired *userpkg.MFARequiredError
+	s.ErrorAs(err, &mfaRequired)
+	s.mockUserRepo.AssertExpectations(s.T())
+}
+
+func (s *UserUsecaseTestSuite) TestLoginUser_FailedHashUpgradeStillSignsIn() {
+	userID := primitive.NewObjectID()
+	testUser := userpkg.User{ID: userID, Username: "jane", Password: "$2a$10$legacyhash", Role: "user", IsVerified: true}
+
+	s.mockUserRepo.On("GetUserByLogin", s.ctx, "jane").Return(testUser, nil)
+	s.mockPasswordSvc.On("ComparePassword", testUser.Password, "secret").Return(nil)
+	s.mockPasswordSvc.On("NeedsRehash", testUser.Password).Return(true)
+	s.mockPasswordSvc.On("HashPassword", "secret").Return("$argon2id$v=19$new", nil)
+	s.mockUserRepo.On("ReplacePasswordHash", s.ctx, userID.Hex(), testUser.Password, mock.Anything).Return(errors.New("connection reset"))
+	s.mockMFA.On("IsEnabled", s.ctx, userID.Hex()).Return(false, nil)
+	s.mockJWTService.On("GenerateToken", mock.Anything).Return(userpkg.TokenResult{AccessToken: "access"}, nil)
+	s.mockTokenRepo.On("StoreToken", s.ctx, mock.Anything).Return(nil)
+
+	_, accessToken, _, err := s.usecase.LoginUser(s.ctx, "jane", "secret", userpkg.DeviceInfo{})
+
+	s.NoError(err)
+	s.Equal("access", accessToken)
+}
+
+func (s *UserUsecaseTestSuite) TestCompleteMFALogin() {
+	userID := primitive.NewObjectID()
+	testUser := userpkg.User{ID: userID, Username: "jane", Password: "hashed", Role: "admin", IsVerified: true}
+	tokenRes := userpkg.TokenResult{AccessToken: "access", RefreshToken: "refresh", RefreshExpiresAt: time.Now().Add(time.Hour)}
+
+	s.mockMFA.On("ChallengeUser", s.ctx, "challenge").Return(userID.Hex(), nil)
+	s.mockMFA.On("CompleteChallenge", s.ctx, "challenge", "123456").Return(userID.Hex(), nil)
+	s.mockUserRepo.On("FindByID", s.ctx, userID.Hex()).Return(testUser, nil)
+	s.mockJWTService.On("GenerateToken", tokenSubject(userID.Hex(), "jane", "admin", "", true)).Return(tokenRes, nil)
+	s.mockTokenRepo.On("StoreToken", s.ctx, mock.MatchedBy(func(t userpkg.Token) bool {
+		return t.MFA && t.RefreshToken == "refresh"
+	})).Return(nil)
+
+	user, accessToken, refreshToken, err := s.usecase.CompleteMFALogin(s.ctx, "challenge", "123456", userpkg.DeviceInfo{})
+
+	s.NoError(err)
+	s.Equal("jane", user.Username)
+	s.Empty(user.Password)
+	s.Equal("access", accessToken)
+	s.Equal("refresh", refreshToken)
+	s.mockTokenRepo.AssertExpectations(s.T())
+	s.mockLoginGuard.AssertCalled(s.T(), "RecordSuccess", s.ctx, userID.Hex())
+}
+
+func (s *UserUsecaseTestSuite) TestCompleteMFALogin_InvalidCodeIsRecorded() {
+	testUser := userpkg.User{ID: primitive.NewObjectID(), Username: "jane", Password: "hashed", IsVerified: true}
+	device := userpkg.DeviceInfo{IPAddress: "203.0.113.7"}
+	s.mockMFA.On("ChallengeUser", s.ctx, "challenge").Return(testUser.ID.Hex(), nil)
+	s.mockMFA.On("CompleteChallenge", s.ctx, "challenge", "000000").Return(testUser.ID.Hex(), mfapkg.ErrInvalidCode)
+	s.mockUserRepo.On("FindByID", s.ctx, testUser.ID.Hex()).Return(testUser, nil)
+
+	_, _, _, err := s.usecase.CompleteMFALogin(s.ctx, "challenge", "000000", device)
+
+	s.ErrorIs(err, mfapkg.ErrInvalidCode)
+	s.mockTokenRepo.AssertNotCalled(s.T(), "StoreToken", mock.Anything, mock.Anything)
+	s.mockLoginGuard.AssertCalled(s.T(), "RecordFailure", s.ctx, testUser, device)
+	s.mockLoginGuard.AssertNotCalled(s.T(), "RecordSuccess", mock.Anything, mock.Anything)
+}
+
+func (s *UserUsecaseTestSuite) TestCompleteMFALogin_ThrottledSkipsTheCode() {
+	s.mockMFA.On("ChallengeUser", s.ctx, "challenge").Return("u1", nil)
+	s.mockLoginGuard.ExpectedCalls = nil
+	// The account's own lockout applies, not just the address's
+	s.mockLoginGuard.On("Check", s.ctx, "u1", "203.0.113.7").Return(&lockoutpkg.ThrottledError{RetryAfter: time.Minute})
+
+	_, _, _, err := s.usecase.CompleteMFALogin(s.ctx, "challenge", "123456", userpkg.DeviceInfo{IPAddress: "203.0.113.7"})
+
+	var throttled *lockoutpkg.ThrottledError
+	s.Require().ErrorAs(err, &throttled)
+	s.mockMFA.AssertNotCalled(s.T(), "CompleteChallenge", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (s *UserUsecaseTestSuite) TestCompleteMFALogin_UnknownChallenge() {
+	s.mockMFA.On("ChallengeUser", s.ctx, "stale").Return("", mfapkg.ErrChallengeNotFound)
+
+	_, _, _, err := s.usecase.CompleteMFALogin(s.ctx, "stale", "123456", userpkg.DeviceInfo{})
+
+	s.ErrorIs(err, mfapkg.ErrChallengeNotFound)
+	s.mockMFA.AssertNotCalled(s.T(), "CompleteChallenge", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (s *UserUsecaseTestSuite) TestRefreshToken_KeepsMFA() {
+	userID := primitive.NewObjectID()
+	s.mockJWTService.On("ValidateToken", "refresh", userpkg.TokenTypeRefresh).Return(map[string]interface{}{"_id": userID.Hex()}, nil)
+	s.mockTokenRepo.On("FindByRefreshToken", s.ctx, "refresh").Return(userpkg.Token{
+		UserID:       userID,
+		RefreshToken: "refresh",
+		FamilyID:     "family-1",
+		MFA:          true,
+		ExpiresAt:    time.Now().Add(time.Hour),
+	}, nil)
+	s.mockUserRepo.On("FindByID", s.ctx, userID.Hex()).Return(userpkg.User{ID: userID, Username: "jane", Role: "admin"}, nil)
+	s.mockJWTService.On("GenerateToken", tokenSubject(userID.Hex(), "jane", "admin", "family-1", true)).Return(userpkg.TokenResult{RefreshToken: "next"}, nil)
+	s.mockTokenRepo.On("RotateRefreshToken", s.ctx, "refresh", mock.MatchedBy(func(t userpkg.Token) bool { return t.MFA })).Return(nil)
+
+	_, err := s.usecase.RefreshToken(s.ctx, "refresh", userpkg.DeviceInfo{})
+
+	s.NoError(err)
+	s.mockTokenRepo.AssertExpectations(s.T())
 }
 
 func (s *UserUsecaseTestSuite) TestLoginUser_NotFound() {
@@ -325,7 +593,7 @@ func (s *UserUsecaseTestSuite) TestLoginUser_NotFound() {
 	s.mockUserRepo.On("GetUserByLogin", s.ctx, login).Return(userpkg.User{}, errors.New("not found"))
 
 	// Act
-	_, _, _, err := s.usecase.LoginUser(s.ctx, login, password)
+	_, _, _, err := s.usecase.LoginUser(s.ctx, login, password, userpkg.DeviceInfo{})
 
 	// Assert
 	s.Error(err)
@@ -350,7 +618,7 @@ func (s *UserUsecaseTestSuite) TestLoginUser_WrongPassword() {
 	s.mockPasswordSvc.On("ComparePassword", hashedPassword, password).Return(errors.New("mismatch"))
 
 	// Act
-	_, _, _, err := s.usecase.LoginUser(s.ctx, login, password)
+	_, _, _, err := s.usecase.LoginUser(s.ctx, login, password, userpkg.DeviceInfo{})
 
 	// Assert
 	s.Error(err)
@@ -359,11 +627,50 @@ func (s *UserUsecaseTestSuite) TestLoginUser_WrongPassword() {
 	s.mockPasswordSvc.AssertExpectations(s.T())
 }
 
+func (s *UserUsecaseTestSuite) TestLoginUser_WrongPasswordIsRecorded() {
+	testUser := userpkg.User{ID: primitive.NewObjectID(), Username: "jane", Password: "hashed", IsVerified: true}
+	device := userpkg.DeviceInfo{IPAddress: "203.0.113.7"}
+	s.mockUserRepo.On("GetUserByLogin", s.ctx, "jane").Return(testUser, nil)
+	s.mockPasswordSvc.On("ComparePassword", "hashed", "guess").Return(errors.New("mismatch"))
+
+	_, _, _, err := s.usecase.LoginUser(s.ctx, "jane", "guess", device)
+
+	s.EqualError(err, "invalid credentials")
+	s.mockLoginGuard.AssertCalled(s.T(), "Check", s.ctx, testUser.ID.Hex(), "203.0.113.7")
+	s.mockLoginGuard.AssertCalled(s.T(), "RecordFailure", s.ctx, testUser, device)
+	s.mockLoginGuard.AssertNotCalled(s.T(), "RecordSuccess", mock.Anything, mock.Anything)
+}
+
+func (s *UserUsecaseTestSuite) TestLoginUser_UnknownLoginOnlyCountsTheAddress() {
+	device := userpkg.DeviceInfo{IPAddress: "203.0.113.7"}
+	s.mockUserRepo.On("GetUserByLogin", s.ctx, "ghost").Return(userpkg.User{}, errors.New("not found"))
+
+	_, _, _, err := s.usecase.LoginUser(s.ctx, "ghost", "guess", device)
+
+	s.EqualError(err, "invalid credentials")
+	s.mockLoginGuard.AssertCalled(s.T(), "Check", s.ctx, "", "203.0.113.7")
+	s.mockLoginGuard.AssertCalled(s.T(), "RecordFailure", s.ctx, userpkg.User{}, device)
+}
+
+func (s *UserUsecaseTestSuite) TestLoginUser_ThrottledSkipsPasswordCheck() {
+	testUser := userpkg.User{ID: primitive.NewObjectID(), Username: "jane", Password: "hashed", IsVerified: true}
+	s.mockUserRepo.On("GetUserByLogin", s.ctx, "jane").Return(testUser, nil)
+	s.mockLoginGuard.ExpectedCalls = nil
+	s.mockLoginGuard.On("Check", s.ctx, testUser.ID.Hex(), "").Return(&lockoutpkg.ThrottledError{RetryAfter: time.Minute, Locked: true})
+
+	_, _, _, err := s.usecase.LoginUser(s.ctx, "jane", "secret", userpkg.DeviceInfo{})
+
+	var throttled *lockoutpkg.ThrottledError
+	s.Require().ErrorAs(err, &throttled)
+	s.True(throttled.Locked)
+	s.mockPasswordSvc.AssertNotCalled(s.T(), "ComparePassword", mock.Anything, mock.Anything)
+}
+
 func (s *UserUsecaseTestSuite) TestSendResetOTP_EmailNotFound() {
 	// Arrange
 	email := "nonexistent@example.com"
 
-	s.mockUserRepo.On("ExistsByEmail", s.ctx, email).Return(false, nil)
+	s.mockUserRepo.On("FindByEmail", s.ctx, email).Return(userpkg.User{}, errors.New("user not found"))
 
 	// Act
 	err := s.usecase.SendResetOTP(s.ctx, email)
@@ -377,15 +684,13 @@ func (s *UserUsecaseTestSuite) TestSendResetOTP_EmailNotFound() {
 func (s *UserUsecaseTestSuite) TestSendResetOTP_Success() {
 	// Arrange
 	email := "user@example.com"
-	hashedOTP := "hashedOTP"
-
-	s.mockUserRepo.On("ExistsByEmail", s.ctx, email).Return(true, nil)
-	s.mockEmailSender.On("SendEmail", "user@example.com", "Your OTP Code", mock.Anything).Return(nil)
-	s.mockPasswordSvc.
-		On("HashPassword", mock.Anything).
-		Return(hashedOTP, nil)
 
-	s.mockResetRepo.On("StoreResetRequest", s.ctx, mock.Anything).Return(nil)
+	s.mockUserRepo.On("FindByEmail", s.ctx, email).Return(userpkg.User{Email: email, Fullname: "Jane", Language: "es-MX"}, nil)
+	s.mockOTP.On("Issue", s.ctx, otppkg.PurposePasswordReset, email, "").Return("482913", time.Now().Add(10*time.Minute), nil)
+	s.expectRender(services.TemplatePasswordReset, "es-MX")
+	s.mockEmailSender.On("SendEmail", mock.MatchedBy(func(m services.EmailMessage) bool {
+		return m.To == email && m.Text == "password_reset es-MX 482913"
+	})).Return(nil)
 
 	// Act
 	err := s.usecase.SendResetOTP(s.ctx, email)
@@ -394,106 +699,36 @@ func (s *UserUsecaseTestSuite) TestSendResetOTP_Success() {
 	s.NoError(err)
 	s.mockUserRepo.AssertExpectations(s.T())
 	s.mockEmailSender.AssertExpectations(s.T())
-	s.mockPasswordSvc.AssertExpectations(s.T())
-	s.mockResetRepo.AssertExpectations(s.T())
+	s.mockOTP.AssertExpectations(s.T())
 }
 
-func (s *UserUsecaseTestSuite) TestVerifyOTP_Success() {
-	// Arrange
+func (s *UserUsecaseTestSuite) TestSendResetOTP_CooldownSendsNothing() {
 	email := "user@example.com"
-	otp := "123456"
-	hashedOTP := "hashedOTP"
-
-	storedReset := userpkg.PasswordReset{
-		Email:     email,
-		OTP:       hashedOTP,
-		ExpiresAt: time.Now().Add(10 * time.Minute),
-	}
-
-	s.mockResetRepo.On("GetResetRequest", s.ctx, email).Return(storedReset, nil)
-	s.mockPasswordSvc.On("ComparePassword", hashedOTP, otp).Return(nil)
-	s.mockResetRepo.On("DeleteResetRequest", s.ctx, email).Return(nil)
+	s.mockUserRepo.On("FindByEmail", s.ctx, email).Return(userpkg.User{Email: email}, nil)
+	s.mockOTP.On("Issue", s.ctx, otppkg.PurposePasswordReset, email, "").Return("", time.Time{}, &otppkg.CooldownError{RetryAfter: 30 * time.Second})
 
-	// Act
-	err := s.usecase.VerifyOTP(s.ctx, email, otp)
-
-	// Assert
-	s.NoError(err)
-	s.mockResetRepo.AssertExpectations(s.T())
-	s.mockPasswordSvc.AssertExpectations(s.T())
-}
-
-func (s *UserUsecaseTestSuite) TestVerifyOTP_Expired() {
-	// Arrange
-	email := "user@example.com"
-	otp := "123456"
-
-	storedReset := userpkg.PasswordReset{
-		Email:     email,
-		OTP:       "hashedOTP",
-		ExpiresAt: time.Now().Add(-10 * time.Minute), // Already expired
-	}
-
-	s.mockResetRepo.On("GetResetRequest", s.ctx, email).Return(storedReset, nil)
-	s.mockResetRepo.On("DeleteResetRequest", s.ctx, email).Return(nil)
-
-	// Act
-	err := s.usecase.VerifyOTP(s.ctx, email, otp)
+	err := s.usecase.SendResetOTP(s.ctx, email)
 
-	// Assert
-	s.Error(err)
-	s.Equal("OTP expired", err.Error())
-	s.mockResetRepo.AssertExpectations(s.T())
+	var cooldown *otppkg.CooldownError
+	s.ErrorAs(err, &cooldown)
+	s.mockEmailSender.AssertNotCalled(s.T(), "SendEmail", mock.Anything)
 }
 
-func (s *UserUsecaseTestSuite) TestVerifyOTP_MaxAttempts() {
-	// Arrange
-	email := "user@example.com"
-	otp := "123456"
-
-	storedReset := userpkg.PasswordReset{
-		Email:        email,
-		OTP:          "hashedOTP",
-		ExpiresAt:    time.Now().Add(10 * time.Minute),
-		AttemptCount: 5, // Max attempts
-	}
-
-	s.mockResetRepo.On("GetResetRequest", s.ctx, email).Return(storedReset, nil)
-	s.mockResetRepo.On("DeleteResetRequest", s.ctx, email).Return(nil)
+func (s *UserUsecaseTestSuite) TestVerifyOTP_ExchangesTheCodeForAResetToken() {
+	s.mockOTP.On("Exchange", s.ctx, otppkg.PurposePasswordReset, "user@example.com", "123456", 15*time.Minute).Return("reset-token", nil)
 
-	// Act
-	err := s.usecase.VerifyOTP(s.ctx, email, otp)
+	resetToken, err := s.usecase.VerifyOTP(s.ctx, "user@example.com", "123456")
 
-	// Assert
-	s.Error(err)
-	s.Equal("too many invalid attempts — OTP expired", err.Error())
-	s.mockResetRepo.AssertExpectations(s.T())
+	s.NoError(err)
+	s.Equal("reset-token", resetToken)
 }
 
 func (s *UserUsecaseTestSuite) TestVerifyOTP_InvalidOTP() {
-	// Arrange
-	email := "user@example.com"
-	otp := "wrong123"
-	hashedOTP := "hashedOTP"
+	s.mockOTP.On("Exchange", s.ctx, otppkg.PurposePasswordReset, "user@example.com", "wrong123", 15*time.Minute).Return("", otppkg.ErrInvalidCode)
 
-	storedReset := userpkg.PasswordReset{
-		Email:     email,
-		OTP:       hashedOTP,
-		ExpiresAt: time.Now().Add(10 * time.Minute),
-	}
-
-	s.mockResetRepo.On("GetResetRequest", s.ctx, email).Return(storedReset, nil)
-	s.mockPasswordSvc.On("ComparePassword", hashedOTP, otp).Return(errors.New("mismatch"))
-	s.mockResetRepo.On("IncrementAttemptCount", s.ctx, email).Return(nil)
-
-	// Act
-	err := s.usecase.VerifyOTP(s.ctx, email, otp)
+	_, err := s.usecase.VerifyOTP(s.ctx, "user@example.com", "wrong123")
 
-	// Assert
-	s.Error(err)
-	s.Equal("invalid OTP", err.Error())
-	s.mockResetRepo.AssertExpectations(s.T())
-	s.mockPasswordSvc.AssertExpectations(s.T())
+	s.ErrorIs(err, otppkg.ErrInvalidCode)
 }
 
 func (s *UserUsecaseTestSuite) TestResetPassword_Success() {
@@ -501,17 +736,65 @@ func (s *UserUsecaseTestSuite) TestResetPassword_Success() {
 	email := "user@example.com"
 	newPassword := "NewPass123!"
 	hashedPassword := "hashedNewPassword"
+	userID := primitive.NewObjectID()
+	device := userpkg.DeviceInfo{IPAddress: "203.0.113.7", UserAgent: "Firefox"}
+	expiresAt := time.Now().Add(10 * time.Minute)
+
+	user := userpkg.User{ID: userID, Email: email, Password: "hashedOldPassword", Language: "es"}
 
+	s.mockOTP.On("Lookup", s.ctx, otppkg.PurposePasswordReset, "reset-token").Return(otppkg.Challenge{Subject: email}, nil)
+	s.mockUserRepo.On("FindByEmail", s.ctx, email).Return(user, nil)
+	s.mockPasswordPolicy.On("Check", s.ctx, newPassword, user).Return(nil).Once()
+	s.mockOTP.On("Redeem", s.ctx, otppkg.PurposePasswordReset, "reset-token").Return(otppkg.Challenge{Subject: email}, nil).Once()
 	s.mockPasswordSvc.On("HashPassword", newPassword).Return(hashedPassword, nil)
+	s.mockPasswordPolicy.On("Retire", s.ctx, user).Return(nil).Once()
 	s.mockUserRepo.On("UpdatePasswordByEmail", s.ctx, email, hashedPassword).Return(nil)
+	s.mockTokenRepo.On("ListActiveAccessTokens", s.ctx, userID.Hex()).Return([]userpkg.Token{
+		{FamilyID: "laptop", AccessTokenID: "laptop-access", AccessExpiresAt: expiresAt},
+	}, nil).Once()
+	s.mockTokenRevoker.On("Revoke", s.ctx, userpkg.RevokedToken{ID: "laptop-access", UserID: userID.Hex(), ExpiresAt: expiresAt}).Return(nil).Once()
+	s.mockTokenRepo.On("DeleteTokensByUserID", s.ctx, userID.Hex()).Return(nil).Once()
+	s.mockPATRepo.On("DeleteByUser", s.ctx, userID.Hex()).Return(nil).Once()
+	s.expectRender(services.TemplateSecurityAlert, "es")
+	s.mockEmailSender.On("SendEmail", mock.MatchedBy(func(m services.EmailMessage) bool { return m.To == email })).Return(nil).Once()
 
 	// Act
-	err := s.usecase.ResetPassword(s.ctx, email, newPassword)
+	err := s.usecase.ResetPassword(s.ctx, "reset-token", newPassword, device)
 
 	// Assert
 	s.NoError(err)
 	s.mockPasswordSvc.AssertExpectations(s.T())
+	s.mockPasswordPolicy.AssertExpectations(s.T())
+	s.mockOTP.AssertExpectations(s.T())
 	s.mockUserRepo.AssertExpectations(s.T())
+	s.mockTokenRepo.AssertExpectations(s.T())
+	s.mockTokenRevoker.AssertExpectations(s.T())
+	s.mockPATRepo.AssertExpectations(s.T())
+	s.mockEmailSender.AssertExpectations(s.T())
+	s.mockLoginGuard.AssertCalled(s.T(), "RecordSuccess", s.ctx, userID.Hex())
+}
+
+func (s *UserUsecaseTestSuite) TestResetPassword_RejectedPasswordKeepsTheToken() {
+	user := userpkg.User{ID: primitive.NewObjectID(), Email: "user@example.com", Password: "hashedOldPassword"}
+	rejected := &passwordpkg.PolicyError{Reasons: []string{"password must not be one of your last 5 passwords"}}
+	s.mockOTP.On("Lookup", s.ctx, otppkg.PurposePasswordReset, "reset-token").Return(otppkg.Challenge{Subject: user.Email}, nil)
+	s.mockUserRepo.On("FindByEmail", s.ctx, user.Email).Return(user, nil)
+	s.mockPasswordPolicy.On("Check", s.ctx, "OldPass123!", user).Return(rejected)
+
+	err := s.usecase.ResetPassword(s.ctx, "reset-token", "OldPass123!", userpkg.DeviceInfo{})
+
+	s.ErrorIs(err, rejected)
+	s.mockOTP.AssertNotCalled(s.T(), "Redeem", mock.Anything, mock.Anything, mock.Anything)
+	s.mockUserRepo.AssertNotCalled(s.T(), "UpdatePasswordByEmail", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (s *UserUsecaseTestSuite) TestResetPassword_InvalidToken() {
+	s.mockOTP.On("Lookup", s.ctx, otppkg.PurposePasswordReset, "forged").Return(otppkg.Challenge{}, otppkg.ErrInvalidToken)
+
+	err := s.usecase.ResetPassword(s.ctx, "forged", "NewPass123!", userpkg.DeviceInfo{})
+
+	s.ErrorIs(err, userpkg.ErrInvalidResetToken)
+	s.mockUserRepo.AssertNotCalled(s.T(), "UpdatePasswordByEmail", mock.Anything, mock.Anything, mock.Anything)
 }
 
 func (s *UserUsecaseTestSuite) TestRefreshToken_Success() {
@@ -530,6 +813,7 @@ func (s *UserUsecaseTestSuite) TestRefreshToken_Success() {
 	storedToken := userpkg.Token{
 		UserID:       userID,
 		RefreshToken: refreshToken,
+		FamilyID:     "family-1",
 		ExpiresAt:    time.Now().Add(24 * time.Hour),
 	}
 
@@ -545,15 +829,17 @@ func (s *UserUsecaseTestSuite) TestRefreshToken_Success() {
 		RefreshExpiresAt: time.Now().Add(24 * time.Hour),
 	}
 
-	s.mockJWTService.On("ValidateToken", refreshToken).Return(claims, nil)
+	s.mockJWTService.On("ValidateToken", refreshToken, userpkg.TokenTypeRefresh).Return(claims, nil)
 	s.mockTokenRepo.On("FindByRefreshToken", s.ctx, refreshToken).Return(storedToken, nil)
 	s.mockUserRepo.On("FindByID", s.ctx, userID.Hex()).Return(user, nil)
-	s.mockJWTService.On("GenerateToken", userID.Hex(), username, role).Return(newTokens, nil)
-	s.mockTokenRepo.On("DeleteByRefreshToken", s.ctx, refreshToken).Return(nil)
-	s.mockTokenRepo.On("StoreToken", s.ctx, mock.Anything).Return(nil)
+	s.mockJWTService.On("GenerateToken", tokenSubject(userID.Hex(), username, role, "family-1", false)).Return(newTokens, nil)
+	s.mockTokenRepo.On("RotateRefreshToken", s.ctx, refreshToken, mock.MatchedBy(func(t userpkg.Token) bool {
+		return t.RefreshToken == "new_refresh_token" && t.FamilyID == "family-1" && t.UserID == userID &&
+			t.CreatedAt.Equal(storedToken.CreatedAt) && t.IPAddress == "198.51.100.2"
+	})).Return(nil)
 
 	// Act
-	result, err := s.usecase.RefreshToken(s.ctx, refreshToken)
+	result, err := s.usecase.RefreshToken(s.ctx, refreshToken, userpkg.DeviceInfo{IPAddress: "198.51.100.2"})
 
 	// Assert
 	s.NoError(err)
@@ -564,14 +850,100 @@ func (s *UserUsecaseTestSuite) TestRefreshToken_Success() {
 	s.mockUserRepo.AssertExpectations(s.T())
 }
 
+func (s *UserUsecaseTestSuite) TestRefreshToken_LegacyTokenStartsFamily() {
+	refreshToken := "pre_family_token"
+	userID := primitive.NewObjectID()
+	user := userpkg.User{ID: userID, Username: "testuser", Role: "user"}
+
+	s.mockJWTService.On("ValidateToken", refreshToken, userpkg.TokenTypeRefresh).Return(map[string]interface{}{"_id": userID.Hex()}, nil)
+	s.mockTokenRepo.On("FindByRefreshToken", s.ctx, refreshToken).Return(userpkg.Token{
+		UserID:       userID,
+		RefreshToken: refreshToken,
+		ExpiresAt:    time.Now().Add(time.Hour),
+	}, nil)
+	s.mockUserRepo.On("FindByID", s.ctx, userID.Hex()).Return(user, nil)
+	s.mockJWTService.On("GenerateToken", tokenSubject(userID.Hex(), "testuser", "user", "", false)).Return(userpkg.TokenResult{RefreshToken: "next"}, nil)
+	s.mockTokenRepo.On("RotateRefreshToken", s.ctx, refreshToken, mock.MatchedBy(func(t userpkg.Token) bool {
+		return t.FamilyID != ""
+	})).Return(nil)
+
+	_, err := s.usecase.RefreshToken(s.ctx, refreshToken, userpkg.DeviceInfo{})
+
+	s.NoError(err)
+	s.mockTokenRepo.AssertExpectations(s.T())
+}
+
+func (s *UserUsecaseTestSuite) TestRefreshToken_ReuseRevokesFamily() {
+	refreshToken := "already_rotated_token"
+	userID := primitive.NewObjectID()
+	rotatedAt := time.Now().Add(-time.Minute)
+	user := userpkg.User{ID: userID, Email: "jane@example.com", Fullname: "Jane", Language: "es"}
+
+	s.mockJWTService.On("ValidateToken", refreshToken, userpkg.TokenTypeRefresh).Return(map[string]interface{}{"_id": userID.Hex()}, nil)
+	s.mockTokenRepo.On("FindByRefreshToken", s.ctx, refreshToken).Return(userpkg.Token{
+		UserID:       userID,
+		RefreshToken: refreshToken,
+		FamilyID:     "family-1",
+		RotatedAt:    &rotatedAt,
+		ExpiresAt:    time.Now().Add(time.Hour),
+	}, nil)
+	s.mockTokenRepo.On("ListActiveAccessTokens", s.ctx, userID.Hex()).Return([]userpkg.Token{
+		{FamilyID: "family-1", AccessTokenID: "stolen-access", AccessExpiresAt: time.Now().Add(time.Minute)},
+		{FamilyID: "family-2", AccessTokenID: "other-device", AccessExpiresAt: time.Now().Add(time.Minute)},
+	}, nil).Once()
+	s.mockTokenRevoker.On("Revoke", s.ctx, mock.MatchedBy(func(t userpkg.RevokedToken) bool {
+		return t.ID == "stolen-access" && t.UserID == userID.Hex()
+	})).Return(nil).Once()
+	s.mockTokenRepo.On("DeleteTokenFamily", s.ctx, "family-1").Return(nil).Once()
+	s.mockUserRepo.On("FindByID", s.ctx, userID.Hex()).Return(user, nil)
+	s.expectRender(services.TemplateSecurityAlert, "es")
+	s.mockEmailSender.On("SendEmail", mock.MatchedBy(func(msg services.EmailMessage) bool {
+		return msg.To == "jane@example.com"
+	})).Return(nil).Once()
+
+	_, err := s.usecase.RefreshToken(s.ctx, refreshToken, userpkg.DeviceInfo{IPAddress: "198.51.100.2"})
+
+	s.ErrorIs(err, userpkg.ErrRefreshTokenReused)
+	s.mockJWTService.AssertNotCalled(s.T(), "GenerateToken", mock.Anything)
+	s.mockTokenRepo.AssertExpectations(s.T())
+	s.mockEmailSender.AssertExpectations(s.T())
+}
+
+func (s *UserUsecaseTestSuite) TestRefreshToken_ConcurrentRotationRevokesFamily() {
+	refreshToken := "raced_token"
+	userID := primitive.NewObjectID()
+	user := userpkg.User{ID: userID, Username: "testuser", Role: "user", Email: "jane@example.com"}
+
+	s.mockJWTService.On("ValidateToken", refreshToken, userpkg.TokenTypeRefresh).Return(map[string]interface{}{"_id": userID.Hex()}, nil)
+	s.mockTokenRepo.On("FindByRefreshToken", s.ctx, refreshToken).Return(userpkg.Token{
+		UserID:       userID,
+		RefreshToken: refreshToken,
+		FamilyID:     "family-1",
+		ExpiresAt:    time.Now().Add(time.Hour),
+	}, nil)
+	s.mockUserRepo.On("FindByID", s.ctx, userID.Hex()).Return(user, nil)
+	s.mockJWTService.On("GenerateToken", tokenSubject(userID.Hex(), "testuser", "user", "family-1", false)).Return(userpkg.TokenResult{RefreshToken: "next"}, nil)
+	s.mockTokenRepo.On("RotateRefreshToken", s.ctx, refreshToken, mock.Anything).Return(userpkg.ErrRefreshTokenReused)
+	s.mockTokenRepo.On("ListActiveAccessTokens", s.ctx, userID.Hex()).Return(nil, nil).Once()
+	s.mockTokenRepo.On("DeleteTokenFamily", s.ctx, "family-1").Return(nil).Once()
+	s.expectRender(services.TemplateSecurityAlert, "")
+	s.mockEmailSender.On("SendEmail", mock.Anything).Return(nil).Once()
+
+	result, err := s.usecase.RefreshToken(s.ctx, refreshToken, userpkg.DeviceInfo{})
+
+	s.ErrorIs(err, userpkg.ErrRefreshTokenReused)
+	s.Empty(result.RefreshToken)
+	s.mockTokenRepo.AssertExpectations(s.T())
+}
+
 func (s *UserUsecaseTestSuite) TestRefreshToken_InvalidToken() {
 	// Arrange
 	invalidToken := "invalid_token"
 
-	s.mockJWTService.On("ValidateToken", invalidToken).Return(nil, errors.New("invalid token"))
+	s.mockJWTService.On("ValidateToken", invalidToken, userpkg.TokenTypeRefresh).Return(nil, errors.New("invalid token"))
 
 	// Act
-	_, err := s.usecase.RefreshToken(s.ctx, invalidToken)
+	_, err := s.usecase.RefreshToken(s.ctx, invalidToken, userpkg.DeviceInfo{})
 
 	// Assert
 	s.Error(err)
@@ -594,11 +966,11 @@ func (s *UserUsecaseTestSuite) TestRefreshToken_TokenExpired() {
 		ExpiresAt:    time.Now().Add(-24 * time.Hour), // Already expired
 	}
 
-	s.mockJWTService.On("ValidateToken", expiredToken).Return(claims, nil)
+	s.mockJWTService.On("ValidateToken", expiredToken, userpkg.TokenTypeRefresh).Return(claims, nil)
 	s.mockTokenRepo.On("FindByRefreshToken", s.ctx, expiredToken).Return(storedToken, nil)
 
 	// Act
-	_, err := s.usecase.RefreshToken(s.ctx, expiredToken)
+	_, err := s.usecase.RefreshToken(s.ctx, expiredToken, userpkg.DeviceInfo{})
 
 	// Assert
 	s.Error(err)
@@ -622,12 +994,12 @@ func (s *UserUsecaseTestSuite) TestRefreshToken_UserNotFound() {
 		ExpiresAt:    time.Now().Add(24 * time.Hour),
 	}
 
-	s.mockJWTService.On("ValidateToken", refreshToken).Return(claims, nil)
+	s.mockJWTService.On("ValidateToken", refreshToken, userpkg.TokenTypeRefresh).Return(claims, nil)
 	s.mockTokenRepo.On("FindByRefreshToken", s.ctx, refreshToken).Return(storedToken, nil)
 	s.mockUserRepo.On("FindByID", s.ctx, userID.Hex()).Return(userpkg.User{}, errors.New("not found"))
 
 	// Act
-	_, err := s.usecase.RefreshToken(s.ctx, refreshToken)
+	_, err := s.usecase.RefreshToken(s.ctx, refreshToken, userpkg.DeviceInfo{})
 
 	// Assert
 	s.Error(err)
@@ -640,12 +1012,13 @@ func (s *UserUsecaseTestSuite) TestLogout_Success() {
 	// Arrange
 	userID := primitive.NewObjectID().Hex()
 
+	s.mockTokenRepo.On("ListActiveAccessTokens", s.ctx, userID).Return(nil, nil)
 	s.mockTokenRepo.
 		On("DeleteTokensByUserID", mock.Anything, userID). // <-- Fix here
 		Return(nil)
 
 	// Act
-	err := s.usecase.Logout(s.ctx, userID)
+	err := s.usecase.Logout(s.ctx, userID, "")
 
 	// Assert
 	s.NoError(err)
@@ -657,18 +1030,108 @@ func (s *UserUsecaseTestSuite) TestLogout_FailureFromTokenRepo() {
 	userID := primitive.NewObjectID().Hex()
 	expectedErr := errors.New("failed to delete tokens")
 
+	s.mockTokenRepo.On("ListActiveAccessTokens", s.ctx, userID).Return(nil, nil)
 	s.mockTokenRepo.
 		On("DeleteTokensByUserID", mock.Anything, userID). // <-- Fix here
 		Return(expectedErr)
 
 	// Act
-	err := s.usecase.Logout(s.ctx, userID)
+	err := s.usecase.Logout(s.ctx, userID, "")
 
 	// Assert
 	s.EqualError(err, expectedErr.Error())
 	s.mockTokenRepo.AssertCalled(s.T(), "DeleteTokensByUserID", mock.Anything, userID) // <-- Fix here
 }
 
+func (s *UserUsecaseTestSuite) TestLogout_EndsCurrentSessionOnly() {
+	userID := primitive.NewObjectID().Hex()
+	s.mockTokenRepo.On("ListActiveAccessTokens", s.ctx, userID).Return([]userpkg.Token{
+		{FamilyID: "session-1", AccessTokenID: "this-device", AccessExpiresAt: time.Now().Add(time.Minute)},
+		{FamilyID: "session-2", AccessTokenID: "other-device", AccessExpiresAt: time.Now().Add(time.Minute)},
+	}, nil).Once()
+	s.mockTokenRevoker.On("Revoke", s.ctx, mock.MatchedBy(func(t userpkg.RevokedToken) bool {
+		return t.ID == "this-device"
+	})).Return(nil).Once()
+	s.mockTokenRepo.On("DeleteSession", s.ctx, userID, "session-1").Return(nil).Once()
+
+	err := s.usecase.Logout(s.ctx, userID, "session-1")
+
+	s.NoError(err)
+	s.mockTokenRevoker.AssertExpectations(s.T())
+	s.mockTokenRepo.AssertNotCalled(s.T(), "DeleteTokensByUserID", mock.Anything, mock.Anything)
+}
+
+func (s *UserUsecaseTestSuite) TestLogout_RevocationFailureKeepsSession() {
+	userID := primitive.NewObjectID().Hex()
+	s.mockTokenRepo.On("ListActiveAccessTokens", s.ctx, userID).Return([]userpkg.Token{
+		{FamilyID: "session-1", AccessTokenID: "this-device", AccessExpiresAt: time.Now().Add(time.Minute)},
+	}, nil).Once()
+	s.mockTokenRevoker.On("Revoke", s.ctx, mock.Anything).Return(errors.New("db down")).Once()
+
+	err := s.usecase.Logout(s.ctx, userID, "session-1")
+
+	s.EqualError(err, "db down")
+	s.mockTokenRepo.AssertNotCalled(s.T(), "DeleteSession", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (s *UserUsecaseTestSuite) TestLogout_AlreadyRevokedSession() {
+	userID := primitive.NewObjectID().Hex()
+	s.mockTokenRepo.On("ListActiveAccessTokens", s.ctx, userID).Return(nil, nil).Once()
+	s.mockTokenRepo.On("DeleteSession", s.ctx, userID, "session-1").Return(userpkg.ErrSessionNotFound).Once()
+
+	s.NoError(s.usecase.Logout(s.ctx, userID, "session-1"))
+}
+
+func (s *UserUsecaseTestSuite) TestListSessions_MarksCurrent() {
+	userID := primitive.NewObjectID().Hex()
+	lastUsed := time.Now()
+	s.mockTokenRepo.On("ListSessions", s.ctx, userID).Return([]userpkg.Token{
+		{FamilyID: "phone", UserAgent: "Safari", IPAddress: "203.0.113.7", LastUsedAt: lastUsed, RefreshToken: "secret"},
+		{FamilyID: "laptop", UserAgent: "Firefox"},
+	}, nil).Once()
+
+	sessions, err := s.usecase.ListSessions(s.ctx, userID, "laptop")
+
+	s.NoError(err)
+	s.Equal([]userpkg.Session{
+		{ID: "phone", UserAgent: "Safari", IPAddress: "203.0.113.7", LastUsedAt: lastUsed},
+		{ID: "laptop", UserAgent: "Firefox", Current: true},
+	}, sessions)
+}
+
+func (s *UserUsecaseTestSuite) TestRevokeSession() {
+	userID := primitive.NewObjectID().Hex()
+	s.mockTokenRepo.On("ListActiveAccessTokens", s.ctx, userID).Return(nil, nil).Once()
+	s.mockTokenRepo.On("DeleteSession", s.ctx, userID, "phone").Return(userpkg.ErrSessionNotFound).Once()
+
+	s.ErrorIs(s.usecase.RevokeSession(s.ctx, userID, "phone"), userpkg.ErrSessionNotFound)
+}
+
+func (s *UserUsecaseTestSuite) TestRevokeOtherSessions() {
+	userID := primitive.NewObjectID().Hex()
+	s.mockTokenRepo.On("ListActiveAccessTokens", s.ctx, userID).Return([]userpkg.Token{
+		{FamilyID: "laptop", AccessTokenID: "laptop-access", AccessExpiresAt: time.Now().Add(time.Minute)},
+		{FamilyID: "phone", AccessTokenID: "phone-access", AccessExpiresAt: time.Now().Add(time.Minute)},
+		{FamilyID: "tablet", AccessTokenID: "tablet-access", AccessExpiresAt: time.Now().Add(time.Minute)},
+	}, nil).Once()
+	s.mockTokenRevoker.On("Revoke", s.ctx,
+		mock.MatchedBy(func(t userpkg.RevokedToken) bool { return t.ID == "phone-access" }),
+		mock.MatchedBy(func(t userpkg.RevokedToken) bool { return t.ID == "tablet-access" }),
+	).Return(nil).Once()
+	s.mockTokenRepo.On("DeleteOtherSessions", s.ctx, userID, "laptop").Return(nil).Once()
+
+	s.NoError(s.usecase.RevokeOtherSessions(s.ctx, userID, "laptop"))
+	s.mockTokenRepo.AssertExpectations(s.T())
+	s.mockTokenRevoker.AssertExpectations(s.T())
+}
+
+func (s *UserUsecaseTestSuite) TestRevokeOtherSessions_RequiresSession() {
+	err := s.usecase.RevokeOtherSessions(s.ctx, primitive.NewObjectID().Hex(), "")
+
+	s.Error(err)
+	s.mockTokenRepo.AssertNotCalled(s.T(), "DeleteOtherSessions", mock.Anything, mock.Anything, mock.Anything)
+}
+
 // TestPromoteUser_CallsRepo ensures PromoteUser calls the repository
 func (s *UserUsecaseTestSuite) TestPromoteUser_CallsRepo() {
 	targetID := "user123"
@@ -680,9 +1143,19 @@ func (s *UserUsecaseTestSuite) TestPromoteUser_CallsRepo() {
 		p := args.Get(3).(*string)
 		s.Equal(actorID, *p)
 	}).Return(nil)
+	s.mockTokenRepo.On("ListActiveAccessTokens", s.ctx, targetID).Return(nil, nil).Once()
 	err := s.usecase.PromoteUser(s.ctx, targetID, actorID)
 	s.NoError(err)
 	s.mockUserRepo.AssertCalled(s.T(), "UpdateRoleAndPromoter", s.ctx, targetID, "admin", mock.AnythingOfType("*string"))
+	s.Equal(eventpkg.UserPromoted{UserID: targetID, PromotedBy: actorID}, eventtest.RequireOne[eventpkg.UserPromoted](s.T(), s.events))
+}
+
+func (s *UserUsecaseTestSuite) TestPromoteUser_AlreadyAdminEmitsNothing() {
+	s.mockUserRepo.On("FindByID", s.ctx, "user123").Return(userpkg.User{Role: "admin"}, nil)
+	s.mockUserRepo.On("FindByID", s.ctx, "admin999").Return(userpkg.User{}, nil)
+
+	s.NoError(s.usecase.PromoteUser(s.ctx, "user123", "admin999"))
+	eventtest.AssertNames(s.T(), s.events)
 }
 
 // TestDemoteUser_CallsRepo ensures DemoteUser calls the repository
@@ -692,70 +1165,165 @@ func (s *UserUsecaseTestSuite) TestDemoteUser_CallsRepo() {
 	s.mockUserRepo.On("FindByID", s.ctx, targetID).Return(userpkg.User{}, nil)
 	s.mockUserRepo.On("FindByID", s.ctx, actorID).Return(userpkg.User{}, nil)
 	s.mockUserRepo.On("UpdateRoleAndPromoter", s.ctx, targetID, "user", (*string)(nil)).Return(nil)
+	s.mockTokenRepo.On("ListActiveAccessTokens", s.ctx, targetID).Return(nil, nil).Once()
 	err := s.usecase.DemoteUser(s.ctx, targetID, actorID)
 	s.NoError(err)
 	s.mockUserRepo.AssertCalled(s.T(), "UpdateRoleAndPromoter", s.ctx, targetID, "user", (*string)(nil))
+	eventtest.AssertNames(s.T(), s.events, "user.demoted")
+}
+
+func (s *UserUsecaseTestSuite) TestDemoteUser_RevokesOutstandingAccessTokens() {
+	targetID := primitive.NewObjectID().Hex()
+	expiresAt := time.Now().Add(10 * time.Minute)
+	s.mockUserRepo.On("FindByID", s.ctx, targetID).Return(userpkg.User{Role: "admin"}, nil)
+	s.mockUserRepo.On("FindByID", s.ctx, "admin999").Return(userpkg.User{}, nil)
+	s.mockUserRepo.On("UpdateRoleAndPromoter", s.ctx, targetID, "user", (*string)(nil)).Return(nil)
+	s.mockTokenRepo.On("ListActiveAccessTokens", s.ctx, targetID).Return([]userpkg.Token{
+		{FamilyID: "laptop", AccessTokenID: "laptop-access", AccessExpiresAt: expiresAt},
+		{FamilyID: "phone", AccessTokenID: "phone-access", AccessExpiresAt: expiresAt},
+	}, nil).Once()
+	s.mockTokenRevoker.On("Revoke", s.ctx,
+		userpkg.RevokedToken{ID: "laptop-access", UserID: targetID, ExpiresAt: expiresAt},
+		userpkg.RevokedToken{ID: "phone-access", UserID: targetID, ExpiresAt: expiresAt},
+	).Return(nil).Once()
+
+	s.NoError(s.usecase.DemoteUser(s.ctx, targetID, "admin999"))
+	s.mockTokenRevoker.AssertExpectations(s.T())
+}
+
+func (s *UserUsecaseTestSuite) TestAssignRole_UpdatesRoleAndRevokesTokens() {
+	targetID := primitive.NewObjectID().Hex()
+	s.mockUserRepo.On("FindByID", s.ctx, targetID).Return(userpkg.User{Role: "user"}, nil)
+	s.mockUserRepo.On("FindByID", s.ctx, "admin999").Return(userpkg.User{}, nil)
+	s.mockUserRepo.On("UpdateRoleAndPromoter", s.ctx, targetID, "editor", (*string)(nil)).Return(nil)
+	s.mockTokenRepo.On("ListActiveAccessTokens", s.ctx, targetID).Return(nil, nil).Once()
+
+	s.NoError(s.usecase.AssignRole(s.ctx, targetID, "editor", "admin999"))
+	s.mockUserRepo.AssertCalled(s.T(), "UpdateRoleAndPromoter", s.ctx, targetID, "editor", (*string)(nil))
+	eventtest.AssertNames(s.T(), s.events, "user.role_changed")
+}
+
+func (s *UserUsecaseTestSuite) TestAssignRole_RejectsUnknownRole() {
+	s.mockPermissions.ExpectedCalls = nil
+	s.mockPermissions.On("Resolve", s.ctx, "wizard").Return(rolepkg.Grant{}, rolepkg.ErrRoleNotFound)
+
+	err := s.usecase.AssignRole(s.ctx, "user456", "wizard", "admin999")
+	s.ErrorIs(err, rolepkg.ErrRoleNotFound)
+	s.mockUserRepo.AssertNotCalled(s.T(), "UpdateRoleAndPromoter", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (s *UserUsecaseTestSuite) TestAssignRole_RejectsSelf() {
+	err := s.usecase.AssignRole(s.ctx, "admin999", "user", "admin999")
+	s.EqualError(err, "cannot change your own role")
 }
 
 // TestSendVerificationOTP_Success ensures registration OTP is stored and sent
 func (s *UserUsecaseTestSuite) TestSendVerificationOTP_Success() {
 	email := "reg@example.com"
 
-	s.mockUserRepo.On("ExistsByEmail", s.ctx, email).Return(true, nil)
-	// capture generated otp
-	s.mockEmailSender.On("SendEmail", email, "Your verification code", mock.MatchedBy(func(body string) bool {
-		return strings.Contains(body, "Your code:")
+	s.mockUserRepo.On("FindByEmail", s.ctx, email).Return(userpkg.User{Email: email}, nil)
+	s.mockOTP.On("Issue", s.ctx, otppkg.PurposeVerification, email, "").Return("482913", time.Now().Add(15*time.Minute), nil)
+	s.expectRender(services.TemplateVerification, "")
+	s.mockEmailSender.On("SendEmail", mock.MatchedBy(func(m services.EmailMessage) bool {
+		return m.To == email && m.Text == "verification  482913"
 	})).Return(nil)
-	s.mockPasswordSvc.On("HashPassword", mock.Anything).Return("hashedOTP", nil)
-	s.mockVerificationRepo.On("StoreVerification", s.ctx, mock.Anything).Return(nil)
 
 	err := s.usecase.SendVerificationOTP(s.ctx, email)
 	s.NoError(err)
-	s.mockVerificationRepo.AssertCalled(s.T(), "StoreVerification", s.ctx, mock.Anything)
+	s.mockOTP.AssertExpectations(s.T())
 }
 
-// TestVerifyUser_Success flips isVerified and deletes record
+// TestVerifyUser_Success flips isVerified
 func (s *UserUsecaseTestSuite) TestVerifyUser_Success() {
 	email := "reg@example.com"
 	otp := "654321"
-	record := userpkg.Verification{Email: email, OTP: "hashedOTP", ExpiresAt: time.Now().Add(10 * time.Minute), AttemptCount: 0}
 
-	s.mockVerificationRepo.On("GetVerification", s.ctx, email).Return(record, nil)
-	s.mockPasswordSvc.On("ComparePassword", record.OTP, otp).Return(nil)
-	s.mockVerificationRepo.On("DeleteVerification", s.ctx, email).Return(nil)
+	s.mockOTP.On("Verify", s.ctx, otppkg.PurposeVerification, email, otp).Return(otppkg.Challenge{Subject: email}, nil)
 	s.mockUserRepo.On("UpdateIsVerifiedByEmail", s.ctx, email, true).Return(nil)
 
 	err := s.usecase.VerifyUser(s.ctx, email, otp)
 	s.NoError(err)
 	s.mockUserRepo.AssertCalled(s.T(), "UpdateIsVerifiedByEmail", s.ctx, email, true)
+	s.Equal(email, eventtest.RequireOne[eventpkg.UserVerified](s.T(), s.events).Email)
 }
 
-// TestVerifyUser_Expired returns error and deletes
-func (s *UserUsecaseTestSuite) TestVerifyUser_Expired() {
+// TestVerifyUser_InvalidCode leaves the user unverified
+func (s *UserUsecaseTestSuite) TestVerifyUser_InvalidCode() {
 	email := "reg@example.com"
-	record := userpkg.Verification{Email: email, OTP: "hash", ExpiresAt: time.Now().Add(-1 * time.Minute), AttemptCount: 0}
 
-	s.mockVerificationRepo.On("GetVerification", s.ctx, email).Return(record, nil)
-	s.mockVerificationRepo.On("DeleteVerification", s.ctx, email).Return(nil)
+	s.mockOTP.On("Verify", s.ctx, otppkg.PurposeVerification, email, "wrong").Return(otppkg.Challenge{}, otppkg.ErrInvalidCode)
 
-	err := s.usecase.VerifyUser(s.ctx, email, "any")
-	s.Error(err)
-	s.Contains(err.Error(), "verification code expired")
+	err := s.usecase.VerifyUser(s.ctx, email, "wrong")
+	s.ErrorIs(err, otppkg.ErrInvalidCode)
+	s.mockUserRepo.AssertNotCalled(s.T(), "UpdateIsVerifiedByEmail", mock.Anything, mock.Anything, mock.Anything)
+	eventtest.AssertNone[eventpkg.UserVerified](s.T(), s.events)
 }
 
-// TestVerifyUser_InvalidCode increments attempt counter
-func (s *UserUsecaseTestSuite) TestVerifyUser_InvalidCode() {
-	email := "reg@example.com"
-	record := userpkg.Verification{Email: email, OTP: "hash", ExpiresAt: time.Now().Add(10 * time.Minute), AttemptCount: 1}
+func (s *UserUsecaseTestSuite) TestRequestEmailChange_SendsCodeToTheNewAddress() {
+	userID := primitive.NewObjectID()
+	s.mockUserRepo.On("FindByID", s.ctx, userID.Hex()).Return(userpkg.User{ID: userID, Email: "old@example.com", Password: "hashed", Language: "es"}, nil)
+	s.mockPasswordSvc.On("ComparePassword", "hashed", "Secret123!").Return(nil)
+	s.mockUserRepo.On("ExistsByEmail", s.ctx, "new@example.com").Return(false, nil)
+	s.mockEmailVerifier.On("IsRealEmail", "new@example.com").Return(true, nil)
+	s.mockOTP.On("Issue", s.ctx, otppkg.PurposeEmailChange, userID.Hex(), "new@example.com").Return("482913", time.Now().Add(15*time.Minute), nil)
+	s.expectRender(services.TemplateEmailChange, "es")
+	s.mockEmailSender.On("SendEmail", mock.MatchedBy(func(m services.EmailMessage) bool {
+		return m.To == "new@example.com" && m.Text == "email_change es 482913"
+	})).Return(nil).Once()
+
+	err := s.usecase.RequestEmailChange(s.ctx, userID.Hex(), "Secret123!", " new@example.com ")
+
+	s.NoError(err)
+	s.mockEmailSender.AssertExpectations(s.T())
+}
+
+func (s *UserUsecaseTestSuite) TestRequestEmailChange_WrongPassword() {
+	userID := primitive.NewObjectID()
+	s.mockUserRepo.On("FindByID", s.ctx, userID.Hex()).Return(userpkg.User{ID: userID, Email: "old@example.com", Password: "hashed"}, nil)
+	s.mockPasswordSvc.On("ComparePassword", "hashed", "guess").Return(errors.New("mismatch"))
 
-	s.mockVerificationRepo.On("GetVerification", s.ctx, email).Return(record, nil)
-	s.mockPasswordSvc.On("ComparePassword", record.OTP, "wrong").Return(errors.New("mismatch"))
-	s.mockVerificationRepo.On("IncrementAttemptCount", s.ctx, email).Return(nil)
+	err := s.usecase.RequestEmailChange(s.ctx, userID.Hex(), "guess", "new@example.com")
 
-	err := s.usecase.VerifyUser(s.ctx, email, "wrong")
-	s.Error(err)
-	s.Equal("invalid code", err.Error())
-	s.mockVerificationRepo.AssertCalled(s.T(), "IncrementAttemptCount", s.ctx, email)
+	s.EqualError(err, "invalid password")
+	s.mockOTP.AssertNotCalled(s.T(), "Issue", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (s *UserUsecaseTestSuite) TestRequestEmailChange_AddressTaken() {
+	userID := primitive.NewObjectID()
+	s.mockUserRepo.On("FindByID", s.ctx, userID.Hex()).Return(userpkg.User{ID: userID, Email: "old@example.com", Password: "hashed"}, nil)
+	s.mockPasswordSvc.On("ComparePassword", "hashed", "Secret123!").Return(nil)
+	s.mockUserRepo.On("ExistsByEmail", s.ctx, "taken@example.com").Return(true, nil)
+
+	err := s.usecase.RequestEmailChange(s.ctx, userID.Hex(), "Secret123!", "taken@example.com")
+
+	s.EqualError(err, "email already taken")
+}
+
+func (s *UserUsecaseTestSuite) TestConfirmEmailChange_MovesTheAccountAndAlertsTheOldAddress() {
+	userID := primitive.NewObjectID()
+	s.mockOTP.On("Verify", s.ctx, otppkg.PurposeEmailChange, userID.Hex(), "482913").Return(otppkg.Challenge{Subject: userID.Hex(), Data: "new@example.com"}, nil)
+	s.mockUserRepo.On("FindByID", s.ctx, userID.Hex()).Return(userpkg.User{ID: userID, Email: "old@example.com"}, nil)
+	s.mockUserRepo.On("ExistsByEmail", s.ctx, "new@example.com").Return(false, nil)
+	s.mockUserRepo.On("UpdateEmailByID", s.ctx, userID.Hex(), "new@example.com").Return(nil).Once()
+	s.mockEmailRenderer.On("Render", services.TemplateSecurityAlert, "", mock.MatchedBy(func(data map[string]interface{}) bool {
+		return strings.Contains(data["Event"].(string), "new@example.com")
+	})).Return(services.EmailMessage{Subject: "alert"}, nil).Once()
+	s.mockEmailSender.On("SendEmail", mock.MatchedBy(func(m services.EmailMessage) bool { return m.To == "old@example.com" })).Return(nil).Once()
+
+	err := s.usecase.ConfirmEmailChange(s.ctx, userID.Hex(), "482913", userpkg.DeviceInfo{})
+
+	s.NoError(err)
+	s.mockUserRepo.AssertExpectations(s.T())
+	s.mockEmailSender.AssertExpectations(s.T())
+}
+
+func (s *UserUsecaseTestSuite) TestConfirmEmailChange_InvalidCode() {
+	s.mockOTP.On("Verify", s.ctx, otppkg.PurposeEmailChange, "u1", "000000").Return(otppkg.Challenge{}, otppkg.ErrInvalidCode)
+
+	err := s.usecase.ConfirmEmailChange(s.ctx, "u1", "000000", userpkg.DeviceInfo{})
+
+	s.ErrorIs(err, otppkg.ErrInvalidCode)
+	s.mockUserRepo.AssertNotCalled(s.T(), "UpdateEmailByID", mock.Anything, mock.Anything, mock.Anything)
 }
 
 func (s *UserUsecaseTestSuite) TestUpdateProfile_Success() {
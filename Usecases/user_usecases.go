@@ -2,51 +2,93 @@ package usecases
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"log"
+	"math"
 	"mime/multipart"
 	"net/url"
 	"regexp"
 	"strings"
 	"time"
 
+	eventpkg "github.com/Amaankaa/Blog-Starter-Project/Domain/event"
+	lockoutpkg "github.com/Amaankaa/Blog-Starter-Project/Domain/lockout"
+	mfapkg "github.com/Amaankaa/Blog-Starter-Project/Domain/mfa"
+	otppkg "github.com/Amaankaa/Blog-Starter-Project/Domain/otp"
+	outboxpkg "github.com/Amaankaa/Blog-Starter-Project/Domain/outbox"
+	passwordpkg "github.com/Amaankaa/Blog-Starter-Project/Domain/password"
+	patpkg "github.com/Amaankaa/Blog-Starter-Project/Domain/pat"
+	rolepkg "github.com/Amaankaa/Blog-Starter-Project/Domain/role"
 	"github.com/Amaankaa/Blog-Starter-Project/Domain/services"
 	userpkg "github.com/Amaankaa/Blog-Starter-Project/Domain/user"
 	utils "github.com/Amaankaa/Blog-Starter-Project/Domain/utils"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
+// resetTokenLifetime is how long a verified reset OTP stays good for setting the new password
+const resetTokenLifetime = 15 * time.Minute
+
 type UserUsecase struct {
 	userRepo          userpkg.IUserRepository
 	passwordSvc       userpkg.IPasswordService
+	passwordPolicy    passwordpkg.IPasswordPolicy
 	tokenRepo         userpkg.ITokenRepository
+	tokenRevoker      userpkg.IAccessTokenRevoker
+	patRepo           patpkg.IPATRepository
+	mfa               mfapkg.IMFAVerifier
+	permissions       rolepkg.IPermissionResolver
+	loginGuard        lockoutpkg.ILoginGuard
 	emailVerifier     services.IEmailVerifier
 	emailSender       services.IEmailSender
+	emailRenderer     services.IEmailRenderer
 	jwtService        userpkg.IJWTService
-	passwordResetRepo userpkg.IPasswordResetRepository
-	verificationRepo  userpkg.IVerificationRepository
+	otp               otppkg.IOTPService
 	cloudinaryService userpkg.ICloudinaryService
+	events            eventpkg.IPublisher
+	outboxRepo        outboxpkg.IOutboxRepository
+	transactor        outboxpkg.ITransactor
 }
 
 func NewUserUsecase(
 	userRepo userpkg.IUserRepository,
 	passwordSvc userpkg.IPasswordService,
+	passwordPolicy passwordpkg.IPasswordPolicy,
 	tokenRepo userpkg.ITokenRepository,
 	jwtService userpkg.IJWTService,
+	tokenRevoker userpkg.IAccessTokenRevoker,
+	patRepo patpkg.IPATRepository,
+	mfa mfapkg.IMFAVerifier,
+	permissions rolepkg.IPermissionResolver,
+	loginGuard lockoutpkg.ILoginGuard,
 	emailVerifier services.IEmailVerifier,
 	emailSender services.IEmailSender,
-	passwordResetRepo userpkg.IPasswordResetRepository,
-	verificationRepo userpkg.IVerificationRepository,
+	emailRenderer services.IEmailRenderer,
+	otp otppkg.IOTPService,
 	cloudinaryService userpkg.ICloudinaryService,
+	events eventpkg.IPublisher,
+	outboxRepo outboxpkg.IOutboxRepository,
+	transactor outboxpkg.ITransactor,
 ) *UserUsecase {
 	return &UserUsecase{
 		userRepo:          userRepo,
 		passwordSvc:       passwordSvc,
+		passwordPolicy:    passwordPolicy,
 		tokenRepo:         tokenRepo,
 		jwtService:        jwtService,
+		tokenRevoker:      tokenRevoker,
+		patRepo:           patRepo,
+		mfa:               mfa,
+		permissions:       permissions,
+		loginGuard:        loginGuard,
 		emailVerifier:     emailVerifier,
 		emailSender:       emailSender,
-		passwordResetRepo: passwordResetRepo,
-		verificationRepo:  verificationRepo,
+		emailRenderer:     emailRenderer,
+		otp:               otp,
 		cloudinaryService: cloudinaryService,
+		events:            events,
+		outboxRepo:        outboxRepo,
+		transactor:        transactor,
 	}
 }
 
@@ -87,9 +129,13 @@ func (uu *UserUsecase) RegisterUser(ctx context.Context, user userpkg.User) (use
 		return userpkg.User{}, errors.New("email is unreachable")
 	}
 
-	// Strong password check
-	if !utils.IsStrongPassword(user.Password) {
-		return userpkg.User{}, errors.New("password must be at least 8 chars, with upper, lower, number, and special char")
+	if user.Language != "" && !IsValidLanguageTag(user.Language) {
+		return userpkg.User{}, errors.New("invalid language tag")
+	}
+
+	// Password policy: guessability, breaches and the user's own details
+	if err := uu.passwordPolicy.Check(ctx, user.Password, user); err != nil {
+		return userpkg.User{}, err
 	}
 
 
@@ -111,47 +157,106 @@ func (uu *UserUsecase) RegisterUser(ctx context.Context, user userpkg.User) (use
 	}
 	user.Password = hashed
 
-	// Create user (with isVerified = false by default)
-	createdUser, err := uu.userRepo.CreateUser(ctx, user)
+	// Create the user (isVerified = false by default) and queue the verification email
+	// in one transaction, so an account never exists without a way to verify it
+	var createdUser userpkg.User
+	err = uu.transactor.WithTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		createdUser, err = uu.userRepo.CreateUser(ctx, user)
+		if err != nil {
+			return err
+		}
+
+		payload, err := json.Marshal(outboxpkg.VerificationEmailPayload{
+			Email:    user.Email,
+			Name:     user.Fullname,
+			Language: user.Language,
+		})
+		if err != nil {
+			return err
+		}
+		now := time.Now()
+		return uu.outboxRepo.Enqueue(ctx, outboxpkg.Message{
+			Kind:           outboxpkg.KindVerificationEmail,
+			IdempotencyKey: "verification-email:" + createdUser.ID.Hex(),
+			Payload:        string(payload),
+			Status:         outboxpkg.StatusPending,
+			NextAttemptAt:  now,
+			CreatedAt:      now,
+		})
+	})
 	if err != nil {
 		return userpkg.User{}, err
 	}
 
-	// Generate and send verification OTP
-	otp := utils.GenerateOTP(6)
+	createdUser.Password = "" // scrub before return
+	uu.events.Publish(ctx, eventpkg.UserRegistered{
+		UserID:   createdUser.ID.Hex(),
+		Username: createdUser.Username,
+		Email:    createdUser.Email,
+		Role:     createdUser.Role,
+	})
+	return createdUser, nil
+}
 
-	// Send verification email
-	err = uu.emailSender.SendEmail(user.Email, "Email Verification Code", "Your verification OTP: "+otp)
-	if err != nil {
-		return userpkg.User{}, errors.New("failed to send verification code")
+// DeliverVerificationEmail handles KindVerificationEmail outbox messages. The OTP is
+// generated and stored here rather than at registration, so a retried message simply
+// issues a fresh code and the outbox never holds a plaintext one. The queued email does,
+// until it is sent or its dead letter expires.
+func (uu *UserUsecase) DeliverVerificationEmail(ctx context.Context, msg outboxpkg.Message) error {
+	var payload outboxpkg.VerificationEmailPayload
+	if err := json.Unmarshal([]byte(msg.Payload), &payload); err != nil {
+		return err
 	}
 
-	// Hash OTP before storing
-	hashedOTP, err := uu.passwordSvc.HashPassword(otp)
+	// Store before sending: an email whose code was never saved could not be used. Within the
+	// resend cooldown this fails, and the message is retried once it has passed.
+	code, expiresAt, err := uu.otp.Issue(ctx, otppkg.PurposeVerification, payload.Email, "")
 	if err != nil {
-		return userpkg.User{}, errors.New("failed to process verification code")
+		return err
 	}
 
-	// Store verification request
-	verification := userpkg.Verification{
-		Email:        user.Email,
-		OTP:          hashedOTP,
-		ExpiresAt:    time.Now().Add(10 * time.Minute),
-		AttemptCount: 0,
+	err = uu.sendTemplatedEmail(payload.Email, payload.Language, services.TemplateVerification, map[string]interface{}{
+		"Name":             payload.Name,
+		"Code":             code,
+		"ExpiresInMinutes": minutesUntil(expiresAt),
+	})
+	if err != nil {
+		return errors.New("failed to send verification code")
 	}
+	return nil
+}
 
-	err = uu.verificationRepo.StoreVerification(ctx, verification)
+// sendTemplatedEmail renders template name in the recipient's language and hands it to the email sender
+func (uu *UserUsecase) sendTemplatedEmail(to, language, name string, data map[string]interface{}) error {
+	msg, err := uu.emailRenderer.Render(name, language, data)
 	if err != nil {
-		return userpkg.User{}, errors.New("failed to store verification code")
+		return err
 	}
+	msg.To = to
+	return uu.emailSender.SendEmail(msg)
+}
 
-	createdUser.Password = "" // scrub before return
-	return createdUser, nil
+// minutesUntil is how long a code is good for, as told to the user
+func minutesUntil(t time.Time) int {
+	return int(math.Ceil(time.Until(t).Minutes()))
 }
 
-func (uu *UserUsecase) LoginUser(ctx context.Context, login, password string) (userpkg.User, string, string, error) {
-	user, err := uu.userRepo.GetUserByLogin(ctx, login)
-	if err != nil {
+// LoginUser checks a password login. Repeated failures get a *lockoutpkg.ThrottledError
+// before the password is even looked at.
+func (uu *UserUsecase) LoginUser(ctx context.Context, login, password string, device userpkg.DeviceInfo) (userpkg.User, string, string, error) {
+	user, lookupErr := uu.userRepo.GetUserByLogin(ctx, login)
+	accountID := ""
+	if lookupErr == nil {
+		accountID = user.ID.Hex()
+	} else {
+		user = userpkg.User{}
+	}
+	if err := uu.loginGuard.Check(ctx, accountID, device.IPAddress); err != nil {
+		return userpkg.User{}, "", "", err
+	}
+	if lookupErr != nil {
+		uu.recordLoginFailure(ctx, user, device)
 		return userpkg.User{}, "", "", errors.New("invalid credentials")
 	}
 	// Prevent login if email not verified
@@ -160,22 +265,125 @@ func (uu *UserUsecase) LoginUser(ctx context.Context, login, password string) (u
 	}
 
 	if err := uu.passwordSvc.ComparePassword(user.Password, password); err != nil {
+		uu.recordLoginFailure(ctx, user, device)
 		return userpkg.User{}, "", "", errors.New("invalid credentials")
 	}
+	uu.upgradePasswordHash(ctx, user, password)
+	// The failures are only forgiven once a session is issued: a password that leads to a
+	// second factor is half a login, and wrong codes keep counting against the account
+	signedIn, accessToken, refreshToken, err := uu.SignIn(ctx, user, device)
+	if err != nil {
+		return userpkg.User{}, "", "", err
+	}
+	uu.recordLoginSuccess(ctx, accountID)
+	return signedIn, accessToken, refreshToken, nil
+}
 
-	// Generate tokens
-	tokenRes, err := uu.jwtService.GenerateToken(user.ID.Hex(), user.Username, user.Role)
+// upgradePasswordHash rehashes a password stored with an older algorithm or weaker settings,
+// while the plaintext is at hand. A failure is only logged; the next login tries again.
+func (uu *UserUsecase) upgradePasswordHash(ctx context.Context, user userpkg.User, password string) {
+	if !uu.passwordSvc.NeedsRehash(user.Password) {
+		return
+	}
+	hashed, err := uu.passwordSvc.HashPassword(password)
+	if err == nil {
+		err = uu.userRepo.ReplacePasswordHash(ctx, user.ID.Hex(), user.Password, hashed)
+	}
+	if err != nil {
+		log.Printf("failed to upgrade the password hash of user %s: %v", user.ID.Hex(), err)
+	}
+}
+
+func (uu *UserUsecase) recordLoginSuccess(ctx context.Context, userID string) {
+	if err := uu.loginGuard.RecordSuccess(ctx, userID); err != nil {
+		log.Printf("failed to reset failed logins of user %s: %v", userID, err)
+	}
+}
+
+// recordLoginFailure only logs errors; the caller reports the wrong password either way
+func (uu *UserUsecase) recordLoginFailure(ctx context.Context, user userpkg.User, device userpkg.DeviceInfo) {
+	if err := uu.loginGuard.RecordFailure(ctx, user, device); err != nil {
+		log.Printf("failed to record a failed login from %s: %v", device.IPAddress, err)
+	}
+}
+
+// SignIn starts a session for a user who has already proved who they are, by password or at an
+// external provider. Accounts with two-factor authentication get an MFARequiredError instead.
+func (uu *UserUsecase) SignIn(ctx context.Context, user userpkg.User, device userpkg.DeviceInfo) (userpkg.User, string, string, error) {
+	enabled, err := uu.mfa.IsEnabled(ctx, user.ID.Hex())
+	if err != nil {
+		return userpkg.User{}, "", "", err
+	}
+	if enabled {
+		challengeToken, expiresAt, err := uu.mfa.StartChallenge(ctx, user.ID.Hex())
+		if err != nil {
+			return userpkg.User{}, "", "", err
+		}
+		return userpkg.User{}, "", "", &userpkg.MFARequiredError{ChallengeToken: challengeToken, ExpiresAt: expiresAt}
+	}
+	return uu.startSession(ctx, user, device, false)
+}
+
+// CompleteMFALogin counts wrong codes as failed logins of the account, like wrong passwords.
+// A locked account is refused before its code is checked, as in LoginUser.
+func (uu *UserUsecase) CompleteMFALogin(ctx context.Context, challengeToken, code string, device userpkg.DeviceInfo) (userpkg.User, string, string, error) {
+	accountID, err := uu.mfa.ChallengeUser(ctx, challengeToken)
+	if err != nil {
+		return userpkg.User{}, "", "", err
+	}
+	if err := uu.loginGuard.Check(ctx, accountID, device.IPAddress); err != nil {
+		return userpkg.User{}, "", "", err
+	}
+	userID, err := uu.mfa.CompleteChallenge(ctx, challengeToken, code)
+	if errors.Is(err, mfapkg.ErrInvalidCode) {
+		user, lookupErr := uu.userRepo.FindByID(ctx, userID)
+		if lookupErr != nil {
+			user = userpkg.User{}
+		}
+		uu.recordLoginFailure(ctx, user, device)
+	}
+	if err != nil {
+		return userpkg.User{}, "", "", err
+	}
+	user, err := uu.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return userpkg.User{}, "", "", err
+	}
+	signedIn, accessToken, refreshToken, err := uu.startSession(ctx, user, device, true)
+	if err != nil {
+		return userpkg.User{}, "", "", err
+	}
+	uu.recordLoginSuccess(ctx, userID)
+	return signedIn, accessToken, refreshToken, nil
+}
+
+// startSession issues the tokens of a new session (refresh-token family) for a signed-in user
+func (uu *UserUsecase) startSession(ctx context.Context, user userpkg.User, device userpkg.DeviceInfo, mfa bool) (userpkg.User, string, string, error) {
+	sessionID := primitive.NewObjectID().Hex()
+	subject, err := uu.tokenSubject(ctx, user, sessionID, mfa)
+	if err != nil {
+		return userpkg.User{}, "", "", err
+	}
+	tokenRes, err := uu.jwtService.GenerateToken(subject)
 	if err != nil {
 		return userpkg.User{}, "", "", err
 	}
 
 	// Store tokens
+	now := time.Now()
 	err = uu.tokenRepo.StoreToken(ctx, userpkg.Token{
 		UserID:       user.ID,
-		AccessToken:  tokenRes.AccessToken,
-		RefreshToken: tokenRes.RefreshToken,
-		CreatedAt:    time.Now(),
-		ExpiresAt:    tokenRes.RefreshExpiresAt,
+		AccessToken:     tokenRes.AccessToken,
+		RefreshToken:    tokenRes.RefreshToken,
+		AccessTokenID:   tokenRes.AccessTokenID,
+		AccessExpiresAt: tokenRes.AccessExpiresAt,
+		FamilyID:        sessionID,
+		MFA:             mfa,
+		UserAgent:       device.UserAgent,
+		IPAddress:       device.IPAddress,
+		CreatedAt:       now,
+		LastUsedAt:      now,
+		ExpiresAt:       tokenRes.RefreshExpiresAt,
 	})
 	if err != nil {
 		return userpkg.User{}, "", "", err
@@ -185,8 +393,26 @@ func (uu *UserUsecase) LoginUser(ctx context.Context, login, password string) (u
 	return user, tokenRes.AccessToken, tokenRes.RefreshToken, nil
 }
 
-func (uu *UserUsecase) RefreshToken(ctx context.Context, refreshToken string) (userpkg.TokenResult, error) {
-	claims, err := uu.jwtService.ValidateToken(refreshToken)
+// tokenSubject describes an access token for user, with their role's current permissions
+func (uu *UserUsecase) tokenSubject(ctx context.Context, user userpkg.User, sessionID string, mfa bool) (userpkg.TokenSubject, error) {
+	grant, err := uu.permissions.Resolve(ctx, user.Role)
+	// A user whose role was deleted may still sign in, just without any permissions
+	if err != nil && !errors.Is(err, rolepkg.ErrRoleNotFound) {
+		return userpkg.TokenSubject{}, err
+	}
+	return userpkg.TokenSubject{
+		UserID:             user.ID.Hex(),
+		Username:           user.Username,
+		Role:               user.Role,
+		SessionID:          sessionID,
+		MFA:                mfa,
+		Permissions:        grant.Permissions,
+		PermissionsVersion: grant.Version,
+	}, nil
+}
+
+func (uu *UserUsecase) RefreshToken(ctx context.Context, refreshToken string, device userpkg.DeviceInfo) (userpkg.TokenResult, error) {
+	claims, err := uu.jwtService.ValidateToken(refreshToken, userpkg.TokenTypeRefresh)
 	if err != nil {
 		return userpkg.TokenResult{}, errors.New("invalid or expired refresh token")
 	}
@@ -201,6 +427,10 @@ func (uu *UserUsecase) RefreshToken(ctx context.Context, refreshToken string) (u
 	if err != nil {
 		return userpkg.TokenResult{}, errors.New("refresh token not recognized")
 	}
+	if stored.RotatedAt != nil {
+		uu.revokeTokenFamily(ctx, stored, device)
+		return userpkg.TokenResult{}, userpkg.ErrRefreshTokenReused
+	}
 
 	if stored.ExpiresAt.Before(time.Now()) {
 		return userpkg.TokenResult{}, errors.New("refresh token expired")
@@ -212,89 +442,248 @@ func (uu *UserUsecase) RefreshToken(ctx context.Context, refreshToken string) (u
 		return userpkg.TokenResult{}, err
 	}
 
-	// Generate new tokens
-	tokens, err := uu.jwtService.GenerateToken(user.ID.Hex(), user.Username, user.Role)
+	// Tokens issued before families existed start one on their first rotation
+	if stored.FamilyID == "" {
+		stored.FamilyID = primitive.NewObjectID().Hex()
+	}
+
+	// Generate new tokens, with the permissions the user's role has now
+	subject, err := uu.tokenSubject(ctx, user, stored.FamilyID, stored.MFA)
+	if err != nil {
+		return userpkg.TokenResult{}, err
+	}
+	tokens, err := uu.jwtService.GenerateToken(subject)
 	if err != nil {
 		return userpkg.TokenResult{}, err
 	}
 
-	// Store new refresh token, remove old
-	_ = uu.tokenRepo.DeleteByRefreshToken(ctx, refreshToken)
-	_ = uu.tokenRepo.StoreToken(ctx, userpkg.Token{
-		UserID:       user.ID,
-		RefreshToken: tokens.RefreshToken,
-		ExpiresAt:    tokens.RefreshExpiresAt,
-		CreatedAt:    time.Now(),
+	err = uu.tokenRepo.RotateRefreshToken(ctx, refreshToken, userpkg.Token{
+		UserID:          user.ID,
+		AccessToken:     tokens.AccessToken,
+		RefreshToken:    tokens.RefreshToken,
+		AccessTokenID:   tokens.AccessTokenID,
+		AccessExpiresAt: tokens.AccessExpiresAt,
+		FamilyID:        stored.FamilyID,
+		MFA:             stored.MFA,
+		UserAgent:       device.UserAgent,
+		IPAddress:       device.IPAddress,
+		CreatedAt:       stored.CreatedAt,
+		LastUsedAt:      time.Now(),
+		ExpiresAt:       tokens.RefreshExpiresAt,
 	})
+	if errors.Is(err, userpkg.ErrRefreshTokenReused) {
+		// Another request rotated the same token first
+		uu.revokeTokenFamily(ctx, stored, device)
+		return userpkg.TokenResult{}, err
+	}
+	if err != nil {
+		return userpkg.TokenResult{}, err
+	}
 
 	return tokens, nil
 }
 
+// revokeTokenFamily handles a replayed refresh token. Either the legitimate client or an
+// attacker holds a stale copy and there is no telling which, so every token of the login
+// is revoked and the user is told.
+func (uu *UserUsecase) revokeTokenFamily(ctx context.Context, stored userpkg.Token, device userpkg.DeviceInfo) {
+	if stored.FamilyID != "" {
+		err := uu.revokeAccessTokens(ctx, stored.UserID.Hex(), inSession(stored.FamilyID))
+		if err != nil {
+			log.Printf("failed to revoke access tokens of family %s: %v", stored.FamilyID, err)
+		}
+		if err := uu.tokenRepo.DeleteTokenFamily(ctx, stored.FamilyID); err != nil {
+			log.Printf("failed to revoke token family %s: %v", stored.FamilyID, err)
+		}
+	}
+
+	user, err := uu.userRepo.FindByID(ctx, stored.UserID.Hex())
+	if err != nil {
+		log.Printf("failed to load user %s for a security alert: %v", stored.UserID.Hex(), err)
+		return
+	}
+	err = uu.sendTemplatedEmail(user.Email, user.Language, services.TemplateSecurityAlert, map[string]interface{}{
+		"Name":  user.Fullname,
+		"Event":     "A sign-in token was reused, so we signed out that session. Please log in again.",
+		"Time":      time.Now(),
+		"IPAddress": device.IPAddress,
+		"UserAgent": device.UserAgent,
+	})
+	if err != nil {
+		log.Printf("failed to send security alert to user %s: %v", stored.UserID.Hex(), err)
+	}
+}
+
 func (u *UserUsecase) SendResetOTP(ctx context.Context, email string) error {
-	exists, _ := u.userRepo.ExistsByEmail(ctx, email)
-	if !exists {
+	user, err := u.userRepo.FindByEmail(ctx, email)
+	if err != nil {
 		return errors.New("email not registered")
 	}
 
-	otp := utils.GenerateOTP(6)
+	code, expiresAt, err := u.otp.Issue(ctx, otppkg.PurposePasswordReset, email, "")
+	if err != nil {
+		return err
+	}
+	return u.sendTemplatedEmail(email, user.Language, services.TemplatePasswordReset, map[string]interface{}{
+		"Name":             user.Fullname,
+		"Code":             code,
+		"ExpiresInMinutes": minutesUntil(expiresAt),
+	})
+}
 
-	err := u.emailSender.SendEmail(email, "Your OTP Code", "Your OTP: "+otp)
+// VerifyOTP checks the code from the reset email and returns a short-lived token for
+// ResetPassword. The code cannot be verified twice.
+func (u *UserUsecase) VerifyOTP(ctx context.Context, email, otp string) (string, error) {
+	return u.otp.Exchange(ctx, otppkg.PurposePasswordReset, email, otp, resetTokenLifetime)
+}
+
+// ResetPassword sets a new password with the token from VerifyOTP. Every session and personal
+// access token of the account is revoked, since whoever forgot the password may not be the only
+// one with it.
+func (u *UserUsecase) ResetPassword(ctx context.Context, resetToken, newPassword string, device userpkg.DeviceInfo) error {
+	// The token is only redeemed once the password passed, so a rejected one doesn't use it up
+	reset, err := u.otp.Lookup(ctx, otppkg.PurposePasswordReset, resetToken)
+	if errors.Is(err, otppkg.ErrInvalidToken) {
+		return userpkg.ErrInvalidResetToken
+	}
 	if err != nil {
 		return err
 	}
+	user, err := u.userRepo.FindByEmail(ctx, reset.Subject)
+	if err != nil {
+		return err
+	}
+	if err := u.passwordPolicy.Check(ctx, newPassword, user); err != nil {
+		return err
+	}
+	if _, err := u.otp.Redeem(ctx, otppkg.PurposePasswordReset, resetToken); err != nil {
+		if errors.Is(err, otppkg.ErrInvalidToken) {
+			return userpkg.ErrInvalidResetToken
+		}
+		return err
+	}
 
-	//Hash OTP before storing
-	hashedOTP, err := u.passwordSvc.HashPassword(otp)
+	hashed, err := u.passwordSvc.HashPassword(newPassword)
 	if err != nil {
 		return err
 	}
+	if err := u.passwordPolicy.Retire(ctx, user); err != nil {
+		return err
+	}
+	if err := u.userRepo.UpdatePasswordByEmail(ctx, user.Email, hashed); err != nil {
+		return err
+	}
 
-	reset := userpkg.PasswordReset{
-		Email:        email,
-		OTP:          hashedOTP,
-		ExpiresAt:    time.Now().Add(10 * time.Minute),
-		AttemptCount: 0,
+	userID := user.ID.Hex()
+	if err := u.revokeAccessTokens(ctx, userID, anySession); err != nil {
+		return err
+	}
+	if err := u.tokenRepo.DeleteTokensByUserID(ctx, userID); err != nil {
+		return err
+	}
+	// A token created by someone else would otherwise outlive the reset
+	if err := u.patRepo.DeleteByUser(ctx, userID); err != nil {
+		return err
+	}
+	// Proving access to the mailbox is as good as a successful login
+	if err := u.loginGuard.RecordSuccess(ctx, userID); err != nil {
+		log.Printf("failed to reset failed logins of user %s: %v", userID, err)
 	}
-	return u.passwordResetRepo.StoreResetRequest(ctx, reset)
-}
 
-func (u *UserUsecase) VerifyOTP(ctx context.Context, email, otp string) error {
-	stored, err := u.passwordResetRepo.GetResetRequest(ctx, email)
+	err = u.sendTemplatedEmail(user.Email, user.Language, services.TemplateSecurityAlert, map[string]interface{}{
+		"Name":      user.Fullname,
+		"Event":     "Your password was reset, every device was signed out and your personal access tokens were revoked.",
+		"Time":      time.Now(),
+		"IPAddress": device.IPAddress,
+		"UserAgent": device.UserAgent,
+	})
 	if err != nil {
-		return errors.New("no reset request found")
+		log.Printf("failed to send password reset confirmation to user %s: %v", userID, err)
 	}
+	return nil
+}
 
-	if time.Now().After(stored.ExpiresAt) {
-		_ = u.passwordResetRepo.DeleteResetRequest(ctx, email)
-		return errors.New("OTP expired")
+// Logout ends the session the request was made from. Access tokens issued before
+// sessions existed carry no session id, so those log out every device.
+func (u *UserUsecase) Logout(ctx context.Context, userID, sessionID string) error {
+	if sessionID == "" {
+		if err := u.revokeAccessTokens(ctx, userID, anySession); err != nil {
+			return err
+		}
+		return u.tokenRepo.DeleteTokensByUserID(ctx, userID)
 	}
-
-	if stored.AttemptCount >= 5 {
-		_ = u.passwordResetRepo.DeleteResetRequest(ctx, email)
-		return errors.New("too many invalid attempts — OTP expired")
+	if err := u.revokeAccessTokens(ctx, userID, inSession(sessionID)); err != nil {
+		return err
 	}
+	err := u.tokenRepo.DeleteSession(ctx, userID, sessionID)
+	if errors.Is(err, userpkg.ErrSessionNotFound) {
+		// Already revoked from another device
+		return nil
+	}
+	return err
+}
 
-	if u.passwordSvc.ComparePassword(stored.OTP, otp) != nil {
-		// increment attempt count
-		_ = u.passwordResetRepo.IncrementAttemptCount(ctx, email)
-		return errors.New("invalid OTP")
+func (u *UserUsecase) ListSessions(ctx context.Context, userID, currentSessionID string) ([]userpkg.Session, error) {
+	tokens, err := u.tokenRepo.ListSessions(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	sessions := make([]userpkg.Session, 0, len(tokens))
+	for _, t := range tokens {
+		sessions = append(sessions, userpkg.Session{
+			ID:         t.FamilyID,
+			UserAgent:  t.UserAgent,
+			IPAddress:  t.IPAddress,
+			CreatedAt:  t.CreatedAt,
+			LastUsedAt: t.LastUsedAt,
+			ExpiresAt:  t.ExpiresAt,
+			Current:    t.FamilyID == currentSessionID,
+		})
+	}
+	return sessions, nil
+}
+
+func (u *UserUsecase) RevokeSession(ctx context.Context, userID, sessionID string) error {
+	if err := u.revokeAccessTokens(ctx, userID, inSession(sessionID)); err != nil {
+		return err
 	}
+	return u.tokenRepo.DeleteSession(ctx, userID, sessionID)
+}
 
-	// OTP is valid — delete it
-	_ = u.passwordResetRepo.DeleteResetRequest(ctx, email)
-	return nil
+func (u *UserUsecase) RevokeOtherSessions(ctx context.Context, userID, currentSessionID string) error {
+	if currentSessionID == "" {
+		return errors.New("current session unknown, please log in again")
+	}
+	err := u.revokeAccessTokens(ctx, userID, func(t userpkg.Token) bool { return t.FamilyID != currentSessionID })
+	if err != nil {
+		return err
+	}
+	return u.tokenRepo.DeleteOtherSessions(ctx, userID, currentSessionID)
 }
 
-func (u *UserUsecase) ResetPassword(ctx context.Context, email, newPassword string) error {
-	hashed, err := u.passwordSvc.HashPassword(newPassword)
+// revokeAccessTokens revokes the user's unexpired access tokens that match. It must run
+// before the tokens' records are deleted, since those hold the access token ids.
+func (u *UserUsecase) revokeAccessTokens(ctx context.Context, userID string, match func(userpkg.Token) bool) error {
+	tokens, err := u.tokenRepo.ListActiveAccessTokens(ctx, userID)
 	if err != nil {
 		return err
 	}
-	return u.userRepo.UpdatePasswordByEmail(ctx, email, hashed)
+	var revoked []userpkg.RevokedToken
+	for _, t := range tokens {
+		if match(t) {
+			revoked = append(revoked, userpkg.RevokedToken{ID: t.AccessTokenID, UserID: userID, ExpiresAt: t.AccessExpiresAt})
+		}
+	}
+	if len(revoked) == 0 {
+		return nil
+	}
+	return u.tokenRevoker.Revoke(ctx, revoked...)
 }
 
-func (u *UserUsecase) Logout(ctx context.Context, userID string) error {
-	return u.tokenRepo.DeleteTokensByUserID(ctx, userID)
+func anySession(userpkg.Token) bool { return true }
+
+func inSession(sessionID string) func(userpkg.Token) bool {
+	return func(t userpkg.Token) bool { return t.FamilyID == sessionID }
 }
 
 func (uu *UserUsecase) PromoteUser(ctx context.Context, targetUserID string, actorUserID string) error {
@@ -320,6 +709,11 @@ func (uu *UserUsecase) PromoteUser(ctx context.Context, targetUserID string, act
 	if err := uu.userRepo.UpdateRoleAndPromoter(ctx, targetUserID, "admin", &actorUserID); err != nil {
 		return err
 	}
+	// Access tokens carry the role; make the user pick up the new one on their next refresh
+	if err := uu.revokeAccessTokens(ctx, targetUserID, anySession); err != nil {
+		return err
+	}
+	uu.events.Publish(ctx, eventpkg.UserPromoted{UserID: targetUserID, PromotedBy: actorUserID})
 	return nil
 }
 
@@ -340,56 +734,163 @@ func (uu *UserUsecase) DemoteUser(ctx context.Context, targetUserID string, acto
 	if err := uu.userRepo.UpdateRoleAndPromoter(ctx, targetUserID, "user", nil); err != nil {
 		return err
 	}
+	// Outstanding access tokens still say "admin"
+	if err := uu.revokeAccessTokens(ctx, targetUserID, anySession); err != nil {
+		return err
+	}
+	uu.events.Publish(ctx, eventpkg.UserDemoted{UserID: targetUserID, DemotedBy: actorUserID})
+	return nil
+}
+
+// AssignRole gives the target any existing role; PromoteUser and DemoteUser are the
+// admin/user shorthands
+func (uu *UserUsecase) AssignRole(ctx context.Context, targetUserID, role, actorUserID string) error {
+	if targetUserID == actorUserID {
+		return errors.New("cannot change your own role")
+	}
+	if _, err := uu.permissions.Resolve(ctx, role); err != nil {
+		return err
+	}
+	target, err := uu.userRepo.FindByID(ctx, targetUserID)
+	if err != nil {
+		return err
+	}
+	actor, err := uu.userRepo.FindByID(ctx, actorUserID)
+	if err != nil {
+		return err
+	}
+	if !actor.PromotedBy.IsZero() && actor.PromotedBy.Hex() == target.ID.Hex() {
+		return errors.New("cannot act on your promoter")
+	}
+	if target.Role == role {
+		return nil
+	}
+
+	var promoter *string
+	if role == rolepkg.RoleAdmin {
+		promoter = &actorUserID
+	}
+	if err := uu.userRepo.UpdateRoleAndPromoter(ctx, targetUserID, role, promoter); err != nil {
+		return err
+	}
+	// Access tokens carry the role; make the user pick up the new one on their next refresh
+	if err := uu.revokeAccessTokens(ctx, targetUserID, anySession); err != nil {
+		return err
+	}
+	uu.events.Publish(ctx, eventpkg.UserRoleChanged{UserID: targetUserID, Role: role, ChangedBy: actorUserID})
 	return nil
 }
 
 func (u *UserUsecase) SendVerificationOTP(ctx context.Context, email string) error {
-	exists, _ := u.userRepo.ExistsByEmail(ctx, email)
-	if !exists {
+	user, err := u.userRepo.FindByEmail(ctx, email)
+	if err != nil {
 		return errors.New("email not registered")
 	}
 
-	otp := utils.GenerateOTP(6)
-	if err := u.emailSender.SendEmail(email, "Your verification code", "Your code: "+otp); err != nil {
+	code, expiresAt, err := u.otp.Issue(ctx, otppkg.PurposeVerification, email, "")
+	if err != nil {
 		return err
 	}
+	return u.sendTemplatedEmail(email, user.Language, services.TemplateVerification, map[string]interface{}{
+		"Name":             user.Fullname,
+		"Code":             code,
+		"ExpiresInMinutes": minutesUntil(expiresAt),
+	})
+}
 
-	hashed, err := u.passwordSvc.HashPassword(otp)
+func (u *UserUsecase) VerifyUser(ctx context.Context, email, otp string) error {
+	if _, err := u.otp.Verify(ctx, otppkg.PurposeVerification, email, otp); err != nil {
+		return err
+	}
+	// flip user verified
+	if err := u.userRepo.UpdateIsVerifiedByEmail(ctx, email, true); err != nil {
+		return err
+	}
+	u.events.Publish(ctx, eventpkg.UserVerified{Email: email})
+	return nil
+}
+
+// RequestEmailChange needs the password, so a session left open somewhere is not enough to
+// take over the account
+func (u *UserUsecase) RequestEmailChange(ctx context.Context, userID, password, newEmail string) error {
+	newEmail = strings.TrimSpace(newEmail)
+	if !utils.IsValidEmail(newEmail) {
+		return errors.New("invalid email format")
+	}
+	user, err := u.userRepo.FindByID(ctx, userID)
 	if err != nil {
 		return err
 	}
+	if user.Password == "" || u.passwordSvc.ComparePassword(user.Password, password) != nil {
+		return errors.New("invalid password")
+	}
+	if strings.EqualFold(user.Email, newEmail) {
+		return errors.New("that is already your email address")
+	}
+	if err := u.checkEmailAvailable(ctx, newEmail); err != nil {
+		return err
+	}
+	// The same rules as at registration, or blocked domains could be reached this way
+	isReal, err := u.emailVerifier.IsRealEmail(newEmail)
+	if err != nil {
+		return errors.New("failed to verify email: " + err.Error())
+	}
+	if !isReal {
+		return errors.New("email is unreachable")
+	}
 
-	v := userpkg.Verification{
-		Email:        email,
-		OTP:          hashed,
-		ExpiresAt:    time.Now().Add(15 * time.Minute),
-		AttemptCount: 0,
+	code, expiresAt, err := u.otp.Issue(ctx, otppkg.PurposeEmailChange, userID, newEmail)
+	if err != nil {
+		return err
 	}
-	return u.verificationRepo.StoreVerification(ctx, v)
+	return u.sendTemplatedEmail(newEmail, user.Language, services.TemplateEmailChange, map[string]interface{}{
+		"Name":             user.Fullname,
+		"Code":             code,
+		"ExpiresInMinutes": minutesUntil(expiresAt),
+	})
 }
 
-func (u *UserUsecase) VerifyUser(ctx context.Context, email, otp string) error {
-	v, err := u.verificationRepo.GetVerification(ctx, email)
+// ConfirmEmailChange moves the account to the address the code was sent to and tells the
+// old address about it
+func (u *UserUsecase) ConfirmEmailChange(ctx context.Context, userID, code string, device userpkg.DeviceInfo) error {
+	challenge, err := u.otp.Verify(ctx, otppkg.PurposeEmailChange, userID, code)
 	if err != nil {
-		return errors.New("no verification found")
-	}
-	if time.Now().After(v.ExpiresAt) {
-		_ = u.verificationRepo.DeleteVerification(ctx, email)
-		return errors.New("verification code expired")
+		return err
 	}
-	if v.AttemptCount >= 5 {
-		_ = u.verificationRepo.DeleteVerification(ctx, email)
-		return errors.New("too many invalid attempts")
+	newEmail := challenge.Data
+	user, err := u.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return err
 	}
-	if u.passwordSvc.ComparePassword(v.OTP, otp) != nil {
-		_ = u.verificationRepo.IncrementAttemptCount(ctx, email)
-		return errors.New("invalid code")
+	// The address may have been taken since the code was sent
+	if err := u.checkEmailAvailable(ctx, newEmail); err != nil {
+		return err
 	}
-	// flip user verified
-	if err := u.userRepo.UpdateIsVerifiedByEmail(ctx, email, true); err != nil {
+	if err := u.userRepo.UpdateEmailByID(ctx, userID, newEmail); err != nil {
 		return err
 	}
-	_ = u.verificationRepo.DeleteVerification(ctx, email)
+
+	err = u.sendTemplatedEmail(user.Email, user.Language, services.TemplateSecurityAlert, map[string]interface{}{
+		"Name":      user.Fullname,
+		"Event":     "The email address of your account was changed to " + newEmail + ".",
+		"Time":      time.Now(),
+		"IPAddress": device.IPAddress,
+		"UserAgent": device.UserAgent,
+	})
+	if err != nil {
+		log.Printf("failed to send email change alert to user %s: %v", userID, err)
+	}
+	return nil
+}
+
+func (u *UserUsecase) checkEmailAvailable(ctx context.Context, email string) error {
+	exists, err := u.userRepo.ExistsByEmail(ctx, email)
+	if err != nil {
+		return errors.New("failed to check email existence: " + err.Error())
+	}
+	if exists {
+		return errors.New("email already taken")
+	}
 	return nil
 }
 
@@ -407,6 +908,9 @@ func (u *UserUsecase) UpdateProfile(ctx context.Context, userID string, updates
     if updates.ContactInfo.Website != "" && !IsValidURL(updates.ContactInfo.Website) {
 		return userpkg.User{}, errors.New("invalid website URL")
     }
+    if updates.Language != "" && !IsValidLanguageTag(updates.Language) {
+        return userpkg.User{}, errors.New("invalid language tag")
+    }
 
 	if file != nil && filename != "" {
 		imageURL, err := u.cloudinaryService.UploadImage(ctx, file, filename)
@@ -425,6 +929,12 @@ func IsValidPhone(phone string) bool {
     return phoneRegex.MatchString(phone)
 }
 
+// IsValidLanguageTag accepts BCP 47 style tags such as "en", "es-MX" or "pt_BR"
+func IsValidLanguageTag(tag string) bool {
+    tagRegex := regexp.MustCompile(`^[a-zA-Z]{2,3}([-_][a-zA-Z0-9]{2,8})*$`)
+    return tagRegex.MatchString(tag)
+}
+
 func IsValidURL(rawurl string) bool {
 	_, err := url.Parse(rawurl)
 	return err == nil && (strings.HasPrefix(rawurl, "http://") || strings.HasPrefix(rawurl, "https://"))
@@ -0,0 +1,337 @@
+package usecases
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	eventpkg "github.com/Amaankaa/Blog-Starter-Project/Domain/event"
+	webhookpkg "github.com/Amaankaa/Blog-Starter-Project/Domain/webhook"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// WebhookRetryPolicy controls redelivery of failed webhook calls.
+// The n-th retry waits BaseBackoff * 2^(n-1), capped at MaxBackoff.
+type WebhookRetryPolicy struct {
+	MaxAttempts int
+	BaseBackoff time.Duration
+	MaxBackoff  time.Duration
+	SendTimeout time.Duration
+}
+
+// DefaultWebhookRetryPolicy retries for roughly an hour and a half before giving up
+var DefaultWebhookRetryPolicy = WebhookRetryPolicy{
+	MaxAttempts: 8,
+	BaseBackoff: 30 * time.Second,
+	MaxBackoff:  30 * time.Minute,
+	SendTimeout: 10 * time.Second,
+}
+
+type WebhookUsecase struct {
+	webhookRepo  webhookpkg.IWebhookRepository
+	deliveryRepo webhookpkg.IDeliveryRepository
+	sender       webhookpkg.IWebhookSender
+	policy       WebhookRetryPolicy
+	wake         chan struct{}
+}
+
+func NewWebhookUsecase(
+	webhookRepo webhookpkg.IWebhookRepository,
+	deliveryRepo webhookpkg.IDeliveryRepository,
+	sender webhookpkg.IWebhookSender,
+	policy WebhookRetryPolicy,
+) *WebhookUsecase {
+	return &WebhookUsecase{
+		webhookRepo:  webhookRepo,
+		deliveryRepo: deliveryRepo,
+		sender:       sender,
+		policy:       policy,
+		wake:         make(chan struct{}, 1),
+	}
+}
+
+func (wu *WebhookUsecase) RegisterWebhook(ctx context.Context, req webhookpkg.CreateWebhookRequest, createdBy string) (webhookpkg.Webhook, error) {
+	if !IsValidURL(req.URL) {
+		return webhookpkg.Webhook{}, errors.New("webhook URL must be an http(s) URL")
+	}
+	events, err := normalizeEventTypes(req.Events)
+	if err != nil {
+		return webhookpkg.Webhook{}, err
+	}
+
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return webhookpkg.Webhook{}, errors.New("failed to generate webhook secret")
+	}
+
+	return wu.webhookRepo.CreateWebhook(ctx, webhookpkg.Webhook{
+		URL:       req.URL,
+		Secret:    hex.EncodeToString(secret),
+		Events:    events,
+		Active:    true,
+		CreatedBy: createdBy,
+		CreatedAt: time.Now(),
+	})
+}
+
+// ListWebhooks returns all webhooks without their signing secrets
+func (wu *WebhookUsecase) ListWebhooks(ctx context.Context) ([]webhookpkg.Webhook, error) {
+	hooks, err := wu.webhookRepo.ListWebhooks(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for i := range hooks {
+		hooks[i].Secret = ""
+	}
+	return hooks, nil
+}
+
+func (wu *WebhookUsecase) DeleteWebhook(ctx context.Context, id string) error {
+	return wu.webhookRepo.DeleteWebhook(ctx, id)
+}
+
+func (wu *WebhookUsecase) ListDeliveries(ctx context.Context, webhookID string) ([]webhookpkg.Delivery, error) {
+	if _, err := wu.webhookRepo.GetWebhook(ctx, webhookID); err != nil {
+		return nil, err
+	}
+	return wu.deliveryRepo.ListDeliveries(ctx, webhookID, 100)
+}
+
+// ReplayDelivery queues a fresh copy of a past delivery, keeping the original in the log
+func (wu *WebhookUsecase) ReplayDelivery(ctx context.Context, deliveryID string) (webhookpkg.Delivery, error) {
+	original, err := wu.deliveryRepo.GetDelivery(ctx, deliveryID)
+	if err != nil {
+		return webhookpkg.Delivery{}, err
+	}
+
+	now := time.Now()
+	replay, err := wu.deliveryRepo.CreateDelivery(ctx, webhookpkg.Delivery{
+		WebhookID:     original.WebhookID,
+		EventID:       original.EventID,
+		EventType:     original.EventType,
+		Payload:       original.Payload,
+		Status:        webhookpkg.DeliveryPending,
+		NextAttemptAt: now,
+		ReplayOf:      original.ID,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	})
+	if err != nil {
+		return webhookpkg.Delivery{}, err
+	}
+	wu.notify()
+	return replay, nil
+}
+
+// Publish records a pending delivery for every webhook subscribed to eventType.
+// It never fails the caller: problems are logged and the event is dropped.
+func (wu *WebhookUsecase) Publish(ctx context.Context, eventType string, data interface{}) {
+	hooks, err := wu.webhookRepo.FindActiveByEvent(ctx, eventType)
+	if err != nil {
+		log.Printf("webhooks: failed to look up subscribers for %s: %v", eventType, err)
+		return
+	}
+	if len(hooks) == 0 {
+		return
+	}
+
+	raw, err := json.Marshal(data)
+	if err != nil {
+		log.Printf("webhooks: failed to encode %s payload: %v", eventType, err)
+		return
+	}
+	now := time.Now().UTC()
+	event := webhookpkg.Event{
+		ID:        primitive.NewObjectID().Hex(),
+		Type:      eventType,
+		CreatedAt: now,
+		Data:      raw,
+	}
+	payload, err := json.Marshal(event)
+	if err != nil {
+		log.Printf("webhooks: failed to encode %s event: %v", eventType, err)
+		return
+	}
+
+	for _, hook := range hooks {
+		_, err := wu.deliveryRepo.CreateDelivery(ctx, webhookpkg.Delivery{
+			WebhookID:     hook.ID,
+			EventID:       event.ID,
+			EventType:     eventType,
+			Payload:       string(payload),
+			Status:        webhookpkg.DeliveryPending,
+			NextAttemptAt: now,
+			CreatedAt:     now,
+			UpdatedAt:     now,
+		})
+		if err != nil {
+			log.Printf("webhooks: failed to queue %s for webhook %s: %v", eventType, hook.ID.Hex(), err)
+		}
+	}
+	wu.notify()
+}
+
+// SubscribeTo turns the domain events that webhooks expose into deliveries. The
+// subscribers are async so queueing deliveries never slows down the request.
+func (wu *WebhookUsecase) SubscribeTo(bus *eventpkg.Bus) {
+	eventpkg.Subscribe(bus, eventpkg.Async, func(ctx context.Context, e eventpkg.BlogPublished) error {
+		wu.Publish(ctx, webhookpkg.EventBlogCreated, e.Blog)
+		return nil
+	})
+	eventpkg.Subscribe(bus, eventpkg.Async, func(ctx context.Context, e eventpkg.BlogUpdated) error {
+		wu.Publish(ctx, webhookpkg.EventBlogUpdated, e.Blog)
+		return nil
+	})
+	eventpkg.Subscribe(bus, eventpkg.Async, func(ctx context.Context, e eventpkg.BlogDeleted) error {
+		wu.Publish(ctx, webhookpkg.EventBlogDeleted, map[string]string{"id": e.BlogID})
+		return nil
+	})
+	eventpkg.Subscribe(bus, eventpkg.Async, func(ctx context.Context, e eventpkg.CommentAdded) error {
+		wu.Publish(ctx, webhookpkg.EventCommentAdded, e.Comment)
+		return nil
+	})
+	eventpkg.Subscribe(bus, eventpkg.Async, func(ctx context.Context, e eventpkg.LikeToggled) error {
+		wu.Publish(ctx, webhookpkg.EventLikeToggled, map[string]interface{}{
+			"blog_id": e.BlogID,
+			"user_id": e.UserID,
+			"liked":   e.Liked,
+		})
+		return nil
+	})
+	eventpkg.Subscribe(bus, eventpkg.Async, func(ctx context.Context, e eventpkg.UserRegistered) error {
+		// The email address is deliberately left out of the external payload
+		wu.Publish(ctx, webhookpkg.EventUserRegistered, map[string]interface{}{
+			"id":       e.UserID,
+			"username": e.Username,
+			"role":     e.Role,
+		})
+		return nil
+	})
+}
+
+// Run delivers due webhooks until ctx is cancelled, waking on new events or every pollInterval
+func (wu *WebhookUsecase) Run(ctx context.Context, pollInterval time.Duration) {
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	for {
+		wu.DispatchDue(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-wu.wake:
+		}
+	}
+}
+
+// DispatchDue attempts every delivery that is currently due and returns how many were attempted
+func (wu *WebhookUsecase) DispatchDue(ctx context.Context) int {
+	attempted := 0
+	for ctx.Err() == nil {
+		// The lease outlives a send so a slow receiver is not called twice concurrently
+		delivery, ok, err := wu.deliveryRepo.ClaimDue(ctx, time.Now(), 2*wu.policy.SendTimeout)
+		if err != nil {
+			log.Printf("webhooks: failed to claim delivery: %v", err)
+			return attempted
+		}
+		if !ok {
+			return attempted
+		}
+		wu.attempt(ctx, delivery)
+		attempted++
+	}
+	return attempted
+}
+
+func (wu *WebhookUsecase) attempt(ctx context.Context, delivery webhookpkg.Delivery) {
+	hook, err := wu.webhookRepo.GetWebhook(ctx, delivery.WebhookID.Hex())
+	if err != nil || !hook.Active {
+		delivery.Status = webhookpkg.DeliveryFailed
+		delivery.LastError = "webhook removed or disabled"
+		delivery.UpdatedAt = time.Now()
+		wu.saveDelivery(ctx, delivery)
+		return
+	}
+
+	headers := map[string]string{
+		"X-Webhook-Event":    delivery.EventType,
+		"X-Webhook-Event-ID": delivery.EventID, // stable across retries and replays, for receiver-side deduplication
+		"X-Webhook-Delivery": delivery.ID.Hex(),
+	}
+	sendCtx, cancel := context.WithTimeout(ctx, wu.policy.SendTimeout)
+	code, err := wu.sender.Send(sendCtx, hook.URL, hook.Secret, headers, []byte(delivery.Payload))
+	cancel()
+
+	now := time.Now()
+	delivery.Attempts++
+	delivery.ResponseCode = code
+	delivery.UpdatedAt = now
+
+	switch {
+	case err == nil:
+		delivery.Status = webhookpkg.DeliverySucceeded
+		delivery.LastError = ""
+	case delivery.Attempts >= wu.policy.MaxAttempts:
+		delivery.Status = webhookpkg.DeliveryFailed
+		delivery.LastError = err.Error()
+	default:
+		delivery.LastError = err.Error()
+		delivery.NextAttemptAt = now.Add(exponentialBackoff(wu.policy.BaseBackoff, wu.policy.MaxBackoff, delivery.Attempts))
+	}
+	wu.saveDelivery(ctx, delivery)
+}
+
+func (wu *WebhookUsecase) saveDelivery(ctx context.Context, delivery webhookpkg.Delivery) {
+	if err := wu.deliveryRepo.UpdateDelivery(ctx, delivery); err != nil {
+		log.Printf("webhooks: failed to record delivery %s: %v", delivery.ID.Hex(), err)
+	}
+}
+
+// exponentialBackoff returns base * 2^(attempts-1), capped at max
+func exponentialBackoff(base, max time.Duration, attempts int) time.Duration {
+	d := base
+	for i := 1; i < attempts && d < max; i++ {
+		d *= 2
+	}
+	if d > max {
+		d = max
+	}
+	return d
+}
+
+func (wu *WebhookUsecase) notify() {
+	select {
+	case wu.wake <- struct{}{}:
+	default:
+	}
+}
+
+// normalizeEventTypes rejects unknown event types and removes duplicates
+func normalizeEventTypes(events []string) ([]string, error) {
+	known := make(map[string]bool, len(webhookpkg.EventTypes))
+	for _, e := range webhookpkg.EventTypes {
+		known[e] = true
+	}
+
+	seen := make(map[string]bool, len(events))
+	normalized := make([]string, 0, len(events))
+	for _, e := range events {
+		if !known[e] {
+			return nil, fmt.Errorf("unknown event type %q", e)
+		}
+		if !seen[e] {
+			seen[e] = true
+			normalized = append(normalized, e)
+		}
+	}
+	if len(normalized) == 0 {
+		return nil, errors.New("at least one event type is required")
+	}
+	return normalized, nil
+}
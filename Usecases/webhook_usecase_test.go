@@ -0,0 +1,297 @@
+package usecases_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	eventpkg "github.com/Amaankaa/Blog-Starter-Project/Domain/event"
+	webhookpkg "github.com/Amaankaa/Blog-Starter-Project/Domain/webhook"
+	infrastructure "github.com/Amaankaa/Blog-Starter-Project/Infrastructure"
+	usecases "github.com/Amaankaa/Blog-Starter-Project/Usecases"
+	"github.com/Amaankaa/Blog-Starter-Project/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const testWebhookSecret = "test-secret"
+
+type WebhookUsecaseSuite struct {
+	suite.Suite
+	ctx          context.Context
+	webhookRepo  *mocks.IWebhookRepository
+	deliveryRepo *mocks.IDeliveryRepository
+	usecase      *usecases.WebhookUsecase
+	receiver     *httptest.Server
+	status       int
+	received     []*http.Request
+	bodies       [][]byte
+}
+
+func TestWebhookUsecaseSuite(t *testing.T) {
+	suite.Run(t, new(WebhookUsecaseSuite))
+}
+
+func (s *WebhookUsecaseSuite) SetupTest() {
+	s.ctx = context.Background()
+	s.webhookRepo = mocks.NewIWebhookRepository(s.T())
+	s.deliveryRepo = mocks.NewIDeliveryRepository(s.T())
+	s.status = http.StatusOK
+	s.received = nil
+	s.bodies = nil
+	s.receiver = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		s.received = append(s.received, r)
+		s.bodies = append(s.bodies, body)
+		w.WriteHeader(s.status)
+	}))
+
+	policy := usecases.WebhookRetryPolicy{
+		MaxAttempts: 3,
+		BaseBackoff: time.Second,
+		MaxBackoff:  90 * time.Second,
+		SendTimeout: 2 * time.Second,
+	}
+	s.usecase = usecases.NewWebhookUsecase(s.webhookRepo, s.deliveryRepo, infrastructure.NewWebhookSender(policy.SendTimeout), policy)
+}
+
+func (s *WebhookUsecaseSuite) TearDownTest() {
+	s.receiver.Close()
+}
+
+func (s *WebhookUsecaseSuite) hook() webhookpkg.Webhook {
+	return webhookpkg.Webhook{
+		ID:     primitive.NewObjectID(),
+		URL:    s.receiver.URL,
+		Secret: testWebhookSecret,
+		Events: []string{webhookpkg.EventBlogCreated},
+		Active: true,
+	}
+}
+
+// expectClaims makes ClaimDue hand out the given deliveries once each, then report nothing due
+func (s *WebhookUsecaseSuite) expectClaims(deliveries ...webhookpkg.Delivery) {
+	for _, d := range deliveries {
+		s.deliveryRepo.On("ClaimDue", mock.Anything, mock.Anything, 4*time.Second).Return(d, true, nil).Once()
+	}
+	s.deliveryRepo.On("ClaimDue", mock.Anything, mock.Anything, 4*time.Second).Return(webhookpkg.Delivery{}, false, nil).Once()
+}
+
+func (s *WebhookUsecaseSuite) TestRegisterWebhook_GeneratesSecret() {
+	s.webhookRepo.On("CreateWebhook", s.ctx, mock.Anything).Return(func(_ context.Context, hook webhookpkg.Webhook) (webhookpkg.Webhook, error) {
+		return hook, nil
+	}).Once()
+
+	hook, err := s.usecase.RegisterWebhook(s.ctx, webhookpkg.CreateWebhookRequest{
+		URL:    "https://example.com/hooks",
+		Events: []string{webhookpkg.EventBlogCreated, webhookpkg.EventBlogCreated, webhookpkg.EventLikeToggled},
+	}, "admin-1")
+
+	s.NoError(err)
+	s.Len(hook.Secret, 64)
+	s.True(hook.Active)
+	s.Equal("admin-1", hook.CreatedBy)
+	s.Equal([]string{webhookpkg.EventBlogCreated, webhookpkg.EventLikeToggled}, hook.Events)
+}
+
+func (s *WebhookUsecaseSuite) TestRegisterWebhook_Validation() {
+	_, err := s.usecase.RegisterWebhook(s.ctx, webhookpkg.CreateWebhookRequest{URL: "ftp://example.com", Events: []string{webhookpkg.EventBlogCreated}}, "admin-1")
+	s.Error(err)
+
+	_, err = s.usecase.RegisterWebhook(s.ctx, webhookpkg.CreateWebhookRequest{URL: "https://example.com", Events: []string{"blog.exploded"}}, "admin-1")
+	s.EqualError(err, `unknown event type "blog.exploded"`)
+
+	_, err = s.usecase.RegisterWebhook(s.ctx, webhookpkg.CreateWebhookRequest{URL: "https://example.com"}, "admin-1")
+	s.EqualError(err, "at least one event type is required")
+}
+
+func (s *WebhookUsecaseSuite) TestListWebhooks_ScrubsSecrets() {
+	s.webhookRepo.On("ListWebhooks", s.ctx).Return([]webhookpkg.Webhook{s.hook(), s.hook()}, nil).Once()
+
+	hooks, err := s.usecase.ListWebhooks(s.ctx)
+	s.NoError(err)
+	s.Len(hooks, 2)
+	for _, h := range hooks {
+		s.Empty(h.Secret)
+	}
+}
+
+func (s *WebhookUsecaseSuite) TestPublish_QueuesOneDeliveryPerSubscriber() {
+	hooks := []webhookpkg.Webhook{s.hook(), s.hook()}
+	s.webhookRepo.On("FindActiveByEvent", s.ctx, webhookpkg.EventBlogDeleted).Return(hooks, nil).Once()
+	var queued []webhookpkg.Delivery
+	s.deliveryRepo.On("CreateDelivery", s.ctx, mock.Anything).Run(func(args mock.Arguments) {
+		queued = append(queued, args.Get(1).(webhookpkg.Delivery))
+	}).Return(webhookpkg.Delivery{}, nil).Twice()
+
+	s.usecase.Publish(s.ctx, webhookpkg.EventBlogDeleted, map[string]string{"id": "b1"})
+
+	s.Require().Len(queued, 2)
+	s.Equal(hooks[0].ID, queued[0].WebhookID)
+	s.Equal(hooks[1].ID, queued[1].WebhookID)
+	s.Equal(queued[0].EventID, queued[1].EventID)
+	s.Equal(webhookpkg.DeliveryPending, queued[0].Status)
+
+	var event webhookpkg.Event
+	s.NoError(json.Unmarshal([]byte(queued[0].Payload), &event))
+	s.Equal(webhookpkg.EventBlogDeleted, event.Type)
+	s.Equal(queued[0].EventID, event.ID)
+	s.JSONEq(`{"id":"b1"}`, string(event.Data))
+}
+
+func (s *WebhookUsecaseSuite) TestPublish_LookupErrorIsSwallowed() {
+	s.webhookRepo.On("FindActiveByEvent", s.ctx, webhookpkg.EventBlogCreated).Return(nil, errors.New("db down")).Once()
+
+	s.NotPanics(func() {
+		s.usecase.Publish(s.ctx, webhookpkg.EventBlogCreated, map[string]string{"id": "b1"})
+	})
+}
+
+func (s *WebhookUsecaseSuite) TestSubscribeTo_MapsDomainEvents() {
+	bus := eventpkg.NewBus()
+	s.usecase.SubscribeTo(bus)
+	s.webhookRepo.On("FindActiveByEvent", mock.Anything, webhookpkg.EventBlogDeleted).Return([]webhookpkg.Webhook{s.hook()}, nil).Once()
+	var queued webhookpkg.Delivery
+	s.deliveryRepo.On("CreateDelivery", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		queued = args.Get(1).(webhookpkg.Delivery)
+	}).Return(webhookpkg.Delivery{}, nil).Once()
+
+	bus.Publish(s.ctx, eventpkg.BlogDeleted{BlogID: "b1", AuthorID: "u1"})
+	// not exposed to webhooks
+	bus.Publish(s.ctx, eventpkg.UserVerified{Email: "a@example.com"})
+	bus.Wait()
+
+	var event webhookpkg.Event
+	s.NoError(json.Unmarshal([]byte(queued.Payload), &event))
+	s.Equal(webhookpkg.EventBlogDeleted, event.Type)
+	s.JSONEq(`{"id":"b1"}`, string(event.Data))
+}
+
+func (s *WebhookUsecaseSuite) TestDispatch_DeliversSignedPayload() {
+	hook := s.hook()
+	s.webhookRepo.On("FindActiveByEvent", s.ctx, webhookpkg.EventBlogCreated).Return([]webhookpkg.Webhook{hook}, nil).Once()
+	var queued webhookpkg.Delivery
+	s.deliveryRepo.On("CreateDelivery", s.ctx, mock.Anything).Run(func(args mock.Arguments) {
+		queued = args.Get(1).(webhookpkg.Delivery)
+		queued.ID = primitive.NewObjectID()
+	}).Return(webhookpkg.Delivery{}, nil).Once()
+
+	s.usecase.Publish(s.ctx, webhookpkg.EventBlogCreated, map[string]string{"title": "Hello"})
+
+	s.expectClaims(queued)
+	s.webhookRepo.On("GetWebhook", s.ctx, hook.ID.Hex()).Return(hook, nil).Once()
+	s.deliveryRepo.On("UpdateDelivery", s.ctx, mock.MatchedBy(func(d webhookpkg.Delivery) bool {
+		return d.ID == queued.ID && d.Status == webhookpkg.DeliverySucceeded && d.Attempts == 1 && d.ResponseCode == http.StatusOK
+	})).Return(nil).Once()
+
+	s.Equal(1, s.usecase.DispatchDue(s.ctx))
+
+	s.Require().Len(s.received, 1)
+	req, body := s.received[0], s.bodies[0]
+	s.Equal(queued.Payload, string(body))
+	s.Equal(webhookpkg.EventBlogCreated, req.Header.Get("X-Webhook-Event"))
+	s.Equal(queued.EventID, req.Header.Get("X-Webhook-Event-ID"))
+	s.Equal(queued.ID.Hex(), req.Header.Get("X-Webhook-Delivery"))
+	s.NoError(infrastructure.VerifyWebhookSignature(testWebhookSecret, req.Header.Get(infrastructure.WebhookSignatureHeader), body, time.Minute))
+	s.Error(infrastructure.VerifyWebhookSignature("wrong-secret", req.Header.Get(infrastructure.WebhookSignatureHeader), body, time.Minute))
+}
+
+func (s *WebhookUsecaseSuite) TestDispatch_RetriesWithExponentialBackoff() {
+	s.status = http.StatusServiceUnavailable
+	hook := s.hook()
+	delivery := webhookpkg.Delivery{ID: primitive.NewObjectID(), WebhookID: hook.ID, EventType: webhookpkg.EventBlogCreated, Payload: `{}`, Status: webhookpkg.DeliveryPending, Attempts: 1}
+	s.expectClaims(delivery)
+	s.webhookRepo.On("GetWebhook", s.ctx, hook.ID.Hex()).Return(hook, nil).Once()
+
+	var saved webhookpkg.Delivery
+	s.deliveryRepo.On("UpdateDelivery", s.ctx, mock.Anything).Run(func(args mock.Arguments) {
+		saved = args.Get(1).(webhookpkg.Delivery)
+	}).Return(nil).Once()
+
+	before := time.Now()
+	s.usecase.DispatchDue(s.ctx)
+
+	s.Equal(webhookpkg.DeliveryPending, saved.Status)
+	s.Equal(2, saved.Attempts)
+	s.Equal(http.StatusServiceUnavailable, saved.ResponseCode)
+	s.Contains(saved.LastError, "503")
+	// second attempt failed: wait BaseBackoff * 2
+	s.WithinDuration(before.Add(2*time.Second), saved.NextAttemptAt, time.Second)
+}
+
+func (s *WebhookUsecaseSuite) TestDispatch_GivesUpAfterMaxAttempts() {
+	s.status = http.StatusInternalServerError
+	hook := s.hook()
+	delivery := webhookpkg.Delivery{ID: primitive.NewObjectID(), WebhookID: hook.ID, Payload: `{}`, Status: webhookpkg.DeliveryPending, Attempts: 2}
+	s.expectClaims(delivery)
+	s.webhookRepo.On("GetWebhook", s.ctx, hook.ID.Hex()).Return(hook, nil).Once()
+	s.deliveryRepo.On("UpdateDelivery", s.ctx, mock.MatchedBy(func(d webhookpkg.Delivery) bool {
+		return d.Status == webhookpkg.DeliveryFailed && d.Attempts == 3 && d.ResponseCode == http.StatusInternalServerError
+	})).Return(nil).Once()
+
+	s.usecase.DispatchDue(s.ctx)
+	s.Len(s.received, 1)
+}
+
+func (s *WebhookUsecaseSuite) TestDispatch_RedirectsAreNotFollowed() {
+	s.status = http.StatusFound
+	hook := s.hook()
+	delivery := webhookpkg.Delivery{ID: primitive.NewObjectID(), WebhookID: hook.ID, Payload: `{}`, Status: webhookpkg.DeliveryPending}
+	s.expectClaims(delivery)
+	s.webhookRepo.On("GetWebhook", s.ctx, hook.ID.Hex()).Return(hook, nil).Once()
+	s.deliveryRepo.On("UpdateDelivery", s.ctx, mock.MatchedBy(func(d webhookpkg.Delivery) bool {
+		return d.Status == webhookpkg.DeliveryPending && d.ResponseCode == http.StatusFound
+	})).Return(nil).Once()
+
+	s.usecase.DispatchDue(s.ctx)
+	s.Len(s.received, 1)
+}
+
+func (s *WebhookUsecaseSuite) TestDispatch_DeletedWebhookFailsDelivery() {
+	delivery := webhookpkg.Delivery{ID: primitive.NewObjectID(), WebhookID: primitive.NewObjectID(), Status: webhookpkg.DeliveryPending}
+	s.expectClaims(delivery)
+	s.webhookRepo.On("GetWebhook", s.ctx, delivery.WebhookID.Hex()).Return(webhookpkg.Webhook{}, errors.New("webhook not found")).Once()
+	s.deliveryRepo.On("UpdateDelivery", s.ctx, mock.MatchedBy(func(d webhookpkg.Delivery) bool {
+		return d.Status == webhookpkg.DeliveryFailed && d.Attempts == 0
+	})).Return(nil).Once()
+
+	s.usecase.DispatchDue(s.ctx)
+	s.Empty(s.received)
+}
+
+func (s *WebhookUsecaseSuite) TestReplayDelivery_QueuesCopy() {
+	original := webhookpkg.Delivery{
+		ID:        primitive.NewObjectID(),
+		WebhookID: primitive.NewObjectID(),
+		EventID:   "evt-1",
+		EventType: webhookpkg.EventBlogUpdated,
+		Payload:   `{"id":"evt-1"}`,
+		Status:    webhookpkg.DeliveryFailed,
+		Attempts:  3,
+		LastError: "boom",
+	}
+	s.deliveryRepo.On("GetDelivery", s.ctx, original.ID.Hex()).Return(original, nil).Once()
+	s.deliveryRepo.On("CreateDelivery", s.ctx, mock.MatchedBy(func(d webhookpkg.Delivery) bool {
+		return d.ReplayOf == original.ID && d.EventID == "evt-1" && d.Payload == original.Payload &&
+			d.Status == webhookpkg.DeliveryPending && d.Attempts == 0 && d.LastError == ""
+	})).Return(func(_ context.Context, d webhookpkg.Delivery) (webhookpkg.Delivery, error) {
+		return d, nil
+	}).Once()
+
+	replay, err := s.usecase.ReplayDelivery(s.ctx, original.ID.Hex())
+	s.NoError(err)
+	s.Equal(original.ID, replay.ReplayOf)
+}
+
+func (s *WebhookUsecaseSuite) TestListDeliveries_UnknownWebhook() {
+	s.webhookRepo.On("GetWebhook", s.ctx, "missing").Return(webhookpkg.Webhook{}, errors.New("webhook not found")).Once()
+
+	_, err := s.usecase.ListDeliveries(s.ctx, "missing")
+	s.EqualError(err, "webhook not found")
+}
@@ -10,7 +10,6 @@ require (
 	github.com/stretchr/testify v1.10.0
 	go.mongodb.org/mongo-driver v1.17.4
 	golang.org/x/crypto v0.37.0
-	golang.org/x/time v0.12.0
 )
 
 require (
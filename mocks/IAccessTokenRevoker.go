@@ -0,0 +1,72 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	userpkg "github.com/Amaankaa/Blog-Starter-Project/Domain/user"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// IAccessTokenRevoker is an autogenerated mock type for the IAccessTokenRevoker type
+type IAccessTokenRevoker struct {
+	mock.Mock
+}
+
+// IsRevoked provides a mock function with given fields: jti
+func (_m *IAccessTokenRevoker) IsRevoked(jti string) bool {
+	ret := _m.Called(jti)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IsRevoked")
+	}
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(string) bool); ok {
+		r0 = rf(jti)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// Revoke provides a mock function with given fields: ctx, tokens
+func (_m *IAccessTokenRevoker) Revoke(ctx context.Context, tokens ...userpkg.RevokedToken) error {
+	_va := make([]interface{}, len(tokens))
+	for _i := range tokens {
+		_va[_i] = tokens[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Revoke")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, ...userpkg.RevokedToken) error); ok {
+		r0 = rf(ctx, tokens...)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewIAccessTokenRevoker creates a new instance of IAccessTokenRevoker. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewIAccessTokenRevoker(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *IAccessTokenRevoker {
+	mock := &IAccessTokenRevoker{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
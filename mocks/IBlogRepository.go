@@ -111,6 +111,24 @@ func (_m *IBlogRepository) DeleteBlog(id string) error {
 	return r0
 }
 
+// DeleteComment provides a mock function with given fields: ctx, blogID, commentID
+func (_m *IBlogRepository) DeleteComment(ctx context.Context, blogID string, commentID string) error {
+	ret := _m.Called(ctx, blogID, commentID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteComment")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, blogID, commentID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // FilterByTags provides a mock function with given fields: ctx, tags, pagination
 func (_m *IBlogRepository) FilterByTags(ctx context.Context, tags []string, pagination blogpkg.PaginationRequest) (blogpkg.PaginationResponse, error) {
 	ret := _m.Called(ctx, tags, pagination)
@@ -227,6 +245,103 @@ func (_m *IBlogRepository) GetBlogByID(id string) (*blogpkg.Blog, error) {
 	return r0, r1
 }
 
+// GetBlogsByIDs provides a mock function with given fields: ctx, ids
+func (_m *IBlogRepository) GetBlogsByIDs(ctx context.Context, ids []string) ([]blogpkg.Blog, error) {
+	ret := _m.Called(ctx, ids)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBlogsByIDs")
+	}
+
+	var r0 []blogpkg.Blog
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string) ([]blogpkg.Blog, error)); ok {
+		return rf(ctx, ids)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []string) []blogpkg.Blog); ok {
+		r0 = rf(ctx, ids)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]blogpkg.Blog)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []string) error); ok {
+		r1 = rf(ctx, ids)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetComment provides a mock function with given fields: ctx, blogID, commentID
+func (_m *IBlogRepository) GetComment(ctx context.Context, blogID string, commentID string) (*blogpkg.Comment, error) {
+	ret := _m.Called(ctx, blogID, commentID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetComment")
+	}
+
+	var r0 *blogpkg.Comment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*blogpkg.Comment, error)); ok {
+		return rf(ctx, blogID, commentID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *blogpkg.Comment); ok {
+		r0 = rf(ctx, blogID, commentID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*blogpkg.Comment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, blogID, commentID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListBlogsExcluding provides a mock function with given fields: ctx, excludeIDs, skip, limit
+func (_m *IBlogRepository) ListBlogsExcluding(ctx context.Context, excludeIDs []string, skip int64, limit int64) ([]blogpkg.Blog, int64, error) {
+	ret := _m.Called(ctx, excludeIDs, skip, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListBlogsExcluding")
+	}
+
+	var r0 []blogpkg.Blog
+	var r1 int64
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string, int64, int64) ([]blogpkg.Blog, int64, error)); ok {
+		return rf(ctx, excludeIDs, skip, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []string, int64, int64) []blogpkg.Blog); ok {
+		r0 = rf(ctx, excludeIDs, skip, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]blogpkg.Blog)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []string, int64, int64) int64); ok {
+		r1 = rf(ctx, excludeIDs, skip, limit)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, []string, int64, int64) error); ok {
+		r2 = rf(ctx, excludeIDs, skip, limit)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // RemoveLike provides a mock function with given fields: ctx, blogID, userID
 func (_m *IBlogRepository) RemoveLike(ctx context.Context, blogID string, userID string) error {
 	ret := _m.Called(ctx, blogID, userID)
@@ -273,6 +388,36 @@ func (_m *IBlogRepository) SearchBlogs(ctx context.Context, query string, pagina
 	return r0, r1
 }
 
+// SetReview provides a mock function with given fields: ctx, blogID, review
+func (_m *IBlogRepository) SetReview(ctx context.Context, blogID string, review blogpkg.Review) (*blogpkg.Blog, error) {
+	ret := _m.Called(ctx, blogID, review)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetReview")
+	}
+
+	var r0 *blogpkg.Blog
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, blogpkg.Review) (*blogpkg.Blog, error)); ok {
+		return rf(ctx, blogID, review)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, blogpkg.Review) *blogpkg.Blog); ok {
+		r0 = rf(ctx, blogID, review)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*blogpkg.Blog)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, blogpkg.Review) error); ok {
+		r1 = rf(ctx, blogID, review)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // UpdateBlog provides a mock function with given fields: id, blog
 func (_m *IBlogRepository) UpdateBlog(id string, blog *blogpkg.Blog) (*blogpkg.Blog, error) {
 	ret := _m.Called(id, blog)
@@ -179,6 +179,124 @@ func (_m *IBlogUsecase) GetBlogByID(ctx context.Context, id string) (*blogpkg.Bl
 	return r0, r1
 }
 
+// GetFeaturedBlogs provides a mock function with given fields: ctx
+func (_m *IBlogUsecase) GetFeaturedBlogs(ctx context.Context) ([]blogpkg.Blog, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetFeaturedBlogs")
+	}
+
+	var r0 []blogpkg.Blog
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]blogpkg.Blog, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []blogpkg.Blog); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]blogpkg.Blog)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPinnedBlogs provides a mock function with given fields: ctx, authorID
+func (_m *IBlogUsecase) GetPinnedBlogs(ctx context.Context, authorID string) ([]blogpkg.Blog, error) {
+	ret := _m.Called(ctx, authorID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPinnedBlogs")
+	}
+
+	var r0 []blogpkg.Blog
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]blogpkg.Blog, error)); ok {
+		return rf(ctx, authorID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []blogpkg.Blog); ok {
+		r0 = rf(ctx, authorID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]blogpkg.Blog)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, authorID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PatchBlog provides a mock function with given fields: ctx, id, patch
+func (_m *IBlogUsecase) PatchBlog(ctx context.Context, id string, patch blogpkg.BlogPatch) (*blogpkg.Blog, error) {
+	ret := _m.Called(ctx, id, patch)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PatchBlog")
+	}
+
+	var r0 *blogpkg.Blog
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, blogpkg.BlogPatch) (*blogpkg.Blog, error)); ok {
+		return rf(ctx, id, patch)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, blogpkg.BlogPatch) *blogpkg.Blog); ok {
+		r0 = rf(ctx, id, patch)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*blogpkg.Blog)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, blogpkg.BlogPatch) error); ok {
+		r1 = rf(ctx, id, patch)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PinBlog provides a mock function with given fields: ctx, blogID, scope, req
+func (_m *IBlogUsecase) PinBlog(ctx context.Context, blogID string, scope blogpkg.PinScope, req blogpkg.PinRequest) (blogpkg.Pin, error) {
+	ret := _m.Called(ctx, blogID, scope, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PinBlog")
+	}
+
+	var r0 blogpkg.Pin
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, blogpkg.PinScope, blogpkg.PinRequest) (blogpkg.Pin, error)); ok {
+		return rf(ctx, blogID, scope, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, blogpkg.PinScope, blogpkg.PinRequest) blogpkg.Pin); ok {
+		r0 = rf(ctx, blogID, scope, req)
+	} else {
+		r0 = ret.Get(0).(blogpkg.Pin)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, blogpkg.PinScope, blogpkg.PinRequest) error); ok {
+		r1 = rf(ctx, blogID, scope, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // SearchBlogs provides a mock function with given fields: ctx, query, pagination
 func (_m *IBlogUsecase) SearchBlogs(ctx context.Context, query string, pagination blogpkg.PaginationRequest) (blogpkg.PaginationResponse, error) {
 	ret := _m.Called(ctx, query, pagination)
@@ -225,6 +343,24 @@ func (_m *IBlogUsecase) ToggleLike(ctx context.Context, blogID string, userID st
 	return r0
 }
 
+// UnpinBlog provides a mock function with given fields: ctx, blogID, scope
+func (_m *IBlogUsecase) UnpinBlog(ctx context.Context, blogID string, scope blogpkg.PinScope) error {
+	ret := _m.Called(ctx, blogID, scope)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UnpinBlog")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, blogpkg.PinScope) error); ok {
+		r0 = rf(ctx, blogID, scope)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // UpdateBlog provides a mock function with given fields: ctx, id, blog
 func (_m *IBlogUsecase) UpdateBlog(ctx context.Context, id string, blog *blogpkg.Blog) (*blogpkg.Blog, error) {
 	ret := _m.Called(ctx, id, blog)
@@ -0,0 +1,56 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// IBreachCorpus is an autogenerated mock type for the IBreachCorpus type
+type IBreachCorpus struct {
+	mock.Mock
+}
+
+// IsBreached provides a mock function with given fields: ctx, password
+func (_m *IBreachCorpus) IsBreached(ctx context.Context, password string) (bool, error) {
+	ret := _m.Called(ctx, password)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IsBreached")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (bool, error)); ok {
+		return rf(ctx, password)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) bool); ok {
+		r0 = rf(ctx, password)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, password)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewIBreachCorpus creates a new instance of IBreachCorpus. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewIBreachCorpus(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *IBreachCorpus {
+	mock := &IBreachCorpus{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,170 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	time "time"
+
+	mock "github.com/stretchr/testify/mock"
+
+	webhookpkg "github.com/Amaankaa/Blog-Starter-Project/Domain/webhook"
+)
+
+// IDeliveryRepository is an autogenerated mock type for the IDeliveryRepository type
+type IDeliveryRepository struct {
+	mock.Mock
+}
+
+// ClaimDue provides a mock function with given fields: ctx, now, lease
+func (_m *IDeliveryRepository) ClaimDue(ctx context.Context, now time.Time, lease time.Duration) (webhookpkg.Delivery, bool, error) {
+	ret := _m.Called(ctx, now, lease)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ClaimDue")
+	}
+
+	var r0 webhookpkg.Delivery
+	var r1 bool
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, time.Duration) (webhookpkg.Delivery, bool, error)); ok {
+		return rf(ctx, now, lease)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, time.Duration) webhookpkg.Delivery); ok {
+		r0 = rf(ctx, now, lease)
+	} else {
+		r0 = ret.Get(0).(webhookpkg.Delivery)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time, time.Duration) bool); ok {
+		r1 = rf(ctx, now, lease)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, time.Time, time.Duration) error); ok {
+		r2 = rf(ctx, now, lease)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// CreateDelivery provides a mock function with given fields: ctx, delivery
+func (_m *IDeliveryRepository) CreateDelivery(ctx context.Context, delivery webhookpkg.Delivery) (webhookpkg.Delivery, error) {
+	ret := _m.Called(ctx, delivery)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateDelivery")
+	}
+
+	var r0 webhookpkg.Delivery
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, webhookpkg.Delivery) (webhookpkg.Delivery, error)); ok {
+		return rf(ctx, delivery)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, webhookpkg.Delivery) webhookpkg.Delivery); ok {
+		r0 = rf(ctx, delivery)
+	} else {
+		r0 = ret.Get(0).(webhookpkg.Delivery)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, webhookpkg.Delivery) error); ok {
+		r1 = rf(ctx, delivery)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetDelivery provides a mock function with given fields: ctx, id
+func (_m *IDeliveryRepository) GetDelivery(ctx context.Context, id string) (webhookpkg.Delivery, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDelivery")
+	}
+
+	var r0 webhookpkg.Delivery
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (webhookpkg.Delivery, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) webhookpkg.Delivery); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(webhookpkg.Delivery)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListDeliveries provides a mock function with given fields: ctx, webhookID, limit
+func (_m *IDeliveryRepository) ListDeliveries(ctx context.Context, webhookID string, limit int64) ([]webhookpkg.Delivery, error) {
+	ret := _m.Called(ctx, webhookID, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListDeliveries")
+	}
+
+	var r0 []webhookpkg.Delivery
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64) ([]webhookpkg.Delivery, error)); ok {
+		return rf(ctx, webhookID, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64) []webhookpkg.Delivery); ok {
+		r0 = rf(ctx, webhookID, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]webhookpkg.Delivery)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int64) error); ok {
+		r1 = rf(ctx, webhookID, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateDelivery provides a mock function with given fields: ctx, delivery
+func (_m *IDeliveryRepository) UpdateDelivery(ctx context.Context, delivery webhookpkg.Delivery) error {
+	ret := _m.Called(ctx, delivery)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateDelivery")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, webhookpkg.Delivery) error); ok {
+		r0 = rf(ctx, delivery)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewIDeliveryRepository creates a new instance of IDeliveryRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewIDeliveryRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *IDeliveryRepository {
+	mock := &IDeliveryRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,135 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	emaildomainpkg "github.com/Amaankaa/Blog-Starter-Project/Domain/emaildomain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// IDomainRuleRepository is an autogenerated mock type for the IDomainRuleRepository type
+type IDomainRuleRepository struct {
+	mock.Mock
+}
+
+// DeleteRule provides a mock function with given fields: ctx, domain
+func (_m *IDomainRuleRepository) DeleteRule(ctx context.Context, domain string) error {
+	ret := _m.Called(ctx, domain)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteRule")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, domain)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// FindRules provides a mock function with given fields: ctx, domains
+func (_m *IDomainRuleRepository) FindRules(ctx context.Context, domains []string) ([]emaildomainpkg.DomainRule, error) {
+	ret := _m.Called(ctx, domains)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindRules")
+	}
+
+	var r0 []emaildomainpkg.DomainRule
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string) ([]emaildomainpkg.DomainRule, error)); ok {
+		return rf(ctx, domains)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []string) []emaildomainpkg.DomainRule); ok {
+		r0 = rf(ctx, domains)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]emaildomainpkg.DomainRule)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []string) error); ok {
+		r1 = rf(ctx, domains)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListRules provides a mock function with given fields: ctx
+func (_m *IDomainRuleRepository) ListRules(ctx context.Context) ([]emaildomainpkg.DomainRule, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListRules")
+	}
+
+	var r0 []emaildomainpkg.DomainRule
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]emaildomainpkg.DomainRule, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []emaildomainpkg.DomainRule); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]emaildomainpkg.DomainRule)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpsertRule provides a mock function with given fields: ctx, rule
+func (_m *IDomainRuleRepository) UpsertRule(ctx context.Context, rule emaildomainpkg.DomainRule) (emaildomainpkg.DomainRule, error) {
+	ret := _m.Called(ctx, rule)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpsertRule")
+	}
+
+	var r0 emaildomainpkg.DomainRule
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, emaildomainpkg.DomainRule) (emaildomainpkg.DomainRule, error)); ok {
+		return rf(ctx, rule)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, emaildomainpkg.DomainRule) emaildomainpkg.DomainRule); ok {
+		r0 = rf(ctx, rule)
+	} else {
+		r0 = ret.Get(0).(emaildomainpkg.DomainRule)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, emaildomainpkg.DomainRule) error); ok {
+		r1 = rf(ctx, rule)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewIDomainRuleRepository creates a new instance of IDomainRuleRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewIDomainRuleRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *IDomainRuleRepository {
+	mock := &IDomainRuleRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,105 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	emaildomainpkg "github.com/Amaankaa/Blog-Starter-Project/Domain/emaildomain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// IDomainRuleUsecase is an autogenerated mock type for the IDomainRuleUsecase type
+type IDomainRuleUsecase struct {
+	mock.Mock
+}
+
+// DeleteRule provides a mock function with given fields: ctx, domain
+func (_m *IDomainRuleUsecase) DeleteRule(ctx context.Context, domain string) error {
+	ret := _m.Called(ctx, domain)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteRule")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, domain)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ListRules provides a mock function with given fields: ctx
+func (_m *IDomainRuleUsecase) ListRules(ctx context.Context) ([]emaildomainpkg.DomainRule, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListRules")
+	}
+
+	var r0 []emaildomainpkg.DomainRule
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]emaildomainpkg.DomainRule, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []emaildomainpkg.DomainRule); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]emaildomainpkg.DomainRule)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SetRule provides a mock function with given fields: ctx, req, createdBy
+func (_m *IDomainRuleUsecase) SetRule(ctx context.Context, req emaildomainpkg.SetDomainRuleRequest, createdBy string) (emaildomainpkg.DomainRule, error) {
+	ret := _m.Called(ctx, req, createdBy)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetRule")
+	}
+
+	var r0 emaildomainpkg.DomainRule
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, emaildomainpkg.SetDomainRuleRequest, string) (emaildomainpkg.DomainRule, error)); ok {
+		return rf(ctx, req, createdBy)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, emaildomainpkg.SetDomainRuleRequest, string) emaildomainpkg.DomainRule); ok {
+		r0 = rf(ctx, req, createdBy)
+	} else {
+		r0 = ret.Get(0).(emaildomainpkg.DomainRule)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, emaildomainpkg.SetDomainRuleRequest, string) error); ok {
+		r1 = rf(ctx, req, createdBy)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewIDomainRuleUsecase creates a new instance of IDomainRuleUsecase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewIDomainRuleUsecase(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *IDomainRuleUsecase {
+	mock := &IDomainRuleUsecase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
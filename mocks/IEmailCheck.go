@@ -0,0 +1,57 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	services "github.com/Amaankaa/Blog-Starter-Project/Domain/services"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// IEmailCheck is an autogenerated mock type for the IEmailCheck type
+type IEmailCheck struct {
+	mock.Mock
+}
+
+// Check provides a mock function with given fields: ctx, email
+func (_m *IEmailCheck) Check(ctx context.Context, email string) (services.EmailVerdict, error) {
+	ret := _m.Called(ctx, email)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Check")
+	}
+
+	var r0 services.EmailVerdict
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (services.EmailVerdict, error)); ok {
+		return rf(ctx, email)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) services.EmailVerdict); ok {
+		r0 = rf(ctx, email)
+	} else {
+		r0 = ret.Get(0).(services.EmailVerdict)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, email)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewIEmailCheck creates a new instance of IEmailCheck. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewIEmailCheck(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *IEmailCheck {
+	mock := &IEmailCheck{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,236 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	emailjobpkg "github.com/Amaankaa/Blog-Starter-Project/Domain/emailjob"
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// IEmailJobRepository is an autogenerated mock type for the IEmailJobRepository type
+type IEmailJobRepository struct {
+	mock.Mock
+}
+
+// Bury provides a mock function with given fields: ctx, job
+func (_m *IEmailJobRepository) Bury(ctx context.Context, job emailjobpkg.EmailJob) error {
+	ret := _m.Called(ctx, job)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Bury")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, emailjobpkg.EmailJob) error); ok {
+		r0 = rf(ctx, job)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ClaimNext provides a mock function with given fields: ctx, now, lease
+func (_m *IEmailJobRepository) ClaimNext(ctx context.Context, now time.Time, lease time.Duration) (emailjobpkg.EmailJob, bool, error) {
+	ret := _m.Called(ctx, now, lease)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ClaimNext")
+	}
+
+	var r0 emailjobpkg.EmailJob
+	var r1 bool
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, time.Duration) (emailjobpkg.EmailJob, bool, error)); ok {
+		return rf(ctx, now, lease)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, time.Duration) emailjobpkg.EmailJob); ok {
+		r0 = rf(ctx, now, lease)
+	} else {
+		r0 = ret.Get(0).(emailjobpkg.EmailJob)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time, time.Duration) bool); ok {
+		r1 = rf(ctx, now, lease)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, time.Time, time.Duration) error); ok {
+		r2 = rf(ctx, now, lease)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// Complete provides a mock function with given fields: ctx, id
+func (_m *IEmailJobRepository) Complete(ctx context.Context, id string) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Complete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Enqueue provides a mock function with given fields: ctx, job
+func (_m *IEmailJobRepository) Enqueue(ctx context.Context, job emailjobpkg.EmailJob) (emailjobpkg.EmailJob, error) {
+	ret := _m.Called(ctx, job)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Enqueue")
+	}
+
+	var r0 emailjobpkg.EmailJob
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, emailjobpkg.EmailJob) (emailjobpkg.EmailJob, error)); ok {
+		return rf(ctx, job)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, emailjobpkg.EmailJob) emailjobpkg.EmailJob); ok {
+		r0 = rf(ctx, job)
+	} else {
+		r0 = ret.Get(0).(emailjobpkg.EmailJob)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, emailjobpkg.EmailJob) error); ok {
+		r1 = rf(ctx, job)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListDead provides a mock function with given fields: ctx, limit
+func (_m *IEmailJobRepository) ListDead(ctx context.Context, limit int64) ([]emailjobpkg.EmailJob, error) {
+	ret := _m.Called(ctx, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListDead")
+	}
+
+	var r0 []emailjobpkg.EmailJob
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) ([]emailjobpkg.EmailJob, error)); ok {
+		return rf(ctx, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) []emailjobpkg.EmailJob); ok {
+		r0 = rf(ctx, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]emailjobpkg.EmailJob)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListQueued provides a mock function with given fields: ctx, limit
+func (_m *IEmailJobRepository) ListQueued(ctx context.Context, limit int64) ([]emailjobpkg.EmailJob, error) {
+	ret := _m.Called(ctx, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListQueued")
+	}
+
+	var r0 []emailjobpkg.EmailJob
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) ([]emailjobpkg.EmailJob, error)); ok {
+		return rf(ctx, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) []emailjobpkg.EmailJob); ok {
+		r0 = rf(ctx, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]emailjobpkg.EmailJob)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Requeue provides a mock function with given fields: ctx, id, now
+func (_m *IEmailJobRepository) Requeue(ctx context.Context, id string, now time.Time) (emailjobpkg.EmailJob, error) {
+	ret := _m.Called(ctx, id, now)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Requeue")
+	}
+
+	var r0 emailjobpkg.EmailJob
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) (emailjobpkg.EmailJob, error)); ok {
+		return rf(ctx, id, now)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) emailjobpkg.EmailJob); ok {
+		r0 = rf(ctx, id, now)
+	} else {
+		r0 = ret.Get(0).(emailjobpkg.EmailJob)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, time.Time) error); ok {
+		r1 = rf(ctx, id, now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Reschedule provides a mock function with given fields: ctx, job
+func (_m *IEmailJobRepository) Reschedule(ctx context.Context, job emailjobpkg.EmailJob) error {
+	ret := _m.Called(ctx, job)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Reschedule")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, emailjobpkg.EmailJob) error); ok {
+		r0 = rf(ctx, job)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewIEmailJobRepository creates a new instance of IEmailJobRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewIEmailJobRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *IEmailJobRepository {
+	mock := &IEmailJobRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
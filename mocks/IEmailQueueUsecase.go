@@ -0,0 +1,117 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	emailjobpkg "github.com/Amaankaa/Blog-Starter-Project/Domain/emailjob"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// IEmailQueueUsecase is an autogenerated mock type for the IEmailQueueUsecase type
+type IEmailQueueUsecase struct {
+	mock.Mock
+}
+
+// ListDeadLetters provides a mock function with given fields: ctx
+func (_m *IEmailQueueUsecase) ListDeadLetters(ctx context.Context) ([]emailjobpkg.EmailJob, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListDeadLetters")
+	}
+
+	var r0 []emailjobpkg.EmailJob
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]emailjobpkg.EmailJob, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []emailjobpkg.EmailJob); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]emailjobpkg.EmailJob)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListQueued provides a mock function with given fields: ctx
+func (_m *IEmailQueueUsecase) ListQueued(ctx context.Context) ([]emailjobpkg.EmailJob, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListQueued")
+	}
+
+	var r0 []emailjobpkg.EmailJob
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]emailjobpkg.EmailJob, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []emailjobpkg.EmailJob); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]emailjobpkg.EmailJob)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RequeueDeadLetter provides a mock function with given fields: ctx, id
+func (_m *IEmailQueueUsecase) RequeueDeadLetter(ctx context.Context, id string) (emailjobpkg.EmailJob, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RequeueDeadLetter")
+	}
+
+	var r0 emailjobpkg.EmailJob
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (emailjobpkg.EmailJob, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) emailjobpkg.EmailJob); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(emailjobpkg.EmailJob)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewIEmailQueueUsecase creates a new instance of IEmailQueueUsecase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewIEmailQueueUsecase(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *IEmailQueueUsecase {
+	mock := &IEmailQueueUsecase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,103 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	services "github.com/Amaankaa/Blog-Starter-Project/Domain/services"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// IEmailRenderer is an autogenerated mock type for the IEmailRenderer type
+type IEmailRenderer struct {
+	mock.Mock
+}
+
+// Locales provides a mock function with no fields
+func (_m *IEmailRenderer) Locales() []string {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Locales")
+	}
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func() []string); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	return r0
+}
+
+// Preview provides a mock function with given fields: name, locale
+func (_m *IEmailRenderer) Preview(name string, locale string) (services.EmailMessage, error) {
+	ret := _m.Called(name, locale)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Preview")
+	}
+
+	var r0 services.EmailMessage
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string) (services.EmailMessage, error)); ok {
+		return rf(name, locale)
+	}
+	if rf, ok := ret.Get(0).(func(string, string) services.EmailMessage); ok {
+		r0 = rf(name, locale)
+	} else {
+		r0 = ret.Get(0).(services.EmailMessage)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(name, locale)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Render provides a mock function with given fields: name, locale, data
+func (_m *IEmailRenderer) Render(name string, locale string, data map[string]interface{}) (services.EmailMessage, error) {
+	ret := _m.Called(name, locale, data)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Render")
+	}
+
+	var r0 services.EmailMessage
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string, map[string]interface{}) (services.EmailMessage, error)); ok {
+		return rf(name, locale, data)
+	}
+	if rf, ok := ret.Get(0).(func(string, string, map[string]interface{}) services.EmailMessage); ok {
+		r0 = rf(name, locale, data)
+	} else {
+		r0 = ret.Get(0).(services.EmailMessage)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string, map[string]interface{}) error); ok {
+		r1 = rf(name, locale, data)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewIEmailRenderer creates a new instance of IEmailRenderer. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewIEmailRenderer(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *IEmailRenderer {
+	mock := &IEmailRenderer{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
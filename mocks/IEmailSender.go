@@ -2,24 +2,27 @@
 
 package mocks
 
-import mock "github.com/stretchr/testify/mock"
+import (
+	services "github.com/Amaankaa/Blog-Starter-Project/Domain/services"
+	mock "github.com/stretchr/testify/mock"
+)
 
 // IEmailSender is an autogenerated mock type for the IEmailSender type
 type IEmailSender struct {
 	mock.Mock
 }
 
-// SendEmail provides a mock function with given fields: to, subject, content
-func (_m *IEmailSender) SendEmail(to string, subject string, content string) error {
-	ret := _m.Called(to, subject, content)
+// SendEmail provides a mock function with given fields: msg
+func (_m *IEmailSender) SendEmail(msg services.EmailMessage) error {
+	ret := _m.Called(msg)
 
 	if len(ret) == 0 {
 		panic("no return value specified for SendEmail")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(string, string, string) error); ok {
-		r0 = rf(to, subject, content)
+	if rf, ok := ret.Get(0).(func(services.EmailMessage) error); ok {
+		r0 = rf(msg)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -0,0 +1,103 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	identitypkg "github.com/Amaankaa/Blog-Starter-Project/Domain/identity"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// IIdentityProvider is an autogenerated mock type for the IIdentityProvider type
+type IIdentityProvider struct {
+	mock.Mock
+}
+
+// AuthCodeURL provides a mock function with given fields: ctx, state, nonce, codeChallenge
+func (_m *IIdentityProvider) AuthCodeURL(ctx context.Context, state string, nonce string, codeChallenge string) (string, error) {
+	ret := _m.Called(ctx, state, nonce, codeChallenge)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AuthCodeURL")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) (string, error)); ok {
+		return rf(ctx, state, nonce, codeChallenge)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) string); ok {
+		r0 = rf(ctx, state, nonce, codeChallenge)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
+		r1 = rf(ctx, state, nonce, codeChallenge)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Exchange provides a mock function with given fields: ctx, code, codeVerifier, nonce
+func (_m *IIdentityProvider) Exchange(ctx context.Context, code string, codeVerifier string, nonce string) (identitypkg.ExternalIdentity, error) {
+	ret := _m.Called(ctx, code, codeVerifier, nonce)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Exchange")
+	}
+
+	var r0 identitypkg.ExternalIdentity
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) (identitypkg.ExternalIdentity, error)); ok {
+		return rf(ctx, code, codeVerifier, nonce)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) identitypkg.ExternalIdentity); ok {
+		r0 = rf(ctx, code, codeVerifier, nonce)
+	} else {
+		r0 = ret.Get(0).(identitypkg.ExternalIdentity)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
+		r1 = rf(ctx, code, codeVerifier, nonce)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Name provides a mock function with no fields
+func (_m *IIdentityProvider) Name() string {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Name")
+	}
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func() string); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// NewIIdentityProvider creates a new instance of IIdentityProvider. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewIIdentityProvider(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *IIdentityProvider {
+	mock := &IIdentityProvider{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,197 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	identitypkg "github.com/Amaankaa/Blog-Starter-Project/Domain/identity"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// IIdentityRepository is an autogenerated mock type for the IIdentityRepository type
+type IIdentityRepository struct {
+	mock.Mock
+}
+
+// ConsumeState provides a mock function with given fields: ctx, id
+func (_m *IIdentityRepository) ConsumeState(ctx context.Context, id string) (identitypkg.AuthState, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ConsumeState")
+	}
+
+	var r0 identitypkg.AuthState
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (identitypkg.AuthState, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) identitypkg.AuthState); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(identitypkg.AuthState)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateIdentity provides a mock function with given fields: ctx, identity
+func (_m *IIdentityRepository) CreateIdentity(ctx context.Context, identity identitypkg.Identity) (identitypkg.Identity, error) {
+	ret := _m.Called(ctx, identity)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateIdentity")
+	}
+
+	var r0 identitypkg.Identity
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, identitypkg.Identity) (identitypkg.Identity, error)); ok {
+		return rf(ctx, identity)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, identitypkg.Identity) identitypkg.Identity); ok {
+		r0 = rf(ctx, identity)
+	} else {
+		r0 = ret.Get(0).(identitypkg.Identity)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, identitypkg.Identity) error); ok {
+		r1 = rf(ctx, identity)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteIdentity provides a mock function with given fields: ctx, userID, identityID
+func (_m *IIdentityRepository) DeleteIdentity(ctx context.Context, userID string, identityID string) error {
+	ret := _m.Called(ctx, userID, identityID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteIdentity")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, userID, identityID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// FindIdentity provides a mock function with given fields: ctx, provider, subject
+func (_m *IIdentityRepository) FindIdentity(ctx context.Context, provider string, subject string) (identitypkg.Identity, error) {
+	ret := _m.Called(ctx, provider, subject)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindIdentity")
+	}
+
+	var r0 identitypkg.Identity
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (identitypkg.Identity, error)); ok {
+		return rf(ctx, provider, subject)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) identitypkg.Identity); ok {
+		r0 = rf(ctx, provider, subject)
+	} else {
+		r0 = ret.Get(0).(identitypkg.Identity)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, provider, subject)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListIdentities provides a mock function with given fields: ctx, userID
+func (_m *IIdentityRepository) ListIdentities(ctx context.Context, userID string) ([]identitypkg.Identity, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListIdentities")
+	}
+
+	var r0 []identitypkg.Identity
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]identitypkg.Identity, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []identitypkg.Identity); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]identitypkg.Identity)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// StoreState provides a mock function with given fields: ctx, state
+func (_m *IIdentityRepository) StoreState(ctx context.Context, state identitypkg.AuthState) error {
+	ret := _m.Called(ctx, state)
+
+	if len(ret) == 0 {
+		panic("no return value specified for StoreState")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, identitypkg.AuthState) error); ok {
+		r0 = rf(ctx, state)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// TouchIdentity provides a mock function with given fields: ctx, identityID
+func (_m *IIdentityRepository) TouchIdentity(ctx context.Context, identityID string) error {
+	ret := _m.Called(ctx, identityID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TouchIdentity")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, identityID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewIIdentityRepository creates a new instance of IIdentityRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewIIdentityRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *IIdentityRepository {
+	mock := &IIdentityRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,183 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	identitypkg "github.com/Amaankaa/Blog-Starter-Project/Domain/identity"
+	mock "github.com/stretchr/testify/mock"
+
+	userpkg "github.com/Amaankaa/Blog-Starter-Project/Domain/user"
+)
+
+// IIdentityUsecase is an autogenerated mock type for the IIdentityUsecase type
+type IIdentityUsecase struct {
+	mock.Mock
+}
+
+// Callback provides a mock function with given fields: ctx, provider, code, state, device
+func (_m *IIdentityUsecase) Callback(ctx context.Context, provider string, code string, state string, device userpkg.DeviceInfo) (identitypkg.CallbackResult, error) {
+	ret := _m.Called(ctx, provider, code, state, device)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Callback")
+	}
+
+	var r0 identitypkg.CallbackResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, userpkg.DeviceInfo) (identitypkg.CallbackResult, error)); ok {
+		return rf(ctx, provider, code, state, device)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, userpkg.DeviceInfo) identitypkg.CallbackResult); ok {
+		r0 = rf(ctx, provider, code, state, device)
+	} else {
+		r0 = ret.Get(0).(identitypkg.CallbackResult)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string, userpkg.DeviceInfo) error); ok {
+		r1 = rf(ctx, provider, code, state, device)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListIdentities provides a mock function with given fields: ctx, userID
+func (_m *IIdentityUsecase) ListIdentities(ctx context.Context, userID string) ([]identitypkg.Identity, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListIdentities")
+	}
+
+	var r0 []identitypkg.Identity
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]identitypkg.Identity, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []identitypkg.Identity); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]identitypkg.Identity)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Providers provides a mock function with no fields
+func (_m *IIdentityUsecase) Providers() []string {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Providers")
+	}
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func() []string); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	return r0
+}
+
+// StartLink provides a mock function with given fields: ctx, provider, userID
+func (_m *IIdentityUsecase) StartLink(ctx context.Context, provider string, userID string) (string, error) {
+	ret := _m.Called(ctx, provider, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for StartLink")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (string, error)); ok {
+		return rf(ctx, provider, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) string); ok {
+		r0 = rf(ctx, provider, userID)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, provider, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// StartLogin provides a mock function with given fields: ctx, provider
+func (_m *IIdentityUsecase) StartLogin(ctx context.Context, provider string) (string, error) {
+	ret := _m.Called(ctx, provider)
+
+	if len(ret) == 0 {
+		panic("no return value specified for StartLogin")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (string, error)); ok {
+		return rf(ctx, provider)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) string); ok {
+		r0 = rf(ctx, provider)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, provider)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Unlink provides a mock function with given fields: ctx, userID, identityID
+func (_m *IIdentityUsecase) Unlink(ctx context.Context, userID string, identityID string) error {
+	ret := _m.Called(ctx, userID, identityID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Unlink")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, userID, identityID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewIIdentityUsecase creates a new instance of IIdentityUsecase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewIIdentityUsecase(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *IIdentityUsecase {
+	mock := &IIdentityUsecase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
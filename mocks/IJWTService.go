@@ -12,9 +12,9 @@ type IJWTService struct {
 	mock.Mock
 }
 
-// GenerateToken provides a mock function with given fields: userID, username, role
-func (_m *IJWTService) GenerateToken(userID string, username string, role string) (userpkg.TokenResult, error) {
-	ret := _m.Called(userID, username, role)
+// GenerateToken provides a mock function with given fields: subject
+func (_m *IJWTService) GenerateToken(subject userpkg.TokenSubject) (userpkg.TokenResult, error) {
+	ret := _m.Called(subject)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GenerateToken")
@@ -22,17 +22,17 @@ func (_m *IJWTService) GenerateToken(userID string, username string, role string
 
 	var r0 userpkg.TokenResult
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string, string, string) (userpkg.TokenResult, error)); ok {
-		return rf(userID, username, role)
+	if rf, ok := ret.Get(0).(func(userpkg.TokenSubject) (userpkg.TokenResult, error)); ok {
+		return rf(subject)
 	}
-	if rf, ok := ret.Get(0).(func(string, string, string) userpkg.TokenResult); ok {
-		r0 = rf(userID, username, role)
+	if rf, ok := ret.Get(0).(func(userpkg.TokenSubject) userpkg.TokenResult); ok {
+		r0 = rf(subject)
 	} else {
 		r0 = ret.Get(0).(userpkg.TokenResult)
 	}
 
-	if rf, ok := ret.Get(1).(func(string, string, string) error); ok {
-		r1 = rf(userID, username, role)
+	if rf, ok := ret.Get(1).(func(userpkg.TokenSubject) error); ok {
+		r1 = rf(subject)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -40,9 +40,9 @@ func (_m *IJWTService) GenerateToken(userID string, username string, role string
 	return r0, r1
 }
 
-// ValidateToken provides a mock function with given fields: tokenString
-func (_m *IJWTService) ValidateToken(tokenString string) (map[string]interface{}, error) {
-	ret := _m.Called(tokenString)
+// ValidateToken provides a mock function with given fields: tokenString, tokenType
+func (_m *IJWTService) ValidateToken(tokenString string, tokenType string) (map[string]interface{}, error) {
+	ret := _m.Called(tokenString, tokenType)
 
 	if len(ret) == 0 {
 		panic("no return value specified for ValidateToken")
@@ -50,19 +50,19 @@ func (_m *IJWTService) ValidateToken(tokenString string) (map[string]interface{}
 
 	var r0 map[string]interface{}
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string) (map[string]interface{}, error)); ok {
-		return rf(tokenString)
+	if rf, ok := ret.Get(0).(func(string, string) (map[string]interface{}, error)); ok {
+		return rf(tokenString, tokenType)
 	}
-	if rf, ok := ret.Get(0).(func(string) map[string]interface{}); ok {
-		r0 = rf(tokenString)
+	if rf, ok := ret.Get(0).(func(string, string) map[string]interface{}); ok {
+		r0 = rf(tokenString, tokenType)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(map[string]interface{})
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(tokenString)
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(tokenString, tokenType)
 	} else {
 		r1 = ret.Error(1)
 	}
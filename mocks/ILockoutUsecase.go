@@ -0,0 +1,46 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// ILockoutUsecase is an autogenerated mock type for the ILockoutUsecase type
+type ILockoutUsecase struct {
+	mock.Mock
+}
+
+// Unlock provides a mock function with given fields: ctx, token
+func (_m *ILockoutUsecase) Unlock(ctx context.Context, token string) error {
+	ret := _m.Called(ctx, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Unlock")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, token)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewILockoutUsecase creates a new instance of ILockoutUsecase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewILockoutUsecase(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ILockoutUsecase {
+	mock := &ILockoutUsecase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
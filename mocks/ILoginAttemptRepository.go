@@ -0,0 +1,179 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	lockoutpkg "github.com/Amaankaa/Blog-Starter-Project/Domain/lockout"
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// ILoginAttemptRepository is an autogenerated mock type for the ILoginAttemptRepository type
+type ILoginAttemptRepository struct {
+	mock.Mock
+}
+
+// ConsumeUnlockToken provides a mock function with given fields: ctx, id
+func (_m *ILoginAttemptRepository) ConsumeUnlockToken(ctx context.Context, id string) (lockoutpkg.UnlockToken, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ConsumeUnlockToken")
+	}
+
+	var r0 lockoutpkg.UnlockToken
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (lockoutpkg.UnlockToken, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) lockoutpkg.UnlockToken); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(lockoutpkg.UnlockToken)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetCounter provides a mock function with given fields: ctx, key
+func (_m *ILoginAttemptRepository) GetCounter(ctx context.Context, key string) (lockoutpkg.Counter, error) {
+	ret := _m.Called(ctx, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCounter")
+	}
+
+	var r0 lockoutpkg.Counter
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (lockoutpkg.Counter, error)); ok {
+		return rf(ctx, key)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) lockoutpkg.Counter); ok {
+		r0 = rf(ctx, key)
+	} else {
+		r0 = ret.Get(0).(lockoutpkg.Counter)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, key)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Lock provides a mock function with given fields: ctx, key, until
+func (_m *ILoginAttemptRepository) Lock(ctx context.Context, key string, until time.Time) (bool, error) {
+	ret := _m.Called(ctx, key, until)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Lock")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) (bool, error)); ok {
+		return rf(ctx, key, until)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) bool); ok {
+		r0 = rf(ctx, key, until)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, time.Time) error); ok {
+		r1 = rf(ctx, key, until)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RecordFailure provides a mock function with given fields: ctx, key, at, window
+func (_m *ILoginAttemptRepository) RecordFailure(ctx context.Context, key string, at time.Time, window time.Duration) (lockoutpkg.Counter, error) {
+	ret := _m.Called(ctx, key, at, window)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RecordFailure")
+	}
+
+	var r0 lockoutpkg.Counter
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time, time.Duration) (lockoutpkg.Counter, error)); ok {
+		return rf(ctx, key, at, window)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time, time.Duration) lockoutpkg.Counter); ok {
+		r0 = rf(ctx, key, at, window)
+	} else {
+		r0 = ret.Get(0).(lockoutpkg.Counter)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, time.Time, time.Duration) error); ok {
+		r1 = rf(ctx, key, at, window)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ResetCounter provides a mock function with given fields: ctx, key
+func (_m *ILoginAttemptRepository) ResetCounter(ctx context.Context, key string) error {
+	ret := _m.Called(ctx, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ResetCounter")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, key)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// StoreUnlockToken provides a mock function with given fields: ctx, token
+func (_m *ILoginAttemptRepository) StoreUnlockToken(ctx context.Context, token lockoutpkg.UnlockToken) error {
+	ret := _m.Called(ctx, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for StoreUnlockToken")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, lockoutpkg.UnlockToken) error); ok {
+		r0 = rf(ctx, token)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewILoginAttemptRepository creates a new instance of ILoginAttemptRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewILoginAttemptRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ILoginAttemptRepository {
+	mock := &ILoginAttemptRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,84 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	userpkg "github.com/Amaankaa/Blog-Starter-Project/Domain/user"
+)
+
+// ILoginGuard is an autogenerated mock type for the ILoginGuard type
+type ILoginGuard struct {
+	mock.Mock
+}
+
+// Check provides a mock function with given fields: ctx, userID, ip
+func (_m *ILoginGuard) Check(ctx context.Context, userID string, ip string) error {
+	ret := _m.Called(ctx, userID, ip)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Check")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, userID, ip)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RecordFailure provides a mock function with given fields: ctx, user, device
+func (_m *ILoginGuard) RecordFailure(ctx context.Context, user userpkg.User, device userpkg.DeviceInfo) error {
+	ret := _m.Called(ctx, user, device)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RecordFailure")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, userpkg.User, userpkg.DeviceInfo) error); ok {
+		r0 = rf(ctx, user, device)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RecordSuccess provides a mock function with given fields: ctx, userID
+func (_m *ILoginGuard) RecordSuccess(ctx context.Context, userID string) error {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RecordSuccess")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewILoginGuard creates a new instance of ILoginGuard. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewILoginGuard(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ILoginGuard {
+	mock := &ILoginGuard{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
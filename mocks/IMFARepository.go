@@ -0,0 +1,213 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mfapkg "github.com/Amaankaa/Blog-Starter-Project/Domain/mfa"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// IMFARepository is an autogenerated mock type for the IMFARepository type
+type IMFARepository struct {
+	mock.Mock
+}
+
+// ConsumeRecoveryCode provides a mock function with given fields: ctx, userID, codeHash
+func (_m *IMFARepository) ConsumeRecoveryCode(ctx context.Context, userID string, codeHash string) (bool, error) {
+	ret := _m.Called(ctx, userID, codeHash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ConsumeRecoveryCode")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (bool, error)); ok {
+		return rf(ctx, userID, codeHash)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) bool); ok {
+		r0 = rf(ctx, userID, codeHash)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, userID, codeHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteEnrollment provides a mock function with given fields: ctx, userID
+func (_m *IMFARepository) DeleteEnrollment(ctx context.Context, userID string) error {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteEnrollment")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// FindEnrollment provides a mock function with given fields: ctx, userID
+func (_m *IMFARepository) FindEnrollment(ctx context.Context, userID string) (mfapkg.Enrollment, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindEnrollment")
+	}
+
+	var r0 mfapkg.Enrollment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (mfapkg.Enrollment, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) mfapkg.Enrollment); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Get(0).(mfapkg.Enrollment)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPolicy provides a mock function with given fields: ctx
+func (_m *IMFARepository) GetPolicy(ctx context.Context) (mfapkg.Policy, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPolicy")
+	}
+
+	var r0 mfapkg.Policy
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (mfapkg.Policy, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) mfapkg.Policy); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(mfapkg.Policy)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RecordUsedStep provides a mock function with given fields: ctx, userID, step
+func (_m *IMFARepository) RecordUsedStep(ctx context.Context, userID string, step int64) (bool, error) {
+	ret := _m.Called(ctx, userID, step)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RecordUsedStep")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64) (bool, error)); ok {
+		return rf(ctx, userID, step)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64) bool); ok {
+		r0 = rf(ctx, userID, step)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int64) error); ok {
+		r1 = rf(ctx, userID, step)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ReplaceRecoveryCodes provides a mock function with given fields: ctx, userID, codeHashes
+func (_m *IMFARepository) ReplaceRecoveryCodes(ctx context.Context, userID string, codeHashes []string) error {
+	ret := _m.Called(ctx, userID, codeHashes)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReplaceRecoveryCodes")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, []string) error); ok {
+		r0 = rf(ctx, userID, codeHashes)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SaveEnrollment provides a mock function with given fields: ctx, enrollment
+func (_m *IMFARepository) SaveEnrollment(ctx context.Context, enrollment mfapkg.Enrollment) error {
+	ret := _m.Called(ctx, enrollment)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveEnrollment")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, mfapkg.Enrollment) error); ok {
+		r0 = rf(ctx, enrollment)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SavePolicy provides a mock function with given fields: ctx, policy
+func (_m *IMFARepository) SavePolicy(ctx context.Context, policy mfapkg.Policy) error {
+	ret := _m.Called(ctx, policy)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SavePolicy")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, mfapkg.Policy) error); ok {
+		r0 = rf(ctx, policy)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewIMFARepository creates a new instance of IMFARepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewIMFARepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *IMFARepository {
+	mock := &IMFARepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,221 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mfapkg "github.com/Amaankaa/Blog-Starter-Project/Domain/mfa"
+	mock "github.com/stretchr/testify/mock"
+
+	userpkg "github.com/Amaankaa/Blog-Starter-Project/Domain/user"
+)
+
+// IMFAUsecase is an autogenerated mock type for the IMFAUsecase type
+type IMFAUsecase struct {
+	mock.Mock
+}
+
+// BeginSetup provides a mock function with given fields: ctx, userID, accountName
+func (_m *IMFAUsecase) BeginSetup(ctx context.Context, userID string, accountName string) (mfapkg.Setup, error) {
+	ret := _m.Called(ctx, userID, accountName)
+
+	if len(ret) == 0 {
+		panic("no return value specified for BeginSetup")
+	}
+
+	var r0 mfapkg.Setup
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (mfapkg.Setup, error)); ok {
+		return rf(ctx, userID, accountName)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) mfapkg.Setup); ok {
+		r0 = rf(ctx, userID, accountName)
+	} else {
+		r0 = ret.Get(0).(mfapkg.Setup)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, userID, accountName)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Disable provides a mock function with given fields: ctx, userID, code, device
+func (_m *IMFAUsecase) Disable(ctx context.Context, userID string, code string, device userpkg.DeviceInfo) error {
+	ret := _m.Called(ctx, userID, code, device)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Disable")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, userpkg.DeviceInfo) error); ok {
+		r0 = rf(ctx, userID, code, device)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Enable provides a mock function with given fields: ctx, userID, code
+func (_m *IMFAUsecase) Enable(ctx context.Context, userID string, code string) ([]string, error) {
+	ret := _m.Called(ctx, userID, code)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Enable")
+	}
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) ([]string, error)); ok {
+		return rf(ctx, userID, code)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) []string); ok {
+		r0 = rf(ctx, userID, code)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, userID, code)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPolicy provides a mock function with given fields: ctx
+func (_m *IMFAUsecase) GetPolicy(ctx context.Context) (mfapkg.Policy, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPolicy")
+	}
+
+	var r0 mfapkg.Policy
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (mfapkg.Policy, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) mfapkg.Policy); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(mfapkg.Policy)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RegenerateRecoveryCodes provides a mock function with given fields: ctx, userID, code, device
+func (_m *IMFAUsecase) RegenerateRecoveryCodes(ctx context.Context, userID string, code string, device userpkg.DeviceInfo) ([]string, error) {
+	ret := _m.Called(ctx, userID, code, device)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RegenerateRecoveryCodes")
+	}
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, userpkg.DeviceInfo) ([]string, error)); ok {
+		return rf(ctx, userID, code, device)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, userpkg.DeviceInfo) []string); ok {
+		r0 = rf(ctx, userID, code, device)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, userpkg.DeviceInfo) error); ok {
+		r1 = rf(ctx, userID, code, device)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SetPolicy provides a mock function with given fields: ctx, policy, actorID
+func (_m *IMFAUsecase) SetPolicy(ctx context.Context, policy mfapkg.Policy, actorID string) (mfapkg.Policy, error) {
+	ret := _m.Called(ctx, policy, actorID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetPolicy")
+	}
+
+	var r0 mfapkg.Policy
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, mfapkg.Policy, string) (mfapkg.Policy, error)); ok {
+		return rf(ctx, policy, actorID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, mfapkg.Policy, string) mfapkg.Policy); ok {
+		r0 = rf(ctx, policy, actorID)
+	} else {
+		r0 = ret.Get(0).(mfapkg.Policy)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, mfapkg.Policy, string) error); ok {
+		r1 = rf(ctx, policy, actorID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Status provides a mock function with given fields: ctx, userID
+func (_m *IMFAUsecase) Status(ctx context.Context, userID string) (mfapkg.Status, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Status")
+	}
+
+	var r0 mfapkg.Status
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (mfapkg.Status, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) mfapkg.Status); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Get(0).(mfapkg.Status)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewIMFAUsecase creates a new instance of IMFAUsecase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewIMFAUsecase(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *IMFAUsecase {
+	mock := &IMFAUsecase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
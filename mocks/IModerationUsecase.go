@@ -0,0 +1,164 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	blogpkg "github.com/Amaankaa/Blog-Starter-Project/Domain/blog"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// IModerationUsecase is an autogenerated mock type for the IModerationUsecase type
+type IModerationUsecase struct {
+	mock.Mock
+}
+
+// DeleteComment provides a mock function with given fields: ctx, blogID, commentID
+func (_m *IModerationUsecase) DeleteComment(ctx context.Context, blogID string, commentID string) error {
+	ret := _m.Called(ctx, blogID, commentID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteComment")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, blogID, commentID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// HandleReport provides a mock function with given fields: ctx, reportID, req
+func (_m *IModerationUsecase) HandleReport(ctx context.Context, reportID string, req blogpkg.HandleReportRequest) (blogpkg.Report, error) {
+	ret := _m.Called(ctx, reportID, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for HandleReport")
+	}
+
+	var r0 blogpkg.Report
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, blogpkg.HandleReportRequest) (blogpkg.Report, error)); ok {
+		return rf(ctx, reportID, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, blogpkg.HandleReportRequest) blogpkg.Report); ok {
+		r0 = rf(ctx, reportID, req)
+	} else {
+		r0 = ret.Get(0).(blogpkg.Report)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, blogpkg.HandleReportRequest) error); ok {
+		r1 = rf(ctx, reportID, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListReports provides a mock function with given fields: ctx, status
+func (_m *IModerationUsecase) ListReports(ctx context.Context, status blogpkg.ReportStatus) ([]blogpkg.Report, error) {
+	ret := _m.Called(ctx, status)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListReports")
+	}
+
+	var r0 []blogpkg.Report
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, blogpkg.ReportStatus) ([]blogpkg.Report, error)); ok {
+		return rf(ctx, status)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, blogpkg.ReportStatus) []blogpkg.Report); ok {
+		r0 = rf(ctx, status)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]blogpkg.Report)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, blogpkg.ReportStatus) error); ok {
+		r1 = rf(ctx, status)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ReportContent provides a mock function with given fields: ctx, blogID, req
+func (_m *IModerationUsecase) ReportContent(ctx context.Context, blogID string, req blogpkg.ReportRequest) (blogpkg.Report, error) {
+	ret := _m.Called(ctx, blogID, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReportContent")
+	}
+
+	var r0 blogpkg.Report
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, blogpkg.ReportRequest) (blogpkg.Report, error)); ok {
+		return rf(ctx, blogID, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, blogpkg.ReportRequest) blogpkg.Report); ok {
+		r0 = rf(ctx, blogID, req)
+	} else {
+		r0 = ret.Get(0).(blogpkg.Report)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, blogpkg.ReportRequest) error); ok {
+		r1 = rf(ctx, blogID, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ReviewBlog provides a mock function with given fields: ctx, blogID, req
+func (_m *IModerationUsecase) ReviewBlog(ctx context.Context, blogID string, req blogpkg.ReviewRequest) (*blogpkg.Blog, error) {
+	ret := _m.Called(ctx, blogID, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReviewBlog")
+	}
+
+	var r0 *blogpkg.Blog
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, blogpkg.ReviewRequest) (*blogpkg.Blog, error)); ok {
+		return rf(ctx, blogID, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, blogpkg.ReviewRequest) *blogpkg.Blog); ok {
+		r0 = rf(ctx, blogID, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*blogpkg.Blog)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, blogpkg.ReviewRequest) error); ok {
+		r1 = rf(ctx, blogID, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewIModerationUsecase creates a new instance of IModerationUsecase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewIModerationUsecase(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *IModerationUsecase {
+	mock := &IModerationUsecase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,207 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	otppkg "github.com/Amaankaa/Blog-Starter-Project/Domain/otp"
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// IOTPRepository is an autogenerated mock type for the IOTPRepository type
+type IOTPRepository struct {
+	mock.Mock
+}
+
+// ConsumeToken provides a mock function with given fields: ctx, purpose, tokenHash
+func (_m *IOTPRepository) ConsumeToken(ctx context.Context, purpose otppkg.Purpose, tokenHash string) (otppkg.Challenge, error) {
+	ret := _m.Called(ctx, purpose, tokenHash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ConsumeToken")
+	}
+
+	var r0 otppkg.Challenge
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, otppkg.Purpose, string) (otppkg.Challenge, error)); ok {
+		return rf(ctx, purpose, tokenHash)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, otppkg.Purpose, string) otppkg.Challenge); ok {
+		r0 = rf(ctx, purpose, tokenHash)
+	} else {
+		r0 = ret.Get(0).(otppkg.Challenge)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, otppkg.Purpose, string) error); ok {
+		r1 = rf(ctx, purpose, tokenHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Delete provides a mock function with given fields: ctx, id
+func (_m *IOTPRepository) Delete(ctx context.Context, id string) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Exchange provides a mock function with given fields: ctx, id, tokenHash, expiresAt
+func (_m *IOTPRepository) Exchange(ctx context.Context, id string, tokenHash string, expiresAt time.Time) error {
+	ret := _m.Called(ctx, id, tokenHash, expiresAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Exchange")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, time.Time) error); ok {
+		r0 = rf(ctx, id, tokenHash, expiresAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Find provides a mock function with given fields: ctx, id
+func (_m *IOTPRepository) Find(ctx context.Context, id string) (otppkg.Challenge, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Find")
+	}
+
+	var r0 otppkg.Challenge
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (otppkg.Challenge, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) otppkg.Challenge); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(otppkg.Challenge)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindByToken provides a mock function with given fields: ctx, purpose, tokenHash
+func (_m *IOTPRepository) FindByToken(ctx context.Context, purpose otppkg.Purpose, tokenHash string) (otppkg.Challenge, error) {
+	ret := _m.Called(ctx, purpose, tokenHash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindByToken")
+	}
+
+	var r0 otppkg.Challenge
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, otppkg.Purpose, string) (otppkg.Challenge, error)); ok {
+		return rf(ctx, purpose, tokenHash)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, otppkg.Purpose, string) otppkg.Challenge); ok {
+		r0 = rf(ctx, purpose, tokenHash)
+	} else {
+		r0 = ret.Get(0).(otppkg.Challenge)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, otppkg.Purpose, string) error); ok {
+		r1 = rf(ctx, purpose, tokenHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Issue provides a mock function with given fields: ctx, challenge, sentBefore
+func (_m *IOTPRepository) Issue(ctx context.Context, challenge otppkg.Challenge, sentBefore time.Time) (bool, error) {
+	ret := _m.Called(ctx, challenge, sentBefore)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Issue")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, otppkg.Challenge, time.Time) (bool, error)); ok {
+		return rf(ctx, challenge, sentBefore)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, otppkg.Challenge, time.Time) bool); ok {
+		r0 = rf(ctx, challenge, sentBefore)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, otppkg.Challenge, time.Time) error); ok {
+		r1 = rf(ctx, challenge, sentBefore)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TakeAttempt provides a mock function with given fields: ctx, id, maxAttempts
+func (_m *IOTPRepository) TakeAttempt(ctx context.Context, id string, maxAttempts int) (otppkg.Challenge, error) {
+	ret := _m.Called(ctx, id, maxAttempts)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TakeAttempt")
+	}
+
+	var r0 otppkg.Challenge
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) (otppkg.Challenge, error)); ok {
+		return rf(ctx, id, maxAttempts)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) otppkg.Challenge); ok {
+		r0 = rf(ctx, id, maxAttempts)
+	} else {
+		r0 = ret.Get(0).(otppkg.Challenge)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int) error); ok {
+		r1 = rf(ctx, id, maxAttempts)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewIOTPRepository creates a new instance of IOTPRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewIOTPRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *IOTPRepository {
+	mock := &IOTPRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
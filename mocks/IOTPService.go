@@ -0,0 +1,234 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	otppkg "github.com/Amaankaa/Blog-Starter-Project/Domain/otp"
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// IOTPService is an autogenerated mock type for the IOTPService type
+type IOTPService struct {
+	mock.Mock
+}
+
+// Attempt provides a mock function with given fields: ctx, purpose, subject, check
+func (_m *IOTPService) Attempt(ctx context.Context, purpose otppkg.Purpose, subject string, check func(otppkg.Challenge) (bool, error)) (otppkg.Challenge, error) {
+	ret := _m.Called(ctx, purpose, subject, check)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Attempt")
+	}
+
+	var r0 otppkg.Challenge
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, otppkg.Purpose, string, func(otppkg.Challenge) (bool, error)) (otppkg.Challenge, error)); ok {
+		return rf(ctx, purpose, subject, check)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, otppkg.Purpose, string, func(otppkg.Challenge) (bool, error)) otppkg.Challenge); ok {
+		r0 = rf(ctx, purpose, subject, check)
+	} else {
+		r0 = ret.Get(0).(otppkg.Challenge)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, otppkg.Purpose, string, func(otppkg.Challenge) (bool, error)) error); ok {
+		r1 = rf(ctx, purpose, subject, check)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Exchange provides a mock function with given fields: ctx, purpose, subject, code, lifetime
+func (_m *IOTPService) Exchange(ctx context.Context, purpose otppkg.Purpose, subject string, code string, lifetime time.Duration) (string, error) {
+	ret := _m.Called(ctx, purpose, subject, code, lifetime)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Exchange")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, otppkg.Purpose, string, string, time.Duration) (string, error)); ok {
+		return rf(ctx, purpose, subject, code, lifetime)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, otppkg.Purpose, string, string, time.Duration) string); ok {
+		r0 = rf(ctx, purpose, subject, code, lifetime)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, otppkg.Purpose, string, string, time.Duration) error); ok {
+		r1 = rf(ctx, purpose, subject, code, lifetime)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Find provides a mock function with given fields: ctx, purpose, subject
+func (_m *IOTPService) Find(ctx context.Context, purpose otppkg.Purpose, subject string) (otppkg.Challenge, error) {
+	ret := _m.Called(ctx, purpose, subject)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Find")
+	}
+
+	var r0 otppkg.Challenge
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, otppkg.Purpose, string) (otppkg.Challenge, error)); ok {
+		return rf(ctx, purpose, subject)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, otppkg.Purpose, string) otppkg.Challenge); ok {
+		r0 = rf(ctx, purpose, subject)
+	} else {
+		r0 = ret.Get(0).(otppkg.Challenge)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, otppkg.Purpose, string) error); ok {
+		r1 = rf(ctx, purpose, subject)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Issue provides a mock function with given fields: ctx, purpose, subject, data
+func (_m *IOTPService) Issue(ctx context.Context, purpose otppkg.Purpose, subject string, data string) (string, time.Time, error) {
+	ret := _m.Called(ctx, purpose, subject, data)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Issue")
+	}
+
+	var r0 string
+	var r1 time.Time
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, otppkg.Purpose, string, string) (string, time.Time, error)); ok {
+		return rf(ctx, purpose, subject, data)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, otppkg.Purpose, string, string) string); ok {
+		r0 = rf(ctx, purpose, subject, data)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, otppkg.Purpose, string, string) time.Time); ok {
+		r1 = rf(ctx, purpose, subject, data)
+	} else {
+		r1 = ret.Get(1).(time.Time)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, otppkg.Purpose, string, string) error); ok {
+		r2 = rf(ctx, purpose, subject, data)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// Lookup provides a mock function with given fields: ctx, purpose, token
+func (_m *IOTPService) Lookup(ctx context.Context, purpose otppkg.Purpose, token string) (otppkg.Challenge, error) {
+	ret := _m.Called(ctx, purpose, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Lookup")
+	}
+
+	var r0 otppkg.Challenge
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, otppkg.Purpose, string) (otppkg.Challenge, error)); ok {
+		return rf(ctx, purpose, token)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, otppkg.Purpose, string) otppkg.Challenge); ok {
+		r0 = rf(ctx, purpose, token)
+	} else {
+		r0 = ret.Get(0).(otppkg.Challenge)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, otppkg.Purpose, string) error); ok {
+		r1 = rf(ctx, purpose, token)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Redeem provides a mock function with given fields: ctx, purpose, token
+func (_m *IOTPService) Redeem(ctx context.Context, purpose otppkg.Purpose, token string) (otppkg.Challenge, error) {
+	ret := _m.Called(ctx, purpose, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Redeem")
+	}
+
+	var r0 otppkg.Challenge
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, otppkg.Purpose, string) (otppkg.Challenge, error)); ok {
+		return rf(ctx, purpose, token)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, otppkg.Purpose, string) otppkg.Challenge); ok {
+		r0 = rf(ctx, purpose, token)
+	} else {
+		r0 = ret.Get(0).(otppkg.Challenge)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, otppkg.Purpose, string) error); ok {
+		r1 = rf(ctx, purpose, token)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Verify provides a mock function with given fields: ctx, purpose, subject, code
+func (_m *IOTPService) Verify(ctx context.Context, purpose otppkg.Purpose, subject string, code string) (otppkg.Challenge, error) {
+	ret := _m.Called(ctx, purpose, subject, code)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Verify")
+	}
+
+	var r0 otppkg.Challenge
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, otppkg.Purpose, string, string) (otppkg.Challenge, error)); ok {
+		return rf(ctx, purpose, subject, code)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, otppkg.Purpose, string, string) otppkg.Challenge); ok {
+		r0 = rf(ctx, purpose, subject, code)
+	} else {
+		r0 = ret.Get(0).(otppkg.Challenge)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, otppkg.Purpose, string, string) error); ok {
+		r1 = rf(ctx, purpose, subject, code)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewIOTPService creates a new instance of IOTPService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewIOTPService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *IOTPService {
+	mock := &IOTPService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
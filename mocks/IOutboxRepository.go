@@ -0,0 +1,102 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	outboxpkg "github.com/Amaankaa/Blog-Starter-Project/Domain/outbox"
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// IOutboxRepository is an autogenerated mock type for the IOutboxRepository type
+type IOutboxRepository struct {
+	mock.Mock
+}
+
+// ClaimDue provides a mock function with given fields: ctx, now, lease
+func (_m *IOutboxRepository) ClaimDue(ctx context.Context, now time.Time, lease time.Duration) (outboxpkg.Message, bool, error) {
+	ret := _m.Called(ctx, now, lease)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ClaimDue")
+	}
+
+	var r0 outboxpkg.Message
+	var r1 bool
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, time.Duration) (outboxpkg.Message, bool, error)); ok {
+		return rf(ctx, now, lease)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, time.Duration) outboxpkg.Message); ok {
+		r0 = rf(ctx, now, lease)
+	} else {
+		r0 = ret.Get(0).(outboxpkg.Message)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time, time.Duration) bool); ok {
+		r1 = rf(ctx, now, lease)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, time.Time, time.Duration) error); ok {
+		r2 = rf(ctx, now, lease)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// Enqueue provides a mock function with given fields: ctx, msg
+func (_m *IOutboxRepository) Enqueue(ctx context.Context, msg outboxpkg.Message) error {
+	ret := _m.Called(ctx, msg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Enqueue")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, outboxpkg.Message) error); ok {
+		r0 = rf(ctx, msg)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateMessage provides a mock function with given fields: ctx, msg
+func (_m *IOutboxRepository) UpdateMessage(ctx context.Context, msg outboxpkg.Message) error {
+	ret := _m.Called(ctx, msg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateMessage")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, outboxpkg.Message) error); ok {
+		r0 = rf(ctx, msg)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewIOutboxRepository creates a new instance of IOutboxRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewIOutboxRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *IOutboxRepository {
+	mock := &IOutboxRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,57 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	patpkg "github.com/Amaankaa/Blog-Starter-Project/Domain/pat"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// IPATAuthenticator is an autogenerated mock type for the IPATAuthenticator type
+type IPATAuthenticator struct {
+	mock.Mock
+}
+
+// Authenticate provides a mock function with given fields: ctx, token
+func (_m *IPATAuthenticator) Authenticate(ctx context.Context, token string) (patpkg.Principal, error) {
+	ret := _m.Called(ctx, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Authenticate")
+	}
+
+	var r0 patpkg.Principal
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (patpkg.Principal, error)); ok {
+		return rf(ctx, token)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) patpkg.Principal); ok {
+		r0 = rf(ctx, token)
+	} else {
+		r0 = ret.Get(0).(patpkg.Principal)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, token)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewIPATAuthenticator creates a new instance of IPATAuthenticator. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewIPATAuthenticator(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *IPATAuthenticator {
+	mock := &IPATAuthenticator{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
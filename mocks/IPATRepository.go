@@ -0,0 +1,199 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	patpkg "github.com/Amaankaa/Blog-Starter-Project/Domain/pat"
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// IPATRepository is an autogenerated mock type for the IPATRepository type
+type IPATRepository struct {
+	mock.Mock
+}
+
+// CountByUser provides a mock function with given fields: ctx, userID
+func (_m *IPATRepository) CountByUser(ctx context.Context, userID string) (int64, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountByUser")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (int64, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) int64); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Create provides a mock function with given fields: ctx, token
+func (_m *IPATRepository) Create(ctx context.Context, token patpkg.PersonalAccessToken) (patpkg.PersonalAccessToken, error) {
+	ret := _m.Called(ctx, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 patpkg.PersonalAccessToken
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, patpkg.PersonalAccessToken) (patpkg.PersonalAccessToken, error)); ok {
+		return rf(ctx, token)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, patpkg.PersonalAccessToken) patpkg.PersonalAccessToken); ok {
+		r0 = rf(ctx, token)
+	} else {
+		r0 = ret.Get(0).(patpkg.PersonalAccessToken)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, patpkg.PersonalAccessToken) error); ok {
+		r1 = rf(ctx, token)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Delete provides a mock function with given fields: ctx, userID, tokenID
+func (_m *IPATRepository) Delete(ctx context.Context, userID string, tokenID string) error {
+	ret := _m.Called(ctx, userID, tokenID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, userID, tokenID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteByUser provides a mock function with given fields: ctx, userID
+func (_m *IPATRepository) DeleteByUser(ctx context.Context, userID string) error {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteByUser")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// FindByHash provides a mock function with given fields: ctx, tokenHash
+func (_m *IPATRepository) FindByHash(ctx context.Context, tokenHash string) (patpkg.PersonalAccessToken, error) {
+	ret := _m.Called(ctx, tokenHash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindByHash")
+	}
+
+	var r0 patpkg.PersonalAccessToken
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (patpkg.PersonalAccessToken, error)); ok {
+		return rf(ctx, tokenHash)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) patpkg.PersonalAccessToken); ok {
+		r0 = rf(ctx, tokenHash)
+	} else {
+		r0 = ret.Get(0).(patpkg.PersonalAccessToken)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, tokenHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListByUser provides a mock function with given fields: ctx, userID
+func (_m *IPATRepository) ListByUser(ctx context.Context, userID string) ([]patpkg.PersonalAccessToken, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListByUser")
+	}
+
+	var r0 []patpkg.PersonalAccessToken
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]patpkg.PersonalAccessToken, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []patpkg.PersonalAccessToken); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]patpkg.PersonalAccessToken)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TouchLastUsed provides a mock function with given fields: ctx, tokenID, at
+func (_m *IPATRepository) TouchLastUsed(ctx context.Context, tokenID string, at time.Time) error {
+	ret := _m.Called(ctx, tokenID, at)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TouchLastUsed")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) error); ok {
+		r0 = rf(ctx, tokenID, at)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewIPATRepository creates a new instance of IPATRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewIPATRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *IPATRepository {
+	mock := &IPATRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
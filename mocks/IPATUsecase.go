@@ -0,0 +1,105 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	patpkg "github.com/Amaankaa/Blog-Starter-Project/Domain/pat"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// IPATUsecase is an autogenerated mock type for the IPATUsecase type
+type IPATUsecase struct {
+	mock.Mock
+}
+
+// Create provides a mock function with given fields: ctx, userID, req
+func (_m *IPATUsecase) Create(ctx context.Context, userID string, req patpkg.CreateRequest) (patpkg.CreatedToken, error) {
+	ret := _m.Called(ctx, userID, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 patpkg.CreatedToken
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, patpkg.CreateRequest) (patpkg.CreatedToken, error)); ok {
+		return rf(ctx, userID, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, patpkg.CreateRequest) patpkg.CreatedToken); ok {
+		r0 = rf(ctx, userID, req)
+	} else {
+		r0 = ret.Get(0).(patpkg.CreatedToken)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, patpkg.CreateRequest) error); ok {
+		r1 = rf(ctx, userID, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// List provides a mock function with given fields: ctx, userID
+func (_m *IPATUsecase) List(ctx context.Context, userID string) ([]patpkg.PersonalAccessToken, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for List")
+	}
+
+	var r0 []patpkg.PersonalAccessToken
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]patpkg.PersonalAccessToken, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []patpkg.PersonalAccessToken); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]patpkg.PersonalAccessToken)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Revoke provides a mock function with given fields: ctx, userID, tokenID
+func (_m *IPATUsecase) Revoke(ctx context.Context, userID string, tokenID string) error {
+	ret := _m.Called(ctx, userID, tokenID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Revoke")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, userID, tokenID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewIPATUsecase creates a new instance of IPATUsecase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewIPATUsecase(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *IPATUsecase {
+	mock := &IPATUsecase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
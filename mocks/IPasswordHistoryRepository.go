@@ -0,0 +1,77 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	passwordpkg "github.com/Amaankaa/Blog-Starter-Project/Domain/password"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// IPasswordHistoryRepository is an autogenerated mock type for the IPasswordHistoryRepository type
+type IPasswordHistoryRepository struct {
+	mock.Mock
+}
+
+// Add provides a mock function with given fields: ctx, entry, keep
+func (_m *IPasswordHistoryRepository) Add(ctx context.Context, entry passwordpkg.HistoryEntry, keep int) error {
+	ret := _m.Called(ctx, entry, keep)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Add")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, passwordpkg.HistoryEntry, int) error); ok {
+		r0 = rf(ctx, entry, keep)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Recent provides a mock function with given fields: ctx, userID, limit
+func (_m *IPasswordHistoryRepository) Recent(ctx context.Context, userID string, limit int) ([]string, error) {
+	ret := _m.Called(ctx, userID, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Recent")
+	}
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) ([]string, error)); ok {
+		return rf(ctx, userID, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) []string); ok {
+		r0 = rf(ctx, userID, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int) error); ok {
+		r1 = rf(ctx, userID, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewIPasswordHistoryRepository creates a new instance of IPasswordHistoryRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewIPasswordHistoryRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *IPasswordHistoryRepository {
+	mock := &IPasswordHistoryRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,66 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	userpkg "github.com/Amaankaa/Blog-Starter-Project/Domain/user"
+)
+
+// IPasswordPolicy is an autogenerated mock type for the IPasswordPolicy type
+type IPasswordPolicy struct {
+	mock.Mock
+}
+
+// Check provides a mock function with given fields: ctx, password, user
+func (_m *IPasswordPolicy) Check(ctx context.Context, password string, user userpkg.User) error {
+	ret := _m.Called(ctx, password, user)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Check")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, userpkg.User) error); ok {
+		r0 = rf(ctx, password, user)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Retire provides a mock function with given fields: ctx, user
+func (_m *IPasswordPolicy) Retire(ctx context.Context, user userpkg.User) error {
+	ret := _m.Called(ctx, user)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Retire")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, userpkg.User) error); ok {
+		r0 = rf(ctx, user)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewIPasswordPolicy creates a new instance of IPasswordPolicy. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewIPasswordPolicy(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *IPasswordPolicy {
+	mock := &IPasswordPolicy{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
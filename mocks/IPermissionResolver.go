@@ -0,0 +1,85 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	rolepkg "github.com/Amaankaa/Blog-Starter-Project/Domain/role"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// IPermissionResolver is an autogenerated mock type for the IPermissionResolver type
+type IPermissionResolver struct {
+	mock.Mock
+}
+
+// CurrentVersion provides a mock function with given fields: ctx
+func (_m *IPermissionResolver) CurrentVersion(ctx context.Context) (int64, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CurrentVersion")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (int64, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) int64); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Resolve provides a mock function with given fields: ctx, role
+func (_m *IPermissionResolver) Resolve(ctx context.Context, role string) (rolepkg.Grant, error) {
+	ret := _m.Called(ctx, role)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Resolve")
+	}
+
+	var r0 rolepkg.Grant
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (rolepkg.Grant, error)); ok {
+		return rf(ctx, role)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) rolepkg.Grant); ok {
+		r0 = rf(ctx, role)
+	} else {
+		r0 = ret.Get(0).(rolepkg.Grant)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, role)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewIPermissionResolver creates a new instance of IPermissionResolver. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewIPermissionResolver(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *IPermissionResolver {
+	mock := &IPermissionResolver{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
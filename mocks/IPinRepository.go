@@ -0,0 +1,126 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	blogpkg "github.com/Amaankaa/Blog-Starter-Project/Domain/blog"
+
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// IPinRepository is an autogenerated mock type for the IPinRepository type
+type IPinRepository struct {
+	mock.Mock
+}
+
+// DeletePin provides a mock function with given fields: ctx, scope, ownerID, blogID
+func (_m *IPinRepository) DeletePin(ctx context.Context, scope blogpkg.PinScope, ownerID string, blogID string) error {
+	ret := _m.Called(ctx, scope, ownerID, blogID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeletePin")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, blogpkg.PinScope, string, string) error); ok {
+		r0 = rf(ctx, scope, ownerID, blogID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeletePinsOfBlog provides a mock function with given fields: ctx, blogID
+func (_m *IPinRepository) DeletePinsOfBlog(ctx context.Context, blogID string) error {
+	ret := _m.Called(ctx, blogID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeletePinsOfBlog")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, blogID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ListActivePins provides a mock function with given fields: ctx, scope, ownerID, now
+func (_m *IPinRepository) ListActivePins(ctx context.Context, scope blogpkg.PinScope, ownerID string, now time.Time) ([]blogpkg.Pin, error) {
+	ret := _m.Called(ctx, scope, ownerID, now)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListActivePins")
+	}
+
+	var r0 []blogpkg.Pin
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, blogpkg.PinScope, string, time.Time) ([]blogpkg.Pin, error)); ok {
+		return rf(ctx, scope, ownerID, now)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, blogpkg.PinScope, string, time.Time) []blogpkg.Pin); ok {
+		r0 = rf(ctx, scope, ownerID, now)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]blogpkg.Pin)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, blogpkg.PinScope, string, time.Time) error); ok {
+		r1 = rf(ctx, scope, ownerID, now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpsertPin provides a mock function with given fields: ctx, pin
+func (_m *IPinRepository) UpsertPin(ctx context.Context, pin blogpkg.Pin) (blogpkg.Pin, error) {
+	ret := _m.Called(ctx, pin)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpsertPin")
+	}
+
+	var r0 blogpkg.Pin
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, blogpkg.Pin) (blogpkg.Pin, error)); ok {
+		return rf(ctx, pin)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, blogpkg.Pin) blogpkg.Pin); ok {
+		r0 = rf(ctx, pin)
+	} else {
+		r0 = ret.Get(0).(blogpkg.Pin)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, blogpkg.Pin) error); ok {
+		r1 = rf(ctx, pin)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewIPinRepository creates a new instance of IPinRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewIPinRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *IPinRepository {
+	mock := &IPinRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
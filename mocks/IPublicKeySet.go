@@ -0,0 +1,45 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	signingkeypkg "github.com/Amaankaa/Blog-Starter-Project/Domain/signingkey"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// IPublicKeySet is an autogenerated mock type for the IPublicKeySet type
+type IPublicKeySet struct {
+	mock.Mock
+}
+
+// PublicKeys provides a mock function with no fields
+func (_m *IPublicKeySet) PublicKeys() signingkeypkg.JSONWebKeySet {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for PublicKeys")
+	}
+
+	var r0 signingkeypkg.JSONWebKeySet
+	if rf, ok := ret.Get(0).(func() signingkeypkg.JSONWebKeySet); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(signingkeypkg.JSONWebKeySet)
+	}
+
+	return r0
+}
+
+// NewIPublicKeySet creates a new instance of IPublicKeySet. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewIPublicKeySet(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *IPublicKeySet {
+	mock := &IPublicKeySet{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
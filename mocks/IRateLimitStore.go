@@ -0,0 +1,59 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	ratelimitpkg "github.com/Amaankaa/Blog-Starter-Project/Domain/ratelimit"
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// IRateLimitStore is an autogenerated mock type for the IRateLimitStore type
+type IRateLimitStore struct {
+	mock.Mock
+}
+
+// Take provides a mock function with given fields: ctx, key, policy, now
+func (_m *IRateLimitStore) Take(ctx context.Context, key string, policy ratelimitpkg.Policy, now time.Time) (ratelimitpkg.Decision, error) {
+	ret := _m.Called(ctx, key, policy, now)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Take")
+	}
+
+	var r0 ratelimitpkg.Decision
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, ratelimitpkg.Policy, time.Time) (ratelimitpkg.Decision, error)); ok {
+		return rf(ctx, key, policy, now)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, ratelimitpkg.Policy, time.Time) ratelimitpkg.Decision); ok {
+		r0 = rf(ctx, key, policy, now)
+	} else {
+		r0 = ret.Get(0).(ratelimitpkg.Decision)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, ratelimitpkg.Policy, time.Time) error); ok {
+		r1 = rf(ctx, key, policy, now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewIRateLimitStore creates a new instance of IRateLimitStore. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewIRateLimitStore(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *IRateLimitStore {
+	mock := &IRateLimitStore{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
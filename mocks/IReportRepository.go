@@ -0,0 +1,118 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	blogpkg "github.com/Amaankaa/Blog-Starter-Project/Domain/blog"
+
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// IReportRepository is an autogenerated mock type for the IReportRepository type
+type IReportRepository struct {
+	mock.Mock
+}
+
+// CreateReport provides a mock function with given fields: ctx, report
+func (_m *IReportRepository) CreateReport(ctx context.Context, report blogpkg.Report) (blogpkg.Report, error) {
+	ret := _m.Called(ctx, report)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateReport")
+	}
+
+	var r0 blogpkg.Report
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, blogpkg.Report) (blogpkg.Report, error)); ok {
+		return rf(ctx, report)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, blogpkg.Report) blogpkg.Report); ok {
+		r0 = rf(ctx, report)
+	} else {
+		r0 = ret.Get(0).(blogpkg.Report)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, blogpkg.Report) error); ok {
+		r1 = rf(ctx, report)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// HandleReport provides a mock function with given fields: ctx, id, status, handledBy, note, at
+func (_m *IReportRepository) HandleReport(ctx context.Context, id string, status blogpkg.ReportStatus, handledBy string, note string, at time.Time) (blogpkg.Report, error) {
+	ret := _m.Called(ctx, id, status, handledBy, note, at)
+
+	if len(ret) == 0 {
+		panic("no return value specified for HandleReport")
+	}
+
+	var r0 blogpkg.Report
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, blogpkg.ReportStatus, string, string, time.Time) (blogpkg.Report, error)); ok {
+		return rf(ctx, id, status, handledBy, note, at)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, blogpkg.ReportStatus, string, string, time.Time) blogpkg.Report); ok {
+		r0 = rf(ctx, id, status, handledBy, note, at)
+	} else {
+		r0 = ret.Get(0).(blogpkg.Report)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, blogpkg.ReportStatus, string, string, time.Time) error); ok {
+		r1 = rf(ctx, id, status, handledBy, note, at)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListReports provides a mock function with given fields: ctx, status, limit
+func (_m *IReportRepository) ListReports(ctx context.Context, status blogpkg.ReportStatus, limit int64) ([]blogpkg.Report, error) {
+	ret := _m.Called(ctx, status, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListReports")
+	}
+
+	var r0 []blogpkg.Report
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, blogpkg.ReportStatus, int64) ([]blogpkg.Report, error)); ok {
+		return rf(ctx, status, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, blogpkg.ReportStatus, int64) []blogpkg.Report); ok {
+		r0 = rf(ctx, status, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]blogpkg.Report)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, blogpkg.ReportStatus, int64) error); ok {
+		r1 = rf(ctx, status, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewIReportRepository creates a new instance of IReportRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewIReportRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *IReportRepository {
+	mock := &IReportRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
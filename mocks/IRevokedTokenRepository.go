@@ -0,0 +1,79 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	time "time"
+
+	mock "github.com/stretchr/testify/mock"
+
+	userpkg "github.com/Amaankaa/Blog-Starter-Project/Domain/user"
+)
+
+// IRevokedTokenRepository is an autogenerated mock type for the IRevokedTokenRepository type
+type IRevokedTokenRepository struct {
+	mock.Mock
+}
+
+// ListRevokedSince provides a mock function with given fields: ctx, since
+func (_m *IRevokedTokenRepository) ListRevokedSince(ctx context.Context, since time.Time) ([]userpkg.RevokedToken, error) {
+	ret := _m.Called(ctx, since)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListRevokedSince")
+	}
+
+	var r0 []userpkg.RevokedToken
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) ([]userpkg.RevokedToken, error)); ok {
+		return rf(ctx, since)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) []userpkg.RevokedToken); ok {
+		r0 = rf(ctx, since)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]userpkg.RevokedToken)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, since)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// StoreRevokedTokens provides a mock function with given fields: ctx, tokens
+func (_m *IRevokedTokenRepository) StoreRevokedTokens(ctx context.Context, tokens []userpkg.RevokedToken) error {
+	ret := _m.Called(ctx, tokens)
+
+	if len(ret) == 0 {
+		panic("no return value specified for StoreRevokedTokens")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []userpkg.RevokedToken) error); ok {
+		r0 = rf(ctx, tokens)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewIRevokedTokenRepository creates a new instance of IRevokedTokenRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewIRevokedTokenRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *IRevokedTokenRepository {
+	mock := &IRevokedTokenRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
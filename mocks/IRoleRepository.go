@@ -0,0 +1,235 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	rolepkg "github.com/Amaankaa/Blog-Starter-Project/Domain/role"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// IRoleRepository is an autogenerated mock type for the IRoleRepository type
+type IRoleRepository struct {
+	mock.Mock
+}
+
+// BumpVersion provides a mock function with given fields: ctx
+func (_m *IRoleRepository) BumpVersion(ctx context.Context) (int64, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for BumpVersion")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (int64, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) int64); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CountUsersWithRole provides a mock function with given fields: ctx, name
+func (_m *IRoleRepository) CountUsersWithRole(ctx context.Context, name string) (int64, error) {
+	ret := _m.Called(ctx, name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountUsersWithRole")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (int64, error)); ok {
+		return rf(ctx, name)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) int64); ok {
+		r0 = rf(ctx, name)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteRole provides a mock function with given fields: ctx, name
+func (_m *IRoleRepository) DeleteRole(ctx context.Context, name string) error {
+	ret := _m.Called(ctx, name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteRole")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, name)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// FindRole provides a mock function with given fields: ctx, name
+func (_m *IRoleRepository) FindRole(ctx context.Context, name string) (rolepkg.Role, error) {
+	ret := _m.Called(ctx, name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindRole")
+	}
+
+	var r0 rolepkg.Role
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (rolepkg.Role, error)); ok {
+		return rf(ctx, name)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) rolepkg.Role); ok {
+		r0 = rf(ctx, name)
+	} else {
+		r0 = ret.Get(0).(rolepkg.Role)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// InsertRoleIfMissing provides a mock function with given fields: ctx, role
+func (_m *IRoleRepository) InsertRoleIfMissing(ctx context.Context, role rolepkg.Role) (bool, error) {
+	ret := _m.Called(ctx, role)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InsertRoleIfMissing")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, rolepkg.Role) (bool, error)); ok {
+		return rf(ctx, role)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, rolepkg.Role) bool); ok {
+		r0 = rf(ctx, role)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, rolepkg.Role) error); ok {
+		r1 = rf(ctx, role)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListRoles provides a mock function with given fields: ctx
+func (_m *IRoleRepository) ListRoles(ctx context.Context) ([]rolepkg.Role, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListRoles")
+	}
+
+	var r0 []rolepkg.Role
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]rolepkg.Role, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []rolepkg.Role); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]rolepkg.Role)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SaveRole provides a mock function with given fields: ctx, role
+func (_m *IRoleRepository) SaveRole(ctx context.Context, role rolepkg.Role) error {
+	ret := _m.Called(ctx, role)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveRole")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, rolepkg.Role) error); ok {
+		r0 = rf(ctx, role)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Version provides a mock function with given fields: ctx
+func (_m *IRoleRepository) Version(ctx context.Context) (int64, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Version")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (int64, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) int64); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewIRoleRepository creates a new instance of IRoleRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewIRoleRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *IRoleRepository {
+	mock := &IRoleRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,133 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	rolepkg "github.com/Amaankaa/Blog-Starter-Project/Domain/role"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// IRoleUsecase is an autogenerated mock type for the IRoleUsecase type
+type IRoleUsecase struct {
+	mock.Mock
+}
+
+// CreateRole provides a mock function with given fields: ctx, req
+func (_m *IRoleUsecase) CreateRole(ctx context.Context, req rolepkg.RoleRequest) (rolepkg.Role, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateRole")
+	}
+
+	var r0 rolepkg.Role
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, rolepkg.RoleRequest) (rolepkg.Role, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, rolepkg.RoleRequest) rolepkg.Role); ok {
+		r0 = rf(ctx, req)
+	} else {
+		r0 = ret.Get(0).(rolepkg.Role)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, rolepkg.RoleRequest) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteRole provides a mock function with given fields: ctx, name
+func (_m *IRoleUsecase) DeleteRole(ctx context.Context, name string) error {
+	ret := _m.Called(ctx, name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteRole")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, name)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ListRoles provides a mock function with given fields: ctx
+func (_m *IRoleUsecase) ListRoles(ctx context.Context) ([]rolepkg.Role, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListRoles")
+	}
+
+	var r0 []rolepkg.Role
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]rolepkg.Role, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []rolepkg.Role); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]rolepkg.Role)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateRole provides a mock function with given fields: ctx, name, req
+func (_m *IRoleUsecase) UpdateRole(ctx context.Context, name string, req rolepkg.RoleRequest) (rolepkg.Role, error) {
+	ret := _m.Called(ctx, name, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateRole")
+	}
+
+	var r0 rolepkg.Role
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, rolepkg.RoleRequest) (rolepkg.Role, error)); ok {
+		return rf(ctx, name, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, rolepkg.RoleRequest) rolepkg.Role); ok {
+		r0 = rf(ctx, name, req)
+	} else {
+		r0 = ret.Get(0).(rolepkg.Role)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, rolepkg.RoleRequest) error); ok {
+		r1 = rf(ctx, name, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewIRoleUsecase creates a new instance of IRoleUsecase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewIRoleUsecase(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *IRoleUsecase {
+	mock := &IRoleUsecase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,72 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	userpkg "github.com/Amaankaa/Blog-Starter-Project/Domain/user"
+)
+
+// ISessionIssuer is an autogenerated mock type for the ISessionIssuer type
+type ISessionIssuer struct {
+	mock.Mock
+}
+
+// SignIn provides a mock function with given fields: ctx, user, device
+func (_m *ISessionIssuer) SignIn(ctx context.Context, user userpkg.User, device userpkg.DeviceInfo) (userpkg.User, string, string, error) {
+	ret := _m.Called(ctx, user, device)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SignIn")
+	}
+
+	var r0 userpkg.User
+	var r1 string
+	var r2 string
+	var r3 error
+	if rf, ok := ret.Get(0).(func(context.Context, userpkg.User, userpkg.DeviceInfo) (userpkg.User, string, string, error)); ok {
+		return rf(ctx, user, device)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, userpkg.User, userpkg.DeviceInfo) userpkg.User); ok {
+		r0 = rf(ctx, user, device)
+	} else {
+		r0 = ret.Get(0).(userpkg.User)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, userpkg.User, userpkg.DeviceInfo) string); ok {
+		r1 = rf(ctx, user, device)
+	} else {
+		r1 = ret.Get(1).(string)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, userpkg.User, userpkg.DeviceInfo) string); ok {
+		r2 = rf(ctx, user, device)
+	} else {
+		r2 = ret.Get(2).(string)
+	}
+
+	if rf, ok := ret.Get(3).(func(context.Context, userpkg.User, userpkg.DeviceInfo) error); ok {
+		r3 = rf(ctx, user, device)
+	} else {
+		r3 = ret.Error(3)
+	}
+
+	return r0, r1, r2, r3
+}
+
+// NewISessionIssuer creates a new instance of ISessionIssuer. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewISessionIssuer(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ISessionIssuer {
+	mock := &ISessionIssuer{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,95 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	signingkeypkg "github.com/Amaankaa/Blog-Starter-Project/Domain/signingkey"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// ISigningKeyRepository is an autogenerated mock type for the ISigningKeyRepository type
+type ISigningKeyRepository struct {
+	mock.Mock
+}
+
+// InsertKey provides a mock function with given fields: ctx, key
+func (_m *ISigningKeyRepository) InsertKey(ctx context.Context, key signingkeypkg.SigningKey) error {
+	ret := _m.Called(ctx, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InsertKey")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, signingkeypkg.SigningKey) error); ok {
+		r0 = rf(ctx, key)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ListKeys provides a mock function with given fields: ctx
+func (_m *ISigningKeyRepository) ListKeys(ctx context.Context) ([]signingkeypkg.SigningKey, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListKeys")
+	}
+
+	var r0 []signingkeypkg.SigningKey
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]signingkeypkg.SigningKey, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []signingkeypkg.SigningKey); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]signingkeypkg.SigningKey)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SealKey provides a mock function with given fields: ctx, id, encrypted
+func (_m *ISigningKeyRepository) SealKey(ctx context.Context, id string, encrypted []byte) error {
+	ret := _m.Called(ctx, id, encrypted)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SealKey")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, []byte) error); ok {
+		r0 = rf(ctx, id, encrypted)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewISigningKeyRepository creates a new instance of ISigningKeyRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewISigningKeyRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ISigningKeyRepository {
+	mock := &ISigningKeyRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
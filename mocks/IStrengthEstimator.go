@@ -0,0 +1,45 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	passwordpkg "github.com/Amaankaa/Blog-Starter-Project/Domain/password"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// IStrengthEstimator is an autogenerated mock type for the IStrengthEstimator type
+type IStrengthEstimator struct {
+	mock.Mock
+}
+
+// Estimate provides a mock function with given fields: password, userInputs
+func (_m *IStrengthEstimator) Estimate(password string, userInputs []string) passwordpkg.Strength {
+	ret := _m.Called(password, userInputs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Estimate")
+	}
+
+	var r0 passwordpkg.Strength
+	if rf, ok := ret.Get(0).(func(string, []string) passwordpkg.Strength); ok {
+		r0 = rf(password, userInputs)
+	} else {
+		r0 = ret.Get(0).(passwordpkg.Strength)
+	}
+
+	return r0
+}
+
+// NewIStrengthEstimator creates a new instance of IStrengthEstimator. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewIStrengthEstimator(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *IStrengthEstimator {
+	mock := &IStrengthEstimator{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -69,6 +69,149 @@ func (_c *ITokenRepository_DeleteByRefreshToken_Call) RunAndReturn(run func(cont
 	return _c
 }
 
+// DeleteOtherSessions provides a mock function with given fields: ctx, userID, keepSessionID
+func (_m *ITokenRepository) DeleteOtherSessions(ctx context.Context, userID string, keepSessionID string) error {
+	ret := _m.Called(ctx, userID, keepSessionID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteOtherSessions")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, userID, keepSessionID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ITokenRepository_DeleteOtherSessions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteOtherSessions'
+type ITokenRepository_DeleteOtherSessions_Call struct {
+	*mock.Call
+}
+
+// DeleteOtherSessions is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - keepSessionID string
+func (_e *ITokenRepository_Expecter) DeleteOtherSessions(ctx interface{}, userID interface{}, keepSessionID interface{}) *ITokenRepository_DeleteOtherSessions_Call {
+	return &ITokenRepository_DeleteOtherSessions_Call{Call: _e.mock.On("DeleteOtherSessions", ctx, userID, keepSessionID)}
+}
+
+func (_c *ITokenRepository_DeleteOtherSessions_Call) Run(run func(ctx context.Context, userID string, keepSessionID string)) *ITokenRepository_DeleteOtherSessions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *ITokenRepository_DeleteOtherSessions_Call) Return(_a0 error) *ITokenRepository_DeleteOtherSessions_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ITokenRepository_DeleteOtherSessions_Call) RunAndReturn(run func(context.Context, string, string) error) *ITokenRepository_DeleteOtherSessions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteSession provides a mock function with given fields: ctx, userID, sessionID
+func (_m *ITokenRepository) DeleteSession(ctx context.Context, userID string, sessionID string) error {
+	ret := _m.Called(ctx, userID, sessionID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteSession")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, userID, sessionID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ITokenRepository_DeleteSession_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteSession'
+type ITokenRepository_DeleteSession_Call struct {
+	*mock.Call
+}
+
+// DeleteSession is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - sessionID string
+func (_e *ITokenRepository_Expecter) DeleteSession(ctx interface{}, userID interface{}, sessionID interface{}) *ITokenRepository_DeleteSession_Call {
+	return &ITokenRepository_DeleteSession_Call{Call: _e.mock.On("DeleteSession", ctx, userID, sessionID)}
+}
+
+func (_c *ITokenRepository_DeleteSession_Call) Run(run func(ctx context.Context, userID string, sessionID string)) *ITokenRepository_DeleteSession_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *ITokenRepository_DeleteSession_Call) Return(_a0 error) *ITokenRepository_DeleteSession_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ITokenRepository_DeleteSession_Call) RunAndReturn(run func(context.Context, string, string) error) *ITokenRepository_DeleteSession_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteTokenFamily provides a mock function with given fields: ctx, familyID
+func (_m *ITokenRepository) DeleteTokenFamily(ctx context.Context, familyID string) error {
+	ret := _m.Called(ctx, familyID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteTokenFamily")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, familyID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ITokenRepository_DeleteTokenFamily_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteTokenFamily'
+type ITokenRepository_DeleteTokenFamily_Call struct {
+	*mock.Call
+}
+
+// DeleteTokenFamily is a helper method to define mock.On call
+//   - ctx context.Context
+//   - familyID string
+func (_e *ITokenRepository_Expecter) DeleteTokenFamily(ctx interface{}, familyID interface{}) *ITokenRepository_DeleteTokenFamily_Call {
+	return &ITokenRepository_DeleteTokenFamily_Call{Call: _e.mock.On("DeleteTokenFamily", ctx, familyID)}
+}
+
+func (_c *ITokenRepository_DeleteTokenFamily_Call) Run(run func(ctx context.Context, familyID string)) *ITokenRepository_DeleteTokenFamily_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *ITokenRepository_DeleteTokenFamily_Call) Return(_a0 error) *ITokenRepository_DeleteTokenFamily_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ITokenRepository_DeleteTokenFamily_Call) RunAndReturn(run func(context.Context, string) error) *ITokenRepository_DeleteTokenFamily_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // DeleteTokensByUserID provides a mock function with given fields: ctx, userID
 func (_m *ITokenRepository) DeleteTokensByUserID(ctx context.Context, userID string) error {
 	ret := _m.Called(ctx, userID)
@@ -173,6 +316,172 @@ func (_c *ITokenRepository_FindByRefreshToken_Call) RunAndReturn(run func(contex
 	return _c
 }
 
+// ListActiveAccessTokens provides a mock function with given fields: ctx, userID
+func (_m *ITokenRepository) ListActiveAccessTokens(ctx context.Context, userID string) ([]userpkg.Token, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListActiveAccessTokens")
+	}
+
+	var r0 []userpkg.Token
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]userpkg.Token, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []userpkg.Token); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]userpkg.Token)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ITokenRepository_ListActiveAccessTokens_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListActiveAccessTokens'
+type ITokenRepository_ListActiveAccessTokens_Call struct {
+	*mock.Call
+}
+
+// ListActiveAccessTokens is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+func (_e *ITokenRepository_Expecter) ListActiveAccessTokens(ctx interface{}, userID interface{}) *ITokenRepository_ListActiveAccessTokens_Call {
+	return &ITokenRepository_ListActiveAccessTokens_Call{Call: _e.mock.On("ListActiveAccessTokens", ctx, userID)}
+}
+
+func (_c *ITokenRepository_ListActiveAccessTokens_Call) Run(run func(ctx context.Context, userID string)) *ITokenRepository_ListActiveAccessTokens_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *ITokenRepository_ListActiveAccessTokens_Call) Return(_a0 []userpkg.Token, _a1 error) *ITokenRepository_ListActiveAccessTokens_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ITokenRepository_ListActiveAccessTokens_Call) RunAndReturn(run func(context.Context, string) ([]userpkg.Token, error)) *ITokenRepository_ListActiveAccessTokens_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListSessions provides a mock function with given fields: ctx, userID
+func (_m *ITokenRepository) ListSessions(ctx context.Context, userID string) ([]userpkg.Token, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListSessions")
+	}
+
+	var r0 []userpkg.Token
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]userpkg.Token, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []userpkg.Token); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]userpkg.Token)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ITokenRepository_ListSessions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListSessions'
+type ITokenRepository_ListSessions_Call struct {
+	*mock.Call
+}
+
+// ListSessions is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+func (_e *ITokenRepository_Expecter) ListSessions(ctx interface{}, userID interface{}) *ITokenRepository_ListSessions_Call {
+	return &ITokenRepository_ListSessions_Call{Call: _e.mock.On("ListSessions", ctx, userID)}
+}
+
+func (_c *ITokenRepository_ListSessions_Call) Run(run func(ctx context.Context, userID string)) *ITokenRepository_ListSessions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *ITokenRepository_ListSessions_Call) Return(_a0 []userpkg.Token, _a1 error) *ITokenRepository_ListSessions_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ITokenRepository_ListSessions_Call) RunAndReturn(run func(context.Context, string) ([]userpkg.Token, error)) *ITokenRepository_ListSessions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RotateRefreshToken provides a mock function with given fields: ctx, refreshToken, next
+func (_m *ITokenRepository) RotateRefreshToken(ctx context.Context, refreshToken string, next userpkg.Token) error {
+	ret := _m.Called(ctx, refreshToken, next)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RotateRefreshToken")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, userpkg.Token) error); ok {
+		r0 = rf(ctx, refreshToken, next)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ITokenRepository_RotateRefreshToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RotateRefreshToken'
+type ITokenRepository_RotateRefreshToken_Call struct {
+	*mock.Call
+}
+
+// RotateRefreshToken is a helper method to define mock.On call
+//   - ctx context.Context
+//   - refreshToken string
+//   - next userpkg.Token
+func (_e *ITokenRepository_Expecter) RotateRefreshToken(ctx interface{}, refreshToken interface{}, next interface{}) *ITokenRepository_RotateRefreshToken_Call {
+	return &ITokenRepository_RotateRefreshToken_Call{Call: _e.mock.On("RotateRefreshToken", ctx, refreshToken, next)}
+}
+
+func (_c *ITokenRepository_RotateRefreshToken_Call) Run(run func(ctx context.Context, refreshToken string, next userpkg.Token)) *ITokenRepository_RotateRefreshToken_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(userpkg.Token))
+	})
+	return _c
+}
+
+func (_c *ITokenRepository_RotateRefreshToken_Call) Return(_a0 error) *ITokenRepository_RotateRefreshToken_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ITokenRepository_RotateRefreshToken_Call) RunAndReturn(run func(context.Context, string, userpkg.Token) error) *ITokenRepository_RotateRefreshToken_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // StoreToken provides a mock function with given fields: ctx, token
 func (_m *ITokenRepository) StoreToken(ctx context.Context, token userpkg.Token) error {
 	ret := _m.Called(ctx, token)
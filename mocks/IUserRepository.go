@@ -126,6 +126,34 @@ func (_m *IUserRepository) ExistsByUsername(ctx context.Context, username string
 	return r0, r1
 }
 
+// FindByEmail provides a mock function with given fields: ctx, email
+func (_m *IUserRepository) FindByEmail(ctx context.Context, email string) (userpkg.User, error) {
+	ret := _m.Called(ctx, email)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindByEmail")
+	}
+
+	var r0 userpkg.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (userpkg.User, error)); ok {
+		return rf(ctx, email)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) userpkg.User); ok {
+		r0 = rf(ctx, email)
+	} else {
+		r0 = ret.Get(0).(userpkg.User)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, email)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // FindByID provides a mock function with given fields: ctx, userID
 func (_m *IUserRepository) FindByID(ctx context.Context, userID string) (userpkg.User, error) {
 	ret := _m.Called(ctx, userID)
@@ -210,6 +238,42 @@ func (_m *IUserRepository) GetUserProfile(ctx context.Context, userID string) (u
 	return r0, r1
 }
 
+// ReplacePasswordHash provides a mock function with given fields: ctx, userID, oldHash, newHash
+func (_m *IUserRepository) ReplacePasswordHash(ctx context.Context, userID string, oldHash string, newHash string) error {
+	ret := _m.Called(ctx, userID, oldHash, newHash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReplacePasswordHash")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) error); ok {
+		r0 = rf(ctx, userID, oldHash, newHash)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateEmailByID provides a mock function with given fields: ctx, userID, email
+func (_m *IUserRepository) UpdateEmailByID(ctx context.Context, userID string, email string) error {
+	ret := _m.Called(ctx, userID, email)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateEmailByID")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, userID, email)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // UpdateIsVerifiedByEmail provides a mock function with given fields: ctx, email, verified
 func (_m *IUserRepository) UpdateIsVerifiedByEmail(ctx context.Context, email string, verified bool) error {
 	ret := _m.Called(ctx, email, verified)
@@ -274,17 +338,17 @@ func (_m *IUserRepository) UpdateProfile(ctx context.Context, userID string, upd
 	return r0, r1
 }
 
-// UpdateUserRoleByID provides a mock function with given fields: ctx, userID, role
-func (_m *IUserRepository) UpdateUserRoleByID(ctx context.Context, userID string, role string) error {
-	ret := _m.Called(ctx, userID, role)
+// UpdateRoleAndPromoter provides a mock function with given fields: ctx, userID, role, promoterID
+func (_m *IUserRepository) UpdateRoleAndPromoter(ctx context.Context, userID string, role string, promoterID *string) error {
+	ret := _m.Called(ctx, userID, role, promoterID)
 
 	if len(ret) == 0 {
-		panic("no return value specified for UpdateUserRoleByID")
+		panic("no return value specified for UpdateRoleAndPromoter")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
-		r0 = rf(ctx, userID, role)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, *string) error); ok {
+		r0 = rf(ctx, userID, role, promoterID)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -292,17 +356,17 @@ func (_m *IUserRepository) UpdateUserRoleByID(ctx context.Context, userID string
 	return r0
 }
 
-// UpdateRoleAndPromoter provides a mock function with given fields: ctx, userID, role, promoterID
-func (_m *IUserRepository) UpdateRoleAndPromoter(ctx context.Context, userID string, role string, promoterID *string) error {
-	ret := _m.Called(ctx, userID, role, promoterID)
+// UpdateUserRoleByID provides a mock function with given fields: ctx, userID, role
+func (_m *IUserRepository) UpdateUserRoleByID(ctx context.Context, userID string, role string) error {
+	ret := _m.Called(ctx, userID, role)
 
 	if len(ret) == 0 {
-		panic("no return value specified for UpdateRoleAndPromoter")
+		panic("no return value specified for UpdateUserRoleByID")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string, *string) error); ok {
-		r0 = rf(ctx, userID, role, promoterID)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, userID, role)
 	} else {
 		r0 = ret.Error(0)
 	}
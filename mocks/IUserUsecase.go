@@ -16,6 +16,84 @@ type IUserUsecase struct {
 	mock.Mock
 }
 
+// AssignRole provides a mock function with given fields: ctx, targetUserID, role, actorUserID
+func (_m *IUserUsecase) AssignRole(ctx context.Context, targetUserID string, role string, actorUserID string) error {
+	ret := _m.Called(ctx, targetUserID, role, actorUserID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AssignRole")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) error); ok {
+		r0 = rf(ctx, targetUserID, role, actorUserID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CompleteMFALogin provides a mock function with given fields: ctx, challengeToken, code, device
+func (_m *IUserUsecase) CompleteMFALogin(ctx context.Context, challengeToken string, code string, device userpkg.DeviceInfo) (userpkg.User, string, string, error) {
+	ret := _m.Called(ctx, challengeToken, code, device)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CompleteMFALogin")
+	}
+
+	var r0 userpkg.User
+	var r1 string
+	var r2 string
+	var r3 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, userpkg.DeviceInfo) (userpkg.User, string, string, error)); ok {
+		return rf(ctx, challengeToken, code, device)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, userpkg.DeviceInfo) userpkg.User); ok {
+		r0 = rf(ctx, challengeToken, code, device)
+	} else {
+		r0 = ret.Get(0).(userpkg.User)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, userpkg.DeviceInfo) string); ok {
+		r1 = rf(ctx, challengeToken, code, device)
+	} else {
+		r1 = ret.Get(1).(string)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, string, userpkg.DeviceInfo) string); ok {
+		r2 = rf(ctx, challengeToken, code, device)
+	} else {
+		r2 = ret.Get(2).(string)
+	}
+
+	if rf, ok := ret.Get(3).(func(context.Context, string, string, userpkg.DeviceInfo) error); ok {
+		r3 = rf(ctx, challengeToken, code, device)
+	} else {
+		r3 = ret.Error(3)
+	}
+
+	return r0, r1, r2, r3
+}
+
+// ConfirmEmailChange provides a mock function with given fields: ctx, userID, code, device
+func (_m *IUserUsecase) ConfirmEmailChange(ctx context.Context, userID string, code string, device userpkg.DeviceInfo) error {
+	ret := _m.Called(ctx, userID, code, device)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ConfirmEmailChange")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, userpkg.DeviceInfo) error); ok {
+		r0 = rf(ctx, userID, code, device)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // DemoteUser provides a mock function with given fields: ctx, targetUserID, actorUserID
 func (_m *IUserUsecase) DemoteUser(ctx context.Context, targetUserID string, actorUserID string) error {
 	ret := _m.Called(ctx, targetUserID, actorUserID)
@@ -62,9 +140,39 @@ func (_m *IUserUsecase) GetUserProfile(ctx context.Context, userID string) (user
 	return r0, r1
 }
 
-// LoginUser provides a mock function with given fields: ctx, login, password
-func (_m *IUserUsecase) LoginUser(ctx context.Context, login string, password string) (userpkg.User, string, string, error) {
-	ret := _m.Called(ctx, login, password)
+// ListSessions provides a mock function with given fields: ctx, userID, currentSessionID
+func (_m *IUserUsecase) ListSessions(ctx context.Context, userID string, currentSessionID string) ([]userpkg.Session, error) {
+	ret := _m.Called(ctx, userID, currentSessionID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListSessions")
+	}
+
+	var r0 []userpkg.Session
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) ([]userpkg.Session, error)); ok {
+		return rf(ctx, userID, currentSessionID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) []userpkg.Session); ok {
+		r0 = rf(ctx, userID, currentSessionID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]userpkg.Session)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, userID, currentSessionID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LoginUser provides a mock function with given fields: ctx, login, password, device
+func (_m *IUserUsecase) LoginUser(ctx context.Context, login string, password string, device userpkg.DeviceInfo) (userpkg.User, string, string, error) {
+	ret := _m.Called(ctx, login, password, device)
 
 	if len(ret) == 0 {
 		panic("no return value specified for LoginUser")
@@ -74,29 +182,29 @@ func (_m *IUserUsecase) LoginUser(ctx context.Context, login string, password st
 	var r1 string
 	var r2 string
 	var r3 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string) (userpkg.User, string, string, error)); ok {
-		return rf(ctx, login, password)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, userpkg.DeviceInfo) (userpkg.User, string, string, error)); ok {
+		return rf(ctx, login, password, device)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string, string) userpkg.User); ok {
-		r0 = rf(ctx, login, password)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, userpkg.DeviceInfo) userpkg.User); ok {
+		r0 = rf(ctx, login, password, device)
 	} else {
 		r0 = ret.Get(0).(userpkg.User)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string, string) string); ok {
-		r1 = rf(ctx, login, password)
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, userpkg.DeviceInfo) string); ok {
+		r1 = rf(ctx, login, password, device)
 	} else {
 		r1 = ret.Get(1).(string)
 	}
 
-	if rf, ok := ret.Get(2).(func(context.Context, string, string) string); ok {
-		r2 = rf(ctx, login, password)
+	if rf, ok := ret.Get(2).(func(context.Context, string, string, userpkg.DeviceInfo) string); ok {
+		r2 = rf(ctx, login, password, device)
 	} else {
 		r2 = ret.Get(2).(string)
 	}
 
-	if rf, ok := ret.Get(3).(func(context.Context, string, string) error); ok {
-		r3 = rf(ctx, login, password)
+	if rf, ok := ret.Get(3).(func(context.Context, string, string, userpkg.DeviceInfo) error); ok {
+		r3 = rf(ctx, login, password, device)
 	} else {
 		r3 = ret.Error(3)
 	}
@@ -104,17 +212,17 @@ func (_m *IUserUsecase) LoginUser(ctx context.Context, login string, password st
 	return r0, r1, r2, r3
 }
 
-// Logout provides a mock function with given fields: ctx, userID
-func (_m *IUserUsecase) Logout(ctx context.Context, userID string) error {
-	ret := _m.Called(ctx, userID)
+// Logout provides a mock function with given fields: ctx, userID, sessionID
+func (_m *IUserUsecase) Logout(ctx context.Context, userID string, sessionID string) error {
+	ret := _m.Called(ctx, userID, sessionID)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Logout")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
-		r0 = rf(ctx, userID)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, userID, sessionID)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -140,9 +248,9 @@ func (_m *IUserUsecase) PromoteUser(ctx context.Context, targetUserID string, ac
 	return r0
 }
 
-// RefreshToken provides a mock function with given fields: ctx, refreshToken
-func (_m *IUserUsecase) RefreshToken(ctx context.Context, refreshToken string) (userpkg.TokenResult, error) {
-	ret := _m.Called(ctx, refreshToken)
+// RefreshToken provides a mock function with given fields: ctx, refreshToken, device
+func (_m *IUserUsecase) RefreshToken(ctx context.Context, refreshToken string, device userpkg.DeviceInfo) (userpkg.TokenResult, error) {
+	ret := _m.Called(ctx, refreshToken, device)
 
 	if len(ret) == 0 {
 		panic("no return value specified for RefreshToken")
@@ -150,17 +258,17 @@ func (_m *IUserUsecase) RefreshToken(ctx context.Context, refreshToken string) (
 
 	var r0 userpkg.TokenResult
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) (userpkg.TokenResult, error)); ok {
-		return rf(ctx, refreshToken)
+	if rf, ok := ret.Get(0).(func(context.Context, string, userpkg.DeviceInfo) (userpkg.TokenResult, error)); ok {
+		return rf(ctx, refreshToken, device)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string) userpkg.TokenResult); ok {
-		r0 = rf(ctx, refreshToken)
+	if rf, ok := ret.Get(0).(func(context.Context, string, userpkg.DeviceInfo) userpkg.TokenResult); ok {
+		r0 = rf(ctx, refreshToken, device)
 	} else {
 		r0 = ret.Get(0).(userpkg.TokenResult)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = rf(ctx, refreshToken)
+	if rf, ok := ret.Get(1).(func(context.Context, string, userpkg.DeviceInfo) error); ok {
+		r1 = rf(ctx, refreshToken, device)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -196,17 +304,71 @@ func (_m *IUserUsecase) RegisterUser(ctx context.Context, user userpkg.User) (us
 	return r0, r1
 }
 
-// ResetPassword provides a mock function with given fields: ctx, email, newPassword
-func (_m *IUserUsecase) ResetPassword(ctx context.Context, email string, newPassword string) error {
-	ret := _m.Called(ctx, email, newPassword)
+// RequestEmailChange provides a mock function with given fields: ctx, userID, password, newEmail
+func (_m *IUserUsecase) RequestEmailChange(ctx context.Context, userID string, password string, newEmail string) error {
+	ret := _m.Called(ctx, userID, password, newEmail)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RequestEmailChange")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) error); ok {
+		r0 = rf(ctx, userID, password, newEmail)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ResetPassword provides a mock function with given fields: ctx, resetToken, newPassword, device
+func (_m *IUserUsecase) ResetPassword(ctx context.Context, resetToken string, newPassword string, device userpkg.DeviceInfo) error {
+	ret := _m.Called(ctx, resetToken, newPassword, device)
 
 	if len(ret) == 0 {
 		panic("no return value specified for ResetPassword")
 	}
 
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, userpkg.DeviceInfo) error); ok {
+		r0 = rf(ctx, resetToken, newPassword, device)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RevokeOtherSessions provides a mock function with given fields: ctx, userID, currentSessionID
+func (_m *IUserUsecase) RevokeOtherSessions(ctx context.Context, userID string, currentSessionID string) error {
+	ret := _m.Called(ctx, userID, currentSessionID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevokeOtherSessions")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, userID, currentSessionID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RevokeSession provides a mock function with given fields: ctx, userID, sessionID
+func (_m *IUserUsecase) RevokeSession(ctx context.Context, userID string, sessionID string) error {
+	ret := _m.Called(ctx, userID, sessionID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevokeSession")
+	}
+
 	var r0 error
 	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
-		r0 = rf(ctx, email, newPassword)
+		r0 = rf(ctx, userID, sessionID)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -279,21 +441,31 @@ func (_m *IUserUsecase) UpdateProfile(ctx context.Context, userID string, update
 }
 
 // VerifyOTP provides a mock function with given fields: ctx, email, otp
-func (_m *IUserUsecase) VerifyOTP(ctx context.Context, email string, otp string) error {
+func (_m *IUserUsecase) VerifyOTP(ctx context.Context, email string, otp string) (string, error) {
 	ret := _m.Called(ctx, email, otp)
 
 	if len(ret) == 0 {
 		panic("no return value specified for VerifyOTP")
 	}
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (string, error)); ok {
+		return rf(ctx, email, otp)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) string); ok {
 		r0 = rf(ctx, email, otp)
 	} else {
-		r0 = ret.Error(0)
+		r0 = ret.Get(0).(string)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, email, otp)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
 // VerifyUser provides a mock function with given fields: ctx, email, otp
@@ -0,0 +1,163 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	webhookpkg "github.com/Amaankaa/Blog-Starter-Project/Domain/webhook"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// IWebhookRepository is an autogenerated mock type for the IWebhookRepository type
+type IWebhookRepository struct {
+	mock.Mock
+}
+
+// CreateWebhook provides a mock function with given fields: ctx, hook
+func (_m *IWebhookRepository) CreateWebhook(ctx context.Context, hook webhookpkg.Webhook) (webhookpkg.Webhook, error) {
+	ret := _m.Called(ctx, hook)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateWebhook")
+	}
+
+	var r0 webhookpkg.Webhook
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, webhookpkg.Webhook) (webhookpkg.Webhook, error)); ok {
+		return rf(ctx, hook)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, webhookpkg.Webhook) webhookpkg.Webhook); ok {
+		r0 = rf(ctx, hook)
+	} else {
+		r0 = ret.Get(0).(webhookpkg.Webhook)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, webhookpkg.Webhook) error); ok {
+		r1 = rf(ctx, hook)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteWebhook provides a mock function with given fields: ctx, id
+func (_m *IWebhookRepository) DeleteWebhook(ctx context.Context, id string) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteWebhook")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// FindActiveByEvent provides a mock function with given fields: ctx, eventType
+func (_m *IWebhookRepository) FindActiveByEvent(ctx context.Context, eventType string) ([]webhookpkg.Webhook, error) {
+	ret := _m.Called(ctx, eventType)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindActiveByEvent")
+	}
+
+	var r0 []webhookpkg.Webhook
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]webhookpkg.Webhook, error)); ok {
+		return rf(ctx, eventType)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []webhookpkg.Webhook); ok {
+		r0 = rf(ctx, eventType)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]webhookpkg.Webhook)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, eventType)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetWebhook provides a mock function with given fields: ctx, id
+func (_m *IWebhookRepository) GetWebhook(ctx context.Context, id string) (webhookpkg.Webhook, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetWebhook")
+	}
+
+	var r0 webhookpkg.Webhook
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (webhookpkg.Webhook, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) webhookpkg.Webhook); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(webhookpkg.Webhook)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListWebhooks provides a mock function with given fields: ctx
+func (_m *IWebhookRepository) ListWebhooks(ctx context.Context) ([]webhookpkg.Webhook, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListWebhooks")
+	}
+
+	var r0 []webhookpkg.Webhook
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]webhookpkg.Webhook, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []webhookpkg.Webhook); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]webhookpkg.Webhook)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewIWebhookRepository creates a new instance of IWebhookRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewIWebhookRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *IWebhookRepository {
+	mock := &IWebhookRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
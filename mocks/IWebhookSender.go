@@ -0,0 +1,56 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// IWebhookSender is an autogenerated mock type for the IWebhookSender type
+type IWebhookSender struct {
+	mock.Mock
+}
+
+// Send provides a mock function with given fields: ctx, url, secret, headers, payload
+func (_m *IWebhookSender) Send(ctx context.Context, url string, secret string, headers map[string]string, payload []byte) (int, error) {
+	ret := _m.Called(ctx, url, secret, headers, payload)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Send")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, map[string]string, []byte) (int, error)); ok {
+		return rf(ctx, url, secret, headers, payload)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, map[string]string, []byte) int); ok {
+		r0 = rf(ctx, url, secret, headers, payload)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, map[string]string, []byte) error); ok {
+		r1 = rf(ctx, url, secret, headers, payload)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewIWebhookSender creates a new instance of IWebhookSender. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewIWebhookSender(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *IWebhookSender {
+	mock := &IWebhookSender{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
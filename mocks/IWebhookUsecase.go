@@ -0,0 +1,168 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	webhookpkg "github.com/Amaankaa/Blog-Starter-Project/Domain/webhook"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// IWebhookUsecase is an autogenerated mock type for the IWebhookUsecase type
+type IWebhookUsecase struct {
+	mock.Mock
+}
+
+// DeleteWebhook provides a mock function with given fields: ctx, id
+func (_m *IWebhookUsecase) DeleteWebhook(ctx context.Context, id string) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteWebhook")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ListDeliveries provides a mock function with given fields: ctx, webhookID
+func (_m *IWebhookUsecase) ListDeliveries(ctx context.Context, webhookID string) ([]webhookpkg.Delivery, error) {
+	ret := _m.Called(ctx, webhookID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListDeliveries")
+	}
+
+	var r0 []webhookpkg.Delivery
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]webhookpkg.Delivery, error)); ok {
+		return rf(ctx, webhookID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []webhookpkg.Delivery); ok {
+		r0 = rf(ctx, webhookID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]webhookpkg.Delivery)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, webhookID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListWebhooks provides a mock function with given fields: ctx
+func (_m *IWebhookUsecase) ListWebhooks(ctx context.Context) ([]webhookpkg.Webhook, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListWebhooks")
+	}
+
+	var r0 []webhookpkg.Webhook
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]webhookpkg.Webhook, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []webhookpkg.Webhook); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]webhookpkg.Webhook)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Publish provides a mock function with given fields: ctx, eventType, data
+func (_m *IWebhookUsecase) Publish(ctx context.Context, eventType string, data interface{}) {
+	_m.Called(ctx, eventType, data)
+}
+
+// RegisterWebhook provides a mock function with given fields: ctx, req, createdBy
+func (_m *IWebhookUsecase) RegisterWebhook(ctx context.Context, req webhookpkg.CreateWebhookRequest, createdBy string) (webhookpkg.Webhook, error) {
+	ret := _m.Called(ctx, req, createdBy)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RegisterWebhook")
+	}
+
+	var r0 webhookpkg.Webhook
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, webhookpkg.CreateWebhookRequest, string) (webhookpkg.Webhook, error)); ok {
+		return rf(ctx, req, createdBy)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, webhookpkg.CreateWebhookRequest, string) webhookpkg.Webhook); ok {
+		r0 = rf(ctx, req, createdBy)
+	} else {
+		r0 = ret.Get(0).(webhookpkg.Webhook)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, webhookpkg.CreateWebhookRequest, string) error); ok {
+		r1 = rf(ctx, req, createdBy)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ReplayDelivery provides a mock function with given fields: ctx, deliveryID
+func (_m *IWebhookUsecase) ReplayDelivery(ctx context.Context, deliveryID string) (webhookpkg.Delivery, error) {
+	ret := _m.Called(ctx, deliveryID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReplayDelivery")
+	}
+
+	var r0 webhookpkg.Delivery
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (webhookpkg.Delivery, error)); ok {
+		return rf(ctx, deliveryID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) webhookpkg.Delivery); ok {
+		r0 = rf(ctx, deliveryID)
+	} else {
+		r0 = ret.Get(0).(webhookpkg.Delivery)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, deliveryID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewIWebhookUsecase creates a new instance of IWebhookUsecase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewIWebhookUsecase(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *IWebhookUsecase {
+	mock := &IWebhookUsecase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}